@@ -13,9 +13,13 @@ const (
 	AnnotationImageVerify              = "kyverno.io/verify-images"
 	AnnotationImageVerifyOutcomes      = "kyverno.io/image-verification-outcomes"
 	AnnotationPolicyCategory           = "policies.kyverno.io/category"
+	AnnotationPolicyDescription        = "policies.kyverno.io/description"
+	AnnotationPolicyHTTPTimeout        = "policies.kyverno.io/http-timeout"
 	AnnotationPolicyScored             = "policies.kyverno.io/scored"
 	AnnotationPolicySeverity           = "policies.kyverno.io/severity"
+	AnnotationPolicyTitle              = "policies.kyverno.io/title"
 	AnnotationCleanupPropagationPolicy = "cleanup.kyverno.io/propagation-policy"
+	AnnotationConversionTodo           = "policies.kyverno.io/conversion-todo"
 	// Well known values
 	ValueKyvernoApp        = "kyverno"
 	ValueTtlDateTimeLayout = "2006-01-02T150405Z"
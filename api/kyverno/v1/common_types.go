@@ -789,6 +789,14 @@ type Generation struct {
 	// +optional
 	Synchronize bool `json:"synchronize,omitempty"`
 
+	// DriftDetection controls if changes to generated resources should be reported without being reverted.
+	// If DriftDetection is set to "true" generated resources that differ from the resource data in Data or
+	// the resource specified in the Clone declaration are reported in policy reports and events, and left untouched.
+	// DriftDetection can't be used together with Synchronize.
+	// Optional. Defaults to "false" if not specified.
+	// +optional
+	DriftDetection bool `json:"driftDetection,omitempty"`
+
 	// OrphanDownstreamOnPolicyDelete controls whether generated resources should be deleted when the rule that generated
 	// them is deleted with synchronization enabled. This option is only applicable to generate rules of the data type.
 	// See https://kyverno.io/docs/writing-policies/generate/#data-examples.
@@ -876,6 +884,10 @@ func (g *Generation) Validate(path *field.Path, namespaced bool, policyNamespace
 		errs = append(errs, field.Forbidden(path, "only one of generate patterns(data, clone, cloneList and foreach) can be specified"))
 		return warnings, errs
 	}
+	if g.Synchronize && g.DriftDetection {
+		errs = append(errs, field.Forbidden(path.Child("driftDetection"), "driftDetection can not be used together with synchronize"))
+		return warnings, errs
+	}
 
 	if g.ForEachGeneration != nil {
 		for i, foreach := range g.ForEachGeneration {
@@ -31,6 +31,9 @@ type PolicyStatus struct {
 	// ValidatingAdmissionPolicy contains status information
 	// +optional
 	ValidatingAdmissionPolicy ValidatingAdmissionPolicyStatus `json:"validatingadmissionpolicy"`
+	// WebhookTiers lists the namespace tiers serving the policy when resource webhooks are split into tiers
+	// +optional
+	WebhookTiers []WebhookTierStatus `json:"webhookTiers,omitempty"`
 }
 
 // RuleCountStatus contains four variables which describes counts for
@@ -82,3 +85,14 @@ type ValidatingAdmissionPolicyStatus struct {
 	// It is an empty string when validating admission policy is successfully generated.
 	Message string `json:"message"`
 }
+
+// WebhookTierStatus contains the webhook settings applied to the policy in a namespace tier
+type WebhookTierStatus struct {
+	// Name of the tier, the default tier serves the namespaces not assigned to a configured tier
+	Name string `json:"name"`
+	// FailurePolicy of the webhooks serving the policy in the tier
+	FailurePolicy FailurePolicyType `json:"failurePolicy"`
+	// TimeoutSeconds of the webhooks serving the policy in the tier, when it is overridden by the tier
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+}
@@ -1223,6 +1223,13 @@ func (in *PolicyStatus) DeepCopyInto(out *PolicyStatus) {
 	in.Autogen.DeepCopyInto(&out.Autogen)
 	out.RuleCount = in.RuleCount
 	out.ValidatingAdmissionPolicy = in.ValidatingAdmissionPolicy
+	if in.WebhookTiers != nil {
+		in, out := &in.WebhookTiers, &out.WebhookTiers
+		*out = make([]WebhookTierStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -1900,3 +1907,24 @@ func (in *WebhookConfiguration) DeepCopy() *WebhookConfiguration {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookTierStatus) DeepCopyInto(out *WebhookTierStatus) {
+	*out = *in
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookTierStatus.
+func (in *WebhookTierStatus) DeepCopy() *WebhookTierStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookTierStatus)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -21,6 +21,7 @@ import (
 
 	"github.com/aptible/supercronic/cronexpr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	policiesv1alpha1 "github.com/kyverno/kyverno/api/policies.kyverno.io/v1alpha1"
 	datautils "github.com/kyverno/kyverno/pkg/utils/data"
 	"github.com/robfig/cron"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -229,12 +230,25 @@ type CleanupPolicySpec struct {
 	// +optional
 	// +kubebuilder:validation:Enum=Foreground;Background;Orphan
 	DeletionPropagationPolicy *metav1.DeletionPropagation `json:"deletionPropagationPolicy,omitempty"`
+
+	// DryRun enables the preview mode of the policy. In preview mode, the resources selected by the policy
+	// are computed on schedule and reported in the policy status and events, but they are not deleted.
+	// +optional
+	DryRun *bool `json:"dryRun,omitempty"`
+}
+
+// DryRunEnabled returns true if the policy runs in preview mode
+func (p *CleanupPolicySpec) DryRunEnabled() bool {
+	return p.DryRun != nil && *p.DryRun
 }
 
 // CleanupPolicyStatus stores the status of the policy.
 type CleanupPolicyStatus struct {
 	Conditions        []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
 	LastExecutionTime metav1.Time        `json:"lastExecutionTime,omitempty"`
+	// DryRun contains the resources that would have been deleted by the last execution of the policy in preview mode.
+	// +optional
+	DryRun *policiesv1alpha1.DryRunStatus `json:"dryRun,omitempty"`
 }
 
 // Validate implements programmatic validation
@@ -16,6 +16,8 @@ limitations under the License.
 package v2
 
 import (
+	"time"
+
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
 	"github.com/kyverno/kyverno/ext/wildcard"
@@ -28,6 +30,7 @@ import (
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 // +kubebuilder:resource:shortName=polex,categories=kyverno
 // +kubebuilder:storageversion
+// +kubebuilder:subresource:status
 
 // PolicyException declares resources to be excluded from specified policies.
 type PolicyException struct {
@@ -36,6 +39,10 @@ type PolicyException struct {
 
 	// Spec declares policy exception behaviors.
 	Spec PolicyExceptionSpec `json:"spec"`
+
+	// Status contains the approval and expiry state of the policy exception.
+	// +optional
+	Status PolicyExceptionStatus `json:"status,omitempty"`
 }
 
 // Validate implements programmatic validation
@@ -53,6 +60,18 @@ func (p *PolicyException) GetKind() string {
 	return "PolicyException"
 }
 
+// IsActive returns true if the exception is within its validity window at the given time,
+// has not been rejected and, when approval is required, has been approved
+func (p *PolicyException) IsActive(now time.Time, approvalRequired bool) bool {
+	if p.Spec.Validity(now) != PolicyExceptionValid {
+		return false
+	}
+	if p.Status.IsRejected() {
+		return false
+	}
+	return !approvalRequired || p.Status.IsApproved()
+}
+
 // HasPodSecurity checks if podSecurity controls is specified
 func (p *PolicyException) HasPodSecurity() bool {
 	return len(p.Spec.PodSecurity) > 0
@@ -80,6 +99,14 @@ type PolicyExceptionSpec struct {
 	// Applicable only to policies that have validate.podSecurity subrule.
 	// +optional
 	PodSecurity []kyvernov1.PodSecurityStandard `json:"podSecurity,omitempty"`
+
+	// NotBefore is the time from which the exception is applied.
+	// +optional
+	NotBefore *metav1.Time `json:"notBefore,omitempty"`
+
+	// NotAfter is the time after which the exception expires and is no longer applied.
+	// +optional
+	NotAfter *metav1.Time `json:"notAfter,omitempty"`
 }
 
 func (p *PolicyExceptionSpec) BackgroundProcessingEnabled() bool {
@@ -106,9 +133,23 @@ func (p *PolicyExceptionSpec) Validate(path *field.Path) (errs field.ErrorList)
 	for i, p := range p.PodSecurity {
 		errs = append(errs, p.Validate(podSecuityPath.Index(i))...)
 	}
+	if p.NotBefore != nil && p.NotAfter != nil && !p.NotAfter.After(p.NotBefore.Time) {
+		errs = append(errs, field.Invalid(path.Child("notAfter"), p.NotAfter, "notAfter must be after notBefore"))
+	}
 	return errs
 }
 
+// Validity returns the state of the exception validity window at the given time
+func (p *PolicyExceptionSpec) Validity(now time.Time) PolicyExceptionValidity {
+	if p.NotBefore != nil && now.Before(p.NotBefore.Time) {
+		return PolicyExceptionNotYetValid
+	}
+	if p.NotAfter != nil && !now.Before(p.NotAfter.Time) {
+		return PolicyExceptionExpired
+	}
+	return PolicyExceptionValid
+}
+
 // Contains returns true if it contains an exception for the given policy/rule pair
 func (p *PolicyExceptionSpec) Contains(policy string, rule string) bool {
 	for _, exception := range p.Exceptions {
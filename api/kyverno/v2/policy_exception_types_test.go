@@ -0,0 +1,69 @@
+package v2
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestPolicyExceptionSpec_Validity(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name string
+		spec PolicyExceptionSpec
+		want PolicyExceptionValidity
+	}{{
+		name: "no window",
+		want: PolicyExceptionValid,
+	}, {
+		name: "not yet valid",
+		spec: PolicyExceptionSpec{
+			NotBefore: &metav1.Time{Time: now.Add(time.Minute)},
+		},
+		want: PolicyExceptionNotYetValid,
+	}, {
+		name: "within window",
+		spec: PolicyExceptionSpec{
+			NotBefore: &metav1.Time{Time: now.Add(-time.Minute)},
+			NotAfter:  &metav1.Time{Time: now.Add(time.Minute)},
+		},
+		want: PolicyExceptionValid,
+	}, {
+		name: "expired",
+		spec: PolicyExceptionSpec{
+			NotAfter: &metav1.Time{Time: now.Add(-time.Minute)},
+		},
+		want: PolicyExceptionExpired,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.spec.Validity(now)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPolicyExceptionSpec_ValidateWindow(t *testing.T) {
+	now := time.Now()
+	spec := PolicyExceptionSpec{
+		NotBefore: &metav1.Time{Time: now},
+		NotAfter:  &metav1.Time{Time: now.Add(-time.Minute)},
+	}
+	errs := spec.Validate(field.NewPath("spec"))
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "spec.notAfter", errs[0].Field)
+}
+
+func TestPolicyException_IsActive(t *testing.T) {
+	now := time.Now()
+	polex := PolicyException{}
+	assert.True(t, polex.IsActive(now, false))
+	assert.False(t, polex.IsActive(now, true))
+	polex.Status.Conditions = []metav1.Condition{{Type: PolicyExceptionConditionApproved, Status: metav1.ConditionTrue}}
+	assert.True(t, polex.IsActive(now, true))
+	polex.Status.Conditions = []metav1.Condition{{Type: PolicyExceptionConditionApproved, Status: metav1.ConditionFalse}}
+	assert.False(t, polex.IsActive(now, false))
+}
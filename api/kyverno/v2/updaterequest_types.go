@@ -37,6 +37,11 @@ type UpdateRequestStatus struct {
 	GeneratedResources []kyvernov1.ResourceSpec `json:"generatedResources,omitempty"`
 
 	RetryCount int `json:"retryCount,omitempty"`
+
+	// AbandonedAt is the time the update request was abandoned.
+	// Abandoned update requests are deleted once their time to live expired.
+	// +optional
+	AbandonedAt *metav1.Time `json:"abandonedAt,omitempty"`
 }
 
 // +genclient
@@ -124,6 +129,10 @@ type RuleContext struct {
 
 	// CacheRestore indicates whether the cache should be restored.
 	CacheRestore bool `json:"cacheRestore,omitempty"`
+
+	// DriftCheck indicates that the downstream resources are only checked for drift,
+	// missing downstream resources are reported instead of being created.
+	DriftCheck bool `json:"driftCheck,omitempty"`
 }
 
 // UpdateRequestSpecContext stores the context to be shared.
@@ -179,6 +188,9 @@ const (
 
 	// Skip - the Update Request Controller skips to generate the resource.
 	Skip UpdateRequestState = "Skip"
+
+	// Abandoned - the Update Request Controller gave up processing the request after too many failed attempts.
+	Abandoned UpdateRequestState = "Abandoned"
 )
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
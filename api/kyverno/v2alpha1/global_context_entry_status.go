@@ -8,6 +8,8 @@ import (
 const (
 	// PolicyConditionReady means that the globalcontextentry is ready
 	GlobalContextEntryConditionReady = "Ready"
+	// GlobalContextEntryConditionConnected means that the streaming connection of the globalcontextentry is established
+	GlobalContextEntryConditionConnected = "Connected"
 )
 
 const (
@@ -15,6 +17,10 @@ const (
 	GlobalContextEntryReasonSucceeded = "Succeeded"
 	// GlobalContextEntryReasonFailed is the reason set when the globalcontextentry is not ready
 	GlobalContextEntryReasonFailed = "Failed"
+	// GlobalContextEntryReasonConnected is the reason set when the streaming connection is established
+	GlobalContextEntryReasonConnected = "Connected"
+	// GlobalContextEntryReasonDisconnected is the reason set when the streaming connection is lost
+	GlobalContextEntryReasonDisconnected = "Disconnected"
 )
 
 type GlobalContextEntryStatus struct {
@@ -25,6 +31,12 @@ type GlobalContextEntryStatus struct {
 	// Indicates the time when the globalcontextentry was last refreshed successfully for the API Call
 	// +optional
 	LastRefreshTime metav1.Time `json:"lastRefreshTime,omitempty"`
+	// ObjectCount is the number of objects cached for the KubernetesResource
+	// +optional
+	ObjectCount *int64 `json:"objectCount,omitempty"`
+	// CacheSizeBytes is the approximate memory footprint in bytes of the objects cached for the KubernetesResource
+	// +optional
+	CacheSizeBytes *int64 `json:"cacheSizeBytes,omitempty"`
 }
 
 func (status *GlobalContextEntryStatus) SetReady(ready bool, message string) {
@@ -43,6 +55,27 @@ func (status *GlobalContextEntryStatus) SetReady(ready bool, message string) {
 	meta.SetStatusCondition(&status.Conditions, condition)
 }
 
+func (status *GlobalContextEntryStatus) SetConnected(connected bool, message string) {
+	condition := metav1.Condition{
+		Type:    GlobalContextEntryConditionConnected,
+		Message: message,
+	}
+	if connected {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = GlobalContextEntryReasonConnected
+	} else {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = GlobalContextEntryReasonDisconnected
+	}
+	meta.SetStatusCondition(&status.Conditions, condition)
+}
+
+// SetCacheStats records the number of cached objects and their approximate size in bytes
+func (status *GlobalContextEntryStatus) SetCacheStats(objects, bytes int64) {
+	status.ObjectCount = &objects
+	status.CacheSizeBytes = &bytes
+}
+
 func (status *GlobalContextEntryStatus) UpdateRefreshTime() {
 	status.LastRefreshTime = metav1.Now()
 }
@@ -52,3 +85,9 @@ func (status *GlobalContextEntryStatus) IsReady() bool {
 	condition := meta.FindStatusCondition(status.Conditions, GlobalContextEntryConditionReady)
 	return condition != nil && condition.Status == metav1.ConditionTrue
 }
+
+// IsConnected indicates if the streaming connection of the globalcontextentry is established
+func (status *GlobalContextEntryStatus) IsConnected() bool {
+	condition := meta.FindStatusCondition(status.Conditions, GlobalContextEntryConditionConnected)
+	return condition != nil && condition.Status == metav1.ConditionTrue
+}
@@ -21,6 +21,7 @@ import (
 	gojmespath "github.com/kyverno/go-jmespath"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
@@ -71,7 +72,7 @@ type GlobalContextEntrySpec struct {
 	// +kubebuilder:validation:Optional
 	APICall *ExternalAPICall `json:"apiCall,omitempty"`
 
-	// Projections defines the list of JMESPath expressions to extract values from the cached resource.
+	// Projections defines the list of JMESPath or CEL expressions to extract values from the cached resource.
 	// +kubebuilder:validation:Optional
 	Projections []GlobalContextEntryProjection `json:"projections,omitempty"`
 }
@@ -131,6 +132,29 @@ type KubernetesResource struct {
 	// +kubebuilder:validation:Optional
 	// +optional
 	Namespace string `json:"namespace,omitempty"`
+	// Namespaces defines the list of namespaces the resources are cached from.
+	// Mutually exclusive with Namespace.
+	// +kubebuilder:validation:Optional
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+	// LabelSelector restricts the cached resources to the ones matching the selector.
+	// +kubebuilder:validation:Optional
+	// +optional
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
+	// FieldSelector restricts the cached resources to the ones matching the selector.
+	// The supported fields depend on the resource type. (Ex., "status.phase=Running")
+	// +kubebuilder:validation:Optional
+	// +optional
+	FieldSelector string `json:"fieldSelector,omitempty"`
+}
+
+// GetNamespaces returns the namespaces the resources are cached from.
+// An empty namespace means all namespaces.
+func (k *KubernetesResource) GetNamespaces() []string {
+	if len(k.Namespaces) != 0 {
+		return k.Namespaces
+	}
+	return []string{k.Namespace}
 }
 
 // Validate implements programmatic validation
@@ -145,6 +169,24 @@ func (k *KubernetesResource) Validate(path *field.Path) (errs field.ErrorList) {
 	if k.Resource == "" {
 		errs = append(errs, field.Required(path.Child("resource"), "A Resource entry requires a resource"))
 	}
+	if k.Namespace != "" && len(k.Namespaces) != 0 {
+		errs = append(errs, field.Forbidden(path.Child("namespaces"), "A Resource entry should either have Namespace or Namespaces"))
+	}
+	for i, namespace := range k.Namespaces {
+		if namespace == "" {
+			errs = append(errs, field.Required(path.Child("namespaces").Index(i), "A namespace must not be empty"))
+		}
+	}
+	if k.LabelSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(k.LabelSelector); err != nil {
+			errs = append(errs, field.Invalid(path.Child("labelSelector"), k.LabelSelector, err.Error()))
+		}
+	}
+	if k.FieldSelector != "" {
+		if _, err := fields.ParseSelector(k.FieldSelector); err != nil {
+			errs = append(errs, field.Invalid(path.Child("fieldSelector"), k.FieldSelector, err.Error()))
+		}
+	}
 	return errs
 }
 
@@ -162,6 +204,15 @@ type ExternalAPICall struct {
 	// +kubebuilder:validation:Optional
 	// +optional
 	RetryLimit int `json:"retryLimit,omitempty"`
+	// Stream keeps a long-lived connection to the service and applies updates as they are received
+	// instead of polling on RefreshInterval. Only supported with Service.
+	// +kubebuilder:validation:Optional
+	// +optional
+	Stream *ExternalAPIStream `json:"stream,omitempty"`
+}
+
+func (e *ExternalAPICall) IsStream() bool {
+	return e.Stream != nil
 }
 
 // Validate implements programmatic validation
@@ -175,6 +226,105 @@ func (e *ExternalAPICall) Validate(path *field.Path) (errs field.ErrorList) {
 	if e.Data != nil && e.Method != "POST" {
 		errs = append(errs, field.Forbidden(path.Child("method"), "An External API call with data should have method as POST"))
 	}
+	if e.IsStream() {
+		if e.Service == nil {
+			errs = append(errs, field.Forbidden(path.Child("stream"), "A streaming External API call requires a Service"))
+		}
+		errs = append(errs, e.Stream.Validate(path.Child("stream"))...)
+	}
+	return errs
+}
+
+// StreamFormat defines how updates are framed on a stream.
+// +kubebuilder:validation:Enum=ServerSentEvents;JSONLines
+type StreamFormat string
+
+const (
+	// StreamFormatServerSentEvents reads updates from the data fields of server-sent events.
+	StreamFormatServerSentEvents StreamFormat = "ServerSentEvents"
+	// StreamFormatJSONLines reads one update per line of a chunked response.
+	StreamFormatJSONLines StreamFormat = "JSONLines"
+)
+
+// StreamUpdateStrategy defines how an update received on a stream is applied to the cached data.
+// +kubebuilder:validation:Enum=Replace;MergePatch
+type StreamUpdateStrategy string
+
+const (
+	// StreamUpdateStrategyReplace replaces the cached data with the update.
+	StreamUpdateStrategyReplace StreamUpdateStrategy = "Replace"
+	// StreamUpdateStrategyMergePatch applies the update to the cached data as a JSON merge patch.
+	StreamUpdateStrategyMergePatch StreamUpdateStrategy = "MergePatch"
+)
+
+type ExternalAPIStream struct {
+	// Format defines how updates are framed on the stream.
+	// +kubebuilder:default=ServerSentEvents
+	// +kubebuilder:validation:Optional
+	// +optional
+	Format StreamFormat `json:"format,omitempty"`
+	// UpdateStrategy defines how an update is applied to the cached data.
+	// +kubebuilder:default=Replace
+	// +kubebuilder:validation:Optional
+	// +optional
+	UpdateStrategy StreamUpdateStrategy `json:"updateStrategy,omitempty"`
+	// MaxReconnectDelay defines the maximum delay between two reconnection attempts,
+	// the delay grows exponentially after each failed attempt.
+	// +kubebuilder:validation:Format=duration
+	// +kubebuilder:default=`1m`
+	// +kubebuilder:validation:Optional
+	// +optional
+	MaxReconnectDelay *metav1.Duration `json:"maxReconnectDelay,omitempty"`
+	// IdleTimeout defines the maximum time without receiving data on the stream, server-sent events comments
+	// count as data and can be used as heartbeats. When it elapses the entry is marked not ready and the
+	// connection is established again.
+	// +kubebuilder:validation:Format=duration
+	// +kubebuilder:default=`5m`
+	// +kubebuilder:validation:Optional
+	// +optional
+	IdleTimeout *metav1.Duration `json:"idleTimeout,omitempty"`
+}
+
+func (s *ExternalAPIStream) GetFormat() StreamFormat {
+	if s.Format == "" {
+		return StreamFormatServerSentEvents
+	}
+	return s.Format
+}
+
+func (s *ExternalAPIStream) GetUpdateStrategy() StreamUpdateStrategy {
+	if s.UpdateStrategy == "" {
+		return StreamUpdateStrategyReplace
+	}
+	return s.UpdateStrategy
+}
+
+func (s *ExternalAPIStream) GetMaxReconnectDelay() time.Duration {
+	if s.MaxReconnectDelay == nil || s.MaxReconnectDelay.Duration <= 0 {
+		return time.Minute
+	}
+	return s.MaxReconnectDelay.Duration
+}
+
+func (s *ExternalAPIStream) GetIdleTimeout() time.Duration {
+	if s.IdleTimeout == nil || s.IdleTimeout.Duration <= 0 {
+		return 5 * time.Minute
+	}
+	return s.IdleTimeout.Duration
+}
+
+// Validate implements programmatic validation
+func (s *ExternalAPIStream) Validate(path *field.Path) (errs field.ErrorList) {
+	switch s.GetFormat() {
+	case StreamFormatServerSentEvents, StreamFormatJSONLines:
+	default:
+		errs = append(errs, field.NotSupported(path.Child("format"), s.Format, []StreamFormat{StreamFormatServerSentEvents, StreamFormatJSONLines}))
+	}
+	switch s.GetUpdateStrategy() {
+	case StreamUpdateStrategyReplace, StreamUpdateStrategyMergePatch:
+	default:
+		errs = append(errs, field.NotSupported(path.Child("updateStrategy"), s.UpdateStrategy, []StreamUpdateStrategy{StreamUpdateStrategyReplace, StreamUpdateStrategyMergePatch}))
+	}
 	return errs
 }
 
@@ -183,8 +333,22 @@ type GlobalContextEntryProjection struct {
 	// +kubebuilder:validation:Required
 	Name string `json:"name"`
 	// JMESPath is the JMESPath expression to extract the value from the cached resource.
-	// +kubebuilder:validation:Required
-	JMESPath string `json:"jmesPath"`
+	// Mutually exclusive with Expression.
+	// +kubebuilder:validation:Optional
+	JMESPath string `json:"jmesPath,omitempty"`
+	// Expression is the CEL expression to extract the value from the cached resource.
+	// The cached data is available in the `data` variable.
+	// Mutually exclusive with JMESPath.
+	// +kubebuilder:validation:Optional
+	Expression string `json:"expression,omitempty"`
+}
+
+func (p *GlobalContextEntryProjection) IsJMESPath() bool {
+	return p.JMESPath != ""
+}
+
+func (p *GlobalContextEntryProjection) IsCEL() bool {
+	return p.Expression != ""
 }
 
 // Validate implements programmatic validation
@@ -195,12 +359,16 @@ func (p *GlobalContextEntryProjection) Validate(path *field.Path, gctxName strin
 	if p.Name == gctxName {
 		errs = append(errs, field.Required(path.Child("name"), "A projection entry requires a name different from the global context entry name"))
 	}
-	if p.JMESPath == "" {
-		errs = append(errs, field.Required(path.Child("jmesPath"), "A projection entry requires a JMESPath"))
+	if !p.IsJMESPath() && !p.IsCEL() {
+		errs = append(errs, field.Required(path.Child("jmesPath"), "A projection entry requires either a JMESPath or an Expression"))
+	}
+	if p.IsJMESPath() && p.IsCEL() {
+		errs = append(errs, field.Forbidden(path.Child("expression"), "A projection entry should either have JMESPath or Expression"))
 	}
-	_, err := gojmespath.Compile(p.JMESPath)
-	if err != nil {
-		errs = append(errs, field.Invalid(path.Child("jmesPath"), p.JMESPath, err.Error()))
+	if p.IsJMESPath() {
+		if _, err := gojmespath.Compile(p.JMESPath); err != nil {
+			errs = append(errs, field.Invalid(path.Child("jmesPath"), p.JMESPath, err.Error()))
+		}
 	}
 	return errs
 }
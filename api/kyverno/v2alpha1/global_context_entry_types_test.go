@@ -142,6 +142,58 @@ func TestKubernetesResourceValidate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "valid selectors and namespaces",
+			resource: KubernetesResource{
+				Version:    "v1",
+				Resource:   "configmaps",
+				Namespaces: []string{"kube-system", "platform"},
+				LabelSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"app.kubernetes.io/part-of": "platform"},
+				},
+				FieldSelector: "metadata.name!=kube-root-ca.crt",
+			},
+			wantErr: false,
+		},
+		{
+			name: "both namespace and namespaces",
+			resource: KubernetesResource{
+				Version:    "v1",
+				Resource:   "configmaps",
+				Namespace:  "default",
+				Namespaces: []string{"platform"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "empty namespace in namespaces",
+			resource: KubernetesResource{
+				Version:    "v1",
+				Resource:   "configmaps",
+				Namespaces: []string{""},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid label selector",
+			resource: KubernetesResource{
+				Version:  "v1",
+				Resource: "configmaps",
+				LabelSelector: &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "app", Operator: "Matches"}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid field selector",
+			resource: KubernetesResource{
+				Version:       "v1",
+				Resource:      "configmaps",
+				FieldSelector: "metadata.name",
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -224,6 +276,44 @@ func TestExternalAPICallValidate(t *testing.T) {
 
 			wantErr: true,
 		},
+		{
+			name: "valid stream",
+			apiCall: ExternalAPICall{
+				APICall: kyvernov1.APICall{
+					Service: &kyvernov1.ServiceCall{URL: "https://svc.kyverno/events"},
+				},
+				RefreshInterval: &metav1.Duration{Duration: 10 * time.Minute},
+				Stream: &ExternalAPIStream{
+					Format:         StreamFormatJSONLines,
+					UpdateStrategy: StreamUpdateStrategyMergePatch,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "stream with URLPath",
+			apiCall: ExternalAPICall{
+				APICall: kyvernov1.APICall{
+					URLPath: "/api/v1/namespaces",
+				},
+				RefreshInterval: &metav1.Duration{Duration: 10 * time.Minute},
+				Stream:          &ExternalAPIStream{},
+			},
+			wantErr: true,
+		},
+		{
+			name: "stream with invalid format",
+			apiCall: ExternalAPICall{
+				APICall: kyvernov1.APICall{
+					Service: &kyvernov1.ServiceCall{URL: "https://svc.kyverno/events"},
+				},
+				RefreshInterval: &metav1.Duration{Duration: 10 * time.Minute},
+				Stream: &ExternalAPIStream{
+					Format: "WebSocket",
+				},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -286,6 +376,25 @@ func TestGlobalContextEntryProjectionValidate(t *testing.T) {
 			gctxName: "globalContext",
 			wantErr:  true,
 		},
+		{
+			name: "valid expression",
+			projection: GlobalContextEntryProjection{
+				Name:       "example",
+				Expression: "data.metadata.name",
+			},
+			gctxName: "globalContext",
+			wantErr:  false,
+		},
+		{
+			name: "both JMESPath and expression",
+			projection: GlobalContextEntryProjection{
+				Name:       "example",
+				JMESPath:   "metadata.name",
+				Expression: "data.metadata.name",
+			},
+			gctxName: "globalContext",
+			wantErr:  true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -35,6 +35,11 @@ func (in *ExternalAPICall) DeepCopyInto(out *ExternalAPICall) {
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.Stream != nil {
+		in, out := &in.Stream, &out.Stream
+		*out = new(ExternalAPIStream)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -48,6 +53,32 @@ func (in *ExternalAPICall) DeepCopy() *ExternalAPICall {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalAPIStream) DeepCopyInto(out *ExternalAPIStream) {
+	*out = *in
+	if in.MaxReconnectDelay != nil {
+		in, out := &in.MaxReconnectDelay, &out.MaxReconnectDelay
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.IdleTimeout != nil {
+		in, out := &in.IdleTimeout, &out.IdleTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalAPIStream.
+func (in *ExternalAPIStream) DeepCopy() *ExternalAPIStream {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalAPIStream)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GlobalContextEntry) DeepCopyInto(out *GlobalContextEntry) {
 	*out = *in
@@ -131,7 +162,7 @@ func (in *GlobalContextEntrySpec) DeepCopyInto(out *GlobalContextEntrySpec) {
 	if in.KubernetesResource != nil {
 		in, out := &in.KubernetesResource, &out.KubernetesResource
 		*out = new(KubernetesResource)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.APICall != nil {
 		in, out := &in.APICall, &out.APICall
@@ -172,6 +203,16 @@ func (in *GlobalContextEntryStatus) DeepCopyInto(out *GlobalContextEntryStatus)
 		}
 	}
 	in.LastRefreshTime.DeepCopyInto(&out.LastRefreshTime)
+	if in.ObjectCount != nil {
+		in, out := &in.ObjectCount, &out.ObjectCount
+		*out = new(int64)
+		**out = **in
+	}
+	if in.CacheSizeBytes != nil {
+		in, out := &in.CacheSizeBytes, &out.CacheSizeBytes
+		*out = new(int64)
+		**out = **in
+	}
 	return
 }
 
@@ -188,6 +229,16 @@ func (in *GlobalContextEntryStatus) DeepCopy() *GlobalContextEntryStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubernetesResource) DeepCopyInto(out *KubernetesResource) {
 	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LabelSelector != nil {
+		in, out := &in.LabelSelector, &out.LabelSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
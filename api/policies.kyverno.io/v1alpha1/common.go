@@ -21,6 +21,15 @@ type EvaluationConfiguration struct {
 	// Background  controls policy evaluation during background scan.
 	// +optional
 	Background *BackgroundConfiguration `json:"background,omitempty"`
+
+	// Shadow controls shadow evaluation of the policy during admission.
+	// +optional
+	Shadow *ShadowConfiguration `json:"shadow,omitempty"`
+
+	// Cost bounds the runtime cost of the CEL expressions of the policy.
+	// Limits that are not set default to the cluster wide limits of the Kyverno ConfigMap.
+	// +optional
+	Cost *CostConfiguration `json:"cost,omitempty"`
 }
 
 type AdmissionConfiguration struct {
@@ -39,3 +48,34 @@ type BackgroundConfiguration struct {
 	// +kubebuilder:default=true
 	Enabled *bool `json:"enabled,omitempty"`
 }
+
+type ShadowConfiguration struct {
+	// Enabled controls if the policy is evaluated in shadow mode.
+	// A shadow policy is evaluated on live admission requests but never affects the admission response
+	// nor runs in background scans, requests where the shadow policy diverges from the decision of the
+	// enforced policy are recorded in policy reports.
+	// Optional. Default value is "false".
+	// +optional
+	// +kubebuilder:default=false
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// PolicyName is the name of the enforced validating policy the shadow policy is a candidate revision of.
+	// The shadow decision is compared with the decision of that policy, when empty the shadow policy
+	// is compared with admitting the request.
+	// +optional
+	PolicyName string `json:"policyName,omitempty"`
+}
+
+type CostConfiguration struct {
+	// ExpressionLimit is the maximum runtime cost of a single CEL expression evaluation.
+	// An expression exceeding it is interrupted and fails.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	ExpressionLimit *int64 `json:"expressionLimit,omitempty"`
+
+	// PolicyLimit is the maximum runtime cost of all the CEL expressions evaluated by the policy
+	// for a single resource, the evaluation fails when it is exceeded.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	PolicyLimit *int64 `json:"policyLimit,omitempty"`
+}
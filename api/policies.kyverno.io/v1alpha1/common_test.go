@@ -82,6 +82,21 @@ func TestValidatingPolicySpec_BackgroundEnabled(t *testing.T) {
 			},
 		},
 		want: false,
+	}, {
+		name: "shadow",
+		policy: &ValidatingPolicy{
+			Spec: ValidatingPolicySpec{
+				EvaluationConfiguration: &EvaluationConfiguration{
+					Background: &BackgroundConfiguration{
+						Enabled: ptr.To(true),
+					},
+					Shadow: &ShadowConfiguration{
+						Enabled: ptr.To(true),
+					},
+				},
+			},
+		},
+		want: false,
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -118,3 +133,76 @@ func TestValidatingPolicySpec_EvaluationMode(t *testing.T) {
 		})
 	}
 }
+
+func TestValidatingPolicySpec_ShadowEnabled(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy *ValidatingPolicy
+		want   bool
+	}{{
+		name:   "nil",
+		policy: &ValidatingPolicy{},
+		want:   false,
+	}, {
+		name: "true",
+		policy: &ValidatingPolicy{
+			Spec: ValidatingPolicySpec{
+				EvaluationConfiguration: &EvaluationConfiguration{
+					Shadow: &ShadowConfiguration{
+						Enabled: ptr.To(true),
+					},
+				},
+			},
+		},
+		want: true,
+	}, {
+		name: "false",
+		policy: &ValidatingPolicy{
+			Spec: ValidatingPolicySpec{
+				EvaluationConfiguration: &EvaluationConfiguration{
+					Shadow: &ShadowConfiguration{
+						Enabled: ptr.To(false),
+					},
+				},
+			},
+		},
+		want: false,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.policy.Spec.ShadowEnabled()
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestValidatingPolicySpec_ShadowPolicyName(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy *ValidatingPolicy
+		want   string
+	}{{
+		name:   "nil",
+		policy: &ValidatingPolicy{},
+		want:   "",
+	}, {
+		name: "set",
+		policy: &ValidatingPolicy{
+			Spec: ValidatingPolicySpec{
+				EvaluationConfiguration: &EvaluationConfiguration{
+					Shadow: &ShadowConfiguration{
+						Enabled:    ptr.To(true),
+						PolicyName: "live",
+					},
+				},
+			},
+		},
+		want: "live",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.policy.Spec.ShadowPolicyName()
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -3,6 +3,7 @@ package v1alpha1
 import (
 	"time"
 
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -31,6 +32,17 @@ type ConditionStatus struct {
 	Message string `json:"message"`
 }
 
+// WebhookTierStatus describes a namespace tier serving a policy
+type WebhookTierStatus struct {
+	// Name of the tier, the default tier serves the namespaces not assigned to a configured tier
+	Name string `json:"name"`
+	// FailurePolicy of the webhooks serving the policy in the tier
+	FailurePolicy admissionregistrationv1.FailurePolicyType `json:"failurePolicy"`
+	// TimeoutSeconds of the webhooks serving the policy in the tier, when it is overridden by the tier
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+}
+
 func (status *ConditionStatus) SetReadyByCondition(c PolicyConditionType, s metav1.ConditionStatus, message string) {
 	reason := "Succeeded"
 	if s != metav1.ConditionTrue {
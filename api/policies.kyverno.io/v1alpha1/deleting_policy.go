@@ -81,12 +81,51 @@ type DeletingPolicySpec struct {
 	// +optional
 	// +kubebuilder:validation:Enum=Foreground;Background;Orphan
 	DeletionPropagationPolicy *metav1.DeletionPropagation `json:"deletionPropagationPolicy,omitempty"`
+
+	// DryRun enables the preview mode of the policy. In preview mode, the resources matching the policy
+	// are computed on schedule and reported in the policy status and events, but they are not deleted.
+	// +optional
+	DryRun *bool `json:"dryRun,omitempty"`
+}
+
+// DryRunEnabled returns true if the policy runs in preview mode
+func (s DeletingPolicySpec) DryRunEnabled() bool {
+	const defaultValue = false
+	if s.DryRun == nil {
+		return defaultValue
+	}
+	return *s.DryRun
 }
 
 type DeletingPolicyStatus struct {
 	// +optional
 	ConditionStatus   ConditionStatus `json:"conditionStatus,omitempty"`
 	LastExecutionTime metav1.Time     `json:"lastExecutionTime,omitempty"`
+	// DryRun contains the resources that would have been deleted by the last execution of the policy in preview mode.
+	// +optional
+	DryRun *DryRunStatus `json:"dryRun,omitempty"`
+}
+
+// DryRunStatus reports the resources a policy would delete when it runs in preview mode.
+type DryRunStatus struct {
+	// Count is the number of resources that would have been deleted.
+	Count int `json:"count"`
+	// Candidates is a sample of the resources that would have been deleted, it is bounded to a small number of entries.
+	// +optional
+	Candidates []DryRunCandidate `json:"candidates,omitempty"`
+}
+
+// DryRunCandidate identifies a resource that would have been deleted.
+type DryRunCandidate struct {
+	// APIVersion specifies the resource apiVersion.
+	APIVersion string `json:"apiVersion"`
+	// Kind specifies the resource kind.
+	Kind string `json:"kind"`
+	// Namespace specifies the resource namespace.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// Name specifies the resource name.
+	Name string `json:"name"`
 }
 
 // GetExecutionTime returns the execution time of the policy
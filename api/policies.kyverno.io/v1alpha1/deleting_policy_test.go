@@ -71,4 +71,14 @@ func Test_DeletingPolicy(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, created.Add(1*time.Minute).Format("2006-01-02 15:04"), exec.Format("2006-01-02 15:04"))
 	})
+	t.Run("dry run", func(t *testing.T) {
+		assert.False(t, dpol.Spec.DryRunEnabled())
+		enabled := true
+		dpol := DeletingPolicy{
+			Spec: DeletingPolicySpec{
+				DryRun: &enabled,
+			},
+		}
+		assert.True(t, dpol.Spec.DryRunEnabled())
+	})
 }
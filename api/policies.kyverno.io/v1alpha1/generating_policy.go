@@ -166,6 +166,20 @@ func (s GeneratingPolicySpec) SynchronizationEnabled() bool {
 	return *s.EvaluationConfiguration.SynchronizationConfiguration.Enabled
 }
 
+func (s GeneratingPolicySpec) DriftDetectionEnabled() bool {
+	const defaultValue = false
+	if s.EvaluationConfiguration == nil {
+		return defaultValue
+	}
+	if s.EvaluationConfiguration.DriftDetectionConfiguration == nil {
+		return defaultValue
+	}
+	if s.EvaluationConfiguration.DriftDetectionConfiguration.Enabled == nil {
+		return defaultValue
+	}
+	return *s.EvaluationConfiguration.DriftDetectionConfiguration.Enabled
+}
+
 func (s GeneratingPolicySpec) AdmissionEnabled() bool {
 	if s.EvaluationConfiguration == nil || s.EvaluationConfiguration.Admission == nil || s.EvaluationConfiguration.Admission.Enabled == nil {
 		return true
@@ -173,6 +187,14 @@ func (s GeneratingPolicySpec) AdmissionEnabled() bool {
 	return *s.EvaluationConfiguration.Admission.Enabled
 }
 
+// CostConfiguration returns the cost limits of the policy, nil when they are not set
+func (s GeneratingPolicySpec) CostConfiguration() *CostConfiguration {
+	if s.EvaluationConfiguration == nil {
+		return nil
+	}
+	return s.EvaluationConfiguration.Cost
+}
+
 type GeneratingPolicyEvaluationConfiguration struct {
 	// Admission controls policy evaluation during admission.
 	// +optional
@@ -186,8 +208,17 @@ type GeneratingPolicyEvaluationConfiguration struct {
 	// +optional
 	SynchronizationConfiguration *SynchronizationConfiguration `json:"synchronize,omitempty"`
 
+	// DriftDetection defines the configuration for the detection of drifts in generated resources.
+	// +optional
+	DriftDetectionConfiguration *DriftDetectionConfiguration `json:"driftDetection,omitempty"`
+
 	// OrphanDownstreamOnPolicyDelete defines the configuration for orphaning downstream resources on policy delete.
 	OrphanDownstreamOnPolicyDelete *OrphanDownstreamOnPolicyDeleteConfiguration `json:"orphanDownstreamOnPolicyDelete,omitempty"`
+
+	// Cost bounds the runtime cost of the CEL expressions of the policy.
+	// Limits that are not set default to the cluster wide limits of the Kyverno ConfigMap.
+	// +optional
+	Cost *CostConfiguration `json:"cost,omitempty"`
 }
 
 // GenerateExistingConfiguration defines the configuration for generating resources for existing triggers.
@@ -211,6 +242,18 @@ type SynchronizationConfiguration struct {
 	Enabled *bool `json:"enabled,omitempty"`
 }
 
+// DriftDetectionConfiguration defines the configuration for the detection of drifts in generated resources.
+type DriftDetectionConfiguration struct {
+	// Enabled controls if changes to generated resources should be reported without being reverted.
+	// If DriftDetection is set to "true" generated resources that differ from the resources produced
+	// by the policy are reported in policy reports and events, and left untouched.
+	// DriftDetection can't be used together with Synchronize.
+	// Optional. Defaults to "false" if not specified.
+	// +optional
+	// +kubebuilder:default=false
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
 // OrphanDownstreamOnPolicyDeleteConfiguration defines the configuration for orphaning downstream resources on policy delete.
 type OrphanDownstreamOnPolicyDeleteConfiguration struct {
 	// Enabled controls whether generated resources should be deleted when the policy that generated
@@ -230,4 +273,26 @@ type Generation struct {
 type GeneratingPolicyStatus struct {
 	// +optional
 	ConditionStatus ConditionStatus `json:"conditionStatus,omitempty"`
+
+	// Sources lists the OCI artifacts fetched by the policy and the digests they resolved to.
+	// +optional
+	Sources []GeneratingPolicySource `json:"sources,omitempty"`
+
+	// WebhookTiers lists the namespace tiers serving the policy when resource webhooks are split into tiers
+	// +optional
+	WebhookTiers []WebhookTierStatus `json:"webhookTiers,omitempty"`
+}
+
+// GeneratingPolicySource describes an OCI artifact fetched by the policy.
+type GeneratingPolicySource struct {
+	// Reference is the artifact reference used in the policy.
+	Reference string `json:"reference"`
+
+	// Digest is the digest the reference resolved to.
+	Digest string `json:"digest"`
+
+	// PolicyGeneration is the generation of the policy the digest was recorded for.
+	// Entries recorded for a previous generation are dropped once the policy fetches its sources again.
+	// +optional
+	PolicyGeneration int64 `json:"policyGeneration,omitempty"`
 }
@@ -43,6 +43,10 @@ type ImageValidatingPolicyStatus struct {
 
 	// +optional
 	Autogen ImageValidatingPolicyAutogenStatus `json:"autogen,omitempty"`
+
+	// WebhookTiers lists the namespace tiers serving the policy when resource webhooks are split into tiers
+	// +optional
+	WebhookTiers []WebhookTierStatus `json:"webhookTiers,omitempty"`
 }
 
 func (s *ImageValidatingPolicy) GetMatchConstraints() admissionregistrationv1.MatchResources {
@@ -209,13 +213,29 @@ type ImageValidatingPolicySpec struct {
 
 	// EvaluationConfiguration defines the configuration for the policy evaluation.
 	// +optional
-	EvaluationConfiguration *EvaluationConfiguration `json:"evaluation,omitempty"`
+	EvaluationConfiguration *ImageValidatingPolicyEvaluationConfiguration `json:"evaluation,omitempty"`
 
 	// AutogenConfiguration defines the configuration for the generation controller.
 	// +optional
 	AutogenConfiguration *ImageValidatingPolicyAutogenConfiguration `json:"autogen,omitempty"`
 }
 
+type ImageValidatingPolicyEvaluationConfiguration struct {
+	// Mode is the mode of policy evaluation.
+	// Allowed values are "Kubernetes" or "JSON".
+	// Optional. Default value is "Kubernetes".
+	// +optional
+	Mode EvaluationMode `json:"mode,omitempty"`
+
+	// Admission controls policy evaluation during admission.
+	// +optional
+	Admission *AdmissionConfiguration `json:"admission,omitempty"`
+
+	// Background  controls policy evaluation during background scan.
+	// +optional
+	Background *BackgroundConfiguration `json:"background,omitempty"`
+}
+
 // MatchImageReference defines a Glob or a CEL expression for matching images
 // +kubebuilder:oneOf:={required:{glob}}
 // +kubebuilder:oneOf:={required:{expression}}
@@ -192,7 +192,7 @@ func TestImageValidatingPolicySpec_EvaluationMode(t *testing.T) {
 	}, {
 		name: "json",
 		policy: &ImageValidatingPolicySpec{
-			EvaluationConfiguration: &EvaluationConfiguration{
+			EvaluationConfiguration: &ImageValidatingPolicyEvaluationConfiguration{
 				Mode: EvaluationModeJSON,
 			},
 		},
@@ -434,7 +434,7 @@ func TestImageValidatingPolicy_BackgroundEnabled(t *testing.T) {
 		name: "true",
 		policy: &ImageValidatingPolicy{
 			Spec: ImageValidatingPolicySpec{
-				EvaluationConfiguration: &EvaluationConfiguration{
+				EvaluationConfiguration: &ImageValidatingPolicyEvaluationConfiguration{
 					Background: &BackgroundConfiguration{
 						Enabled: ptr.To(true),
 					},
@@ -446,7 +446,7 @@ func TestImageValidatingPolicy_BackgroundEnabled(t *testing.T) {
 		name: "false",
 		policy: &ImageValidatingPolicy{
 			Spec: ImageValidatingPolicySpec{
-				EvaluationConfiguration: &EvaluationConfiguration{
+				EvaluationConfiguration: &ImageValidatingPolicyEvaluationConfiguration{
 					Background: &BackgroundConfiguration{
 						Enabled: ptr.To(false),
 					},
@@ -476,7 +476,7 @@ func TestImageValidatingPolicySpec_AdmissionEnabled(t *testing.T) {
 		name: "true",
 		policy: &ImageValidatingPolicy{
 			Spec: ImageValidatingPolicySpec{
-				EvaluationConfiguration: &EvaluationConfiguration{
+				EvaluationConfiguration: &ImageValidatingPolicyEvaluationConfiguration{
 					Admission: &AdmissionConfiguration{
 						Enabled: ptr.To(true),
 					},
@@ -488,7 +488,7 @@ func TestImageValidatingPolicySpec_AdmissionEnabled(t *testing.T) {
 		name: "false",
 		policy: &ImageValidatingPolicy{
 			Spec: ImageValidatingPolicySpec{
-				EvaluationConfiguration: &EvaluationConfiguration{
+				EvaluationConfiguration: &ImageValidatingPolicyEvaluationConfiguration{
 					Admission: &AdmissionConfiguration{
 						Enabled: ptr.To(false),
 					},
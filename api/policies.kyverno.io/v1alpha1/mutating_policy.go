@@ -37,6 +37,10 @@ type MutatingPolicyStatus struct {
 	// Generated indicates whether a MutatingAdmissionPolicy is generated from the policy or not
 	// +optional
 	Generated bool `json:"generated"`
+
+	// WebhookTiers lists the namespace tiers serving the policy when resource webhooks are split into tiers
+	// +optional
+	WebhookTiers []WebhookTierStatus `json:"webhookTiers,omitempty"`
 }
 
 // MutatingPolicySpec is the specification of the desired behavior of the MutatingPolicy.
@@ -317,6 +321,14 @@ func (s MutatingPolicySpec) MutateExistingEnabled() bool {
 	return *s.EvaluationConfiguration.MutateExistingConfiguration.Enabled
 }
 
+// CostConfiguration returns the cost limits of the policy, nil when they are not set
+func (s MutatingPolicySpec) CostConfiguration() *CostConfiguration {
+	if s.EvaluationConfiguration == nil {
+		return nil
+	}
+	return s.EvaluationConfiguration.Cost
+}
+
 func (s *MutatingPolicy) GetStatus() *MutatingPolicyStatus {
 	return &s.Status
 }
@@ -341,6 +353,11 @@ type MutatingPolicyEvaluationConfiguration struct {
 	// MutateExisting controls whether existing resources are mutated.
 	// +optional
 	MutateExistingConfiguration *MutateExistingConfiguration `json:"mutateExisting,omitempty"`
+
+	// Cost bounds the runtime cost of the CEL expressions of the policy.
+	// Limits that are not set default to the cluster wide limits of the Kyverno ConfigMap.
+	// +optional
+	Cost *CostConfiguration `json:"cost,omitempty"`
 }
 
 type MutatingPolicyAutogenConfiguration struct {
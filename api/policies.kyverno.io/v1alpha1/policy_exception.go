@@ -1,6 +1,8 @@
 package v1alpha1
 
 import (
+	"time"
+
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -8,6 +10,7 @@ import (
 
 // +genclient
 // +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // PolicyException declares resources to be excluded from specified policies.
@@ -17,12 +20,28 @@ type PolicyException struct {
 
 	// Spec declares policy exception behaviors.
 	Spec PolicyExceptionSpec `json:"spec"`
+
+	// Status contains the approval and expiry state of the policy exception.
+	// +optional
+	Status PolicyExceptionStatus `json:"status,omitempty"`
 }
 
 func (p *PolicyException) GetKind() string {
 	return "PolicyException"
 }
 
+// IsActive returns true if the exception is within its validity window at the given time,
+// has not been rejected and, when approval is required, has been approved
+func (p *PolicyException) IsActive(now time.Time, approvalRequired bool) bool {
+	if p.Spec.Validity(now) != PolicyExceptionValid {
+		return false
+	}
+	if p.Status.IsRejected() {
+		return false
+	}
+	return !approvalRequired || p.Status.IsApproved()
+}
+
 // Validate implements programmatic validation
 func (p *PolicyException) Validate() (errs field.ErrorList) {
 	errs = append(errs, p.Spec.Validate(field.NewPath("spec"))...)
@@ -37,6 +56,14 @@ type PolicyExceptionSpec struct {
 	// MatchConditions is a list of CEL expressions that must be met for a resource to be excluded.
 	// +optional
 	MatchConditions []admissionregistrationv1.MatchCondition `json:"matchConditions,omitempty"`
+
+	// NotBefore is the time from which the exception is applied.
+	// +optional
+	NotBefore *metav1.Time `json:"notBefore,omitempty"`
+
+	// NotAfter is the time after which the exception expires and is no longer applied.
+	// +optional
+	NotAfter *metav1.Time `json:"notAfter,omitempty"`
 }
 
 // Validate implements programmatic validation
@@ -48,9 +75,23 @@ func (p *PolicyExceptionSpec) Validate(path *field.Path) (errs field.ErrorList)
 			errs = append(errs, policyRef.Validate(path.Child("policyRefs").Index(i))...)
 		}
 	}
+	if p.NotBefore != nil && p.NotAfter != nil && !p.NotAfter.After(p.NotBefore.Time) {
+		errs = append(errs, field.Invalid(path.Child("notAfter"), p.NotAfter, "notAfter must be after notBefore"))
+	}
 	return errs
 }
 
+// Validity returns the state of the exception validity window at the given time
+func (p *PolicyExceptionSpec) Validity(now time.Time) PolicyExceptionValidity {
+	if p.NotBefore != nil && now.Before(p.NotBefore.Time) {
+		return PolicyExceptionNotYetValid
+	}
+	if p.NotAfter != nil && !now.Before(p.NotAfter.Time) {
+		return PolicyExceptionExpired
+	}
+	return PolicyExceptionValid
+}
+
 type PolicyRef struct {
 	// Name is the name of the policy
 	Name string `json:"name"`
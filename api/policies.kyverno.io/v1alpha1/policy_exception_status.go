@@ -0,0 +1,72 @@
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PolicyExceptionValidity is the state of the validity window of a policy exception
+type PolicyExceptionValidity string
+
+const (
+	// PolicyExceptionNotYetValid means the exception validity window has not started yet
+	PolicyExceptionNotYetValid PolicyExceptionValidity = "NotYetValid"
+	// PolicyExceptionValid means the exception is within its validity window
+	PolicyExceptionValid PolicyExceptionValidity = "Valid"
+	// PolicyExceptionExpired means the exception validity window has ended
+	PolicyExceptionExpired PolicyExceptionValidity = "Expired"
+)
+
+const (
+	// PolicyExceptionConditionApproved is set by approvers to approve or reject the exception
+	PolicyExceptionConditionApproved = "Approved"
+	// PolicyExceptionConditionExpired means that the exception validity window has ended
+	PolicyExceptionConditionExpired = "Expired"
+)
+
+// PolicyExceptionStatus stores the approval and expiry state of a policy exception
+type PolicyExceptionStatus struct {
+	// Conditions contains the Approved and Expired conditions of the exception.
+	// The Approved condition is managed by exception approvers, the Expired condition is managed by Kyverno.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// SetValidity records the validity window state of the exception in the Expired condition
+func (status *PolicyExceptionStatus) SetValidity(validity PolicyExceptionValidity, message string) {
+	condition := metav1.Condition{
+		Type:    PolicyExceptionConditionExpired,
+		Reason:  string(validity),
+		Message: message,
+	}
+	if validity == PolicyExceptionExpired {
+		condition.Status = metav1.ConditionTrue
+	} else {
+		condition.Status = metav1.ConditionFalse
+	}
+	meta.SetStatusCondition(&status.Conditions, condition)
+}
+
+// Validity returns the validity window state recorded in the Expired condition
+func (status *PolicyExceptionStatus) Validity() PolicyExceptionValidity {
+	condition := meta.FindStatusCondition(status.Conditions, PolicyExceptionConditionExpired)
+	if condition == nil {
+		return ""
+	}
+	return PolicyExceptionValidity(condition.Reason)
+}
+
+// IsExpired indicates if the exception has been marked as expired
+func (status *PolicyExceptionStatus) IsExpired() bool {
+	return meta.IsStatusConditionTrue(status.Conditions, PolicyExceptionConditionExpired)
+}
+
+// IsApproved indicates if the exception has been approved
+func (status *PolicyExceptionStatus) IsApproved() bool {
+	return meta.IsStatusConditionTrue(status.Conditions, PolicyExceptionConditionApproved)
+}
+
+// IsRejected indicates if the exception has been explicitly rejected
+func (status *PolicyExceptionStatus) IsRejected() bool {
+	return meta.IsStatusConditionFalse(status.Conditions, PolicyExceptionConditionApproved)
+}
@@ -2,6 +2,7 @@ package v1alpha1
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -99,3 +100,78 @@ func TestCELPolicyExceptionSpec_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestCELPolicyException_IsActive(t *testing.T) {
+	now := time.Now()
+	approved := PolicyExceptionStatus{
+		Conditions: []v1.Condition{{Type: PolicyExceptionConditionApproved, Status: v1.ConditionTrue}},
+	}
+	rejected := PolicyExceptionStatus{
+		Conditions: []v1.Condition{{Type: PolicyExceptionConditionApproved, Status: v1.ConditionFalse}},
+	}
+	tests := []struct {
+		name             string
+		policy           *PolicyException
+		approvalRequired bool
+		want             bool
+	}{{
+		name:   "no window",
+		policy: &PolicyException{},
+		want:   true,
+	}, {
+		name: "within window",
+		policy: &PolicyException{
+			Spec: PolicyExceptionSpec{
+				NotBefore: &v1.Time{Time: now.Add(-time.Hour)},
+				NotAfter:  &v1.Time{Time: now.Add(time.Hour)},
+			},
+		},
+		want: true,
+	}, {
+		name: "not yet valid",
+		policy: &PolicyException{
+			Spec: PolicyExceptionSpec{
+				NotBefore: &v1.Time{Time: now.Add(time.Hour)},
+			},
+		},
+		want: false,
+	}, {
+		name: "expired",
+		policy: &PolicyException{
+			Spec: PolicyExceptionSpec{
+				NotAfter: &v1.Time{Time: now},
+			},
+		},
+		want: false,
+	}, {
+		name:   "rejected",
+		policy: &PolicyException{Status: rejected},
+		want:   false,
+	}, {
+		name:             "approval required but not approved",
+		policy:           &PolicyException{},
+		approvalRequired: true,
+		want:             false,
+	}, {
+		name:             "approval required and approved",
+		policy:           &PolicyException{Status: approved},
+		approvalRequired: true,
+		want:             true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.policy.IsActive(now, tt.approvalRequired)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCELPolicyExceptionStatus_SetValidity(t *testing.T) {
+	var status PolicyExceptionStatus
+	status.SetValidity(PolicyExceptionValid, "")
+	assert.False(t, status.IsExpired())
+	assert.Equal(t, PolicyExceptionValid, status.Validity())
+	status.SetValidity(PolicyExceptionExpired, "expired")
+	assert.True(t, status.IsExpired())
+	assert.Equal(t, PolicyExceptionExpired, status.Validity())
+}
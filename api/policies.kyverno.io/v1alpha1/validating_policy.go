@@ -41,6 +41,10 @@ type ValidatingPolicyStatus struct {
 	// Generated indicates whether a ValidatingAdmissionPolicy/MutatingAdmissionPolicy is generated from the policy or not
 	// +optional
 	Generated bool `json:"generated"`
+
+	// WebhookTiers lists the namespace tiers serving the policy when resource webhooks are split into tiers
+	// +optional
+	WebhookTiers []WebhookTierStatus `json:"webhookTiers,omitempty"`
 }
 
 func (s *ValidatingPolicy) GetMatchConstraints() admissionregistrationv1.MatchResources {
@@ -58,6 +62,10 @@ func (s *ValidatingPolicy) GetFailurePolicy() admissionregistrationv1.FailurePol
 	if toggle.FromContext(context.TODO()).ForceFailurePolicyIgnore() {
 		return admissionregistrationv1.Ignore
 	}
+	// a shadow policy must never block admission requests
+	if s.Spec.ShadowEnabled() {
+		return admissionregistrationv1.Ignore
+	}
 	if s.Spec.FailurePolicy == nil {
 		return admissionregistrationv1.Fail
 	}
@@ -191,6 +199,10 @@ type ValidatingPolicySpec struct {
 // GenerateValidatingAdmissionPolicyEnabled checks if validating admission policy generation is enabled
 func (s ValidatingPolicySpec) GenerateValidatingAdmissionPolicyEnabled() bool {
 	const defaultValue = false
+	// a shadow policy can't be enforced by the API server
+	if s.ShadowEnabled() {
+		return false
+	}
 	if s.AutogenConfiguration == nil {
 		return defaultValue
 	}
@@ -215,12 +227,41 @@ func (s ValidatingPolicySpec) AdmissionEnabled() bool {
 // BackgroundEnabled checks if background is set to true
 func (s ValidatingPolicySpec) BackgroundEnabled() bool {
 	const defaultValue = true
+	// a shadow policy is only evaluated on admission requests
+	if s.ShadowEnabled() {
+		return false
+	}
 	if s.EvaluationConfiguration == nil || s.EvaluationConfiguration.Background == nil || s.EvaluationConfiguration.Background.Enabled == nil {
 		return defaultValue
 	}
 	return *s.EvaluationConfiguration.Background.Enabled
 }
 
+// ShadowEnabled checks if the policy is evaluated in shadow mode
+func (s ValidatingPolicySpec) ShadowEnabled() bool {
+	const defaultValue = false
+	if s.EvaluationConfiguration == nil || s.EvaluationConfiguration.Shadow == nil || s.EvaluationConfiguration.Shadow.Enabled == nil {
+		return defaultValue
+	}
+	return *s.EvaluationConfiguration.Shadow.Enabled
+}
+
+// ShadowPolicyName returns the name of the enforced policy the shadow policy is compared with
+func (s ValidatingPolicySpec) ShadowPolicyName() string {
+	if s.EvaluationConfiguration == nil || s.EvaluationConfiguration.Shadow == nil {
+		return ""
+	}
+	return s.EvaluationConfiguration.Shadow.PolicyName
+}
+
+// CostConfiguration returns the cost limits of the policy, nil when they are not set
+func (s ValidatingPolicySpec) CostConfiguration() *CostConfiguration {
+	if s.EvaluationConfiguration == nil {
+		return nil
+	}
+	return s.EvaluationConfiguration.Cost
+}
+
 // EvaluationMode returns the evaluation mode of the policy.
 func (s ValidatingPolicySpec) EvaluationMode() EvaluationMode {
 	const defaultValue = EvaluationModeKubernetes
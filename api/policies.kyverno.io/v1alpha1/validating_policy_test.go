@@ -100,6 +100,19 @@ func TestValidatingPolicy_GetFailurePolicy(t *testing.T) {
 			},
 		},
 		want: admissionregistrationv1.Ignore,
+	}, {
+		name: "shadow",
+		policy: &ValidatingPolicy{
+			Spec: ValidatingPolicySpec{
+				FailurePolicy: ptr.To(admissionregistrationv1.Fail),
+				EvaluationConfiguration: &EvaluationConfiguration{
+					Shadow: &ShadowConfiguration{
+						Enabled: ptr.To(true),
+					},
+				},
+			},
+		},
+		want: admissionregistrationv1.Ignore,
 	},
 	}
 	for _, tt := range tests {
@@ -272,6 +272,32 @@ func (in *Credentials) DeepCopy() *Credentials {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CostConfiguration) DeepCopyInto(out *CostConfiguration) {
+	*out = *in
+	if in.ExpressionLimit != nil {
+		in, out := &in.ExpressionLimit, &out.ExpressionLimit
+		*out = new(int64)
+		**out = **in
+	}
+	if in.PolicyLimit != nil {
+		in, out := &in.PolicyLimit, &out.PolicyLimit
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CostConfiguration.
+func (in *CostConfiguration) DeepCopy() *CostConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(CostConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DeletingPolicy) DeepCopyInto(out *DeletingPolicy) {
 	*out = *in
@@ -356,6 +382,11 @@ func (in *DeletingPolicySpec) DeepCopyInto(out *DeletingPolicySpec) {
 		*out = new(v1.DeletionPropagation)
 		**out = **in
 	}
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
@@ -374,6 +405,11 @@ func (in *DeletingPolicyStatus) DeepCopyInto(out *DeletingPolicyStatus) {
 	*out = *in
 	in.ConditionStatus.DeepCopyInto(&out.ConditionStatus)
 	in.LastExecutionTime.DeepCopyInto(&out.LastExecutionTime)
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(DryRunStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -387,6 +423,64 @@ func (in *DeletingPolicyStatus) DeepCopy() *DeletingPolicyStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriftDetectionConfiguration) DeepCopyInto(out *DriftDetectionConfiguration) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriftDetectionConfiguration.
+func (in *DriftDetectionConfiguration) DeepCopy() *DriftDetectionConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(DriftDetectionConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DryRunCandidate) DeepCopyInto(out *DryRunCandidate) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DryRunCandidate.
+func (in *DryRunCandidate) DeepCopy() *DryRunCandidate {
+	if in == nil {
+		return nil
+	}
+	out := new(DryRunCandidate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DryRunStatus) DeepCopyInto(out *DryRunStatus) {
+	*out = *in
+	if in.Candidates != nil {
+		in, out := &in.Candidates, &out.Candidates
+		*out = make([]DryRunCandidate, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DryRunStatus.
+func (in *DryRunStatus) DeepCopy() *DryRunStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DryRunStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EvaluationConfiguration) DeepCopyInto(out *EvaluationConfiguration) {
 	*out = *in
@@ -400,6 +494,16 @@ func (in *EvaluationConfiguration) DeepCopyInto(out *EvaluationConfiguration) {
 		*out = new(BackgroundConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Shadow != nil {
+		in, out := &in.Shadow, &out.Shadow
+		*out = new(ShadowConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Cost != nil {
+		in, out := &in.Cost, &out.Cost
+		*out = new(CostConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -480,11 +584,21 @@ func (in *GeneratingPolicyEvaluationConfiguration) DeepCopyInto(out *GeneratingP
 		*out = new(SynchronizationConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DriftDetectionConfiguration != nil {
+		in, out := &in.DriftDetectionConfiguration, &out.DriftDetectionConfiguration
+		*out = new(DriftDetectionConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.OrphanDownstreamOnPolicyDelete != nil {
 		in, out := &in.OrphanDownstreamOnPolicyDelete, &out.OrphanDownstreamOnPolicyDelete
 		*out = new(OrphanDownstreamOnPolicyDeleteConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Cost != nil {
+		in, out := &in.Cost, &out.Cost
+		*out = new(CostConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -531,6 +645,22 @@ func (in *GeneratingPolicyList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GeneratingPolicySource) DeepCopyInto(out *GeneratingPolicySource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GeneratingPolicySource.
+func (in *GeneratingPolicySource) DeepCopy() *GeneratingPolicySource {
+	if in == nil {
+		return nil
+	}
+	out := new(GeneratingPolicySource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GeneratingPolicySpec) DeepCopyInto(out *GeneratingPolicySpec) {
 	*out = *in
@@ -581,6 +711,18 @@ func (in *GeneratingPolicySpec) DeepCopy() *GeneratingPolicySpec {
 func (in *GeneratingPolicyStatus) DeepCopyInto(out *GeneratingPolicyStatus) {
 	*out = *in
 	in.ConditionStatus.DeepCopyInto(&out.ConditionStatus)
+	if in.Sources != nil {
+		in, out := &in.Sources, &out.Sources
+		*out = make([]GeneratingPolicySource, len(*in))
+		copy(*out, *in)
+	}
+	if in.WebhookTiers != nil {
+		in, out := &in.WebhookTiers, &out.WebhookTiers
+		*out = make([]WebhookTierStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -740,6 +882,32 @@ func (in *ImageValidatingPolicyAutogenStatus) DeepCopy() *ImageValidatingPolicyA
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageValidatingPolicyEvaluationConfiguration) DeepCopyInto(out *ImageValidatingPolicyEvaluationConfiguration) {
+	*out = *in
+	if in.Admission != nil {
+		in, out := &in.Admission, &out.Admission
+		*out = new(AdmissionConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Background != nil {
+		in, out := &in.Background, &out.Background
+		*out = new(BackgroundConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageValidatingPolicyEvaluationConfiguration.
+func (in *ImageValidatingPolicyEvaluationConfiguration) DeepCopy() *ImageValidatingPolicyEvaluationConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageValidatingPolicyEvaluationConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ImageValidatingPolicyList) DeepCopyInto(out *ImageValidatingPolicyList) {
 	*out = *in
@@ -850,7 +1018,7 @@ func (in *ImageValidatingPolicySpec) DeepCopyInto(out *ImageValidatingPolicySpec
 	}
 	if in.EvaluationConfiguration != nil {
 		in, out := &in.EvaluationConfiguration, &out.EvaluationConfiguration
-		*out = new(EvaluationConfiguration)
+		*out = new(ImageValidatingPolicyEvaluationConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.AutogenConfiguration != nil {
@@ -876,6 +1044,13 @@ func (in *ImageValidatingPolicyStatus) DeepCopyInto(out *ImageValidatingPolicySt
 	*out = *in
 	in.ConditionStatus.DeepCopyInto(&out.ConditionStatus)
 	in.Autogen.DeepCopyInto(&out.Autogen)
+	if in.WebhookTiers != nil {
+		in, out := &in.WebhookTiers, &out.WebhookTiers
+		*out = make([]WebhookTierStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -1116,6 +1291,11 @@ func (in *MutatingPolicyEvaluationConfiguration) DeepCopyInto(out *MutatingPolic
 		*out = new(MutateExistingConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Cost != nil {
+		in, out := &in.Cost, &out.Cost
+		*out = new(CostConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1230,6 +1410,13 @@ func (in *MutatingPolicyStatus) DeepCopyInto(out *MutatingPolicyStatus) {
 	*out = *in
 	in.ConditionStatus.DeepCopyInto(&out.ConditionStatus)
 	in.Autogen.DeepCopyInto(&out.Autogen)
+	if in.WebhookTiers != nil {
+		in, out := &in.WebhookTiers, &out.WebhookTiers
+		*out = make([]WebhookTierStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -1317,6 +1504,7 @@ func (in *PolicyException) DeepCopyInto(out *PolicyException) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -1384,6 +1572,14 @@ func (in *PolicyExceptionSpec) DeepCopyInto(out *PolicyExceptionSpec) {
 		*out = make([]admissionregistrationv1.MatchCondition, len(*in))
 		copy(*out, *in)
 	}
+	if in.NotBefore != nil {
+		in, out := &in.NotBefore, &out.NotBefore
+		*out = (*in).DeepCopy()
+	}
+	if in.NotAfter != nil {
+		in, out := &in.NotAfter, &out.NotAfter
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
@@ -1397,6 +1593,29 @@ func (in *PolicyExceptionSpec) DeepCopy() *PolicyExceptionSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyExceptionStatus) DeepCopyInto(out *PolicyExceptionStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyExceptionStatus.
+func (in *PolicyExceptionStatus) DeepCopy() *PolicyExceptionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyExceptionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PolicyRef) DeepCopyInto(out *PolicyRef) {
 	*out = *in
@@ -1429,6 +1648,27 @@ func (in *Referrer) DeepCopy() *Referrer {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShadowConfiguration) DeepCopyInto(out *ShadowConfiguration) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShadowConfiguration.
+func (in *ShadowConfiguration) DeepCopy() *ShadowConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(ShadowConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Source) DeepCopyInto(out *Source) {
 	*out = *in
@@ -1745,6 +1985,13 @@ func (in *ValidatingPolicyStatus) DeepCopyInto(out *ValidatingPolicyStatus) {
 	*out = *in
 	in.ConditionStatus.DeepCopyInto(&out.ConditionStatus)
 	in.Autogen.DeepCopyInto(&out.Autogen)
+	if in.WebhookTiers != nil {
+		in, out := &in.WebhookTiers, &out.WebhookTiers
+		*out = make([]WebhookTierStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -1830,3 +2077,24 @@ func (in *WebhookConfiguration) DeepCopy() *WebhookConfiguration {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookTierStatus) DeepCopyInto(out *WebhookTierStatus) {
+	*out = *in
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookTierStatus.
+func (in *WebhookTierStatus) DeepCopy() *WebhookTierStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookTierStatus)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,80 @@
+package v1
+
+import (
+	openreportsv1alpha1 "github.com/openreports/reports-api/apis/openreports.io/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ComplianceSummaryName is the name of the compliance summary maintained in every namespace
+const ComplianceSummaryName = "kyverno-compliance-summary"
+
+type ComplianceSummarySpec struct {
+	// Summary counts the results of all the policy reports in the namespace
+	// +optional
+	Summary openreportsv1alpha1.ReportSummary `json:"summary,omitempty"`
+
+	// Policies counts the results by policy
+	// +optional
+	Policies []ComplianceCount `json:"policies,omitempty"`
+
+	// Severities counts the results by severity, results without severity are counted under an empty name
+	// +optional
+	Severities []ComplianceCount `json:"severities,omitempty"`
+
+	// Categories counts the results by category, results without category are counted under an empty name
+	// +optional
+	Categories []ComplianceCount `json:"categories,omitempty"`
+
+	// History contains one snapshot of the summary per day, oldest first,
+	// it is bounded and the oldest snapshots are dropped when the limit is reached
+	// +optional
+	History []ComplianceSnapshot `json:"history,omitempty"`
+}
+
+// ComplianceCount counts the results sharing the same policy, severity or category
+type ComplianceCount struct {
+	// Name is the policy, severity or category name
+	Name string `json:"name"`
+
+	// Summary counts the results by status
+	Summary openreportsv1alpha1.ReportSummary `json:"summary"`
+}
+
+// ComplianceSnapshot is the summary of a namespace at the end of a day
+type ComplianceSnapshot struct {
+	// Date is the day of the snapshot, formatted as YYYY-MM-DD in UTC
+	Date string `json:"date"`
+
+	// Summary counts the results by status
+	Summary openreportsv1alpha1.ReportSummary `json:"summary"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:resource:shortName=csum,categories=kyverno
+// +kubebuilder:printcolumn:name="Pass",type=integer,JSONPath=".spec.summary.pass"
+// +kubebuilder:printcolumn:name="Fail",type=integer,JSONPath=".spec.summary.fail"
+// +kubebuilder:printcolumn:name="Warn",type=integer,JSONPath=".spec.summary.warn"
+// +kubebuilder:printcolumn:name="Error",type=integer,JSONPath=".spec.summary.error"
+// +kubebuilder:printcolumn:name="Skip",type=integer,JSONPath=".spec.summary.skip"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ComplianceSummary is the Schema for the ComplianceSummaries API,
+// it rolls up the policy reports of a namespace and keeps a daily history of the counts
+type ComplianceSummary struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              ComplianceSummarySpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ComplianceSummaryList contains a list of ComplianceSummary
+type ComplianceSummaryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ComplianceSummary `json:"items"`
+}
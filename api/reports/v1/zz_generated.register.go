@@ -63,6 +63,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&ClusterEphemeralReport{},
 		&ClusterEphemeralReportList{},
+		&ComplianceSummary{},
+		&ComplianceSummaryList{},
 		&EphemeralReport{},
 		&EphemeralReportList{},
 	)
@@ -11,6 +11,7 @@ import (
 	policiesv1alpha1 "github.com/kyverno/kyverno/api/policies.kyverno.io/v1alpha1"
 	"github.com/kyverno/kyverno/cmd/internal"
 	"github.com/kyverno/kyverno/pkg/background"
+	backgroundcommon "github.com/kyverno/kyverno/pkg/background/common"
 	"github.com/kyverno/kyverno/pkg/background/gpol"
 	"github.com/kyverno/kyverno/pkg/breaker"
 	"github.com/kyverno/kyverno/pkg/cel/libs"
@@ -30,6 +31,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 	"github.com/kyverno/kyverno/pkg/event"
 	"github.com/kyverno/kyverno/pkg/globalcontext/store"
+	"github.com/kyverno/kyverno/pkg/httpguard"
 	"github.com/kyverno/kyverno/pkg/leaderelection"
 	"github.com/kyverno/kyverno/pkg/logging"
 	"github.com/kyverno/kyverno/pkg/metrics"
@@ -73,8 +75,13 @@ func createrLeaderControllers(
 	mpolEngine mpolengine.Engine,
 	mapper meta.RESTMapper,
 	reportsConfig reportutils.ReportingConfiguration,
+	queueConfig background.QueueConfiguration,
 ) ([]internal.Controller, error) {
-	watchManager := gpol.NewWatchManager(logging.WithName("WatchManager"), dynamicClient)
+	watchManager := gpol.NewWatchManager(
+		logging.WithName("WatchManager"),
+		dynamicClient,
+		backgroundcommon.NewDriftReporter(kyvernoClient, eventGenerator, reportsConfig),
+	)
 	policyCtrl, err := policy.NewPolicyController(
 		kyvernoClient,
 		dynamicClient,
@@ -114,6 +121,7 @@ func createrLeaderControllers(
 		configuration,
 		jp,
 		reportsConfig,
+		queueConfig,
 	)
 	return []internal.Controller{
 		internal.NewController("policy-controller", policyCtrl, 2),
@@ -129,6 +137,11 @@ func main() {
 		maxAPICallResponseLength        int64
 		maxBackgroundReports            int
 		controllerRuntimeMetricsAddress string
+		updateRequestQueueWeights       string
+		updateRequestRetryBackoff       time.Duration
+		updateRequestMaxRetryBackoff    time.Duration
+		updateRequestMaxAttempts        int
+		updateRequestAbandonedTTL       time.Duration
 	)
 	flagset := flag.NewFlagSet("updaterequest-controller", flag.ExitOnError)
 	flagset.IntVar(&genWorkers, "genWorkers", 10, "Workers for the background controller.")
@@ -136,6 +149,11 @@ func main() {
 	flagset.StringVar(&omitEvents, "omitEvents", "", "Set this flag to a comma sperated list of PolicyViolation, PolicyApplied, PolicyError, PolicySkipped to disable events, e.g. --omitEvents=PolicyApplied,PolicyViolation")
 	flagset.Int64Var(&maxAPICallResponseLength, "maxAPICallResponseLength", 2*1000*1000, "Maximum allowed response size from API Calls. A value of 0 bypasses checks (not recommended).")
 	flagset.IntVar(&maxBackgroundReports, "maxBackgroundReports", 10000, "Maximum number of ephemeralreports created for the background policies.")
+	flagset.StringVar(&updateRequestQueueWeights, "updateRequestQueueWeights", "", "Comma separated list of request type weights used to share workers between update request types, e.g. --updateRequestQueueWeights=mutate=2,generate=1. Types default to a weight of 1.")
+	flagset.DurationVar(&updateRequestRetryBackoff, "updateRequestRetryBackoff", 5*time.Second, "Delay before retrying a failed update request, doubled with every attempt.")
+	flagset.DurationVar(&updateRequestMaxRetryBackoff, "updateRequestMaxRetryBackoff", 5*time.Minute, "Maximum delay between two attempts of a failed update request.")
+	flagset.IntVar(&updateRequestMaxAttempts, "updateRequestMaxAttempts", 5, "Number of failed attempts after which an update request is abandoned.")
+	flagset.DurationVar(&updateRequestAbandonedTTL, "updateRequestAbandonedTTL", 24*time.Hour, "Time abandoned update requests are kept before being deleted, 0 keeps them.")
 	flagset.StringVar(&controllerRuntimeMetricsAddress, "controllerRuntimeMetricsAddress", "", `Bind address for controller-runtime metrics server. It will be defaulted to ":8080" if unspecified. Set this to "0" to disable the metrics server.`)
 
 	// config
@@ -148,6 +166,7 @@ func main() {
 		internal.WithConfigMapCaching(),
 		internal.WithDeferredLoading(),
 		internal.WithRegistryClient(),
+		internal.WithHTTPGuard(),
 		internal.WithLeaderElection(),
 		internal.WithKyvernoClient(),
 		internal.WithDynamicClient(),
@@ -176,6 +195,11 @@ func main() {
 			}
 		}
 		setup.Logger.V(2).Info("setting the background scan interval", "value", bgscanInterval.String())
+		queueWeights, err := background.ParseQueueWeights(updateRequestQueueWeights)
+		if err != nil {
+			setup.Logger.Error(err, "failed to parse update request queue weights")
+			os.Exit(1)
+		}
 		// THIS IS AN UGLY FIX
 		// ELSE KYAML IS NOT THREAD SAFE
 		kyamlopenapi.Schema()
@@ -232,7 +256,7 @@ func main() {
 			setup.KubeClient,
 			setup.KyvernoClient,
 			setup.RegistrySecretLister,
-			apicall.NewAPICallConfiguration(maxAPICallResponseLength),
+			apicall.NewAPICallConfiguration(maxAPICallResponseLength).WithGuard(httpguard.Default()),
 			polexCache,
 			gcstore,
 		)
@@ -288,6 +312,7 @@ func main() {
 				contextProvider, err := libs.NewContextProvider(
 					setup.KyvernoDynamicClient,
 					nil,
+					setup.RegistryClient,
 					gcstore,
 					false,
 				)
@@ -395,6 +420,13 @@ func main() {
 					mpolEngine,
 					restMapper,
 					setup.ReportingConfiguration,
+					background.QueueConfiguration{
+						Weights:         queueWeights,
+						RetryBackoff:    updateRequestRetryBackoff,
+						MaxRetryBackoff: updateRequestMaxRetryBackoff,
+						MaxAttempts:     updateRequestMaxAttempts,
+						AbandonedTTL:    updateRequestAbandonedTTL,
+					},
 				)
 				if err != nil {
 					logger.Error(err, "failed to create leader controllers")
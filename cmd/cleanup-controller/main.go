@@ -209,7 +209,7 @@ func main() {
 			os.Exit(1)
 		}
 
-		libCtx, err := libs.NewContextProvider(setup.KyvernoDynamicClient, nil, gcstore, false)
+		libCtx, err := libs.NewContextProvider(setup.KyvernoDynamicClient, nil, nil, gcstore, false)
 		if err != nil {
 			setup.Logger.Error(err, "failed to create CEL context provider")
 			os.Exit(1)
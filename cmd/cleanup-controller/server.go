@@ -56,7 +56,7 @@ func NewServer(
 		"POST",
 		config.CleanupValidatingWebhookServicePath,
 		handlers.FromAdmissionFunc("VALIDATE", validationHandler).
-			WithDump(debugModeOpts.DumpPayload).
+			WithDump(debugModeOpts.DumpPayload, debugModeOpts.DumpWriter).
 			WithSubResourceFilter().
 			WithMetrics(policyLogger, metricsConfig.Config(), metrics.WebhookValidating).
 			WithAdmission(policyLogger.WithName("validate")).
@@ -66,7 +66,7 @@ func NewServer(
 		"POST",
 		config.TtlValidatingWebhookServicePath,
 		handlers.FromAdmissionFunc("VALIDATE", labelValidationHandler).
-			WithDump(debugModeOpts.DumpPayload).
+			WithDump(debugModeOpts.DumpPayload, debugModeOpts.DumpWriter).
 			WithSubResourceFilter().
 			WithMetrics(labelLogger, metricsConfig.Config(), metrics.WebhookValidating).
 			WithAdmission(labelLogger.WithName("validate")).
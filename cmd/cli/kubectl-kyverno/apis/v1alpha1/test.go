@@ -61,6 +61,22 @@ type CheckResult struct {
 
 	// Error contains negative assertion to be performed on the relevant rule responses
 	Error v1alpha1.Any `json:"error"`
+
+	// CEL contains CEL assertions to be performed on the relevant rule responses,
+	// expressions can use `object`, `oldObject`, `mutatedObject`, `patch`, `generatedResources` and `rule`,
+	// `oldObject` is only set for UPDATE and DELETE operations (from the `request.oldObject` value when present)
+	// and `patch` contains the patches of the whole policy response, not only the ones of the rule
+	// +optional
+	CEL []CELAssertion `json:"cel,omitempty"`
+}
+
+type CELAssertion struct {
+	// Expression is a CEL expression that must evaluate to true
+	Expression string `json:"expression"`
+
+	// Message is displayed when the expression evaluates to false
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 type TestResourceSpec struct {
@@ -38,6 +38,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/config"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	gctxstore "github.com/kyverno/kyverno/pkg/globalcontext/store"
 	eval "github.com/kyverno/kyverno/pkg/imageverification/evaluator"
 	"github.com/kyverno/kyverno/pkg/imageverification/imagedataloader"
 	gitutils "github.com/kyverno/kyverno/pkg/utils/git"
@@ -93,10 +94,11 @@ type ApplyCommandConfig struct {
 	GeneratedExceptionTTL time.Duration
 	JSONPaths             []string
 	ClusterWideResources  bool
+	Snapshot              string
 }
 
 func Command() *cobra.Command {
-	var removeColor, detailedResults, table bool
+	var removeColor, detailedResults, table, watchMode bool
 	applyCommandConfig := &ApplyCommandConfig{}
 	cmd := &cobra.Command{
 		Use:          "apply",
@@ -108,55 +110,14 @@ func Command() *cobra.Command {
 			out := cmd.OutOrStdout()
 			color.Init(removeColor)
 			applyCommandConfig.PolicyPaths = args
-			rc, _, skipInvalidPolicies, responses, err := applyCommandConfig.applyCommandHelper(out)
+			if watchMode {
+				return applyCommandConfig.watch(out, detailedResults, table)
+			}
+			rc, _, err := applyCommandConfig.run(out, detailedResults, table)
 			if err != nil {
 				return err
 			}
 			cmd.SilenceErrors = true
-			printSkippedAndInvalidPolicies(out, skipInvalidPolicies)
-			if applyCommandConfig.PolicyReport {
-				printReports(out, responses, applyCommandConfig.AuditWarn, applyCommandConfig.OutputFormat)
-			} else if applyCommandConfig.GenerateExceptions {
-				printExceptions(out, responses, applyCommandConfig.AuditWarn, applyCommandConfig.OutputFormat, applyCommandConfig.GeneratedExceptionTTL)
-			} else if table {
-				printTable(out, detailedResults, applyCommandConfig.AuditWarn, responses...)
-			} else {
-				for _, response := range responses {
-					var failedRules []engineapi.RuleResponse
-					resPath := fmt.Sprintf("%s/%s/%s", response.Resource.GetNamespace(), response.Resource.GetKind(), response.Resource.GetName())
-					if resPath == "//" {
-						resPath = "JSON payload"
-					}
-					for _, rule := range response.PolicyResponse.Rules {
-						if rule.Status() == engineapi.RuleStatusFail {
-							failedRules = append(failedRules, rule)
-						}
-						if rule.RuleType() == engineapi.Mutation {
-							if rule.Status() == engineapi.RuleStatusSkip {
-								fmt.Fprintln(out, "\nskipped mutate policy", response.Policy().GetName(), "->", "resource", resPath)
-							} else if rule.Status() == engineapi.RuleStatusError {
-								fmt.Fprintln(out, "\nerror while applying mutate policy", response.Policy().GetName(), "->", "resource", resPath, "\nerror: ", rule.Message())
-							}
-						}
-					}
-					if len(failedRules) > 0 {
-						auditWarn := false
-						if applyCommandConfig.AuditWarn && response.GetValidationFailureAction().Audit() {
-							auditWarn = true
-						}
-						if auditWarn {
-							fmt.Fprintln(out, "policy", response.Policy().GetName(), "->", "resource", resPath, "failed as audit warning:")
-						} else {
-							fmt.Fprintln(out, "policy", response.Policy().GetName(), "->", "resource", resPath, "failed:")
-						}
-						for i, rule := range failedRules {
-							fmt.Fprintln(out, i+1, "-", rule.Name(), rule.Message())
-						}
-						fmt.Fprintln(out, "")
-					}
-				}
-				printViolations(out, rc)
-			}
 			return exit(out, rc, applyCommandConfig.warnExitCode, applyCommandConfig.warnNoPassed)
 		},
 	}
@@ -174,7 +135,7 @@ func Command() *cobra.Command {
 	cmd.Flags().StringVarP(&applyCommandConfig.ValuesFile, "values-file", "f", "", "File containing values for policy variables")
 	cmd.Flags().StringVarP(&applyCommandConfig.ContextPath, "context-file", "", "", "File containing context data for CEL policies")
 	cmd.Flags().BoolVarP(&applyCommandConfig.PolicyReport, "policy-report", "p", false, "Generates policy report when passed (default policyviolation)")
-	cmd.Flags().StringVarP(&applyCommandConfig.OutputFormat, "output-format", "", "yaml", "Specifies the policy report format (json or yaml). Default: yaml.")
+	cmd.Flags().StringVarP(&applyCommandConfig.OutputFormat, "output-format", "", "yaml", "Specifies the policy report format (json or yaml), or sarif to print the failed rules in SARIF format. Default: yaml.")
 	cmd.Flags().StringVarP(&applyCommandConfig.Namespace, "namespace", "n", "", "Optional Policy parameter passed with cluster flag")
 	cmd.Flags().BoolVarP(&applyCommandConfig.Stdin, "stdin", "i", false, "Optional mutate policy parameter to pipe directly through to kubectl")
 	cmd.Flags().BoolVar(&applyCommandConfig.RegistryAccess, "registry", false, "If set to true, access the image registry using local docker credentials to populate external data")
@@ -195,10 +156,71 @@ func Command() *cobra.Command {
 	cmd.Flags().BoolVarP(&applyCommandConfig.inlineExceptions, "exceptions-with-resources", "", false, "Evaluate policy exceptions from the resources path")
 	cmd.Flags().BoolVarP(&applyCommandConfig.GenerateExceptions, "generate-exceptions", "", false, "Generate policy exceptions for each violation")
 	cmd.Flags().DurationVarP(&applyCommandConfig.GeneratedExceptionTTL, "generated-exception-ttl", "", time.Hour*24*30, "Default TTL for generated exceptions")
+	cmd.Flags().BoolVar(&watchMode, "watch", false, "If set to true, watch the policy and resource files and apply the policies again when they change")
 	cmd.Flags().BoolVarP(&applyCommandConfig.ClusterWideResources, "cluster-wide-resources", "", false, "If set to true, will apply policies to cluster-wide resources")
+	cmd.Flags().StringVar(&applyCommandConfig.Snapshot, "snapshot", "", "Path to a cluster snapshot directory or tar archive used instead of a live cluster, requires the cluster flag")
 	return cmd
 }
 
+// run applies the policies and prints the results
+func (c *ApplyCommandConfig) run(out io.Writer, detailedResults, table bool) (*processor.ResultCounts, []engineapi.EngineResponse, error) {
+	rc, _, skipInvalidPolicies, responses, err := c.applyCommandHelper(out)
+	if err != nil {
+		return nil, nil, err
+	}
+	if c.OutputFormat == "sarif" {
+		if err := printSarif(out, c.ResourcePaths, c.AuditWarn, responses...); err != nil {
+			return nil, nil, err
+		}
+		return rc, responses, nil
+	}
+	printSkippedAndInvalidPolicies(out, skipInvalidPolicies)
+	if c.PolicyReport {
+		printReports(out, responses, c.AuditWarn, c.OutputFormat)
+	} else if c.GenerateExceptions {
+		printExceptions(out, responses, c.AuditWarn, c.OutputFormat, c.GeneratedExceptionTTL)
+	} else if table {
+		printTable(out, detailedResults, c.AuditWarn, responses...)
+	} else {
+		for _, response := range responses {
+			var failedRules []engineapi.RuleResponse
+			resPath := fmt.Sprintf("%s/%s/%s", response.Resource.GetNamespace(), response.Resource.GetKind(), response.Resource.GetName())
+			if resPath == "//" {
+				resPath = "JSON payload"
+			}
+			for _, rule := range response.PolicyResponse.Rules {
+				if rule.Status() == engineapi.RuleStatusFail {
+					failedRules = append(failedRules, rule)
+				}
+				if rule.RuleType() == engineapi.Mutation {
+					if rule.Status() == engineapi.RuleStatusSkip {
+						fmt.Fprintln(out, "\nskipped mutate policy", response.Policy().GetName(), "->", "resource", resPath)
+					} else if rule.Status() == engineapi.RuleStatusError {
+						fmt.Fprintln(out, "\nerror while applying mutate policy", response.Policy().GetName(), "->", "resource", resPath, "\nerror: ", rule.Message())
+					}
+				}
+			}
+			if len(failedRules) > 0 {
+				auditWarn := false
+				if c.AuditWarn && response.GetValidationFailureAction().Audit() {
+					auditWarn = true
+				}
+				if auditWarn {
+					fmt.Fprintln(out, "policy", response.Policy().GetName(), "->", "resource", resPath, "failed as audit warning:")
+				} else {
+					fmt.Fprintln(out, "policy", response.Policy().GetName(), "->", "resource", resPath, "failed:")
+				}
+				for i, rule := range failedRules {
+					fmt.Fprintln(out, i+1, "-", rule.Name(), rule.Message())
+				}
+				fmt.Fprintln(out, "")
+			}
+		}
+		printViolations(out, rc)
+	}
+	return rc, responses, nil
+}
+
 func (c *ApplyCommandConfig) applyCommandHelper(out io.Writer) (*processor.ResultCounts, []*unstructured.Unstructured, SkippedInvalidPolicies, []engineapi.EngineResponse, error) {
 	var skippedInvalidPolicies SkippedInvalidPolicies
 	err := c.checkArguments()
@@ -286,7 +308,7 @@ func (c *ApplyCommandConfig) applyCommandHelper(out io.Writer) (*processor.Resul
 			celexceptions = results.CELExceptions
 		}
 	}
-	if !c.Stdin && !c.PolicyReport && !c.GenerateExceptions {
+	if !c.Stdin && !c.PolicyReport && !c.GenerateExceptions && c.OutputFormat != "sarif" {
 		var policyRulesCount int
 		for _, policy := range kpols {
 			policyRulesCount += len(autogen.Default.ComputeRules(policy, ""))
@@ -331,17 +353,18 @@ func (c *ApplyCommandConfig) applyCommandHelper(out io.Writer) (*processor.Resul
 	if err != nil {
 		return rc, resources1, skippedInvalidPolicies, responses1, err
 	}
-	responses4, err := c.applyImageValidatingPolicies(ivps, jsonPayloads, resources1, celexceptions, variables.Namespace, userInfo, rc, dClient)
+	namespaceProvider := processor.NamespaceProvider(variables, dClient, c.Cluster)
+	responses4, err := c.applyImageValidatingPolicies(ivps, jsonPayloads, resources1, celexceptions, namespaceProvider, userInfo, rc, dClient, store.GetGlobalContext())
 	if err != nil {
 		return rc, resources1, skippedInvalidPolicies, responses4, err
 	}
 
-	responses5, err := c.applyDeletingPolicies(dps, resources1, celexceptions, variables.Namespace, rc, dClient, "resource")
+	responses5, err := c.applyDeletingPolicies(dps, resources1, celexceptions, namespaceProvider, rc, dClient, store.GetGlobalContext(), "resource")
 	if err != nil {
 		return rc, resources1, skippedInvalidPolicies, responses4, err
 	}
 
-	responses6, err := c.applyDeletingPolicies(dps, jsonPayloads, celexceptions, variables.Namespace, rc, dClient, "json")
+	responses6, err := c.applyDeletingPolicies(dps, jsonPayloads, celexceptions, namespaceProvider, rc, dClient, store.GetGlobalContext(), "json")
 	if err != nil {
 		return rc, resources1, skippedInvalidPolicies, responses4, err
 	}
@@ -505,6 +528,7 @@ func (c *ApplyCommandConfig) applyImageValidatingPolicies(
 	userInfo *kyvernov2.RequestInfo,
 	rc *processor.ResultCounts,
 	dclient dclient.Interface,
+	gctx gctxstore.Store,
 ) ([]engineapi.EngineResponse, error) {
 	if len(ivps) == 0 {
 		return nil, nil
@@ -523,6 +547,7 @@ func (c *ApplyCommandConfig) applyImageValidatingPolicies(
 		matching.NewMatcher(),
 		lister,
 		[]imagedataloader.Option{imagedataloader.WithLocalCredentials(c.RegistryAccess)},
+		nil,
 	)
 
 	restMapper, err := utils.GetRESTMapper(dclient, !c.Cluster)
@@ -530,7 +555,7 @@ func (c *ApplyCommandConfig) applyImageValidatingPolicies(
 		return nil, err
 	}
 
-	contextProvider, err := processor.NewContextProvider(dclient, restMapper, c.ContextPath, c.RegistryAccess, !c.Cluster)
+	contextProvider, err := processor.NewContextProvider(dclient, restMapper, c.ContextPath, c.RegistryAccess, !c.Cluster, gctx)
 	if err != nil {
 		return nil, err
 	}
@@ -635,6 +660,7 @@ func (c *ApplyCommandConfig) applyDeletingPolicies(
 	namespaceProvider func(string) *corev1.Namespace,
 	rc *processor.ResultCounts,
 	dclient dclient.Interface,
+	gctx gctxstore.Store,
 	payloadType string,
 ) ([]engineapi.EngineResponse, error) {
 	provider, err := dpolengine.NewProvider(dpolcompiler.NewCompiler(), dps, celExceptions)
@@ -647,7 +673,7 @@ func (c *ApplyCommandConfig) applyDeletingPolicies(
 		return nil, err
 	}
 
-	contextProvider, err := processor.NewContextProvider(dclient, restMapper, c.ContextPath, c.RegistryAccess, !c.Cluster)
+	contextProvider, err := processor.NewContextProvider(dclient, restMapper, c.ContextPath, c.RegistryAccess, !c.Cluster, gctx)
 	if err != nil {
 		return nil, err
 	}
@@ -832,7 +858,21 @@ func (c *ApplyCommandConfig) initStoreAndClusterClient(store *store.Store, targe
 	}
 	var err error
 	var dClient dclient.Interface
-	if c.Cluster {
+	if c.Cluster && c.Snapshot != "" {
+		snapshot, err := source.LoadSnapshot(c.Snapshot)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load snapshot (%w)", err)
+		}
+		dClient, err = snapshot.Client(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("failed to load snapshot (%w)", err)
+		}
+		gctx, err := snapshot.GlobalContext(context.Background(), dClient)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load snapshot (%w)", err)
+		}
+		store.SetGlobalContext(gctx)
+	} else if c.Cluster {
 		restConfig, err := config.CreateClientConfigWithContext(c.KubeConfig, c.Context)
 		if err != nil {
 			return nil, err
@@ -898,6 +938,9 @@ func (c *ApplyCommandConfig) checkArguments() error {
 	if len(c.ResourcePaths) == 0 && len(c.JSONPaths) == 0 && !c.Cluster {
 		return fmt.Errorf("resource file(s) or cluster required")
 	}
+	if c.Snapshot != "" && !c.Cluster {
+		return fmt.Errorf("a snapshot can only be used together with the cluster flag")
+	}
 	return nil
 }
 
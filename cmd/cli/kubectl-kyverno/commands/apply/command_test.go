@@ -2,6 +2,7 @@ package apply
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
@@ -10,6 +11,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/sarif"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/report"
 	openreportsv1alpha1 "github.com/openreports/reports-api/apis/openreports.io/v1alpha1"
 	"github.com/stretchr/testify/assert"
@@ -670,6 +672,34 @@ func Test_Apply_ValidatingPolicies(t *testing.T) {
 	}
 }
 
+func Test_Apply_Snapshot(t *testing.T) {
+	testcases := []*TestCase{
+		{
+			config: ApplyCommandConfig{
+				PolicyPaths:  []string{"../../../../../test/cli/apply/snapshot/policy.yaml"},
+				Cluster:      true,
+				Snapshot:     "../../../../../test/cli/apply/snapshot/cluster",
+				PolicyReport: true,
+			},
+			expectedReports: []openreportsv1alpha1.Report{{
+				Summary: openreportsv1alpha1.ReportSummary{
+					Pass:  1,
+					Fail:  1,
+					Skip:  0,
+					Error: 0,
+					Warn:  0,
+				},
+			}},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run("", func(t *testing.T) {
+			verifyTestcase(t, tc, compareSummary)
+		})
+	}
+}
+
 func Test_Apply_ImageVerificationPolicies(t *testing.T) {
 	testcases := []*TestCase{
 		{
@@ -1036,3 +1066,26 @@ func TestCommandHelp(t *testing.T) {
 	assert.NoError(t, err)
 	assert.True(t, strings.HasPrefix(string(out), cmd.Long))
 }
+
+func TestCommandSarifOutput(t *testing.T) {
+	cmd := Command()
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{
+		"../../../../../test/cli/test-fail/sarif/policy.yaml",
+		"--resource",
+		"../../../../../test/cli/test-fail/sarif/resources.yaml",
+		"--output-format",
+		"sarif",
+	})
+	err := cmd.Execute()
+	assert.Error(t, err)
+	var log sarif.Log
+	assert.NoError(t, json.Unmarshal(b.Bytes(), &log))
+	if assert.Len(t, log.Runs, 1) && assert.Len(t, log.Runs[0].Results, 1) {
+		result := log.Runs[0].Results[0]
+		assert.Equal(t, "require-run-as-non-root/run-as-non-root", result.RuleID)
+		assert.Equal(t, "../../../../../test/cli/test-fail/sarif/resources.yaml", result.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+		assert.Equal(t, 20, result.Locations[0].PhysicalLocation.Region.StartLine)
+	}
+}
@@ -31,4 +31,12 @@ var examples = [][]string{
 		"# Apply multiple policy with variable on multiple resource",
 		"kyverno apply /path/to/policy1.yaml /path/to/policy2.yaml --resource /path/to/resource1.yaml --resource /path/to/resource2.yaml -f /path/to/value.yaml",
 	},
+	{
+		"# Apply a policy on a resource again each time one of the files changes",
+		"kyverno apply /path/to/policy.yaml --resource /path/to/resource.yaml --watch",
+	},
+	{
+		"# Apply policies on a folder of resources and write the failed rules in SARIF format",
+		"kyverno apply /path/to/policies/ --resource /path/to/resources/ --output-format sarif > results.sarif",
+	},
 }
@@ -10,9 +10,11 @@ import (
 
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/sarif"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/processor"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/report"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/openreports"
 	kyvernoreports "github.com/kyverno/kyverno/pkg/utils/report"
 	openreportsv1alpha1 "github.com/openreports/reports-api/apis/openreports.io/v1alpha1"
 	"github.com/opentracing/opentracing-go/log"
@@ -66,6 +68,35 @@ func printReports(out io.Writer, engineResponses []engineapi.EngineResponse, aud
 	}
 }
 
+// printSarif prints the failed, warned and errored rules in SARIF format, the results are located in the resource files when possible
+func printSarif(out io.Writer, resourcePaths []string, auditWarn bool, engineResponses ...engineapi.EngineResponse) error {
+	var locator sarif.Locator
+	locator.AddPaths(resourcePaths...)
+	builder := sarif.NewBuilder()
+	for _, response := range engineResponses {
+		resource := response.Resource
+		for _, rule := range response.PolicyResponse.Rules {
+			result := report.ComputePolicyReportResult(auditWarn, response, rule)
+			if result.Result != openreports.StatusFail && result.Result != openreports.StatusWarn && result.Result != openreports.StatusError {
+				continue
+			}
+			finding := sarif.Finding{
+				Policy:      result.Policy,
+				Rule:        result.Rule,
+				Annotations: response.Policy().GetAnnotations(),
+				Warning:     result.Result == openreports.StatusWarn,
+				Message:     result.Description,
+			}
+			if resource.GetName() != "" {
+				finding.Resource = fmt.Sprintf("%s/%s/%s", resource.GetNamespace(), resource.GetKind(), resource.GetName())
+				finding.Location = locator.Locate(resource.GetAPIVersion(), resource.GetKind(), resource.GetNamespace(), resource.GetName(), sarif.FieldPath(rule.Message()))
+			}
+			builder.Add(finding)
+		}
+	}
+	return builder.Write(out)
+}
+
 func printExceptions(out io.Writer, engineResponses []engineapi.EngineResponse, auditWarn bool, outputFormat string, ttl time.Duration) {
 	clustered, _ := report.ComputePolicyReports(auditWarn, engineResponses...)
 	for _, report := range clustered {
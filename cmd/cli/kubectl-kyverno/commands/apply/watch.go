@@ -0,0 +1,87 @@
+package apply
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/source"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/watch"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+)
+
+// watch applies the policies and applies them again each time a policy, resource or input file changes
+func (c *ApplyCommandConfig) watch(out io.Writer, detailedResults, table bool) error {
+	if c.Stdin {
+		return fmt.Errorf("watch mode is not supported with stdin")
+	}
+	for _, path := range c.PolicyPaths {
+		if path == "-" || source.IsGit(path) {
+			return fmt.Errorf("watch mode is not supported for policy path %s", path)
+		}
+	}
+	paths := c.watchPaths()
+	if len(paths) == 0 {
+		return fmt.Errorf("no local files to watch")
+	}
+	previous := c.apply(out, detailedResults, table, nil)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	return watch.Run(ctx, out, paths, watch.DefaultDebounce, func(changed []string) {
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, "Change detected, applying policies ...")
+		previous = c.apply(out, detailedResults, table, previous)
+	})
+}
+
+// apply runs the command once and prints the result changes compared to the previous run
+func (c *ApplyCommandConfig) apply(out io.Writer, detailedResults, table bool, previous watch.Results) watch.Results {
+	_, responses, err := c.run(out, detailedResults, table)
+	if err != nil {
+		fmt.Fprintln(out, "Error:", err)
+		// keep the previous results so that the next successful run is compared to them
+		return previous
+	}
+	current := results(responses)
+	if previous != nil {
+		fmt.Fprintln(out)
+		current.Diff(out, previous)
+	}
+	return current
+}
+
+func (c *ApplyCommandConfig) watchPaths() []string {
+	var paths []string
+	add := func(files ...string) {
+		for _, file := range files {
+			if file != "" && !source.IsGit(file) {
+				if _, err := os.Stat(file); err == nil {
+					paths = append(paths, file)
+				}
+			}
+		}
+	}
+	add(c.PolicyPaths...)
+	add(c.ResourcePaths...)
+	add(c.TargetResourcePaths...)
+	add(c.JSONPaths...)
+	add(c.Exception...)
+	add(c.ValuesFile, c.UserInfoPath, c.ContextPath, c.Snapshot)
+	return paths
+}
+
+func results(responses []engineapi.EngineResponse) watch.Results {
+	results := watch.Results{}
+	for _, response := range responses {
+		resPath := fmt.Sprintf("%s/%s/%s", response.Resource.GetNamespace(), response.Resource.GetKind(), response.Resource.GetName())
+		if resPath == "//" {
+			resPath = "JSON payload"
+		}
+		for _, rule := range response.PolicyResponse.Rules {
+			results[watch.Key(response.Policy().GetName(), rule.Name(), resPath)] = string(rule.Status())
+		}
+	}
+	return results
+}
@@ -0,0 +1,438 @@
+package bench
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	policiesv1alpha1 "github.com/kyverno/kyverno/api/policies.kyverno.io/v1alpha1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/data"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/processor"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/store"
+	"github.com/kyverno/kyverno/pkg/cel/compiler"
+	celengine "github.com/kyverno/kyverno/pkg/cel/engine"
+	"github.com/kyverno/kyverno/pkg/cel/libs"
+	"github.com/kyverno/kyverno/pkg/cel/matching"
+	dpolcompiler "github.com/kyverno/kyverno/pkg/cel/policies/dpol/compiler"
+	dpolengine "github.com/kyverno/kyverno/pkg/cel/policies/dpol/engine"
+	gpolcompiler "github.com/kyverno/kyverno/pkg/cel/policies/gpol/compiler"
+	gpolengine "github.com/kyverno/kyverno/pkg/cel/policies/gpol/engine"
+	mpolcompiler "github.com/kyverno/kyverno/pkg/cel/policies/mpol/compiler"
+	mpolengine "github.com/kyverno/kyverno/pkg/cel/policies/mpol/engine"
+	vpolcompiler "github.com/kyverno/kyverno/pkg/cel/policies/vpol/compiler"
+	vpolengine "github.com/kyverno/kyverno/pkg/cel/policies/vpol/engine"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/engine"
+	"github.com/kyverno/kyverno/pkg/engine/adapters"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/factories"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/engine/policycontext"
+	"github.com/kyverno/kyverno/pkg/exceptions"
+	"github.com/kyverno/kyverno/pkg/imageverifycache"
+	"github.com/kyverno/kyverno/pkg/registryclient"
+	"github.com/kyverno/kyverno/pkg/utils/restmapper"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/openapi"
+	"sigs.k8s.io/kubectl-validate/pkg/openapiclient"
+)
+
+// ruleTiming is the processing time of a rule reported by the engine
+type ruleTiming struct {
+	name     string
+	duration time.Duration
+}
+
+// evaluation evaluates a policy against a prepared resource
+type evaluation = func(context.Context) ([]ruleTiming, error)
+
+// target is a policy to measure
+type target struct {
+	kind string
+	name string
+	// prepare builds the evaluation of the policy against a resource outside of the measurements,
+	// a nil evaluation means the resource can't be evaluated by the policy engine
+	prepare func(*unstructured.Unstructured) (evaluation, error)
+}
+
+// celCost is the cumulated runtime cost recorded by the CEL engines for a policy
+type celCost struct {
+	count uint64
+	sum   uint64
+}
+
+// preparedEvaluation is the evaluation of a policy against a resource
+type preparedEvaluation struct {
+	resource string
+	evaluate evaluation
+}
+
+// prepareAll builds the evaluations of the policy against all the resources
+func (t target) prepareAll(resources []*unstructured.Unstructured) ([]preparedEvaluation, error) {
+	out := make([]preparedEvaluation, 0, len(resources))
+	for _, resource := range resources {
+		evaluate, err := t.prepare(resource)
+		if err != nil {
+			return nil, fmt.Errorf("failed to prepare evaluation of %s %s on %s (%w)", t.kind, t.name, resource.GetName(), err)
+		}
+		if evaluate != nil {
+			out = append(out, preparedEvaluation{resource: resource.GetName(), evaluate: evaluate})
+		}
+	}
+	return out, nil
+}
+
+// run measures a policy against all the resources
+func (t target) run(ctx context.Context, resources []*unstructured.Unstructured, iterations, warmup int) (PolicyResult, error) {
+	result := PolicyResult{
+		Kind: t.kind,
+		Name: t.name,
+	}
+	// the CEL engines record the cost of the policies they evaluate with their kind
+	var cost celCost
+	ctx = compiler.WithCostRecorder(ctx, func(policyType string, policyName string, value uint64) {
+		if policyType == t.kind && policyName == t.name {
+			cost.count++
+			cost.sum += value
+		}
+	})
+	for i := 0; i < warmup; i++ {
+		evaluations, err := t.prepareAll(resources)
+		if err != nil {
+			return result, err
+		}
+		for _, evaluation := range evaluations {
+			if _, err := evaluation.evaluate(ctx); err != nil {
+				return result, fmt.Errorf("failed to evaluate %s %s on %s (%w)", t.kind, t.name, evaluation.resource, err)
+			}
+		}
+	}
+	cost = celCost{}
+	// the evaluations of all the iterations are prepared before the measurements,
+	// the memory statistics are read once around the measured evaluations
+	var evaluations []preparedEvaluation
+	for i := 0; i < iterations; i++ {
+		prepared, err := t.prepareAll(resources)
+		if err != nil {
+			return result, err
+		}
+		evaluations = append(evaluations, prepared...)
+	}
+	durations := make([]time.Duration, len(evaluations))
+	rules := make([][]ruleTiming, len(evaluations))
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	for i, evaluation := range evaluations {
+		start := time.Now()
+		timings, err := evaluation.evaluate(ctx)
+		durations[i] = time.Since(start)
+		if err != nil {
+			return result, fmt.Errorf("failed to evaluate %s %s on %s (%w)", t.kind, t.name, evaluation.resource, err)
+		}
+		rules[i] = timings
+	}
+	runtime.ReadMemStats(&after)
+	result.Evaluations = len(durations)
+	result.Latency = newLatency(durations)
+	if result.Evaluations != 0 {
+		result.Allocations = (after.Mallocs - before.Mallocs) / uint64(result.Evaluations)
+		result.AllocatedBytes = (after.TotalAlloc - before.TotalAlloc) / uint64(result.Evaluations)
+	}
+	ruleDurations := map[string][]time.Duration{}
+	var ruleNames []string
+	for _, timings := range rules {
+		for _, rule := range timings {
+			if _, ok := ruleDurations[rule.name]; !ok {
+				ruleNames = append(ruleNames, rule.name)
+			}
+			ruleDurations[rule.name] = append(ruleDurations[rule.name], rule.duration)
+		}
+	}
+	for _, name := range ruleNames {
+		result.Rules = append(result.Rules, RuleResult{
+			Name:        name,
+			Evaluations: len(ruleDurations[name]),
+			Latency:     newLatency(ruleDurations[name]),
+		})
+	}
+	if cost.count != 0 {
+		average := cost.sum / cost.count
+		result.CELCost = &average
+	}
+	return result, nil
+}
+
+// targets builds the policies to measure
+type targets struct {
+	config            config.Configuration
+	jp                jmespath.Interface
+	restMapper        meta.RESTMapper
+	context           libs.Context
+	namespaces        map[string]*corev1.Namespace
+	namespaceProvider func(string) *corev1.Namespace
+}
+
+func newTargets(namespaces map[string]*corev1.Namespace) (*targets, error) {
+	cfg := config.NewDefaultConfiguration(false)
+	restMapper, err := restmapper.GetRESTMapper(nil, true)
+	if err != nil {
+		return nil, err
+	}
+	contextProvider, err := processor.NewContextProvider(nil, restMapper, "", false, true, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &targets{
+		config:     cfg,
+		jp:         jmespath.New(cfg),
+		restMapper: restMapper,
+		context:    contextProvider,
+		namespaces: namespaces,
+		namespaceProvider: func(name string) *corev1.Namespace {
+			return namespaces[name]
+		},
+	}, nil
+}
+
+func (t *targets) namespaceLabels(resource *unstructured.Unstructured) map[string]string {
+	if namespace := t.namespaces[resource.GetNamespace()]; namespace != nil {
+		return namespace.GetLabels()
+	}
+	return nil
+}
+
+// kyvernoPolicies returns the targets of kyverno.io policies, mutate and validate rules are evaluated
+func (t *targets) kyvernoPolicies(s *store.Store, policies []kyvernov1.PolicyInterface) []target {
+	rclient := s.GetRegistryClient()
+	if rclient == nil {
+		rclient = registryclient.NewOrDie()
+	}
+	isCluster := false
+	eng := engine.NewEngine(
+		t.config,
+		config.NewDefaultMetricsConfiguration(),
+		t.jp,
+		nil,
+		factories.DefaultRegistryClientFactory(adapters.RegistryClient(rclient), nil),
+		imageverifycache.DisabledImageVerifyCache(),
+		store.ContextLoaderFactory(s, nil),
+		exceptions.New(emptyExceptionLister{}),
+		&isCluster,
+	)
+	var out []target
+	for _, policy := range policies {
+		spec := policy.GetSpec()
+		if !spec.HasMutateStandard() && !spec.HasValidate() {
+			continue
+		}
+		out = append(out, target{
+			kind: policy.GetKind(),
+			name: policy.GetName(),
+			prepare: func(resource *unstructured.Unstructured) (evaluation, error) {
+				policyContext, err := policycontext.NewPolicyContext(t.jp, *resource, kyvernov1.Create, nil, t.config)
+				if err != nil {
+					return nil, err
+				}
+				policyContext = policyContext.
+					WithPolicy(policy).
+					WithNamespaceLabels(t.namespaceLabels(resource)).
+					WithResourceKind(resource.GroupVersionKind(), "")
+				return func(ctx context.Context) ([]ruleTiming, error) {
+					var rules []ruleTiming
+					if spec.HasMutateStandard() {
+						rules = append(rules, ruleTimings(eng.Mutate(ctx, policyContext))...)
+					}
+					if spec.HasValidate() {
+						rules = append(rules, ruleTimings(eng.Validate(ctx, policyContext))...)
+					}
+					return rules, nil
+				}, nil
+			},
+		})
+	}
+	return out
+}
+
+func ruleTimings(response engineapi.EngineResponse) []ruleTiming {
+	var out []ruleTiming
+	for _, rule := range response.PolicyResponse.Rules {
+		out = append(out, ruleTiming{name: rule.Name(), duration: rule.Stats().ProcessingTime()})
+	}
+	return out
+}
+
+// request returns the admission request of the creation of a resource, nil if the resource kind is unknown
+func (t *targets) request(resource *unstructured.Unstructured) *celengine.EngineRequest {
+	gvk := resource.GroupVersionKind()
+	mapping, err := t.restMapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil
+	}
+	request := celengine.Request(
+		t.context,
+		gvk,
+		mapping.Resource,
+		"",
+		resource.GetName(),
+		resource.GetNamespace(),
+		admissionv1.Create,
+		authenticationv1.UserInfo{},
+		resource,
+		nil,
+		false,
+		nil,
+	)
+	return &request
+}
+
+// validatingPolicies returns the targets of ValidatingPolicies, all the policies share an engine
+func (t *targets) validatingPolicies(policies []policiesv1alpha1.ValidatingPolicy) ([]target, error) {
+	if len(policies) == 0 {
+		return nil, nil
+	}
+	provider, err := vpolengine.NewProvider(vpolcompiler.NewCompiler(), policies, nil)
+	if err != nil {
+		return nil, err
+	}
+	eng := vpolengine.NewEngine(provider, t.namespaceProvider, matching.NewMatcher())
+	var out []target
+	for _, policy := range policies {
+		name := policy.GetName()
+		predicate := func(policy policiesv1alpha1.ValidatingPolicy) bool { return policy.GetName() == name }
+		out = append(out, target{
+			kind: "ValidatingPolicy",
+			name: name,
+			prepare: func(resource *unstructured.Unstructured) (evaluation, error) {
+				request := t.request(resource)
+				if request == nil {
+					return nil, nil
+				}
+				return func(ctx context.Context) ([]ruleTiming, error) {
+					_, err := eng.Handle(ctx, *request, predicate)
+					return nil, err
+				}, nil
+			},
+		})
+	}
+	return out, nil
+}
+
+// mutatingPolicies returns the targets of MutatingPolicies, all the policies share an engine
+func (t *targets) mutatingPolicies(policies []policiesv1alpha1.MutatingPolicy) ([]target, error) {
+	if len(policies) == 0 {
+		return nil, nil
+	}
+	provider, err := mpolengine.NewProvider(mpolcompiler.NewCompiler(), policies, nil)
+	if err != nil {
+		return nil, err
+	}
+	tcm := mpolcompiler.NewStaticTypeConverterManager(openAPI())
+	eng := mpolengine.NewEngine(provider, t.namespaceProvider, matching.NewMatcher(), tcm, t.context)
+	var out []target
+	for _, policy := range policies {
+		name := policy.GetName()
+		predicate := func(policy policiesv1alpha1.MutatingPolicy) bool { return policy.GetName() == name }
+		out = append(out, target{
+			kind: "MutatingPolicy",
+			name: name,
+			prepare: func(resource *unstructured.Unstructured) (evaluation, error) {
+				request := t.request(resource)
+				if request == nil {
+					return nil, nil
+				}
+				return func(ctx context.Context) ([]ruleTiming, error) {
+					_, err := eng.Handle(ctx, *request, predicate)
+					return nil, err
+				}, nil
+			},
+		})
+	}
+	return out, nil
+}
+
+// generatingPolicies returns the targets of GeneratingPolicies, the generated resources are not created
+func (t *targets) generatingPolicies(policies []policiesv1alpha1.GeneratingPolicy) ([]target, error) {
+	if len(policies) == 0 {
+		return nil, nil
+	}
+	compiler := gpolcompiler.NewCompiler()
+	eng := gpolengine.NewEngine(t.namespaceProvider, matching.NewMatcher())
+	var out []target
+	for i := range policies {
+		compiled, errs := compiler.Compile(&policies[i], nil)
+		if len(errs) > 0 {
+			return nil, fmt.Errorf("failed to compile policy %s (%w)", policies[i].GetName(), errs.ToAggregate())
+		}
+		policy := gpolengine.Policy{
+			Policy:         policies[i],
+			CompiledPolicy: compiled,
+		}
+		out = append(out, target{
+			kind: "GeneratingPolicy",
+			name: policy.Policy.GetName(),
+			prepare: func(resource *unstructured.Unstructured) (evaluation, error) {
+				request := t.request(resource)
+				if request == nil {
+					return nil, nil
+				}
+				return func(ctx context.Context) ([]ruleTiming, error) {
+					_, err := eng.Handle(*request, policy, false)
+					return nil, err
+				}, nil
+			},
+		})
+	}
+	return out, nil
+}
+
+// deletingPolicies returns the targets of DeletingPolicies
+func (t *targets) deletingPolicies(ctx context.Context, policies []policiesv1alpha1.DeletingPolicy) ([]target, error) {
+	if len(policies) == 0 {
+		return nil, nil
+	}
+	provider, err := dpolengine.NewProvider(dpolcompiler.NewCompiler(), policies, nil)
+	if err != nil {
+		return nil, err
+	}
+	compiled, err := provider.Fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	eng := dpolengine.NewEngine(t.namespaceProvider, t.restMapper, t.context, matching.NewMatcher())
+	var out []target
+	for _, policy := range compiled {
+		out = append(out, target{
+			kind: "DeletingPolicy",
+			name: policy.Policy.GetName(),
+			prepare: func(resource *unstructured.Unstructured) (evaluation, error) {
+				return func(ctx context.Context) ([]ruleTiming, error) {
+					_, err := eng.Handle(ctx, policy, *resource)
+					return nil, err
+				}, nil
+			},
+		})
+	}
+	return out, nil
+}
+
+func openAPI() openapi.Client {
+	clients := []openapi.Client{openapiclient.NewHardcodedBuiltins("1.32")}
+	if crds, err := data.Crds(); err == nil {
+		clients = append(clients, openapiclient.NewLocalSchemaFiles(crds))
+	}
+	return openapiclient.NewComposite(clients...)
+}
+
+type emptyExceptionLister struct{}
+
+func (emptyExceptionLister) List(labels.Selector) ([]*kyvernov2.PolicyException, error) {
+	return nil, nil
+}
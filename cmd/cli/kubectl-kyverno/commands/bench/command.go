@@ -0,0 +1,37 @@
+package bench
+
+import (
+	"log"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
+	"github.com/spf13/cobra"
+)
+
+func Command() *cobra.Command {
+	var options options
+	cmd := &cobra.Command{
+		Use:          "bench [policy paths]...",
+		Short:        command.FormatDescription(true, websiteUrl, false, description...),
+		Long:         command.FormatDescription(false, websiteUrl, false, description...),
+		Example:      command.FormatExamples(examples...),
+		Args:         cobra.MinimumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.policies = args
+			if err := options.validate(); err != nil {
+				return err
+			}
+			return options.execute(cmd.Context(), cmd.OutOrStdout())
+		},
+	}
+	cmd.Flags().StringSliceVarP(&options.resources, "resource", "r", nil, "Path to resource files or directories")
+	cmd.Flags().IntVarP(&options.iterations, "iterations", "n", 100, "Number of evaluations of every policy against every resource")
+	cmd.Flags().IntVar(&options.warmup, "warmup", 1, "Number of evaluations of every policy against every resource before measurements start")
+	cmd.Flags().StringVarP(&options.output, "output", "o", "text", "Output format (text or json), latencies are reported in nanoseconds in JSON")
+	cmd.Flags().StringVar(&options.baseline, "baseline", "", "Path to a JSON report of a previous run, the command fails if a policy or rule regressed compared to it")
+	cmd.Flags().Float64Var(&options.threshold, "threshold", 10, "Allowed increase in percent of the p95 latency and allocations before a regression is reported")
+	if err := cmd.MarkFlagRequired("resource"); err != nil {
+		log.Println("WARNING", err)
+	}
+	return cmd
+}
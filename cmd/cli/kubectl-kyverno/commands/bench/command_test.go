@@ -0,0 +1,107 @@
+package bench
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runCommand(t *testing.T, args ...string) (Report, error) {
+	cmd := Command()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs(append([]string{
+		"../../../../../test/cli/bench/policies.yaml",
+		"--resource", "../../../../../test/cli/bench/resources.yaml",
+		"--iterations", "5",
+		"--output", "json",
+	}, args...))
+	err := cmd.Execute()
+	var report Report
+	require.NoError(t, json.Unmarshal(out.Bytes(), &report))
+	return report, err
+}
+
+func TestCommand(t *testing.T) {
+	report, err := runCommand(t)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, report.Iterations)
+	assert.Equal(t, 3, report.Resources)
+	require.Len(t, report.Policies, 5)
+	policies := map[string]PolicyResult{}
+	for _, policy := range report.Policies {
+		policies[policy.key()] = policy
+		assert.Equal(t, 15, policy.Evaluations)
+		assert.NotZero(t, policy.Latency.P50)
+		assert.LessOrEqual(t, policy.Latency.P50, policy.Latency.P95)
+		assert.LessOrEqual(t, policy.Latency.P95, policy.Latency.P99)
+	}
+	clusterPolicy := policies["ClusterPolicy/require-labels"]
+	require.Len(t, clusterPolicy.Rules, 2)
+	for _, rule := range clusterPolicy.Rules {
+		// rules match pods only
+		assert.Equal(t, 10, rule.Evaluations)
+	}
+	assert.Nil(t, clusterPolicy.CELCost)
+	vpol := policies["ValidatingPolicy/disallow-latest-tag"]
+	require.NotNil(t, vpol.CELCost)
+	assert.NotZero(t, *vpol.CELCost)
+	// mutating policies report the cost of their variables
+	mpol := policies["MutatingPolicy/add-image-tier"]
+	require.NotNil(t, mpol.CELCost)
+	assert.NotZero(t, *mpol.CELCost)
+	assert.Contains(t, policies, "DeletingPolicy/delete-stale-pods")
+	assert.Contains(t, policies, "GeneratingPolicy/generate-pod-config")
+}
+
+func TestCommandBaseline(t *testing.T) {
+	report, err := runCommand(t)
+	require.NoError(t, err)
+	write := func(report Report) string {
+		path := filepath.Join(t.TempDir(), "baseline.json")
+		content, err := json.Marshal(report)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(path, content, 0o600))
+		return path
+	}
+	// a slower baseline doesn't report regressions
+	slower := report
+	slower.Policies = nil
+	for _, policy := range report.Policies {
+		policy.Latency.P95 *= 1000
+		policy.Allocations *= 1000
+		policy.Rules = nil
+		slower.Policies = append(slower.Policies, policy)
+	}
+	_, err = runCommand(t, "--baseline", write(slower))
+	assert.NoError(t, err)
+	// a faster baseline reports regressions of the policies latency and allocations
+	faster := report
+	faster.Policies = nil
+	for _, policy := range report.Policies {
+		policy.Latency.P95 = 1
+		policy.Allocations = 1
+		policy.Rules = nil
+		faster.Policies = append(faster.Policies, policy)
+	}
+	_, err = runCommand(t, "--baseline", write(faster))
+	assert.EqualError(t, err, "10 regressions above 10% compared to the baseline")
+}
+
+func TestCommandInvalidIterations(t *testing.T) {
+	cmd := Command()
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{
+		"../../../../../test/cli/bench/policies.yaml",
+		"--resource", "../../../../../test/cli/bench/resources.yaml",
+		"--iterations", "0",
+	})
+	assert.EqualError(t, cmd.Execute(), "invalid number of iterations 0, must be at least 1")
+}
@@ -0,0 +1,33 @@
+package bench
+
+var websiteUrl = `https://kyverno.io/docs/kyverno-cli/usage/bench/`
+
+var description = []string{
+	`Measures the latency of policies against a set of resources.`,
+	``,
+	`Every policy is evaluated repeatedly against every resource through the same engines as the admission controller.`,
+	`The command reports the p50, p95 and p99 latency of every policy and rule, the allocations of a policy evaluation`,
+	`and the average runtime cost of the CEL expressions, when the engine reports it.`,
+	``,
+	`A JSON report can be used as a baseline of a later run, the command then fails if the p95 latency or the allocations`,
+	`of a policy or rule increased by more than the threshold.`,
+	``,
+	`Policies, ClusterPolicies, ValidatingPolicies, MutatingPolicies, GeneratingPolicies and DeletingPolicies are measured,`,
+	`other policies are ignored. Image verification and generate rules are not evaluated, GeneratingPolicies are evaluated`,
+	`without creating the generated resources.`,
+}
+
+var examples = [][]string{
+	{
+		`# Measure the latency of the policies of a directory`,
+		`kyverno bench /path/to/policies --resource /path/to/resources/`,
+	},
+	{
+		`# Save a baseline report`,
+		`kyverno bench /path/to/policies --resource /path/to/resources/ --iterations 500 --output json > baseline.json`,
+	},
+	{
+		`# Fail if the p95 latency or the allocations of a policy increased by more than 20% compared to the baseline`,
+		`kyverno bench /path/to/policies --resource /path/to/resources/ --iterations 500 --baseline baseline.json --threshold 20`,
+	},
+}
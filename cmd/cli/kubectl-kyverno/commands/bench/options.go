@@ -0,0 +1,146 @@
+package bench
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/store"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/common"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+type options struct {
+	policies   []string
+	resources  []string
+	iterations int
+	warmup     int
+	output     string
+	baseline   string
+	threshold  float64
+}
+
+func (o options) validate() error {
+	if len(o.resources) == 0 {
+		return errors.New("at least one resource is required")
+	}
+	if o.iterations < 1 {
+		return fmt.Errorf("invalid number of iterations %d, must be at least 1", o.iterations)
+	}
+	if o.warmup < 0 {
+		return fmt.Errorf("invalid number of warmup iterations %d, must be positive", o.warmup)
+	}
+	if o.threshold < 0 {
+		return fmt.Errorf("invalid threshold %g, must be positive", o.threshold)
+	}
+	if o.output != "text" && o.output != "json" {
+		return fmt.Errorf("invalid output format %s, must be text or json", o.output)
+	}
+	return nil
+}
+
+func (o options) execute(ctx context.Context, out io.Writer) error {
+	var baseline *Report
+	if o.baseline != "" {
+		report, err := loadReport(o.baseline)
+		if err != nil {
+			return fmt.Errorf("failed to load baseline (%w)", err)
+		}
+		baseline = &report
+	}
+	results, err := policy.Load(nil, "", o.policies...)
+	if err != nil {
+		return fmt.Errorf("failed to load policies (%w)", err)
+	}
+	resources, err := common.GetResourcesWithTest(out, nil, o.resources, "")
+	if err != nil {
+		return fmt.Errorf("failed to load resources (%w)", err)
+	}
+	namespaces, err := loadNamespaces(resources)
+	if err != nil {
+		return err
+	}
+	ignored := len(results.VAPs) + len(results.MAPs) + len(results.ImageValidatingPolicies) + len(results.CleanupPolicies)
+	if ignored != 0 && o.output == "text" {
+		fmt.Fprintln(out, "Warning: only Policies, ClusterPolicies, ValidatingPolicies, MutatingPolicies, GeneratingPolicies and DeletingPolicies are measured, other policies are ignored")
+	}
+	targets, err := newTargets(namespaces)
+	if err != nil {
+		return err
+	}
+	var s store.Store
+	s.SetLocal(true)
+	all := targets.kyvernoPolicies(&s, results.Policies)
+	vpols, err := targets.validatingPolicies(results.ValidatingPolicies)
+	if err != nil {
+		return fmt.Errorf("failed to compile validating policies (%w)", err)
+	}
+	all = append(all, vpols...)
+	mpols, err := targets.mutatingPolicies(results.MutatingPolicies)
+	if err != nil {
+		return fmt.Errorf("failed to compile mutating policies (%w)", err)
+	}
+	all = append(all, mpols...)
+	gpols, err := targets.generatingPolicies(results.GeneratingPolicies)
+	if err != nil {
+		return fmt.Errorf("failed to compile generating policies (%w)", err)
+	}
+	all = append(all, gpols...)
+	dpols, err := targets.deletingPolicies(ctx, results.DeletingPolicies)
+	if err != nil {
+		return fmt.Errorf("failed to compile deleting policies (%w)", err)
+	}
+	all = append(all, dpols...)
+	if len(all) == 0 {
+		return errors.New("no policy to measure")
+	}
+	report := Report{
+		Iterations: o.iterations,
+		Resources:  len(resources),
+		Policies:   make([]PolicyResult, 0, len(all)),
+	}
+	for _, target := range all {
+		result, err := target.run(ctx, resources, o.iterations, o.warmup)
+		if err != nil {
+			return err
+		}
+		report.Policies = append(report.Policies, result)
+	}
+	if o.output == "json" {
+		if err := report.writeJSON(out); err != nil {
+			return err
+		}
+	} else if err := report.writeText(out); err != nil {
+		return err
+	}
+	if baseline == nil {
+		return nil
+	}
+	regressions := report.Compare(*baseline, o.threshold)
+	if o.output == "text" {
+		writeRegressions(out, regressions, o.threshold)
+	}
+	if len(regressions) != 0 {
+		return fmt.Errorf("%d regressions above %g%% compared to the baseline", len(regressions), o.threshold)
+	}
+	return nil
+}
+
+func loadNamespaces(resources []*unstructured.Unstructured) (map[string]*corev1.Namespace, error) {
+	namespaces := map[string]*corev1.Namespace{}
+	for _, resource := range resources {
+		if resource.GetAPIVersion() != "v1" || resource.GetKind() != "Namespace" {
+			continue
+		}
+		var namespace corev1.Namespace
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(resource.Object, &namespace); err != nil {
+			return nil, fmt.Errorf("failed to convert namespace %s (%w)", resource.GetName(), err)
+		}
+		namespaces[namespace.Name] = &namespace
+	}
+	return namespaces, nil
+}
@@ -0,0 +1,188 @@
+package bench
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"text/tabwriter"
+	"time"
+)
+
+// Report is the result of a benchmark run
+type Report struct {
+	Iterations int            `json:"iterations"`
+	Resources  int            `json:"resources"`
+	Policies   []PolicyResult `json:"policies"`
+}
+
+// PolicyResult contains the measurements of a policy
+type PolicyResult struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+	// Evaluations is the number of measured evaluations of the policy
+	Evaluations int     `json:"evaluations"`
+	Latency     Latency `json:"latency"`
+	// Allocations is the average number of heap allocations of an evaluation
+	Allocations uint64 `json:"allocations"`
+	// AllocatedBytes is the average number of bytes allocated by an evaluation
+	AllocatedBytes uint64 `json:"allocatedBytes"`
+	// CELCost is the average runtime cost of the CEL expressions evaluated by the policy for a resource
+	CELCost *uint64      `json:"celCost,omitempty"`
+	Rules   []RuleResult `json:"rules,omitempty"`
+}
+
+// RuleResult contains the measurements of a rule, rules are only measured when they apply to a resource
+type RuleResult struct {
+	Name        string  `json:"name"`
+	Evaluations int     `json:"evaluations"`
+	Latency     Latency `json:"latency"`
+}
+
+// Latency contains latency percentiles
+type Latency struct {
+	P50 time.Duration `json:"p50"`
+	P95 time.Duration `json:"p95"`
+	P99 time.Duration `json:"p99"`
+}
+
+// Regression is a measurement that increased by more than the threshold compared to the baseline
+type Regression struct {
+	Policy   string
+	Rule     string
+	Metric   string
+	Baseline uint64
+	Current  uint64
+}
+
+func newLatency(durations []time.Duration) Latency {
+	if len(durations) == 0 {
+		return Latency{}
+	}
+	sorted := slices.Clone(durations)
+	slices.Sort(sorted)
+	return Latency{
+		P50: percentile(sorted, 50),
+		P95: percentile(sorted, 95),
+		P99: percentile(sorted, 99),
+	}
+}
+
+// percentile returns the nearest rank percentile of sorted durations
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+func (r PolicyResult) key() string {
+	return r.Kind + "/" + r.Name
+}
+
+// Compare returns the measurements of the report that increased by more than threshold percent compared to the baseline,
+// policies and rules missing from the baseline are ignored
+func (r Report) Compare(baseline Report, threshold float64) []Regression {
+	regressed := func(baseline, current uint64) bool {
+		return float64(current) > float64(baseline)*(1+threshold/100)
+	}
+	policies := map[string]PolicyResult{}
+	for _, policy := range baseline.Policies {
+		policies[policy.key()] = policy
+	}
+	var regressions []Regression
+	for _, policy := range r.Policies {
+		base, ok := policies[policy.key()]
+		if !ok || base.Evaluations == 0 || policy.Evaluations == 0 {
+			continue
+		}
+		if regressed(uint64(base.Latency.P95), uint64(policy.Latency.P95)) {
+			regressions = append(regressions, Regression{Policy: policy.key(), Metric: "p95", Baseline: uint64(base.Latency.P95), Current: uint64(policy.Latency.P95)})
+		}
+		if regressed(base.Allocations, policy.Allocations) {
+			regressions = append(regressions, Regression{Policy: policy.key(), Metric: "allocations", Baseline: base.Allocations, Current: policy.Allocations})
+		}
+		rules := map[string]RuleResult{}
+		for _, rule := range base.Rules {
+			rules[rule.Name] = rule
+		}
+		for _, rule := range policy.Rules {
+			base, ok := rules[rule.Name]
+			if !ok || base.Evaluations == 0 || rule.Evaluations == 0 {
+				continue
+			}
+			if regressed(uint64(base.Latency.P95), uint64(rule.Latency.P95)) {
+				regressions = append(regressions, Regression{Policy: policy.key(), Rule: rule.Name, Metric: "p95", Baseline: uint64(base.Latency.P95), Current: uint64(rule.Latency.P95)})
+			}
+		}
+	}
+	return regressions
+}
+
+func (r Regression) String() string {
+	name := r.Policy
+	if r.Rule != "" {
+		name += "/" + r.Rule
+	}
+	baseline, current := fmt.Sprint(r.Baseline), fmt.Sprint(r.Current)
+	if r.Metric == "p95" {
+		baseline, current = time.Duration(r.Baseline).String(), time.Duration(r.Current).String()
+	}
+	increase := "+inf"
+	if r.Baseline != 0 {
+		increase = fmt.Sprintf("+%.1f%%", (float64(r.Current)/float64(r.Baseline)-1)*100)
+	}
+	return fmt.Sprintf("%s: %s increased from %s to %s (%s)", name, r.Metric, baseline, current, increase)
+}
+
+func loadReport(path string) (Report, error) {
+	var report Report
+	content, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return report, err
+	}
+	if err := json.Unmarshal(content, &report); err != nil {
+		return report, fmt.Errorf("failed to parse %s (%w)", path, err)
+	}
+	return report, nil
+}
+
+func (r Report) writeJSON(out io.Writer) error {
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+func (r Report) writeText(out io.Writer) error {
+	fmt.Fprintf(out, "Evaluated %d policies against %d resources, %d iterations\n\n", len(r.Policies), r.Resources, r.Iterations)
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "KIND\tPOLICY\tRULE\tEVALUATIONS\tP50\tP95\tP99\tALLOCS/OP\tBYTES/OP\tCEL COST")
+	for _, policy := range r.Policies {
+		cost := "-"
+		if policy.CELCost != nil {
+			cost = fmt.Sprint(*policy.CELCost)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\t%s\t%s\t%d\t%d\t%s\n", policy.Kind, policy.Name, "-", policy.Evaluations,
+			policy.Latency.P50, policy.Latency.P95, policy.Latency.P99, policy.Allocations, policy.AllocatedBytes, cost)
+		for _, rule := range policy.Rules {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\t%s\t%s\t%s\t%s\t%s\n", policy.Kind, policy.Name, rule.Name, rule.Evaluations,
+				rule.Latency.P50, rule.Latency.P95, rule.Latency.P99, "-", "-", "-")
+		}
+	}
+	return w.Flush()
+}
+
+func writeRegressions(out io.Writer, regressions []Regression, threshold float64) {
+	if len(regressions) == 0 {
+		fmt.Fprintf(out, "\nNo regression above %g%% compared to the baseline\n", threshold)
+		return
+	}
+	fmt.Fprintf(out, "\n%d regressions above %g%% compared to the baseline:\n", len(regressions), threshold)
+	for _, regression := range regressions {
+		fmt.Fprintf(out, "  %s\n", regression)
+	}
+}
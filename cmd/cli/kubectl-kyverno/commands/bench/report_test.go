@@ -0,0 +1,96 @@
+package bench
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_newLatency(t *testing.T) {
+	var durations []time.Duration
+	for i := 100; i > 0; i-- {
+		durations = append(durations, time.Duration(i)*time.Millisecond)
+	}
+	assert.Equal(t, Latency{
+		P50: 50 * time.Millisecond,
+		P95: 95 * time.Millisecond,
+		P99: 99 * time.Millisecond,
+	}, newLatency(durations))
+	assert.Equal(t, Latency{P50: time.Second, P95: time.Second, P99: time.Second}, newLatency([]time.Duration{time.Second}))
+	assert.Equal(t, Latency{}, newLatency(nil))
+}
+
+func TestReport_Compare(t *testing.T) {
+	baseline := Report{
+		Policies: []PolicyResult{{
+			Kind:        "ClusterPolicy",
+			Name:        "require-labels",
+			Evaluations: 10,
+			Latency:     Latency{P95: 100 * time.Microsecond},
+			Allocations: 100,
+			Rules: []RuleResult{{
+				Name:        "check-team",
+				Evaluations: 10,
+				Latency:     Latency{P95: 50 * time.Microsecond},
+			}},
+		}},
+	}
+	tests := []struct {
+		name   string
+		policy PolicyResult
+		want   []Regression
+	}{{
+		name:   "unchanged",
+		policy: baseline.Policies[0],
+	}, {
+		name: "within threshold",
+		policy: PolicyResult{
+			Kind:        "ClusterPolicy",
+			Name:        "require-labels",
+			Evaluations: 10,
+			Latency:     Latency{P95: 109 * time.Microsecond},
+			Allocations: 110,
+		},
+	}, {
+		name: "regressed",
+		policy: PolicyResult{
+			Kind:        "ClusterPolicy",
+			Name:        "require-labels",
+			Evaluations: 10,
+			Latency:     Latency{P95: 150 * time.Microsecond},
+			Allocations: 200,
+			Rules: []RuleResult{{
+				Name:        "check-team",
+				Evaluations: 10,
+				Latency:     Latency{P95: 60 * time.Microsecond},
+			}},
+		},
+		want: []Regression{
+			{Policy: "ClusterPolicy/require-labels", Metric: "p95", Baseline: 100000, Current: 150000},
+			{Policy: "ClusterPolicy/require-labels", Metric: "allocations", Baseline: 100, Current: 200},
+			{Policy: "ClusterPolicy/require-labels", Rule: "check-team", Metric: "p95", Baseline: 50000, Current: 60000},
+		},
+	}, {
+		name: "not in baseline",
+		policy: PolicyResult{
+			Kind:        "ValidatingPolicy",
+			Name:        "require-labels",
+			Evaluations: 10,
+			Latency:     Latency{P95: time.Second},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := Report{Policies: []PolicyResult{tt.policy}}
+			assert.Equal(t, tt.want, report.Compare(baseline, 10))
+		})
+	}
+}
+
+func TestRegression_String(t *testing.T) {
+	regression := Regression{Policy: "ClusterPolicy/require-labels", Rule: "check-team", Metric: "p95", Baseline: 50000, Current: 60000}
+	assert.Equal(t, "ClusterPolicy/require-labels/check-team: p95 increased from 50µs to 60µs (+20.0%)", regression.String())
+	regression = Regression{Policy: "ClusterPolicy/require-labels", Metric: "allocations", Baseline: 100, Current: 250}
+	assert.Equal(t, "ClusterPolicy/require-labels: allocations increased from 100 to 250 (+150.0%)", regression.String())
+}
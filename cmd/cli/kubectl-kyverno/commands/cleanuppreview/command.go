@@ -0,0 +1,33 @@
+package cleanuppreview
+
+import (
+	"log"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
+	"github.com/spf13/cobra"
+)
+
+func Command() *cobra.Command {
+	var options options
+	cmd := &cobra.Command{
+		Use:          "cleanup-preview [policy paths]...",
+		Short:        command.FormatDescription(true, websiteUrl, false, description...),
+		Long:         command.FormatDescription(false, websiteUrl, false, description...),
+		Example:      command.FormatExamples(examples...),
+		Args:         cobra.MinimumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.policies = args
+			if err := options.validate(); err != nil {
+				return err
+			}
+			return options.execute(cmd.Context(), cmd.OutOrStdout())
+		},
+	}
+	cmd.Flags().StringSliceVarP(&options.resources, "resource", "r", nil, "Path to resource files or directories")
+	cmd.Flags().StringVarP(&options.output, "output", "o", "text", "Output format (text or json)")
+	if err := cmd.MarkFlagRequired("resource"); err != nil {
+		log.Println("WARNING", err)
+	}
+	return cmd
+}
@@ -0,0 +1,63 @@
+package cleanuppreview
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommand(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{
+		"../../../../../test/cli/cleanup-preview/policies.yaml",
+		"--resource", "../../../../../test/cli/cleanup-preview/resources.yaml",
+		"--output", "json",
+	})
+	assert.NoError(t, cmd.Execute())
+	var report report
+	assert.NoError(t, json.Unmarshal(out.Bytes(), &report))
+	assert.Equal(t, 9, report.Resources)
+	assert.Equal(t, 3, report.Deleted)
+	candidates := map[string][]string{}
+	for _, result := range report.Policies {
+		for _, candidate := range result.Candidates {
+			candidates[result.Name] = append(candidates[result.Name], candidate.Kind+"/"+candidate.Namespace+"/"+candidate.Name)
+		}
+	}
+	assert.Equal(t, map[string][]string{
+		"delete-stale-pods":            {"Pod/dev/stale-pod"},
+		"cleanup-temporary-configmaps": {"ConfigMap/dev/temporary-config"},
+		"cleanup-jobs":                 {"Job/dev/migration"},
+	}, candidates)
+}
+
+func TestCommandText(t *testing.T) {
+	cmd := Command()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{
+		"../../../../../test/cli/cleanup-preview/policies.yaml",
+		"--resource", "../../../../../test/cli/cleanup-preview/resources.yaml",
+	})
+	assert.NoError(t, cmd.Execute())
+	assert.True(t, strings.HasSuffix(out.String(), "3 resource(s) would be deleted out of 9 evaluated by 3 policies\n"))
+	assert.Contains(t, out.String(), "CleanupPolicy dev/cleanup-jobs: 1 resource(s) would be deleted\n  Job dev/migration (batch/v1)\n")
+}
+
+func TestCommandInvalidOutput(t *testing.T) {
+	cmd := Command()
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{
+		"../../../../../test/cli/cleanup-preview/policies.yaml",
+		"--resource", "../../../../../test/cli/cleanup-preview/resources.yaml",
+		"--output", "yaml",
+	})
+	assert.EqualError(t, cmd.Execute(), "invalid output format yaml, must be text or json")
+}
@@ -0,0 +1,23 @@
+package cleanuppreview
+
+var websiteUrl = `https://kyverno.io/docs/kyverno-cli/usage/cleanup-preview/`
+
+var description = []string{
+	`Prints the resources that deleting and cleanup policies would remove.`,
+	``,
+	`DeletingPolicies, CleanupPolicies and ClusterCleanupPolicies are evaluated against local resource files,`,
+	`nothing is deleted. Namespace labels are taken from the Namespace resources found in the resource files.`,
+	``,
+	`API calls, ConfigMap and global context entries of cleanup policies are not loaded.`,
+}
+
+var examples = [][]string{
+	{
+		`# Print the resources a policy would delete`,
+		`kyverno cleanup-preview /path/to/policy.yaml --resource /path/to/resources/`,
+	},
+	{
+		`# Print the resources in JSON format`,
+		`kyverno cleanup-preview /path/to/policies/ --resource /path/to/resources.yaml --output json`,
+	},
+}
@@ -0,0 +1,216 @@
+package cleanuppreview
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	policiesv1alpha1 "github.com/kyverno/kyverno/api/policies.kyverno.io/v1alpha1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/processor"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/common"
+	"github.com/kyverno/kyverno/pkg/cel/matching"
+	dpolcompiler "github.com/kyverno/kyverno/pkg/cel/policies/dpol/compiler"
+	dpolengine "github.com/kyverno/kyverno/pkg/cel/policies/dpol/engine"
+	"github.com/kyverno/kyverno/pkg/cleanup"
+	"github.com/kyverno/kyverno/pkg/config"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/factories"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/logging"
+	"github.com/kyverno/kyverno/pkg/utils/restmapper"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+type options struct {
+	policies  []string
+	resources []string
+	output    string
+}
+
+// result lists the resources a policy would delete
+type result struct {
+	Kind       string                             `json:"kind"`
+	Namespace  string                             `json:"namespace,omitempty"`
+	Name       string                             `json:"name"`
+	Candidates []policiesv1alpha1.DryRunCandidate `json:"candidates"`
+}
+
+type report struct {
+	Resources int      `json:"resources"`
+	Deleted   int      `json:"deleted"`
+	Policies  []result `json:"policies"`
+}
+
+func (o options) validate() error {
+	if len(o.resources) == 0 {
+		return fmt.Errorf("at least one resource is required")
+	}
+	if o.output != "text" && o.output != "json" {
+		return fmt.Errorf("invalid output format %s, must be text or json", o.output)
+	}
+	return nil
+}
+
+func (o options) execute(ctx context.Context, out io.Writer) error {
+	results, err := policy.Load(nil, "", o.policies...)
+	if err != nil {
+		return fmt.Errorf("failed to load policies (%w)", err)
+	}
+	if len(results.DeletingPolicies) == 0 && len(results.CleanupPolicies) == 0 {
+		return fmt.Errorf("no deleting or cleanup policy found")
+	}
+	resources, err := common.GetResourcesWithTest(out, nil, o.resources, "")
+	if err != nil {
+		return fmt.Errorf("failed to load resources (%w)", err)
+	}
+	namespaces, err := loadNamespaces(resources)
+	if err != nil {
+		return err
+	}
+	report := report{
+		Resources: len(resources),
+		Policies:  []result{},
+	}
+	deleting, err := previewDeletingPolicies(ctx, results.DeletingPolicies, resources, namespaces)
+	if err != nil {
+		return err
+	}
+	report.Policies = append(report.Policies, deleting...)
+	for _, policy := range results.CleanupPolicies {
+		result, err := previewCleanupPolicy(ctx, policy, resources, namespaces)
+		if err != nil {
+			return err
+		}
+		report.Policies = append(report.Policies, result)
+	}
+	for _, result := range report.Policies {
+		report.Deleted += len(result.Candidates)
+	}
+	if o.output == "json" {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+	printText(out, report)
+	return nil
+}
+
+func loadNamespaces(resources []*unstructured.Unstructured) (map[string]*corev1.Namespace, error) {
+	namespaces := map[string]*corev1.Namespace{}
+	for _, resource := range resources {
+		if resource.GetAPIVersion() != "v1" || resource.GetKind() != "Namespace" {
+			continue
+		}
+		var namespace corev1.Namespace
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(resource.Object, &namespace); err != nil {
+			return nil, fmt.Errorf("failed to convert namespace %s (%w)", resource.GetName(), err)
+		}
+		namespaces[namespace.Name] = &namespace
+	}
+	return namespaces, nil
+}
+
+func previewDeletingPolicies(ctx context.Context, policies []policiesv1alpha1.DeletingPolicy, resources []*unstructured.Unstructured, namespaces map[string]*corev1.Namespace) ([]result, error) {
+	if len(policies) == 0 {
+		return nil, nil
+	}
+	provider, err := dpolengine.NewProvider(dpolcompiler.NewCompiler(), policies, nil)
+	if err != nil {
+		return nil, err
+	}
+	restMapper, err := restmapper.GetRESTMapper(nil, true)
+	if err != nil {
+		return nil, err
+	}
+	contextProvider, err := processor.NewContextProvider(nil, restMapper, "", false, true, nil)
+	if err != nil {
+		return nil, err
+	}
+	namespaceProvider := func(name string) *corev1.Namespace {
+		return namespaces[name]
+	}
+	engine := dpolengine.NewEngine(namespaceProvider, restMapper, contextProvider, matching.NewMatcher())
+	compiled, err := provider.Fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var results []result
+	for _, dpol := range compiled {
+		result := result{
+			Kind:       "DeletingPolicy",
+			Name:       dpol.Policy.GetName(),
+			Candidates: []policiesv1alpha1.DryRunCandidate{},
+		}
+		for _, resource := range resources {
+			response, err := engine.Handle(ctx, dpol, *resource)
+			if err != nil {
+				return nil, fmt.Errorf("failed to evaluate policy %s on %s (%w)", dpol.Policy.GetName(), resource.GetName(), err)
+			}
+			if response.Match {
+				result.Candidates = append(result.Candidates, cleanup.DryRunCandidate(*resource))
+			}
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func previewCleanupPolicy(ctx context.Context, policy kyvernov2.CleanupPolicyInterface, resources []*unstructured.Unstructured, namespaces map[string]*corev1.Namespace) (result, error) {
+	result := result{
+		Kind:       policy.GetKind(),
+		Namespace:  policy.GetNamespace(),
+		Name:       policy.GetName(),
+		Candidates: []policiesv1alpha1.DryRunCandidate{},
+	}
+	logger := logging.WithName("cleanup-preview").V(4)
+	configuration := config.NewDefaultConfiguration(false)
+	jp := jmespath.New(configuration)
+	enginectx := enginecontext.NewContext(jp)
+	// only variable context entries can be loaded locally
+	loader := factories.DefaultContextLoaderFactory(nil)(nil, kyvernov1.Rule{})
+	if err := loader.Load(ctx, jp, nil, nil, policy.GetSpec().Context, enginectx); err != nil {
+		return result, fmt.Errorf("failed to load context of policy %s (%w)", policy.GetName(), err)
+	}
+	for _, resource := range resources {
+		// like the cleanup controller, namespaced policies only list resources in their namespace
+		if policy.GetNamespace() != "" && resource.GetNamespace() != policy.GetNamespace() {
+			continue
+		}
+		var nsLabels map[string]string
+		if namespace := namespaces[resource.GetNamespace()]; namespace != nil {
+			nsLabels = namespace.GetLabels()
+		}
+		matched, err := cleanup.Matches(logger, policy, *resource, nsLabels, enginectx, configuration)
+		if err != nil {
+			return result, fmt.Errorf("failed to evaluate policy %s on %s (%w)", policy.GetName(), resource.GetName(), err)
+		}
+		if matched {
+			result.Candidates = append(result.Candidates, cleanup.DryRunCandidate(*resource))
+		}
+	}
+	return result, nil
+}
+
+func printText(out io.Writer, report report) {
+	for _, result := range report.Policies {
+		name := result.Name
+		if result.Namespace != "" {
+			name = result.Namespace + "/" + result.Name
+		}
+		fmt.Fprintf(out, "%s %s: %d resource(s) would be deleted\n", result.Kind, name, len(result.Candidates))
+		for _, candidate := range result.Candidates {
+			if candidate.Namespace != "" {
+				fmt.Fprintf(out, "  %s %s/%s (%s)\n", candidate.Kind, candidate.Namespace, candidate.Name, candidate.APIVersion)
+			} else {
+				fmt.Fprintf(out, "  %s %s (%s)\n", candidate.Kind, candidate.Name, candidate.APIVersion)
+			}
+		}
+	}
+	fmt.Fprintf(out, "\n%d resource(s) would be deleted out of %d evaluated by %d policies\n", report.Deleted, report.Resources, len(report.Policies))
+}
@@ -3,6 +3,9 @@ package commands
 import (
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/apply"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/bench"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/cleanuppreview"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/convert"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/create"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/docs"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/fix"
@@ -10,6 +13,8 @@ import (
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/json"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/migrate"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/oci"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/replay"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/snapshot"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/test"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/version"
 	"github.com/spf13/cobra"
@@ -27,11 +32,16 @@ func RootCommand(experimental bool) *cobra.Command {
 	}
 	cmd.AddCommand(
 		apply.Command(),
+		bench.Command(),
+		cleanuppreview.Command(),
+		convert.Command(),
 		create.Command(),
 		docs.Command(cmd),
 		jp.Command(),
 		json.Command(),
 		migrate.Command(),
+		replay.Command(),
+		snapshot.Command(),
 		test.Command(),
 		version.Command(),
 	)
@@ -0,0 +1,29 @@
+package convert
+
+import (
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
+	"github.com/spf13/cobra"
+)
+
+func Command() *cobra.Command {
+	var options options
+	cmd := &cobra.Command{
+		Use:          "convert [policy paths]...",
+		Short:        command.FormatDescription(true, websiteUrl, false, description...),
+		Long:         command.FormatDescription(false, websiteUrl, false, description...),
+		Example:      command.FormatExamples(examples...),
+		Args:         cobra.MinimumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.policies = args
+			if err := options.validate(); err != nil {
+				return err
+			}
+			return options.execute(cmd.OutOrStdout(), cmd.ErrOrStderr())
+		},
+	}
+	cmd.Flags().StringVarP(&options.output, "output", "o", "", "Output file, the converted policies are printed to stdout when not set")
+	cmd.Flags().StringVar(&options.reportFormat, "report-format", "text", "Format of the conversion report printed to stderr (text or json)")
+	cmd.Flags().BoolVar(&options.failOnTodo, "fail-on-todo", false, "Fail if at least one construct could not be translated")
+	return cmd
+}
@@ -0,0 +1,72 @@
+package convert
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kyverno/kyverno/pkg/cel/policies/vpol/convert"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommand(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	var out, errOut bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&errOut)
+	cmd.SetArgs([]string{
+		"../../../../../test/cli/convert/policies.yaml",
+		"--report-format", "json",
+	})
+	assert.NoError(t, cmd.Execute())
+	assert.Equal(t, 4, strings.Count(out.String(), "kind: ValidatingPolicy\n"))
+	assert.NotContains(t, out.String(), "creationTimestamp")
+	assert.NotContains(t, out.String(), "status:")
+	assert.Contains(t, out.String(), "name: disallow-latest-tag-validate-image-tag\n")
+	var report report
+	assert.NoError(t, json.Unmarshal(errOut.Bytes(), &report))
+	assert.Equal(t, 4, report.Converted)
+	assert.Equal(t, []convert.Issue{{
+		Policy:  "restrict-pod-security",
+		Rule:    "baseline",
+		Path:    "spec.rules[0].validate.podSecurity",
+		Message: "pod security rules are not supported, write the pod security checks as CEL expressions",
+	}, {
+		Policy:  "restrict-pod-security",
+		Rule:    "baseline",
+		Path:    "spec.rules[0].validate",
+		Message: "no validation could be translated",
+	}}, report.Issues)
+}
+
+func TestCommandOutput(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "policies.yaml")
+	cmd := Command()
+	var out, errOut bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&errOut)
+	cmd.SetArgs([]string{
+		"../../../../../test/cli/convert/policies.yaml",
+		"--output", output,
+		"--fail-on-todo",
+	})
+	assert.EqualError(t, cmd.Execute(), "2 constructs could not be translated")
+	assert.Empty(t, out.String())
+	assert.True(t, strings.HasPrefix(errOut.String(), "Converted 4 validating policies, 2 TODOs.\n"))
+	assert.Contains(t, errOut.String(), "  - rule baseline: spec.rules[0].validate: no validation could be translated\n")
+	content, err := os.ReadFile(output)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, strings.Count(string(content), "kind: ValidatingPolicy\n"))
+}
+
+func TestCommandInvalidReportFormat(t *testing.T) {
+	cmd := Command()
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"../../../../../test/cli/convert/policies.yaml", "--report-format", "yaml"})
+	assert.EqualError(t, cmd.Execute(), "invalid report format yaml, must be text or json")
+}
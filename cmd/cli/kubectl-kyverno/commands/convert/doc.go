@@ -0,0 +1,29 @@
+package convert
+
+var websiteUrl = `https://kyverno.io/docs/kyverno-cli/usage/convert/`
+
+var description = []string{
+	`Converts the validate rules of Kyverno policies to ValidatingPolicies.`,
+	``,
+	`Every validate rule is converted to a ValidatingPolicy. Patterns, anyPatterns, deny conditions and foreach declarations`,
+	`are translated to CEL validations, match and exclude blocks to match constraints and match conditions,`,
+	`and context entries to variables.`,
+	``,
+	`Constructs that can't be translated are listed in the conversion report printed to stderr and recorded in the`,
+	`policies.kyverno.io/conversion-todo annotation of the generated policy, they must be reviewed manually.`,
+}
+
+var examples = [][]string{
+	{
+		`# Convert the policies of a directory and print the validating policies`,
+		`kyverno convert /path/to/policies`,
+	},
+	{
+		`# Convert a policy to a file`,
+		`kyverno convert /path/to/policy.yaml --output validating-policies.yaml`,
+	},
+	{
+		`# Print the conversion report in JSON format and fail if something could not be translated`,
+		`kyverno convert /path/to/policies --report-format json --fail-on-todo > /dev/null`,
+	},
+}
@@ -0,0 +1,115 @@
+package convert
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy"
+	"github.com/kyverno/kyverno/pkg/cel/policies/vpol/convert"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+type options struct {
+	policies     []string
+	output       string
+	reportFormat string
+	failOnTodo   bool
+}
+
+type report struct {
+	Converted int             `json:"converted"`
+	Issues    []convert.Issue `json:"issues"`
+}
+
+func (o options) validate() error {
+	if o.reportFormat != "text" && o.reportFormat != "json" {
+		return fmt.Errorf("invalid report format %s, must be text or json", o.reportFormat)
+	}
+	return nil
+}
+
+func (o options) execute(out io.Writer, errOut io.Writer) error {
+	results, err := policy.Load(nil, "", o.policies...)
+	if err != nil {
+		return fmt.Errorf("failed to load policies (%w)", err)
+	}
+	report := report{Issues: []convert.Issue{}}
+	var documents []byte
+	for _, p := range results.Policies {
+		converted := convert.Convert(p)
+		for _, vp := range converted.Policies {
+			bytes, err := marshal(vp)
+			if err != nil {
+				return fmt.Errorf("failed to marshal validating policy %s (%w)", vp.Name, err)
+			}
+			documents = append(documents, []byte("---\n")...)
+			documents = append(documents, bytes...)
+		}
+		report.Converted += len(converted.Policies)
+		report.Issues = append(report.Issues, converted.Issues...)
+	}
+	if o.output == "" {
+		if _, err := out.Write(documents); err != nil {
+			return err
+		}
+	} else {
+		if err := os.MkdirAll(filepath.Dir(o.output), 0o750); err != nil {
+			return err
+		}
+		if err := os.WriteFile(o.output, documents, 0o600); err != nil {
+			return fmt.Errorf("failed to write validating policies (%w)", err)
+		}
+	}
+	if err := o.printReport(errOut, report); err != nil {
+		return err
+	}
+	if o.failOnTodo && len(report.Issues) != 0 {
+		return fmt.Errorf("%d constructs could not be translated", len(report.Issues))
+	}
+	return nil
+}
+
+func marshal(obj any) ([]byte, error) {
+	untyped, err := kubeutils.ObjToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+	unstructured.RemoveNestedField(untyped.UnstructuredContent(), "status")
+	unstructured.RemoveNestedField(untyped.UnstructuredContent(), "metadata", "creationTimestamp")
+	jsonBytes, err := untyped.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return yaml.JSONToYAML(jsonBytes)
+}
+
+func (o options) printReport(out io.Writer, report report) error {
+	if o.reportFormat == "json" {
+		bytes, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(out, string(bytes))
+		return err
+	}
+	fmt.Fprintf(out, "Converted %d validating policies, %d TODOs.\n", report.Converted, len(report.Issues))
+	var last string
+	for _, issue := range report.Issues {
+		if issue.Policy != last {
+			fmt.Fprintln(out, "")
+			fmt.Fprintln(out, "Policy", issue.Policy)
+			last = issue.Policy
+		}
+		if issue.Rule != "" {
+			fmt.Fprintf(out, "  - rule %s: %s\n", issue.Rule, issue)
+		} else {
+			fmt.Fprintf(out, "  - %s\n", issue)
+		}
+	}
+	return nil
+}
@@ -3,9 +3,15 @@ package oci
 import (
 	"bytes"
 	"io"
+	"log"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/sigstore/cosign/v2/pkg/cosign"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -64,3 +70,51 @@ func TestCommandHelp(t *testing.T) {
 	assert.NoError(t, err)
 	assert.True(t, strings.HasPrefix(string(out), cmd.Long))
 }
+
+func TestSignedPolicyImage(t *testing.T) {
+	server := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	defer server.Close()
+	imageRef := strings.TrimPrefix(server.URL, "http://") + "/policies:v1"
+	password := func(bool) ([]byte, error) { return []byte("secret"), nil }
+	keys, err := cosign.GenerateKeyPair(password)
+	assert.NoError(t, err)
+	other, err := cosign.GenerateKeyPair(password)
+	assert.NoError(t, err)
+	keysDir := t.TempDir()
+	privateKey := filepath.Join(keysDir, "cosign.key")
+	publicKey := filepath.Join(keysDir, "cosign.pub")
+	otherKey := filepath.Join(keysDir, "other.pub")
+	assert.NoError(t, os.WriteFile(privateKey, keys.PrivateBytes, 0o600))
+	assert.NoError(t, os.WriteFile(publicKey, keys.PublicBytes, 0o600))
+	assert.NoError(t, os.WriteFile(otherKey, other.PublicBytes, 0o600))
+	t.Setenv("COSIGN_PASSWORD", "secret")
+	run := func(args ...string) error {
+		cmd := Command()
+		cmd.SetOut(io.Discard)
+		cmd.SetErr(io.Discard)
+		cmd.SetArgs(args)
+		return cmd.Execute()
+	}
+	assert.NoError(t, run("push", "../../../../../test/best_practices/disallow_latest_tag.yaml", "-i", imageRef, "--sign-key", privateKey))
+	// verified with the public key
+	dir := t.TempDir()
+	assert.NoError(t, run("pull", dir, "-i", imageRef, "--key", publicKey))
+	_, err = os.Stat(filepath.Join(dir, "disallow-latest-tag.yaml"))
+	assert.NoError(t, err)
+	// verification with another key fails closed
+	dir = t.TempDir()
+	assert.ErrorContains(t, run("pull", dir, "-i", imageRef, "--key", otherKey), "verifying image signature")
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+	// the tag is replaced by an unsigned image
+	assert.NoError(t, run("push", "../../../../../test/best_practices/disallow_privileged.yaml", "-i", imageRef))
+	dir = filepath.Join(t.TempDir(), "policies")
+	assert.ErrorContains(t, run("pull", dir, "-i", imageRef, "--key", publicKey), "verifying image signature")
+	_, err = os.Stat(dir)
+	assert.True(t, os.IsNotExist(err))
+	// unsigned images can still be pulled without verification
+	assert.NoError(t, run("pull", dir, "-i", imageRef))
+	_, err = os.Stat(filepath.Join(dir, "disallow-privileged.yaml"))
+	assert.NoError(t, err)
+}
@@ -0,0 +1,71 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+)
+
+const PolicyManifestLayerMediaType = "application/vnd.cncf.kyverno.policy.manifest.v1+json"
+
+// Manifest lists the policies contained in a policy image
+type Manifest struct {
+	Policies []ManifestEntry `json:"policies"`
+}
+
+// ManifestEntry describes a policy layer of a policy image
+type ManifestEntry struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+	// Digest is the digest of the policy layer
+	Digest string `json:"digest"`
+}
+
+// ManifestEntryFor returns the manifest entry of a policy layer
+func ManifestEntryFor(annotations map[string]string, layer v1.Layer) (ManifestEntry, error) {
+	digest, err := layer.Digest()
+	if err != nil {
+		return ManifestEntry{}, err
+	}
+	return ManifestEntry{
+		APIVersion: annotations[AnnotationApiVersion],
+		Kind:       annotations[AnnotationKind],
+		Name:       annotations[AnnotationName],
+		Digest:     digest.String(),
+	}, nil
+}
+
+// VerifyManifest checks that the policy layers of an image are exactly the layers listed in its manifest
+func VerifyManifest(manifest Manifest, layers []v1.Layer) error {
+	expected := map[string]ManifestEntry{}
+	for _, entry := range manifest.Policies {
+		expected[entry.Digest] = entry
+	}
+	for _, layer := range layers {
+		digest, err := layer.Digest()
+		if err != nil {
+			return fmt.Errorf("getting layer digest: %v", err)
+		}
+		if _, ok := expected[digest.String()]; !ok {
+			return fmt.Errorf("policy layer %s is not listed in the manifest", digest)
+		}
+		delete(expected, digest.String())
+	}
+	for _, entry := range manifest.Policies {
+		if _, ok := expected[entry.Digest]; ok {
+			return fmt.Errorf("policy %s listed in the manifest is missing (layer %s)", entry.Name, entry.Digest)
+		}
+	}
+	return nil
+}
+
+// ParseManifest parses the content of a manifest layer
+func ParseManifest(content []byte) (Manifest, error) {
+	var manifest Manifest
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return Manifest{}, fmt.Errorf("unmarshaling manifest: %v", err)
+	}
+	return manifest, nil
+}
@@ -0,0 +1,46 @@
+package internal
+
+import (
+	"testing"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyManifest(t *testing.T) {
+	first := static.NewLayer([]byte("first"), PolicyLayerMediaType)
+	second := static.NewLayer([]byte("second"), PolicyLayerMediaType)
+	entry := func(name string, layer v1.Layer) ManifestEntry {
+		e, err := ManifestEntryFor(map[string]string{AnnotationName: name, AnnotationKind: "ClusterPolicy", AnnotationApiVersion: "kyverno.io/v1"}, layer)
+		assert.NoError(t, err)
+		return e
+	}
+	manifest := Manifest{Policies: []ManifestEntry{entry("first", first), entry("second", second)}}
+	tests := []struct {
+		name    string
+		layers  []v1.Layer
+		wantErr string
+	}{{
+		name:   "match",
+		layers: []v1.Layer{second, first},
+	}, {
+		name:    "missing",
+		layers:  []v1.Layer{first},
+		wantErr: "policy second listed in the manifest is missing",
+	}, {
+		name:    "unlisted",
+		layers:  []v1.Layer{first, second, static.NewLayer([]byte("third"), PolicyLayerMediaType)},
+		wantErr: "is not listed in the manifest",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyManifest(manifest, tt.layers)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -26,6 +26,11 @@ func Command(keychain authn.Keychain) *cobra.Command {
 		},
 	}
 	cmd.Flags().StringVarP(&options.imageRef, "image", "i", "", "image reference to push to or pull from")
+	cmd.Flags().StringVar(&options.key, "key", "", "path to a public key, or a KMS or Kubernetes secret reference, used to verify the image signature")
+	cmd.Flags().StringVar(&options.certIdentity, "certificate-identity", "", "identity of the keyless signature certificate")
+	cmd.Flags().StringVar(&options.certIdentityRegexp, "certificate-identity-regexp", "", "regular expression matching the identity of the keyless signature certificate")
+	cmd.Flags().StringVar(&options.certOidcIssuer, "certificate-oidc-issuer", "", "OIDC issuer of the keyless signature certificate")
+	cmd.Flags().StringVar(&options.rekorURL, "rekor-url", "https://rekor.sigstore.dev", "URL of the Rekor transparency log used to verify keyless signatures")
 	if err := cmd.MarkFlagRequired("image"); err != nil {
 		log.Println("WARNING", err)
 	}
@@ -4,6 +4,9 @@ var websiteUrl = `https://kyverno.io/docs/kyverno-cli/#pulling`
 
 var description = []string{
 	`Pulls policie(s) that are included in an OCI image from OCI registry and saves them to a local directory.`,
+	``,
+	`When a public key or a keyless identity is provided, the image signature and its policy manifest are verified`,
+	`before any file is written, and the command fails if the verification fails.`,
 }
 
 var examples = [][]string{
@@ -11,4 +14,12 @@ var examples = [][]string{
 		`# Pull policy from an OCI image and save it to the specific directory`,
 		`kyverno oci pull . -i <imgref>`,
 	},
+	{
+		`# Pull policies from an OCI image signed with a cosign key`,
+		`kyverno oci pull . -i <imgref> --key cosign.pub`,
+	},
+	{
+		`# Pull policies from an OCI image signed keyless`,
+		`kyverno oci pull . -i <imgref> --certificate-identity https://github.com/org/repo/.github/workflows/release.yaml@refs/heads/main --certificate-oidc-issuer https://token.actions.githubusercontent.com`,
+	},
 }
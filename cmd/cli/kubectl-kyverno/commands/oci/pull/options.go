@@ -11,14 +11,23 @@ import (
 	securejoin "github.com/cyphar/filepath-securejoin"
 	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/oci/internal"
+	"github.com/kyverno/kyverno/pkg/cosign"
+	"github.com/kyverno/kyverno/pkg/images"
+	"github.com/kyverno/kyverno/pkg/registryclient"
 	policyutils "github.com/kyverno/kyverno/pkg/utils/policy"
 	yamlutils "github.com/kyverno/kyverno/pkg/utils/yaml"
 )
 
 type options struct {
-	imageRef string
+	imageRef           string
+	key                string
+	certIdentity       string
+	certIdentityRegexp string
+	certOidcIssuer     string
+	rekorURL           string
 }
 
 func (o options) validate(dir string) error {
@@ -28,9 +37,23 @@ func (o options) validate(dir string) error {
 	if dir == "" {
 		return errors.New("dir is required")
 	}
+	if o.key != "" && o.keyless() {
+		return errors.New("a key and a keyless identity can't be verified together")
+	}
+	if o.keyless() && (o.certIdentity == "" && o.certIdentityRegexp == "" || o.certOidcIssuer == "") {
+		return errors.New("keyless verification requires a certificate identity and an OIDC issuer")
+	}
 	return nil
 }
 
+func (o options) keyless() bool {
+	return o.certIdentity != "" || o.certIdentityRegexp != "" || o.certOidcIssuer != ""
+}
+
+func (o options) verify() bool {
+	return o.key != "" || o.keyless()
+}
+
 func (o options) execute(ctx context.Context, dir string, keychain authn.Keychain) error {
 	dir = filepath.Clean(dir)
 	if !filepath.IsAbs(dir) {
@@ -43,14 +66,9 @@ func (o options) execute(ctx context.Context, dir string, keychain authn.Keychai
 			return err
 		}
 	}
-	fi, err := os.Lstat(dir)
 	// Dir does not need to exist, as it can later be created.
-	if err != nil && errors.Is(err, os.ErrNotExist) {
-		if err := os.MkdirAll(dir, 0o750); err != nil {
-			return fmt.Errorf("unable to create directory %s: %w", dir, err)
-		}
-	}
-	if err == nil && !fi.IsDir() {
+	fi, statErr := os.Lstat(dir)
+	if statErr == nil && !fi.IsDir() {
 		return fmt.Errorf("dir '%s' must be a directory", dir)
 	}
 	ref, err := name.ParseReference(o.imageRef)
@@ -62,6 +80,14 @@ func (o options) execute(ctx context.Context, dir string, keychain authn.Keychai
 	if err != nil {
 		return fmt.Errorf("getting image: %v", err)
 	}
+	if o.verify() {
+		// the image is verified and read by digest so that the tag can't be moved in between
+		digest := ref.Context().Digest(rmt.Digest.String())
+		fmt.Fprintf(os.Stderr, "Verifying signature of [%s]...\n", digest.String())
+		if err := o.verifySignature(ctx, digest, keychain); err != nil {
+			return fmt.Errorf("verifying image signature: %v", err)
+		}
+	}
 	img, err := rmt.Image()
 	if err != nil {
 		return fmt.Errorf("getting image: %v", err)
@@ -70,39 +96,112 @@ func (o options) execute(ctx context.Context, dir string, keychain authn.Keychai
 	if err != nil {
 		return fmt.Errorf("getting image layers: %v", err)
 	}
+	var policyLayers []v1.Layer
+	var manifest *internal.Manifest
 	for _, layer := range l {
 		lmt, err := layer.MediaType()
 		if err != nil {
 			return fmt.Errorf("getting layer media type: %v", err)
 		}
-		if lmt == internal.PolicyLayerMediaType {
-			blob, err := layer.Compressed()
+		switch lmt {
+		case internal.PolicyLayerMediaType:
+			policyLayers = append(policyLayers, layer)
+		case internal.PolicyManifestLayerMediaType:
+			layerBytes, err := readLayer(layer)
 			if err != nil {
-				return fmt.Errorf("getting layer blob: %v", err)
+				return err
 			}
-			defer blob.Close()
-
-			layerBytes, err := io.ReadAll(blob)
+			parsed, err := internal.ParseManifest(layerBytes)
 			if err != nil {
-				return fmt.Errorf("reading layer blob: %v", err)
+				return err
 			}
-			policies, _, _, _, _, _, _, err := yamlutils.GetPolicy(layerBytes)
+			manifest = &parsed
+		}
+	}
+	if manifest != nil {
+		if err := internal.VerifyManifest(*manifest, policyLayers); err != nil {
+			return fmt.Errorf("verifying manifest: %v", err)
+		}
+	} else if o.verify() {
+		return errors.New("verifying manifest: the image doesn't contain a policy manifest")
+	}
+	files := map[string][]byte{}
+	var names []string
+	for _, layer := range policyLayers {
+		layerBytes, err := readLayer(layer)
+		if err != nil {
+			return err
+		}
+		policies, _, _, _, _, _, _, err := yamlutils.GetPolicy(layerBytes)
+		if err != nil {
+			return fmt.Errorf("unmarshaling layer blob: %v", err)
+		}
+		for _, policy := range policies {
+			policyBytes, err := policyutils.ToYaml(policy)
 			if err != nil {
-				return fmt.Errorf("unmarshaling layer blob: %v", err)
+				return fmt.Errorf("converting policy to yaml: %v", err)
 			}
-			for _, policy := range policies {
-				policyBytes, err := policyutils.ToYaml(policy)
-				if err != nil {
-					return fmt.Errorf("converting policy to yaml: %v", err)
-				}
-				pp := filepath.Join(dir, policy.GetName()+".yaml")
-				fmt.Fprintf(os.Stderr, "Saving policy into disk [%s]...\n", pp)
-				if err := os.WriteFile(pp, policyBytes, 0o600); err != nil {
-					return fmt.Errorf("creating file: %v", err)
-				}
+			pp := filepath.Join(dir, policy.GetName()+".yaml")
+			if _, ok := files[pp]; !ok {
+				names = append(names, pp)
 			}
+			files[pp] = policyBytes
+		}
+	}
+	// files are only written once the image has been fully verified
+	if statErr != nil && errors.Is(statErr, os.ErrNotExist) {
+		if err := os.MkdirAll(dir, 0o750); err != nil {
+			return fmt.Errorf("unable to create directory %s: %w", dir, err)
+		}
+	}
+	for _, pp := range names {
+		fmt.Fprintf(os.Stderr, "Saving policy into disk [%s]...\n", pp)
+		if err := os.WriteFile(pp, files[pp], 0o600); err != nil {
+			return fmt.Errorf("creating file: %v", err)
 		}
 	}
 	fmt.Fprintf(os.Stderr, "Done.")
 	return nil
 }
+
+func (o options) verifySignature(ctx context.Context, digest name.Digest, keychain authn.Keychain) error {
+	opts := images.Options{
+		ImageRef:      digest.String(),
+		Client:        registryclient.NewOrDie(registryclient.WithKeychain(keychain)),
+		Subject:       o.certIdentity,
+		SubjectRegExp: o.certIdentityRegexp,
+		Issuer:        o.certOidcIssuer,
+		RekorURL:      o.rekorURL,
+	}
+	if o.key != "" {
+		key := o.key
+		if content, err := os.ReadFile(filepath.Clean(key)); err == nil {
+			key = string(content)
+		}
+		opts.Key = key
+		// kyverno oci push doesn't upload signatures to a transparency log
+		opts.IgnoreTlog = true
+		opts.IgnoreSCT = true
+	}
+	response, err := cosign.NewVerifier().VerifySignature(ctx, opts)
+	if err != nil {
+		return err
+	}
+	if response.Digest != digest.DigestStr() {
+		return fmt.Errorf("signed digest %s doesn't match the image digest %s", response.Digest, digest.DigestStr())
+	}
+	return nil
+}
+
+func readLayer(layer v1.Layer) ([]byte, error) {
+	blob, err := layer.Compressed()
+	if err != nil {
+		return nil, fmt.Errorf("getting layer blob: %v", err)
+	}
+	defer blob.Close()
+	layerBytes, err := io.ReadAll(blob)
+	if err != nil {
+		return nil, fmt.Errorf("reading layer blob: %v", err)
+	}
+	return layerBytes, nil
+}
@@ -26,6 +26,7 @@ func Command(keychain authn.Keychain) *cobra.Command {
 		},
 	}
 	cmd.Flags().StringVarP(&options.imageRef, "image", "i", "", "image reference to push to or pull from")
+	cmd.Flags().StringVar(&options.signKey, "sign-key", "", "path to a cosign private key, or a KMS or Kubernetes secret reference, used to sign the image (the key password is read from COSIGN_PASSWORD)")
 	if err := cmd.MarkFlagRequired("image"); err != nil {
 		log.Println("WARNING", err)
 	}
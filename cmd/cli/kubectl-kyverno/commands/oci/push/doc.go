@@ -4,6 +4,10 @@ var websiteUrl = `https://kyverno.io/docs/kyverno-cli/#pushing`
 
 var description = []string{
 	`Push policie(s) that are included in an OCI image to OCI registry.`,
+	``,
+	`The image contains a manifest listing the digests of the policy layers.`,
+	`When a signing key is provided, the image digest is signed with cosign and the signature is pushed next to the image.`,
+	`Signatures are not uploaded to a transparency log.`,
 }
 
 var examples = [][]string{
@@ -15,4 +19,8 @@ var examples = [][]string{
 		`# Push multiple policies to an OCI image from a given directory that includes policies`,
 		`kyverno oci push . -i <imgref>`,
 	},
+	{
+		`# Push policies and sign the image with a cosign private key`,
+		`COSIGN_PASSWORD=<password> kyverno oci push . -i <imgref> --sign-key cosign.key`,
+	},
 }
@@ -2,6 +2,7 @@ package push
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
@@ -16,12 +17,15 @@ import (
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/oci/internal"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy"
 	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/cosign"
+	"github.com/kyverno/kyverno/pkg/registryclient"
 	policyutils "github.com/kyverno/kyverno/pkg/utils/policy"
 	policyvalidation "github.com/kyverno/kyverno/pkg/validation/policy"
 )
 
 type options struct {
 	imageRef string
+	signKey  string
 }
 
 func (o options) validate(policy string) error {
@@ -51,6 +55,7 @@ func (o options) execute(ctx context.Context, dir string, keychain authn.Keychai
 	if err != nil {
 		return fmt.Errorf("parsing image reference: %v", err)
 	}
+	var manifest internal.Manifest
 	for _, policy := range results.Policies {
 		if policy.IsNamespaced() {
 			fmt.Fprintf(os.Stderr, "Adding policy [%s]\n", policy.GetName())
@@ -62,18 +67,55 @@ func (o options) execute(ctx context.Context, dir string, keychain authn.Keychai
 			return fmt.Errorf("converting policy to yaml: %v", err)
 		}
 		policyLayer := static.NewLayer(policyBytes, internal.PolicyLayerMediaType)
+		annotations := internal.Annotations(policy)
 		img, err = mutate.Append(img, mutate.Addendum{
 			Layer:       policyLayer,
-			Annotations: internal.Annotations(policy),
+			Annotations: annotations,
 		})
 		if err != nil {
 			return fmt.Errorf("mutating image: %v", err)
 		}
+		entry, err := internal.ManifestEntryFor(annotations, policyLayer)
+		if err != nil {
+			return fmt.Errorf("getting layer digest: %v", err)
+		}
+		manifest.Policies = append(manifest.Policies, entry)
+	}
+	// the manifest lists the policy layers, it is covered by the signature of the image digest
+	manifestBytes, err := json.Marshal(manifest)
+	if err != nil {
+		return fmt.Errorf("marshaling manifest: %v", err)
+	}
+	img, err = mutate.Append(img, mutate.Addendum{
+		Layer: static.NewLayer(manifestBytes, internal.PolicyManifestLayerMediaType),
+	})
+	if err != nil {
+		return fmt.Errorf("mutating image: %v", err)
 	}
 	fmt.Fprintf(os.Stderr, "Uploading [%s]...\n", ref.Name())
 	if err = remote.Write(ref, img, remote.WithContext(ctx), remote.WithAuthFromKeychain(keychain)); err != nil {
 		return fmt.Errorf("writing image: %v", err)
 	}
+	if o.signKey != "" {
+		digest, err := img.Digest()
+		if err != nil {
+			return fmt.Errorf("getting image digest: %v", err)
+		}
+		fmt.Fprintf(os.Stderr, "Signing [%s@%s]...\n", ref.Context().Name(), digest)
+		if _, err := cosign.SignImage(ctx, cosign.SignOptions{
+			ImageRef: ref.Context().Digest(digest.String()).String(),
+			Client:   registryclient.NewOrDie(registryclient.WithKeychain(keychain)),
+			Key:      o.signKey,
+			Password: password,
+		}); err != nil {
+			return fmt.Errorf("signing image: %v", err)
+		}
+	}
 	fmt.Fprintf(os.Stderr, "Done.")
 	return nil
 }
+
+// password reads the password of the private key from the COSIGN_PASSWORD environment variable like cosign does
+func password(bool) ([]byte, error) {
+	return []byte(os.Getenv("COSIGN_PASSWORD")), nil
+}
@@ -0,0 +1,38 @@
+package replay
+
+import (
+	"log"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
+	"github.com/spf13/cobra"
+)
+
+func Command() *cobra.Command {
+	var options options
+	cmd := &cobra.Command{
+		Use:          "replay [policy paths]...",
+		Short:        command.FormatDescription(true, websiteUrl, false, description...),
+		Long:         command.FormatDescription(false, websiteUrl, false, description...),
+		Example:      command.FormatExamples(examples...),
+		Args:         cobra.MinimumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.policies = args
+			if err := options.validate(); err != nil {
+				return err
+			}
+			return options.execute(cmd.Context(), cmd.OutOrStdout())
+		},
+	}
+	cmd.Flags().StringSliceVarP(&options.corpus, "corpus", "c", nil, "Recorded admission requests, NDJSON files or directories")
+	cmd.Flags().StringSliceVarP(&options.exceptions, "exception", "e", nil, "Policy exceptions paths")
+	cmd.Flags().StringVar(&options.snapshot, "snapshot", "", "Path to a cluster snapshot directory or tar archive used for namespace labels and API calls")
+	cmd.Flags().StringVarP(&options.output, "output", "o", "text", "Output format (text or json)")
+	cmd.Flags().BoolVar(&options.detailedResults, "detailed-results", false, "Print the recorded and replayed outcomes of the changed requests")
+	cmd.Flags().BoolVar(&options.registryAccess, "registry", false, "If set to true, access the image registry using local docker credentials to populate external data")
+	cmd.Flags().BoolVar(&options.failOnChange, "fail-on-change", false, "Fail if the outcome of at least one request changed")
+	if err := cmd.MarkFlagRequired("corpus"); err != nil {
+		log.Println("WARNING", err)
+	}
+	return cmd
+}
@@ -0,0 +1,139 @@
+package replay
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestCommand(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{
+		"../../../../../test/cli/replay/policies.yaml",
+		"--corpus", "../../../../../test/cli/replay/corpus",
+		"--output", "json",
+	})
+	assert.NoError(t, cmd.Execute())
+	var report report
+	assert.NoError(t, json.Unmarshal(out.Bytes(), &report))
+	assert.Equal(t, 5, report.Requests)
+	assert.Equal(t, 0, report.Errors)
+	changes := map[string][]string{}
+	for _, result := range report.Changed {
+		changes[string(result.UID)] = result.Changes
+	}
+	assert.Equal(t, map[string][]string{
+		"uid-1": {changeDenied},
+		"uid-4": {changeWarnings, changeMutation},
+		"uid-5": {changeAllowed},
+	}, changes)
+}
+
+func TestCommandSkippedPolicies(t *testing.T) {
+	cmd := Command()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{
+		"../../../../../test/cli/replay/policies.yaml",
+		"../../../../../test/cli/replay/skipped.yaml",
+		"--corpus", "../../../../../test/cli/replay/corpus",
+		"--output", "json",
+	})
+	assert.NoError(t, cmd.Execute())
+	var report report
+	assert.NoError(t, json.Unmarshal(out.Bytes(), &report))
+	assert.Equal(t, []skippedPolicy{
+		{Kind: "ImageValidatingPolicy", Name: "check-images"},
+		{Kind: "GeneratingPolicy", Name: "generate-cm"},
+	}, report.Skipped)
+	out.Reset()
+	cmd = Command()
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{
+		"../../../../../test/cli/replay/policies.yaml",
+		"../../../../../test/cli/replay/skipped.yaml",
+		"--corpus", "../../../../../test/cli/replay/corpus",
+	})
+	assert.NoError(t, cmd.Execute())
+	assert.Contains(t, out.String(), "Skipped 2 policies that are not replayed: ImageValidatingPolicy/check-images, GeneratingPolicy/generate-cm\n")
+}
+
+func TestCommandFailOnChange(t *testing.T) {
+	cmd := Command()
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{
+		"../../../../../test/cli/replay/policies.yaml",
+		"--corpus", "../../../../../test/cli/replay/corpus/payloads.ndjson",
+		"--fail-on-change",
+	})
+	assert.EqualError(t, cmd.Execute(), "the outcome of 3 requests changed")
+}
+
+func Test_loadCorpus(t *testing.T) {
+	corpus, err := loadCorpus("../../../../../test/cli/replay/corpus")
+	assert.NoError(t, err)
+	assert.Len(t, corpus, 5)
+	// the request sent to the mutating and validating webhooks is grouped
+	assert.Equal(t, "uid-3", string(corpus[2].uid))
+	assert.Len(t, corpus[2].records, 2)
+	recorded, err := recordedOutcome(corpus[2])
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"team": "platform"}, recorded.Object.GetLabels())
+}
+
+func Test_compare(t *testing.T) {
+	object := func(labels map[string]string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion("v1")
+		obj.SetKind("ConfigMap")
+		obj.SetLabels(labels)
+		return obj
+	}
+	tests := []struct {
+		name     string
+		kind     string
+		recorded outcome
+		replayed outcome
+		want     []string
+	}{{
+		name:     "unchanged",
+		kind:     "ConfigMap",
+		recorded: outcome{Allowed: true, Warnings: []string{"b", "a"}, Object: object(nil)},
+		replayed: outcome{Allowed: true, Warnings: []string{"a", "b", "a"}, Object: object(nil)},
+	}, {
+		name:     "denied",
+		kind:     "ConfigMap",
+		recorded: outcome{Allowed: true, Object: object(nil)},
+		replayed: outcome{Allowed: false, Object: object(map[string]string{"a": "b"})},
+		want:     []string{changeDenied},
+	}, {
+		name:     "allowed and warned",
+		kind:     "ConfigMap",
+		recorded: outcome{Allowed: false},
+		replayed: outcome{Allowed: true, Warnings: []string{"a"}},
+		want:     []string{changeAllowed, changeWarnings},
+	}, {
+		name:     "mutated",
+		kind:     "ConfigMap",
+		recorded: outcome{Allowed: true, Object: object(nil)},
+		replayed: outcome{Allowed: true, Object: object(map[string]string{"a": "b"})},
+		want:     []string{changeMutation},
+	}, {
+		name:     "secret mutations are not compared",
+		kind:     "Secret",
+		recorded: outcome{Allowed: true, Object: object(nil)},
+		replayed: outcome{Allowed: true, Object: object(map[string]string{"a": "b"})},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, compare(tt.kind, tt.recorded, tt.replayed))
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package replay
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/kyverno/kyverno/pkg/webhooks/handlers"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// maxRecordSize is the maximum size of a recorded line, recorded objects can be large
+const maxRecordSize = 16 * 1024 * 1024
+
+// recordedRequest groups the records of an admission request,
+// the same request is recorded once per webhook it was sent to
+type recordedRequest struct {
+	uid     types.UID
+	records []handlers.DumpRecord
+}
+
+// loadCorpus loads the records from NDJSON files or directories of NDJSON files,
+// rotated files are loaded too, the requests are sorted by recording time
+func loadCorpus(paths ...string) ([]recordedRequest, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		err = filepath.WalkDir(path, func(file string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if entry.Type().IsRegular() && isRecordFile(entry.Name()) {
+				files = append(files, file)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	index := map[types.UID]int{}
+	var requests []recordedRequest
+	for _, file := range files {
+		records, err := loadRecords(file)
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range records {
+			uid := record.Request.UID
+			if i, ok := index[uid]; ok {
+				requests[i].records = append(requests[i].records, record)
+				continue
+			}
+			index[uid] = len(requests)
+			requests = append(requests, recordedRequest{uid: uid, records: []handlers.DumpRecord{record}})
+		}
+	}
+	for _, request := range requests {
+		sort.SliceStable(request.records, func(i, j int) bool {
+			return request.records[i].Time.Before(&request.records[j].Time)
+		})
+	}
+	sort.SliceStable(requests, func(i, j int) bool {
+		return requests[i].records[0].Time.Before(&requests[j].records[0].Time)
+	})
+	return requests, nil
+}
+
+// isRecordFile returns true for JSON and NDJSON files, including the rotated ones like payloads.ndjson.1
+func isRecordFile(name string) bool {
+	for _, ext := range []string{".json", ".ndjson", ".jsonl"} {
+		if strings.HasSuffix(name, ext) || strings.Contains(name, ext+".") {
+			return true
+		}
+	}
+	return false
+}
+
+func loadRecords(path string) ([]handlers.DumpRecord, error) {
+	file, err := os.Open(path) // #nosec G304
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	var records []handlers.DumpRecord
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, maxRecordSize)
+	for line := 1; scanner.Scan(); line++ {
+		content := bytes.TrimSpace(scanner.Bytes())
+		if len(content) == 0 {
+			continue
+		}
+		var record handlers.DumpRecord
+		if err := json.Unmarshal(content, &record); err != nil {
+			return nil, fmt.Errorf("failed to decode record at %s:%d (%w)", path, line, err)
+		}
+		if record.Request == nil {
+			return nil, fmt.Errorf("record at %s:%d has no request", path, line)
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s (%w)", path, err)
+	}
+	return records, nil
+}
@@ -0,0 +1,30 @@
+package replay
+
+var websiteUrl = `https://kyverno.io/docs/kyverno-cli/usage/replay/`
+
+var description = []string{
+	`Replays recorded admission requests against a set of candidate policies.`,
+	``,
+	`Admission requests are recorded by the admission controller with the --dumpPayloadFile or --dumpPayloadDir flags.`,
+	`Every recorded request is evaluated by the same engines as the admission webhooks, and the command reports`,
+	`the requests that would now be denied, allowed, warned or mutated differently than when they were recorded.`,
+	``,
+	`Policies using namespace selectors or API calls need a cluster snapshot captured with the snapshot command.`,
+	`Mutations of secrets are not compared because their patches are not recorded.`,
+	`Only Policies, ClusterPolicies, MutatingPolicies and ValidatingPolicies are replayed, other policies are reported as skipped.`,
+}
+
+var examples = [][]string{
+	{
+		`# Replay the recorded requests against the policies of a directory`,
+		`kyverno replay /path/to/policies --corpus /path/to/payloads.ndjson`,
+	},
+	{
+		`# Replay the requests recorded in a directory using a cluster snapshot, and fail if the outcome of a request changed`,
+		`kyverno replay /path/to/policy.yaml --corpus /path/to/payloads/ --snapshot snapshot.tar.gz --fail-on-change`,
+	},
+	{
+		`# Print the changes in JSON format`,
+		`kyverno replay /path/to/policy.yaml --corpus /path/to/payloads.ndjson --output json`,
+	},
+}
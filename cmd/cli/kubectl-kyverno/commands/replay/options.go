@@ -0,0 +1,251 @@
+package replay
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/exception"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/source"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/store"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"gomodules.xyz/jsonpatch/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+type options struct {
+	policies        []string
+	corpus          []string
+	exceptions      []string
+	snapshot        string
+	output          string
+	detailedResults bool
+	registryAccess  bool
+	failOnChange    bool
+}
+
+// result is a request whose outcome changed
+type result struct {
+	UID       types.UID   `json:"uid"`
+	Time      metav1.Time `json:"time"`
+	Operation string      `json:"operation"`
+	Resource  string      `json:"resource"`
+	Changes   []string    `json:"changes"`
+	Recorded  outcome     `json:"recorded"`
+	Replayed  outcome     `json:"replayed"`
+	// MutationDiff is the patch from the recorded to the replayed object
+	MutationDiff []jsonpatch.Operation `json:"mutationDiff,omitempty"`
+}
+
+// skippedPolicy is a loaded policy of a kind that is not replayed
+type skippedPolicy struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+}
+
+type report struct {
+	Requests int             `json:"requests"`
+	Errors   int             `json:"errors"`
+	Skipped  []skippedPolicy `json:"skipped"`
+	Changed  []result        `json:"changed"`
+}
+
+func (o options) validate() error {
+	if len(o.corpus) == 0 {
+		return errors.New("corpus is required")
+	}
+	if o.output != "text" && o.output != "json" {
+		return fmt.Errorf("invalid output format %s, must be text or json", o.output)
+	}
+	return nil
+}
+
+func (o options) execute(ctx context.Context, out io.Writer) error {
+	var s store.Store
+	s.SetLocal(true)
+	s.SetRegistryAccess(o.registryAccess)
+	var client dclient.Interface
+	if o.snapshot != "" {
+		snapshot, err := source.LoadSnapshot(o.snapshot)
+		if err != nil {
+			return fmt.Errorf("failed to load snapshot (%w)", err)
+		}
+		client, err = snapshot.Client(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to load snapshot (%w)", err)
+		}
+		gctx, err := snapshot.GlobalContext(ctx, client)
+		if err != nil {
+			return fmt.Errorf("failed to load snapshot (%w)", err)
+		}
+		s.SetGlobalContext(gctx)
+		s.AllowApiCall(true)
+	}
+	policies, err := policy.Load(nil, "", o.policies...)
+	if err != nil {
+		return fmt.Errorf("failed to load policies (%w)", err)
+	}
+	exceptions, err := exception.Load(o.exceptions...)
+	if err != nil {
+		return fmt.Errorf("failed to load exceptions (%w)", err)
+	}
+	corpus, err := loadCorpus(o.corpus...)
+	if err != nil {
+		return fmt.Errorf("failed to load corpus (%w)", err)
+	}
+	replayer, err := newReplayer(&s, client, policies.Policies, policies.MutatingPolicies, policies.ValidatingPolicies, exceptions.Exceptions, exceptions.CELExceptions)
+	if err != nil {
+		return err
+	}
+	report := report{
+		Requests: len(corpus),
+		Skipped:  skippedPolicies(policies),
+		Changed:  []result{},
+	}
+	for _, request := range corpus {
+		result, err := o.replay(ctx, replayer, request)
+		if err != nil {
+			report.Errors++
+			fmt.Fprintf(out, "Warning: failed to replay request %s (%s)\n", request.uid, err)
+			continue
+		}
+		if len(result.Changes) != 0 {
+			report.Changed = append(report.Changed, result)
+		}
+	}
+	if o.output == "json" {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			return err
+		}
+	} else {
+		o.print(out, report)
+	}
+	if o.failOnChange && len(report.Changed) != 0 {
+		return fmt.Errorf("the outcome of %d requests changed", len(report.Changed))
+	}
+	return nil
+}
+
+// skippedPolicies returns the loaded policies that are not replayed, only Policies, ClusterPolicies,
+// MutatingPolicies and ValidatingPolicies are evaluated by the replayer
+func skippedPolicies(policies *policy.LoaderResults) []skippedPolicy {
+	skipped := []skippedPolicy{}
+	for _, pol := range policies.VAPs {
+		skipped = append(skipped, skippedPolicy{Kind: "ValidatingAdmissionPolicy", Name: pol.GetName()})
+	}
+	for _, pol := range policies.MAPs {
+		skipped = append(skipped, skippedPolicy{Kind: "MutatingAdmissionPolicy", Name: pol.GetName()})
+	}
+	for _, pol := range policies.ImageValidatingPolicies {
+		skipped = append(skipped, skippedPolicy{Kind: "ImageValidatingPolicy", Name: pol.GetName()})
+	}
+	for _, pol := range policies.GeneratingPolicies {
+		skipped = append(skipped, skippedPolicy{Kind: "GeneratingPolicy", Name: pol.GetName()})
+	}
+	for _, pol := range policies.DeletingPolicies {
+		skipped = append(skipped, skippedPolicy{Kind: "DeletingPolicy", Name: pol.GetName()})
+	}
+	return skipped
+}
+
+func (o options) replay(ctx context.Context, replayer *replayer, request recordedRequest) (result, error) {
+	first := request.records[0]
+	payload := first.Request
+	resource := payload.Kind.Kind + " " + payload.Name
+	if payload.Namespace != "" {
+		resource = payload.Kind.Kind + " " + payload.Namespace + "/" + payload.Name
+	}
+	result := result{
+		UID:       request.uid,
+		Time:      first.Time,
+		Operation: payload.Operation,
+		Resource:  resource,
+	}
+	recorded, err := recordedOutcome(request)
+	if err != nil {
+		return result, err
+	}
+	admissionRequest, err := payload.AdmissionRequest()
+	if err != nil {
+		return result, err
+	}
+	replayed, err := replayer.replay(ctx, admissionRequest)
+	if err != nil {
+		return result, err
+	}
+	result.Recorded = recorded
+	result.Replayed = replayed
+	result.Changes = compare(payload.Kind.Kind, recorded, replayed)
+	for _, change := range result.Changes {
+		if change != changeMutation {
+			continue
+		}
+		recordedBytes, err := recorded.Object.MarshalJSON()
+		if err != nil {
+			return result, err
+		}
+		replayedBytes, err := replayed.Object.MarshalJSON()
+		if err != nil {
+			return result, err
+		}
+		result.MutationDiff, err = jsonpatch.CreatePatch(recordedBytes, replayedBytes)
+		if err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+func (o options) print(out io.Writer, report report) {
+	counts := map[string]int{}
+	for _, result := range report.Changed {
+		for _, change := range result.Changes {
+			counts[change]++
+		}
+	}
+	fmt.Fprintf(out, "Replayed %d requests, %d changed (%d newly denied, %d newly allowed, %d warned differently, %d mutated differently), %d errors\n",
+		report.Requests,
+		len(report.Changed),
+		counts[changeDenied],
+		counts[changeAllowed],
+		counts[changeWarnings],
+		counts[changeMutation],
+		report.Errors,
+	)
+	if len(report.Skipped) != 0 {
+		names := make([]string, 0, len(report.Skipped))
+		for _, pol := range report.Skipped {
+			names = append(names, pol.Kind+"/"+pol.Name)
+		}
+		fmt.Fprintf(out, "Skipped %d policies that are not replayed: %s\n", len(report.Skipped), strings.Join(names, ", "))
+	}
+	for _, result := range report.Changed {
+		fmt.Fprintln(out)
+		fmt.Fprintf(out, "%s %s (uid %s, recorded at %s): %s\n", result.Operation, result.Resource, result.UID, result.Time.UTC().Format("2006-01-02T15:04:05Z"), strings.Join(result.Changes, ", "))
+		if !o.detailedResults {
+			continue
+		}
+		for _, change := range result.Changes {
+			switch change {
+			case changeDenied:
+				fmt.Fprintf(out, "  denied: %s\n", strings.TrimSpace(result.Replayed.Message))
+			case changeAllowed:
+				fmt.Fprintf(out, "  was denied: %s\n", strings.TrimSpace(result.Recorded.Message))
+			case changeWarnings:
+				fmt.Fprintf(out, "  recorded warnings: %s\n", strings.Join(normalize(result.Recorded.Warnings), "; "))
+				fmt.Fprintf(out, "  replayed warnings: %s\n", strings.Join(normalize(result.Replayed.Warnings), "; "))
+			case changeMutation:
+				for _, operation := range result.MutationDiff {
+					fmt.Fprintf(out, "  mutation: %s\n", operation.Json())
+				}
+			}
+		}
+	}
+}
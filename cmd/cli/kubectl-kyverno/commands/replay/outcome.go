@@ -0,0 +1,118 @@
+package replay
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"strings"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// changes between the recorded and the replayed outcomes
+const (
+	changeDenied   = "denied"
+	changeAllowed  = "allowed"
+	changeWarnings = "warnings"
+	changeMutation = "mutation"
+)
+
+// outcome is the admission outcome of a request
+type outcome struct {
+	Allowed  bool     `json:"allowed"`
+	Message  string   `json:"message,omitempty"`
+	Warnings []string `json:"warnings,omitempty"`
+	// Object is the object after mutation, nil for DELETE requests
+	Object *unstructured.Unstructured `json:"-"`
+}
+
+func (o *outcome) deny(message string) {
+	o.Allowed = false
+	if o.Message == "" {
+		o.Message = message
+	} else {
+		o.Message += "; " + message
+	}
+}
+
+// recordedOutcome merges the responses of the webhooks the request was sent to,
+// the request is denied if one webhook denied it and the recorded patches are applied in order
+func recordedOutcome(request recordedRequest) (outcome, error) {
+	result := outcome{Allowed: true}
+	first, err := request.records[0].Request.AdmissionRequest()
+	if err != nil {
+		return result, err
+	}
+	object, _, err := admissionutils.ExtractResources(nil, first.AdmissionRequest)
+	if err != nil {
+		return result, err
+	}
+	current := first.Object.Raw
+	for _, record := range request.records {
+		response := record.Response
+		if !response.Allowed {
+			message := ""
+			if response.Result != nil {
+				message = response.Result.Message
+			}
+			result.deny(message)
+		}
+		result.Warnings = append(result.Warnings, response.Warnings...)
+		if len(response.Patch) == 0 || len(current) == 0 {
+			continue
+		}
+		patch, err := jsonpatch.DecodePatch(response.Patch)
+		if err != nil {
+			return result, err
+		}
+		patched, err := patch.Apply(current)
+		if err != nil {
+			return result, err
+		}
+		current = patched
+	}
+	if object.Object != nil && first.Operation != "DELETE" {
+		result.Object = &unstructured.Unstructured{}
+		if err := result.Object.UnmarshalJSON(current); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// compare returns the changes between the recorded and the replayed outcomes,
+// mutations of secrets are not compared because their patches are not recorded
+func compare(kind string, recorded, replayed outcome) []string {
+	var changes []string
+	if recorded.Allowed && !replayed.Allowed {
+		changes = append(changes, changeDenied)
+	} else if !recorded.Allowed && replayed.Allowed {
+		changes = append(changes, changeAllowed)
+	}
+	if !slices.Equal(normalize(recorded.Warnings), normalize(replayed.Warnings)) {
+		changes = append(changes, changeWarnings)
+	}
+	// the mutations of denied requests don't matter
+	if recorded.Allowed && replayed.Allowed && !strings.EqualFold(kind, "Secret") && !sameObject(recorded.Object, replayed.Object) {
+		changes = append(changes, changeMutation)
+	}
+	return changes
+}
+
+func normalize(warnings []string) []string {
+	out := slices.Clone(warnings)
+	slices.Sort(out)
+	return slices.Compact(out)
+}
+
+func sameObject(a, b *unstructured.Unstructured) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	// marshalling sorts the map keys and normalizes the numbers
+	aBytes, aErr := json.Marshal(a.Object)
+	bBytes, bErr := json.Marshal(b.Object)
+	return aErr == nil && bErr == nil && bytes.Equal(aBytes, bBytes)
+}
@@ -0,0 +1,300 @@
+package replay
+
+import (
+	"context"
+	"fmt"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	policiesv1alpha1 "github.com/kyverno/kyverno/api/policies.kyverno.io/v1alpha1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/data"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/log"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/processor"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/store"
+	celengine "github.com/kyverno/kyverno/pkg/cel/engine"
+	"github.com/kyverno/kyverno/pkg/cel/libs"
+	"github.com/kyverno/kyverno/pkg/cel/matching"
+	mpolcompiler "github.com/kyverno/kyverno/pkg/cel/policies/mpol/compiler"
+	mpolengine "github.com/kyverno/kyverno/pkg/cel/policies/mpol/engine"
+	vpolcompiler "github.com/kyverno/kyverno/pkg/cel/policies/vpol/compiler"
+	vpolengine "github.com/kyverno/kyverno/pkg/cel/policies/vpol/engine"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/engine"
+	"github.com/kyverno/kyverno/pkg/engine/adapters"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/factories"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/engine/policycontext"
+	"github.com/kyverno/kyverno/pkg/exceptions"
+	"github.com/kyverno/kyverno/pkg/imageverifycache"
+	"github.com/kyverno/kyverno/pkg/registryclient"
+	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
+	"github.com/kyverno/kyverno/pkg/webhooks/handlers"
+	webhookutils "github.com/kyverno/kyverno/pkg/webhooks/utils"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/openapi"
+	"sigs.k8s.io/kubectl-validate/pkg/openapiclient"
+)
+
+// replayer evaluates recorded admission requests the same way the admission webhooks do,
+// mutations are applied first and validations see the mutated object
+type replayer struct {
+	config     config.Configuration
+	jp         jmespath.Interface
+	engine     engineapi.Engine
+	policies   []kyvernov1.PolicyInterface
+	mpolEngine mpolengine.Engine
+	vpolEngine vpolengine.Engine
+	context    libs.Context
+	client     dclient.Interface
+}
+
+func newReplayer(
+	s *store.Store,
+	client dclient.Interface,
+	policies []kyvernov1.PolicyInterface,
+	mpols []policiesv1alpha1.MutatingPolicy,
+	vpols []policiesv1alpha1.ValidatingPolicy,
+	polexs []*kyvernov2.PolicyException,
+	celexs []*policiesv1alpha1.PolicyException,
+) (*replayer, error) {
+	cfg := config.NewDefaultConfiguration(false)
+	jp := jmespath.New(cfg)
+	var engineClient engineapi.Client
+	if client != nil {
+		engineClient = adapters.Client(client)
+	}
+	rclient := s.GetRegistryClient()
+	if rclient == nil {
+		rclient = registryclient.NewOrDie()
+	}
+	isCluster := false
+	r := &replayer{
+		config: cfg,
+		jp:     jp,
+		engine: engine.NewEngine(
+			cfg,
+			config.NewDefaultMetricsConfiguration(),
+			jp,
+			engineClient,
+			factories.DefaultRegistryClientFactory(adapters.RegistryClient(rclient), nil),
+			imageverifycache.DisabledImageVerifyCache(),
+			store.ContextLoaderFactory(s, nil),
+			exceptions.New(exceptionLister(polexs)),
+			&isCluster,
+		),
+		policies: policies,
+		client:   client,
+	}
+	contextProvider, err := processor.NewContextProvider(client, nil, "", s.GetRegistryAccess(), client == nil, s.GetGlobalContext())
+	if err != nil {
+		return nil, err
+	}
+	r.context = contextProvider
+	namespaceProvider := processor.NamespaceProvider(nil, client, client != nil)
+	if len(mpols) != 0 {
+		provider, err := mpolengine.NewProvider(mpolcompiler.NewCompiler(), mpols, celexs)
+		if err != nil {
+			return nil, err
+		}
+		tcm := mpolcompiler.NewStaticTypeConverterManager(openAPI())
+		r.mpolEngine = mpolengine.NewEngine(provider, namespaceProvider, matching.NewMatcher(), tcm, contextProvider)
+	}
+	if len(vpols) != 0 {
+		provider, err := vpolengine.NewProvider(vpolcompiler.NewCompiler(), vpols, celexs)
+		if err != nil {
+			return nil, err
+		}
+		r.vpolEngine = vpolengine.NewEngine(provider, namespaceProvider, matching.NewMatcher())
+	}
+	return r, nil
+}
+
+// replay evaluates the request against the candidate policies and returns the admission outcome
+func (r *replayer) replay(ctx context.Context, request handlers.AdmissionRequest) (outcome, error) {
+	result := outcome{Allowed: true}
+	object, _, err := admissionutils.ExtractResources(nil, request.AdmissionRequest)
+	if err != nil {
+		return result, err
+	}
+	namespaceLabels := r.namespaceLabels(ctx, request)
+	var mutateResponses []engineapi.EngineResponse
+	// kyverno policies mutation
+	policyContext, err := r.policyContext(request, namespaceLabels)
+	if err != nil {
+		return result, err
+	}
+	failurePolicy := kyvernov1.Ignore
+	for _, policy := range r.policies {
+		if !policy.GetSpec().HasMutateStandard() {
+			continue
+		}
+		if policy.GetSpec().GetFailurePolicy(ctx) == kyvernov1.Fail {
+			failurePolicy = kyvernov1.Fail
+		}
+		response := r.engine.Mutate(ctx, policyContext.WithPolicy(policy))
+		if !response.IsSuccessful() && webhookutils.BlockRequest([]engineapi.EngineResponse{response}, failurePolicy, log.Log) {
+			result.deny(fmt.Sprintf("failed to apply policy %s rules %v", policy.GetName(), response.GetFailedRulesWithErrors()))
+			return result, nil
+		}
+		if response.IsSuccessful() {
+			policyContext = policyContext.WithNewResource(response.PatchedResource)
+			object = response.PatchedResource
+		}
+		if emitWarning := policy.GetSpec().EmitWarning; emitWarning != nil && *emitWarning {
+			response = response.WithWarning()
+		}
+		mutateResponses = append(mutateResponses, response)
+	}
+	result.Warnings = append(result.Warnings, webhookutils.GetWarningMessages(mutateResponses)...)
+	// mutating policies
+	if r.mpolEngine != nil {
+		request, err = withObject(request, object)
+		if err != nil {
+			return result, err
+		}
+		response, err := r.mpolEngine.Handle(ctx, celengine.RequestFromAdmission(r.context, request.AdmissionRequest), nil)
+		if err != nil {
+			return result, err
+		}
+		for _, policy := range response.Policies {
+			for _, rule := range policy.Rules {
+				if rule.Status() == engineapi.RuleStatusWarn {
+					result.Warnings = append(result.Warnings, rule.Message())
+				}
+			}
+		}
+		if response.PatchedResource != nil {
+			object = *response.PatchedResource
+		}
+	}
+	if object.Object != nil && request.Operation != "DELETE" {
+		result.Object = object.DeepCopy()
+	}
+	// validations see the mutated object
+	request, err = withObject(request, object)
+	if err != nil {
+		return result, err
+	}
+	// kyverno policies validation
+	policyContext, err = r.policyContext(request, namespaceLabels)
+	if err != nil {
+		return result, err
+	}
+	var validateResponses []engineapi.EngineResponse
+	failurePolicy = kyvernov1.Ignore
+	for _, policy := range r.policies {
+		if !policy.GetSpec().HasValidate() && !policy.GetSpec().HasVerifyImageChecks() {
+			continue
+		}
+		if policy.GetSpec().GetFailurePolicy(ctx) == kyvernov1.Fail {
+			failurePolicy = kyvernov1.Fail
+		}
+		response := r.engine.Validate(ctx, policyContext.WithPolicy(policy))
+		if response.IsNil() {
+			continue
+		}
+		validateResponses = append(validateResponses, response)
+	}
+	if webhookutils.BlockRequest(validateResponses, failurePolicy, log.Log) {
+		result.deny(webhookutils.GetBlockedMessages(validateResponses))
+	} else {
+		result.Warnings = append(result.Warnings, webhookutils.GetWarningMessages(validateResponses)...)
+	}
+	// validating policies
+	if r.vpolEngine != nil {
+		response, err := r.vpolEngine.Handle(ctx, celengine.RequestFromAdmission(r.context, request.AdmissionRequest), nil)
+		if err != nil {
+			return result, err
+		}
+		for _, policy := range response.Policies {
+			// shadow policies never affect the admission response
+			if policy.Policy.Spec.ShadowEnabled() {
+				continue
+			}
+			for _, rule := range policy.Rules {
+				if rule.Status() != engineapi.RuleStatusFail && rule.Status() != engineapi.RuleStatusError {
+					continue
+				}
+				message := fmt.Sprintf("Policy %s failed: %s", policy.Policy.GetName(), rule.Message())
+				if rule.Status() == engineapi.RuleStatusError {
+					message = fmt.Sprintf("Policy %s error: %s", policy.Policy.GetName(), rule.Message())
+				}
+				if policy.Actions.Has(admissionregistrationv1.Deny) {
+					result.deny(message)
+				}
+				if policy.Actions.Has(admissionregistrationv1.Warn) {
+					result.Warnings = append(result.Warnings, message)
+				}
+			}
+		}
+	}
+	return result, nil
+}
+
+func (r *replayer) policyContext(request handlers.AdmissionRequest, namespaceLabels map[string]string) (*policycontext.PolicyContext, error) {
+	policyContext, err := policycontext.NewPolicyContextFromAdmissionRequest(
+		r.jp,
+		request.AdmissionRequest,
+		kyvernov2.RequestInfo{
+			Roles:             request.Roles,
+			ClusterRoles:      request.ClusterRoles,
+			AdmissionUserInfo: request.UserInfo,
+		},
+		request.GroupVersionKind,
+		r.config,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return policyContext.WithNamespaceLabels(namespaceLabels), nil
+}
+
+// namespaceLabels returns the labels of the request namespace, they are only known when a snapshot is provided
+func (r *replayer) namespaceLabels(ctx context.Context, request handlers.AdmissionRequest) map[string]string {
+	if r.client == nil || request.Namespace == "" || request.Kind.Kind == "Namespace" {
+		return nil
+	}
+	namespace, err := r.client.GetKubeClient().CoreV1().Namespaces().Get(ctx, request.Namespace, metav1.GetOptions{})
+	if err != nil {
+		return nil
+	}
+	return namespace.GetLabels()
+}
+
+func withObject(request handlers.AdmissionRequest, object unstructured.Unstructured) (handlers.AdmissionRequest, error) {
+	if object.Object == nil {
+		return request, nil
+	}
+	raw, err := object.MarshalJSON()
+	if err != nil {
+		return request, err
+	}
+	request.AdmissionRequest.Object.Raw = raw
+	request.AdmissionRequest.Object.Object = nil
+	return request, nil
+}
+
+func openAPI() openapi.Client {
+	clients := []openapi.Client{openapiclient.NewHardcodedBuiltins("1.32")}
+	if crds, err := data.Crds(); err == nil {
+		clients = append(clients, openapiclient.NewLocalSchemaFiles(crds))
+	}
+	return openapiclient.NewComposite(clients...)
+}
+
+type exceptionLister []*kyvernov2.PolicyException
+
+func (l exceptionLister) List(selector labels.Selector) ([]*kyvernov2.PolicyException, error) {
+	var out []*kyvernov2.PolicyException
+	for _, exception := range l {
+		if selector.Matches(labels.Set(exception.GetLabels())) {
+			out = append(out, exception)
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,37 @@
+package snapshot
+
+import (
+	"log"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
+	"github.com/spf13/cobra"
+)
+
+func Command() *cobra.Command {
+	var options options
+	cmd := &cobra.Command{
+		Use:          "snapshot",
+		Short:        command.FormatDescription(true, websiteUrl, false, description...),
+		Long:         command.FormatDescription(false, websiteUrl, false, description...),
+		Example:      command.FormatExamples(examples...),
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := options.validate(); err != nil {
+				return err
+			}
+			return options.execute(cmd.Context(), cmd.OutOrStdout())
+		},
+	}
+	cmd.Flags().StringVar(&options.kubeConfig, "kubeconfig", "", "path to kubeconfig file with authorization and master location information")
+	cmd.Flags().StringVar(&options.context, "context", "", "The name of the kubeconfig context to use")
+	cmd.Flags().StringVarP(&options.output, "output", "o", "", "Output directory, or tar archive if the path ends with .tar, .tar.gz or .tgz")
+	cmd.Flags().StringSliceVarP(&options.resources, "resource", "r", nil, "Resources to capture, in the resource[.version][.group] format (default all the resources that can be listed)")
+	cmd.Flags().StringSliceVar(&options.excludeResources, "exclude-resource", []string{"events", "events.events.k8s.io"}, "Resources to exclude, in the resource[.version][.group] format")
+	cmd.Flags().StringSliceVarP(&options.namespaces, "namespace", "n", nil, "Namespaces to capture namespaced resources from (default all namespaces)")
+	cmd.Flags().BoolVar(&options.includeSecrets, "include-secrets", false, "Include secrets in the snapshot, their data is written in clear text")
+	if err := cmd.MarkFlagRequired("output"); err != nil {
+		log.Println("WARNING", err)
+	}
+	return cmd
+}
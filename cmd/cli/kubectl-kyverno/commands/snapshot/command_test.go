@@ -0,0 +1,118 @@
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/source"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCommandNoOutput(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	err := cmd.Execute()
+	assert.Error(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	expected := `Error: required flag(s) "output" not set`
+	assert.True(t, strings.HasPrefix(strings.TrimSpace(string(out)), expected))
+}
+
+func newObject(apiVersion, kind, namespace, name string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(apiVersion)
+	obj.SetKind(kind)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	obj.SetManagedFields([]metav1.ManagedFieldsEntry{{Manager: "kubectl"}})
+	return obj
+}
+
+func TestCapture(t *testing.T) {
+	kube := kubefake.NewSimpleClientset()
+	kube.Resources = []*metav1.APIResourceList{{
+		GroupVersion: "v1",
+		APIResources: []metav1.APIResource{
+			{Name: "namespaces", Kind: "Namespace", Verbs: metav1.Verbs{"list"}},
+			{Name: "pods", Kind: "Pod", Namespaced: true, Verbs: metav1.Verbs{"list"}},
+			{Name: "pods/log", Kind: "Pod", Namespaced: true, Verbs: metav1.Verbs{"get"}},
+			{Name: "events", Kind: "Event", Namespaced: true, Verbs: metav1.Verbs{"list"}},
+			{Name: "bindings", Kind: "Binding", Namespaced: true, Verbs: metav1.Verbs{"create"}},
+			{Name: "secrets", Kind: "Secret", Namespaced: true, Verbs: metav1.Verbs{"list"}},
+		},
+	}, {
+		GroupVersion: "apps/v1",
+		APIResources: []metav1.APIResource{
+			{Name: "deployments", Kind: "Deployment", Namespaced: true, Verbs: metav1.Verbs{"list"}},
+		},
+	}}
+	dyn := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		{Version: "v1", Resource: "namespaces"}:                 "NamespaceList",
+		{Version: "v1", Resource: "pods"}:                       "PodList",
+		{Version: "v1", Resource: "events"}:                     "EventList",
+		{Version: "v1", Resource: "secrets"}:                    "SecretList",
+		{Group: "apps", Version: "v1", Resource: "deployments"}: "DeploymentList",
+	},
+		newObject("v1", "Namespace", "", "default"),
+		newObject("v1", "Namespace", "", "prod"),
+		newObject("v1", "Pod", "default", "nginx"),
+		newObject("v1", "Pod", "prod", "nginx"),
+		newObject("v1", "Event", "prod", "nginx.1"),
+		newObject("v1", "Secret", "prod", "token"),
+		newObject("apps/v1", "Deployment", "prod", "nginx"),
+	)
+	o := options{
+		resources:        []string{"namespaces", "pods.v1", "events", "deployments.apps", "secrets"},
+		excludeResources: []string{"events"},
+		namespaces:       []string{"prod"},
+	}
+	files, err := o.capture(context.Background(), io.Discard, kube.Discovery(), dyn)
+	assert.NoError(t, err)
+	assert.Len(t, files, 3)
+	assert.Contains(t, files, "namespaces.v1.yaml")
+	assert.Contains(t, files, "pods.v1.yaml")
+	assert.Contains(t, files, "deployments.v1.apps.yaml")
+	assert.NotContains(t, string(files["pods.v1.yaml"]), "managedFields")
+
+	output := filepath.Join(t.TempDir(), "snapshot.tar.gz")
+	assert.NoError(t, write(output, files))
+	snapshot, err := source.LoadSnapshot(output)
+	assert.NoError(t, err)
+	var names []string
+	for _, obj := range snapshot.Objects {
+		names = append(names, obj.GetKind()+"/"+obj.GetNamespace()+"/"+obj.GetName())
+	}
+	assert.ElementsMatch(t, []string{"Namespace//prod", "Pod/prod/nginx", "Deployment/prod/nginx"}, names)
+
+	// secrets are only captured when explicitly included
+	o.includeSecrets = true
+	files, err = o.capture(context.Background(), io.Discard, kube.Discovery(), dyn)
+	assert.NoError(t, err)
+	assert.Len(t, files, 4)
+	assert.Contains(t, files, "secrets.v1.yaml")
+}
+
+func Test_matches(t *testing.T) {
+	deployments := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+	pods := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	assert.True(t, matches([]string{"deployments"}, deployments))
+	assert.True(t, matches([]string{"deployments.apps"}, deployments))
+	assert.True(t, matches([]string{"deployments.v1.apps"}, deployments))
+	assert.False(t, matches([]string{"deployments.extensions"}, deployments))
+	assert.True(t, matches([]string{"pods.v1"}, pods))
+	assert.False(t, matches([]string{"pod"}, pods))
+	assert.False(t, matches(nil, pods))
+}
@@ -0,0 +1,32 @@
+package snapshot
+
+var websiteUrl = `https://kyverno.io/docs/kyverno-cli/usage/snapshot/`
+
+var description = []string{
+	`Captures a snapshot of the resources of a cluster.`,
+	``,
+	`The snapshot can be used by the apply command with the --cluster and --snapshot flags to apply policies`,
+	`against the cluster resources without API access, for example for clusters that are air-gapped.`,
+	``,
+	`The snapshot is written to a directory, or to a tar archive if the output ends with .tar, .tar.gz or .tgz.`,
+	`Events and secrets are excluded by default, secrets are only captured with the --include-secrets flag.`,
+}
+
+var examples = [][]string{
+	{
+		`# Capture a snapshot of all the resources of the current cluster in a directory`,
+		`kyverno snapshot --output ./snapshot`,
+	},
+	{
+		`# Capture the pods, deployments and namespaces of two namespaces in a compressed archive`,
+		`kyverno snapshot --resource pods,deployments.apps,namespaces --namespace default,prod --output snapshot.tar.gz`,
+	},
+	{
+		`# Capture a snapshot including the secrets of a namespace`,
+		`kyverno snapshot --namespace prod --include-secrets --output ./snapshot`,
+	},
+	{
+		`# Apply policies on the snapshot`,
+		`kyverno apply /path/to/policy.yaml --cluster --snapshot snapshot.tar.gz`,
+	},
+}
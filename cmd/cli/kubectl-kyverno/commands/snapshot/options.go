@@ -0,0 +1,215 @@
+package snapshot
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/source"
+	"github.com/kyverno/kyverno/pkg/config"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/yaml"
+)
+
+type options struct {
+	kubeConfig       string
+	context          string
+	output           string
+	resources        []string
+	excludeResources []string
+	namespaces       []string
+	includeSecrets   bool
+}
+
+func (o options) validate() error {
+	if o.output == "" {
+		return errors.New("output is required")
+	}
+	return nil
+}
+
+func (o options) execute(ctx context.Context, out io.Writer) error {
+	restConfig, err := config.CreateClientConfigWithContext(o.kubeConfig, o.context)
+	if err != nil {
+		return err
+	}
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(restConfig)
+	if err != nil {
+		return err
+	}
+	dynamicClient, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return err
+	}
+	files, err := o.capture(ctx, out, discoveryClient, dynamicClient)
+	if err != nil {
+		return err
+	}
+	if err := write(o.output, files); err != nil {
+		return fmt.Errorf("failed to write snapshot (%w)", err)
+	}
+	fmt.Fprintln(out, "Snapshot written to", o.output)
+	return nil
+}
+
+// capture lists the selected resources and returns the content of the snapshot files by file name
+func (o options) capture(ctx context.Context, out io.Writer, discoveryClient discovery.DiscoveryInterface, dynamicClient dynamic.Interface) (map[string][]byte, error) {
+	lists, err := discovery.ServerPreferredResources(discoveryClient)
+	if err != nil {
+		// partial discovery failures, for example an unavailable aggregated api, are not fatal
+		if !discovery.IsGroupDiscoveryFailedError(err) {
+			return nil, fmt.Errorf("failed to discover resources (%w)", err)
+		}
+		fmt.Fprintln(out, "Warning:", err)
+	}
+	files := map[string][]byte{}
+	for _, list := range lists {
+		gv, err := schema.ParseGroupVersion(list.GroupVersion)
+		if err != nil {
+			return nil, err
+		}
+		for _, resource := range list.APIResources {
+			if strings.Contains(resource.Name, "/") || !slices.Contains(resource.Verbs, "list") {
+				continue
+			}
+			gvr := gv.WithResource(resource.Name)
+			if len(o.resources) > 0 && !matches(o.resources, gvr) || matches(o.excludeResources, gvr) {
+				continue
+			}
+			// secrets would be written in clear text to the snapshot, they must be requested explicitly
+			if gvr.GroupResource() == (schema.GroupResource{Resource: "secrets"}) && !o.includeSecrets {
+				continue
+			}
+			items, err := o.list(ctx, dynamicClient, gvr, resource.Namespaced)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list %s (%w)", gvr, err)
+			}
+			if len(items) == 0 {
+				continue
+			}
+			content, err := yaml.Marshal(map[string]any{
+				"apiVersion": "v1",
+				"kind":       "List",
+				"items":      items,
+			})
+			if err != nil {
+				return nil, err
+			}
+			files[fileName(gvr)] = content
+			fmt.Fprintf(out, "Captured %d %s\n", len(items), gvr.GroupResource())
+		}
+	}
+	return files, nil
+}
+
+func (o options) list(ctx context.Context, dynamicClient dynamic.Interface, gvr schema.GroupVersionResource, namespaced bool) ([]any, error) {
+	namespaces := []string{metav1.NamespaceAll}
+	if namespaced && len(o.namespaces) > 0 {
+		namespaces = o.namespaces
+	}
+	var items []any
+	for _, namespace := range namespaces {
+		list, err := dynamicClient.Resource(gvr).Namespace(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range list.Items {
+			// only keep the selected namespaces so that namespace selectors see the same labels
+			if gvr.GroupResource() == (schema.GroupResource{Resource: "namespaces"}) && len(o.namespaces) > 0 && !slices.Contains(o.namespaces, item.GetName()) {
+				continue
+			}
+			item.SetManagedFields(nil)
+			items = append(items, item.Object)
+		}
+	}
+	return items, nil
+}
+
+// matches returns true if the resource matches one of the filters,
+// filters use the resource[.version][.group] format, for example pods, deployments.apps or deployments.v1.apps
+func matches(filters []string, gvr schema.GroupVersionResource) bool {
+	for _, filter := range filters {
+		resource, rest, _ := strings.Cut(filter, ".")
+		if resource != gvr.Resource {
+			continue
+		}
+		if rest == "" || rest == gvr.Group || rest == gvr.Version && gvr.Group == "" || rest == gvr.Version+"."+gvr.Group {
+			return true
+		}
+	}
+	return false
+}
+
+func fileName(gvr schema.GroupVersionResource) string {
+	parts := []string{gvr.Resource, gvr.Version}
+	if gvr.Group != "" {
+		parts = append(parts, gvr.Group)
+	}
+	return strings.Join(parts, ".") + ".yaml"
+}
+
+// write writes the snapshot files to a directory or to a tar archive
+func write(output string, files map[string][]byte) error {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if !source.IsArchive(output) {
+		if err := os.MkdirAll(output, 0o755); err != nil {
+			return err
+		}
+		for _, name := range names {
+			if err := os.WriteFile(filepath.Join(output, name), files[name], 0o600); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	file, err := os.Create(output) // #nosec G304
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	var writer io.Writer = file
+	var gz *gzip.Writer
+	if !strings.HasSuffix(output, ".tar") {
+		gz = gzip.NewWriter(file)
+		writer = gz
+	}
+	archive := tar.NewWriter(writer)
+	now := time.Now()
+	for _, name := range names {
+		header := &tar.Header{
+			Name:    name,
+			Mode:    0o600,
+			Size:    int64(len(files[name])),
+			ModTime: now,
+		}
+		if err := archive.WriteHeader(header); err != nil {
+			return err
+		}
+		if _, err := archive.Write(files[name]); err != nil {
+			return err
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return err
+	}
+	if gz != nil {
+		return gz.Close()
+	}
+	return nil
+}
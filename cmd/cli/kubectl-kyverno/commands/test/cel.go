@@ -0,0 +1,164 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/apis/v1alpha1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/variables"
+	"github.com/kyverno/kyverno/pkg/cel/compiler"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	mutatedObjectKey      = "mutatedObject"
+	patchKey              = "patch"
+	generatedResourcesKey = "generatedResources"
+	ruleKey               = "rule"
+)
+
+type celAssertion struct {
+	v1alpha1.CELAssertion
+	path    *field.Path
+	program cel.Program
+}
+
+// compileCELAssertions compiles the assertions of a check with the base environment used by CEL policies
+func compileCELAssertions(path *field.Path, assertions ...v1alpha1.CELAssertion) ([]celAssertion, error) {
+	if len(assertions) == 0 {
+		return nil, nil
+	}
+	base, err := compiler.NewBaseEnv()
+	if err != nil {
+		return nil, err
+	}
+	env, err := base.Extend(
+		cel.Variable(compiler.ObjectKey, cel.DynType),
+		cel.Variable(compiler.OldObjectKey, cel.DynType),
+		cel.Variable(mutatedObjectKey, cel.DynType),
+		cel.Variable(patchKey, cel.ListType(cel.DynType)),
+		cel.Variable(generatedResourcesKey, cel.ListType(cel.DynType)),
+		cel.Variable(ruleKey, cel.DynType),
+	)
+	if err != nil {
+		return nil, err
+	}
+	var allErrs field.ErrorList
+	compiled := make([]celAssertion, 0, len(assertions))
+	for i, assertion := range assertions {
+		path := path.Index(i).Child("expression")
+		ast, issues := env.Compile(assertion.Expression)
+		if err := issues.Err(); err != nil {
+			allErrs = append(allErrs, field.Invalid(path, assertion.Expression, err.Error()))
+			continue
+		}
+		if !ast.OutputType().IsExactType(types.BoolType) && !ast.OutputType().IsExactType(types.DynType) {
+			allErrs = append(allErrs, field.Invalid(path, assertion.Expression, "output is expected to be of type bool"))
+			continue
+		}
+		program, err := env.Program(ast)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(path, assertion.Expression, err.Error()))
+			continue
+		}
+		compiled = append(compiled, celAssertion{CELAssertion: assertion, path: path, program: program})
+	}
+	if len(allErrs) != 0 {
+		return nil, allErrs.ToAggregate()
+	}
+	return compiled, nil
+}
+
+// celAssertionData builds the variables available to the CEL assertions of a rule response
+func celAssertionData(response engineapi.EngineResponse, rule engineapi.RuleResponse, oldObject any, ruleData map[string]any) (map[string]any, error) {
+	mutated := response.PatchedResource
+	if target, _, _ := rule.PatchedTarget(); target != nil {
+		mutated = *target
+	}
+	patch := []any{}
+	if patches := response.GetPatches(); len(patches) != 0 {
+		data, err := json.Marshal(patches)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, &patch); err != nil {
+			return nil, err
+		}
+	}
+	generated := []any{}
+	for _, resource := range rule.GeneratedResources() {
+		if resource != nil {
+			generated = append(generated, resource.UnstructuredContent())
+		}
+	}
+	return map[string]any{
+		compiler.ObjectKey:    objectOrNil(response.Resource),
+		compiler.OldObjectKey: oldObject,
+		mutatedObjectKey:      objectOrNil(mutated),
+		patchKey:              patch,
+		generatedResourcesKey: generated,
+		ruleKey:               ruleData,
+	}, nil
+}
+
+// celOldObject returns the old resource of a response the same way the policy processor builds it,
+// it is only set for UPDATE and DELETE operations and can be overridden with the `request.oldObject` value
+func celOldObject(vars *variables.Variables, response engineapi.EngineResponse) (any, error) {
+	if vars == nil {
+		return nil, nil
+	}
+	resource := response.Resource
+	values, err := vars.ComputeVariables(nil, response.Policy().GetName(), resource.GetName(), resource.GetKind(), nil)
+	if err != nil {
+		return nil, err
+	}
+	switch values["request.operation"] {
+	case "UPDATE", "DELETE":
+		if oldObject, ok := values["request.oldObject"].(map[string]any); ok {
+			return oldObject, nil
+		}
+		return objectOrNil(resource), nil
+	}
+	return nil, nil
+}
+
+func objectOrNil(object unstructured.Unstructured) any {
+	if object.Object == nil {
+		return nil
+	}
+	return object.UnstructuredContent()
+}
+
+// evaluateCELAssertions returns the failure messages of the assertions that did not evaluate to true
+func evaluateCELAssertions(assertions []celAssertion, data map[string]any) []string {
+	var failures []string
+	for _, assertion := range assertions {
+		out, _, err := assertion.program.Eval(data)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: failed to evaluate %q: %s", assertion.path, assertion.Expression, err))
+			continue
+		}
+		result, ok := out.Value().(bool)
+		if !ok {
+			failures = append(failures, fmt.Sprintf("%s: expression %q returned %v, expected a bool", assertion.path, assertion.Expression, out.Value()))
+			continue
+		}
+		if !result {
+			message := assertion.Message
+			if message == "" {
+				message = fmt.Sprintf("expression %q evaluated to false", assertion.Expression)
+			}
+			failures = append(failures, fmt.Sprintf("%s: %s", assertion.path, message))
+		}
+	}
+	return failures
+}
+
+func celFailureReason(failures []string) string {
+	return strings.Join(failures, "; ")
+}
@@ -0,0 +1,139 @@
+package test
+
+import (
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/apis/v1alpha1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/variables"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestCELAssertions(t *testing.T) {
+	resource := unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata": map[string]any{
+			"name": "test",
+		},
+	}}
+	patched := resource.DeepCopy()
+	patched.SetLabels(map[string]string{"managed": "true"})
+	response := engineapi.NewEngineResponse(resource, nil, nil).WithPatchedResource(*patched)
+	rule := *engineapi.RulePass("add-label", engineapi.Mutation, "mutated", nil)
+	data, err := celAssertionData(response, rule, nil, map[string]any{"name": rule.Name(), "status": string(rule.Status())})
+	require.NoError(t, err)
+
+	assertions, err := compileCELAssertions(field.NewPath("checks").Index(0).Child("cel"),
+		v1alpha1.CELAssertion{Expression: "!has(object.metadata.labels)"},
+		v1alpha1.CELAssertion{Expression: "mutatedObject.metadata.labels.managed == 'true'"},
+		v1alpha1.CELAssertion{Expression: "patch.size() == 1 && patch[0].op == 'add' && patch[0].path == '/metadata/labels'"},
+		v1alpha1.CELAssertion{Expression: "rule.status == 'pass' && oldObject == null && generatedResources.size() == 0"},
+		v1alpha1.CELAssertion{Expression: "mutatedObject.metadata.labels.managed == 'false'", Message: "label must be false"},
+		v1alpha1.CELAssertion{Expression: "patch.size() == 0"},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"checks[0].cel[4].expression: label must be false",
+		`checks[0].cel[5].expression: expression "patch.size() == 0" evaluated to false`,
+	}, evaluateCELAssertions(assertions, data))
+}
+
+func TestCELOldObject(t *testing.T) {
+	resource := unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata": map[string]any{
+			"name": "test",
+		},
+	}}
+	oldObject := map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata": map[string]any{
+			"name":   "test",
+			"labels": map[string]any{"managed": "false"},
+		},
+	}
+	policy := &kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "add-label"}}
+	response := engineapi.NewEngineResponse(resource, engineapi.NewKyvernoPolicy(policy), nil)
+	values := func(values map[string]any) *v1alpha1.ValuesSpec {
+		return &v1alpha1.ValuesSpec{
+			Policies: []v1alpha1.Policy{{
+				Name:      "add-label",
+				Resources: []v1alpha1.Resource{{Name: "test", Values: values}},
+			}},
+		}
+	}
+	tests := []struct {
+		name   string
+		values *v1alpha1.ValuesSpec
+		want   any
+	}{{
+		name: "no values",
+		want: nil,
+	}, {
+		name:   "create",
+		values: values(map[string]any{"request.oldObject": oldObject}),
+		want:   nil,
+	}, {
+		name:   "update",
+		values: values(map[string]any{"request.operation": "UPDATE"}),
+		want:   resource.UnstructuredContent(),
+	}, {
+		name:   "update with old object",
+		values: values(map[string]any{"request.operation": "UPDATE", "request.oldObject": oldObject}),
+		want:   oldObject,
+	}, {
+		name:   "delete",
+		values: values(map[string]any{"request.operation": "DELETE"}),
+		want:   resource.UnstructuredContent(),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vars, err := variables.New(nil, nil, "", "", tt.values)
+			require.NoError(t, err)
+			got, err := celOldObject(vars, response)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCompileCELAssertions(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		wantErr    string
+	}{{
+		name:       "valid",
+		expression: "object.metadata.name == 'test'",
+	}, {
+		name:       "syntax error",
+		expression: "object.metadata.name ==",
+		wantErr:    "checks[0].cel[0].expression",
+	}, {
+		name:       "not a bool",
+		expression: "'test'",
+		wantErr:    "output is expected to be of type bool",
+	}, {
+		name:       "undeclared variable",
+		expression: "foo == 'bar'",
+		wantErr:    "undeclared reference to 'foo'",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := compileCELAssertions(field.NewPath("checks").Index(0).Child("cel"), v1alpha1.CELAssertion{Expression: tt.expression})
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -1,18 +1,25 @@
 package test
 
 import (
+	"context"
 	"fmt"
 	"io"
+	"os"
+	"os/signal"
 	"path/filepath"
+	"slices"
 
 	"github.com/go-git/go-billy/v5"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/apis/v1alpha1"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
-	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/deprecations"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/color"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/sarif"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/table"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/report"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/source"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/test/coverage"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/test/filter"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/watch"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/sergi/go-diff/diffmatchpatch"
 	"github.com/spf13/cobra"
@@ -22,7 +29,8 @@ import (
 func Command() *cobra.Command {
 	var testCase, outputFormat string
 	var fileName, gitBranch string
-	var registryAccess, failOnly, removeColor, detailedResults, requireTests bool
+	var registryAccess, failOnly, removeColor, detailedResults, requireTests, watchMode bool
+	var coverageOptions coverageOptions
 	cmd := &cobra.Command{
 		Use:          "test [local folder or git repository]...",
 		Short:        command.FormatDescription(true, websiteUrl, false, description...),
@@ -35,21 +43,33 @@ func Command() *cobra.Command {
 				removeColor = true
 			}
 			color.Init(removeColor)
-			return testCommandExecute(cmd.OutOrStdout(), dirPath, fileName, gitBranch, testCase, outputFormat, registryAccess, failOnly, detailedResults, requireTests)
+			return testCommandExecute(cmd.OutOrStdout(), dirPath, fileName, gitBranch, testCase, outputFormat, registryAccess, failOnly, detailedResults, requireTests, coverageOptions, watchMode)
 		},
 	}
 	cmd.Flags().StringVarP(&fileName, "file-name", "f", "kyverno-test.yaml", "Test filename")
 	cmd.Flags().StringVarP(&gitBranch, "git-branch", "b", "", "Test github repository branch")
 	cmd.Flags().StringVarP(&testCase, "test-case-selector", "t", "policy=*,rule=*,resource=*", "Filter test cases to run")
-	cmd.Flags().StringVarP(&outputFormat, "output-format", "o", "", "Specifies the output format (json, yaml, markdown, junit)")
+	cmd.Flags().StringVarP(&outputFormat, "output-format", "o", "", "Specifies the output format (json, yaml, markdown, junit, sarif)")
 	cmd.Flags().BoolVar(&registryAccess, "registry", false, "If set to true, access the image registry using local docker credentials to populate external data")
 	cmd.Flags().BoolVar(&failOnly, "fail-only", false, "If set to true, display all the failing test only as output for the test command")
 	cmd.Flags().BoolVar(&removeColor, "remove-color", false, "Remove any color from output")
 	cmd.Flags().BoolVar(&detailedResults, "detailed-results", false, "If set to true, display detailed results")
 	cmd.Flags().BoolVar(&requireTests, "require-tests", false, "If set to true, return an error if no tests are found")
+	cmd.Flags().BoolVar(&watchMode, "watch", false, "If set to true, watch the test directories and re-run the affected tests when files change")
+	cmd.Flags().BoolVar(&coverageOptions.enabled, "coverage", false, "If set to true, report which policy rules and CEL expressions were evaluated by the tests")
+	cmd.Flags().StringVar(&coverageOptions.format, "coverage-format", coverage.FormatText, "Specifies the coverage report format (text, json, cobertura)")
+	cmd.Flags().StringVar(&coverageOptions.output, "coverage-output", "", "Write the coverage report to this file instead of the standard output")
+	cmd.Flags().Float64Var(&coverageOptions.threshold, "coverage-threshold", 0, "Return an error if the coverage percentage is below this threshold")
 	return cmd
 }
 
+type coverageOptions struct {
+	enabled   bool
+	format    string
+	output    string
+	threshold float64
+}
+
 type resultCounts struct {
 	Skip int
 	Pass int
@@ -67,6 +87,8 @@ func testCommandExecute(
 	failOnly bool,
 	detailedResults bool,
 	requireTests bool,
+	coverageOptions coverageOptions,
+	watchMode bool,
 ) (err error) {
 	// check input dir
 	if len(dirPath) == 0 {
@@ -79,11 +101,32 @@ func testCommandExecute(
 			"yaml":     true,
 			"markdown": true,
 			"junit":    true,
+			"sarif":    true,
 		}
 		if !validFormats[outputFormat] {
-			return fmt.Errorf("invalid format, expected (json, yaml, markdown, junit)")
+			return fmt.Errorf("invalid format, expected (json, yaml, markdown, junit, sarif)")
+		}
+	}
+	if watchMode {
+		for _, path := range dirPath {
+			if source.IsGit(path) {
+				return fmt.Errorf("watch mode is not supported with git repositories")
+			}
+		}
+		if coverageOptions.enabled {
+			return fmt.Errorf("watch mode cannot be combined with coverage")
+		}
+		if outputFormat == "sarif" {
+			return fmt.Errorf("watch mode cannot be combined with the sarif output format")
 		}
 	}
+	var coverageReport *coverage.Report
+	if coverageOptions.enabled {
+		if !slices.Contains(coverage.Formats, coverageOptions.format) {
+			return fmt.Errorf("invalid coverage format, expected (text, json, cobertura)")
+		}
+		coverageReport = coverage.NewReport()
+	}
 	// fetch resource filters
 	resourceFilters := filter.ExtractResourceFilters(testCase)
 	// parse filter
@@ -127,70 +170,52 @@ func testCommandExecute(
 			return errors[0]
 		}
 	}
+	runner := &testRunner{
+		out:             out,
+		filter:          filter,
+		resourceFilters: resourceFilters,
+		outputFormat:    outputFormat,
+		registryAccess:  registryAccess,
+		failOnly:        failOnly,
+		detailedResults: detailedResults,
+		coverageReport:  coverageReport,
+	}
+	if watchMode {
+		runner.results = map[string]watch.Results{}
+	}
+	// the sarif log is the only output, progress and summary are discarded
+	if outputFormat == "sarif" {
+		runner.out = io.Discard
+		runner.sarif = sarif.NewBuilder()
+	}
 	rc := &resultCounts{}
 	var fullTable table.Table
 	for _, test := range tests {
 		if test.Err == nil {
-			if deprecations.CheckTest(out, test.Path, test.Test) {
-				return fmt.Errorf("test file %s uses a deprecated schema — please migrate to the latest format", test.Path)
-			}
-
-			// filter results
-			var filteredResults []v1alpha1.TestResult
-			for _, res := range test.Test.Results {
-				if filter.Apply(res) {
-					if len(resourceFilters) > 0 {
-						res.Resources = resourceFilters
-					}
-					filteredResults = append(filteredResults, res)
-				}
-			}
-			if len(filteredResults) == 0 {
-				continue
-			}
-			resourcePath := filepath.Dir(test.Path)
-			responses, err := runTest(out, test, registryAccess)
+			resultsTable, err := runner.run(test, rc)
 			if err != nil {
-				return fmt.Errorf("failed to run test (%w)", err)
-			}
-			fmt.Fprintln(out, "  Checking results ...")
-			var resultsTable table.Table
-			if err := printTestResult(filteredResults, responses, rc, &resultsTable, test.Fs, resourcePath); err != nil {
-				return fmt.Errorf("failed to print test result (%w)", err)
+				return err
 			}
-			if err := printCheckResult(test.Test.Checks, *responses, rc, &resultsTable); err != nil {
-				return fmt.Errorf("failed to print test result (%w)", err)
-			}
-			fullTable.AddFailed(resultsTable.RawRows...)
-			if !failOnly {
-				if len(outputFormat) > 0 {
-					printOutputFormats(out, outputFormat, resultsTable, detailedResults)
-				} else {
-					printer := table.NewTablePrinter(out)
-					fmt.Fprintln(out)
-					printer.Print(resultsTable.Rows(detailedResults))
-					fmt.Fprintln(out)
-				}
+			if resultsTable != nil {
+				fullTable.AddFailed(resultsTable.RawRows...)
 			}
 		}
 	}
-	if !failOnly {
-		fmt.Fprintf(out, "\nTest Summary: %d tests passed and %d tests failed\n", rc.Pass+rc.Skip, rc.Fail)
-	} else {
-		fmt.Fprintf(out, "\nTest Summary: %d out of %d tests failed\n", rc.Fail, rc.Pass+rc.Skip+rc.Fail)
-	}
-	fmt.Fprintln(out)
-	if rc.Fail > 0 {
-		if failOnly {
-			if len(outputFormat) > 0 {
-				printOutputFormats(out, outputFormat, fullTable, detailedResults)
-			} else {
-				printFailedTestResult(out, fullTable, detailedResults)
-			}
+	err = runner.summary(rc, fullTable, coverageOptions)
+	if runner.sarif != nil {
+		if err := runner.sarif.Write(out); err != nil {
+			return err
 		}
-		return fmt.Errorf("%d tests failed", rc.Fail)
 	}
-	return nil
+	if !watchMode {
+		return err
+	}
+	if err != nil {
+		fmt.Fprintln(out, "Error:", err)
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	return runner.watch(ctx, dirPath, fileName, tests)
 }
 
 func checkResult(test v1alpha1.TestResult, fs billy.Filesystem, resoucePath string, response engineapi.EngineResponse, rule engineapi.RuleResponse, actualResource unstructured.Unstructured) (bool, string, string) {
@@ -2,12 +2,14 @@ package test
 
 import (
 	"bytes"
+	"encoding/json"
 	"io"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/sarif"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/test"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -155,3 +157,26 @@ func Test_JSONPayload(t *testing.T) {
 		}
 	})
 }
+
+func TestCommandSarifOutput(t *testing.T) {
+	cmd := Command()
+	outBuffer := bytes.NewBufferString("")
+	cmd.SetOut(outBuffer)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"../../../../../test/cli/test-fail/sarif", "--output-format", "sarif"})
+	err := cmd.Execute()
+	assert.EqualError(t, err, "1 tests failed")
+	var log sarif.Log
+	require.NoError(t, json.Unmarshal(outBuffer.Bytes(), &log))
+	require.Len(t, log.Runs, 1)
+	require.Len(t, log.Runs[0].Tool.Driver.Rules, 1)
+	assert.Equal(t, "require-run-as-non-root/run-as-non-root", log.Runs[0].Tool.Driver.Rules[0].ID)
+	assert.Equal(t, "Require runAsNonRoot", log.Runs[0].Tool.Driver.Rules[0].ShortDescription.Text)
+	require.Len(t, log.Runs[0].Results, 1)
+	result := log.Runs[0].Results[0]
+	assert.Equal(t, sarif.LevelWarning, result.Level)
+	require.Len(t, result.Locations, 1)
+	assert.Equal(t, "../../../../../test/cli/test-fail/sarif/resources.yaml", result.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	assert.Equal(t, 20, result.Locations[0].PhysicalLocation.Region.StartLine)
+	assert.Equal(t, "default/Pod/bad-pod", result.Locations[0].LogicalLocations[0].FullyQualifiedName)
+}
@@ -25,4 +25,16 @@ var examples = [][]string{
 		`# Test some specific test cases out of many test cases in a local folder`,
 		`kyverno test . --test-case-selector "policy=disallow-latest-tag, rule=require-image-tag, resource=test-require-image-tag-pass"`,
 	},
+	{
+		`# Test a local folder and fail if less than 80% of the policy rules and CEL expressions are covered by the tests`,
+		`kyverno test . --coverage --coverage-format cobertura --coverage-output coverage.xml --coverage-threshold 80`,
+	},
+	{
+		`# Watch a local folder and re-run the affected test cases when a policy, resource or test file changes`,
+		`kyverno test . --watch`,
+	},
+	{
+		`# Test a local folder and write the failed test results in SARIF format`,
+		`kyverno test . --output-format sarif > results.sarif`,
+	},
 }
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"os"
 	"strings"
 
 	"github.com/go-git/go-billy/v5"
@@ -12,11 +13,13 @@ import (
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/apis/v1alpha1"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/color"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/table"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/test/coverage"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/openreports"
 	"gopkg.in/yaml.v3"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 func printCheckResult(
@@ -27,7 +30,11 @@ func printCheckResult(
 ) error {
 	ctx := context.Background()
 	testCount := 1
-	for _, check := range checks {
+	for i, check := range checks {
+		celAssertions, err := compileCELAssertions(field.NewPath("checks").Index(i).Child("cel"), check.CEL...)
+		if err != nil {
+			return err
+		}
 		// filter engine responses
 		var matchingEngineResponses []engineapi.EngineResponse
 		for _, engineresponses := range responses.Trigger {
@@ -113,6 +120,7 @@ func printCheckResult(
 							IsFailure: len(errs) != 0,
 						},
 						Message: rule.Message(),
+						Source:  responseSource(response, rule),
 					}
 					if len(errs) == 0 {
 						row.Result = color.ResultPass()
@@ -144,6 +152,7 @@ func printCheckResult(
 							IsFailure: len(errs) != 0,
 						},
 						Message: rule.Message(),
+						Source:  responseSource(response, rule),
 					}
 					if len(errs) != 0 {
 						row.Result = color.ResultPass()
@@ -161,6 +170,43 @@ func printCheckResult(
 					resultsTable.Add(row)
 					testCount++
 				}
+				if len(celAssertions) != 0 {
+					oldObject, err := celOldObject(responses.Variables, response)
+					if err != nil {
+						return err
+					}
+					celData, err := celAssertionData(response, rule, oldObject, data)
+					if err != nil {
+						return err
+					}
+					failures := evaluateCELAssertions(celAssertions, celData)
+					row := table.Row{
+						RowCompact: table.RowCompact{
+							ID:        testCount,
+							Policy:    color.Policy("", response.Policy().GetName()),
+							Rule:      color.Rule(rule.Name()),
+							Resource:  color.Resource(response.Resource.GetKind(), response.Resource.GetNamespace(), response.Resource.GetName()),
+							IsFailure: len(failures) != 0,
+						},
+						Message: rule.Message(),
+						Source:  responseSource(response, rule),
+					}
+					if len(failures) == 0 {
+						row.Result = color.ResultPass()
+						row.Reason = "Ok"
+						if rule.Status() == engineapi.RuleStatusSkip {
+							rc.Skip++
+						} else {
+							rc.Pass++
+						}
+					} else {
+						row.Result = color.ResultFail()
+						row.Reason = celFailureReason(failures)
+						rc.Fail++
+					}
+					resultsTable.Add(row)
+					testCount++
+				}
 			}
 		}
 	}
@@ -354,6 +400,7 @@ func printTestResult(
 						Reason:    color.NotFound(),
 					},
 					Message: color.NotFound(),
+					Source:  rowSource(test.Policy, test.Rule, resourceGVKAndName),
 				}
 				testCount++
 				resultsTable.Add(row)
@@ -379,6 +426,7 @@ func createRowsAccordingToResults(test v1alpha1.TestResult, rc *resultCounts, gl
 			IsFailure: !success,
 		},
 		Message: message,
+		Source:  rowSource(test.Policy, test.Rule, resourceGVKAndName),
 	}
 	if success {
 		row.Result = color.ResultPass()
@@ -415,6 +463,37 @@ func createRowsAccordingToResults(test v1alpha1.TestResult, rc *resultCounts, gl
 	return rows
 }
 
+// rowSource returns the source of a test result row, the resource is given in the apiVersion/kind/namespace/name format
+func rowSource(policy, rule, resourceGVKAndName string) table.RowSource {
+	source := table.RowSource{Policy: policy, Rule: rule}
+	parts := strings.Split(resourceGVKAndName, "/")
+	if len(parts) < 4 {
+		source.Name = parts[len(parts)-1]
+		return source
+	}
+	source.APIVersion = strings.Join(parts[:len(parts)-3], "/")
+	source.Kind = parts[len(parts)-3]
+	source.Namespace = parts[len(parts)-2]
+	source.Name = parts[len(parts)-1]
+	return source
+}
+
+// responseSource returns the source of a check result row
+func responseSource(response engineapi.EngineResponse, rule engineapi.RuleResponse) table.RowSource {
+	policy := response.Policy().GetName()
+	if namespace := response.Policy().GetNamespace(); namespace != "" {
+		policy = namespace + "/" + policy
+	}
+	return table.RowSource{
+		Policy:     policy,
+		Rule:       rule.Name(),
+		APIVersion: response.Resource.GetAPIVersion(),
+		Kind:       response.Resource.GetKind(),
+		Namespace:  response.Resource.GetNamespace(),
+		Name:       response.Resource.GetName(),
+	}
+}
+
 func extractPatchedTargetFromEngineResponse(apiVersion, kind, resourceName, resourceNamespace string, response engineapi.EngineResponse) (*unstructured.Unstructured, *engineapi.RuleResponse) {
 	for _, rule := range response.PolicyResponse.Rules {
 		r, _, _ := rule.PatchedTarget()
@@ -529,3 +608,20 @@ func printOutputFormats(out io.Writer, outputFormat string, resultTable table.Ta
 		fmt.Fprintln(out)
 	}
 }
+
+func printCoverage(out io.Writer, report *coverage.Report, options coverageOptions) error {
+	if options.output == "" {
+		return report.Write(out, options.format)
+	}
+	file, err := os.Create(options.output)
+	if err != nil {
+		return fmt.Errorf("failed to create coverage report file (%w)", err)
+	}
+	defer file.Close()
+	if err := report.Write(file, options.format); err != nil {
+		return fmt.Errorf("failed to write coverage report (%w)", err)
+	}
+	covered, total, percentage := report.Summary()
+	fmt.Fprintf(out, "Coverage Summary: %d/%d items covered (%.2f%%)\n\n", covered, total, percentage)
+	return nil
+}
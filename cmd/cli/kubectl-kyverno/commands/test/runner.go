@@ -0,0 +1,119 @@
+package test
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/apis/v1alpha1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/deprecations"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/sarif"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/table"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/test"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/test/coverage"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/test/filter"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/watch"
+)
+
+type testRunner struct {
+	out             io.Writer
+	filter          filter.Filter
+	resourceFilters []string
+	outputFormat    string
+	registryAccess  bool
+	failOnly        bool
+	detailedResults bool
+	coverageReport  *coverage.Report
+	// sarif collects the failed results when the output format is sarif, they are printed once all tests ran
+	sarif *sarif.Builder
+	// results of the last run of each test file, only tracked in watch mode
+	results map[string]watch.Results
+}
+
+// run runs a test case and prints its results, it returns nil if no result matches the filter
+func (r *testRunner) run(test test.TestCase, rc *resultCounts) (*table.Table, error) {
+	out := r.out
+	if deprecations.CheckTest(out, test.Path, test.Test) {
+		return nil, fmt.Errorf("test file %s uses a deprecated schema — please migrate to the latest format", test.Path)
+	}
+	// filter results
+	var filteredResults []v1alpha1.TestResult
+	for _, res := range test.Test.Results {
+		if r.filter.Apply(res) {
+			if len(r.resourceFilters) > 0 {
+				res.Resources = r.resourceFilters
+			}
+			filteredResults = append(filteredResults, res)
+		}
+	}
+	if len(filteredResults) == 0 {
+		return nil, nil
+	}
+	resourcePath := filepath.Dir(test.Path)
+	responses, err := runTest(out, test, r.registryAccess)
+	if err != nil {
+		return nil, fmt.Errorf("failed to run test (%w)", err)
+	}
+	if r.coverageReport != nil {
+		r.coverageReport.AddPolicies(responses.Policies)
+		for _, ers := range responses.Trigger {
+			r.coverageReport.Record(ers...)
+		}
+	}
+	fmt.Fprintln(out, "  Checking results ...")
+	var resultsTable table.Table
+	if err := printTestResult(filteredResults, responses, rc, &resultsTable, test.Fs, resourcePath); err != nil {
+		return nil, fmt.Errorf("failed to print test result (%w)", err)
+	}
+	if err := printCheckResult(test.Test.Checks, *responses, rc, &resultsTable); err != nil {
+		return nil, fmt.Errorf("failed to print test result (%w)", err)
+	}
+	if r.results != nil {
+		r.record(test, resultsTable)
+	}
+	if r.sarif != nil {
+		addSarifFindings(r.sarif, test, responses.Policies, resultsTable)
+	} else if !r.failOnly {
+		if len(r.outputFormat) > 0 {
+			printOutputFormats(out, r.outputFormat, resultsTable, r.detailedResults)
+		} else {
+			printer := table.NewTablePrinter(out)
+			fmt.Fprintln(out)
+			printer.Print(resultsTable.Rows(r.detailedResults))
+			fmt.Fprintln(out)
+		}
+	}
+	return &resultsTable, nil
+}
+
+// summary prints the test summary and returns an error if tests failed or the coverage is too low
+func (r *testRunner) summary(rc *resultCounts, fullTable table.Table, coverageOptions coverageOptions) error {
+	out := r.out
+	if !r.failOnly {
+		fmt.Fprintf(out, "\nTest Summary: %d tests passed and %d tests failed\n", rc.Pass+rc.Skip, rc.Fail)
+	} else {
+		fmt.Fprintf(out, "\nTest Summary: %d out of %d tests failed\n", rc.Fail, rc.Pass+rc.Skip+rc.Fail)
+	}
+	fmt.Fprintln(out)
+	if r.coverageReport != nil {
+		if err := printCoverage(out, r.coverageReport, coverageOptions); err != nil {
+			return err
+		}
+	}
+	if rc.Fail > 0 {
+		if r.failOnly && r.sarif == nil {
+			if len(r.outputFormat) > 0 {
+				printOutputFormats(out, r.outputFormat, fullTable, r.detailedResults)
+			} else {
+				printFailedTestResult(out, fullTable, r.detailedResults)
+			}
+		}
+		return fmt.Errorf("%d tests failed", rc.Fail)
+	}
+	if r.coverageReport != nil && coverageOptions.threshold > 0 {
+		if _, _, percentage := r.coverageReport.Summary(); percentage < coverageOptions.threshold {
+			return fmt.Errorf("coverage %.2f%% is below the threshold of %.2f%%", percentage, coverageOptions.threshold)
+		}
+	}
+	return nil
+}
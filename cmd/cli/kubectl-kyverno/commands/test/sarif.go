@@ -0,0 +1,97 @@
+package test
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/sarif"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/table"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/test"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// addSarifFindings adds the failed results of a test case to a SARIF log.
+// Results are located in the test resource files when possible, and in the test file otherwise.
+func addSarifFindings(builder *sarif.Builder, testCase test.TestCase, policies *policy.LoaderResults, resultsTable table.Table) {
+	var locator sarif.Locator
+	// resources of git repositories are not available locally
+	if testCase.Fs == nil {
+		dir := testCase.Dir()
+		for _, path := range testCase.Test.Resources {
+			locator.AddPaths(filepath.Join(dir, path))
+		}
+		for _, path := range testCase.Test.TargetResources {
+			locator.AddPaths(filepath.Join(dir, path))
+		}
+	}
+	annotations := policyAnnotations(policies)
+	for _, row := range resultsTable.RawRows {
+		if !row.IsFailure {
+			continue
+		}
+		source := row.Source
+		message := row.Reason
+		if row.Message != "" && row.Message != row.Reason {
+			message = fmt.Sprintf("%s: %s", row.Reason, row.Message)
+		}
+		finding := sarif.Finding{
+			Policy:      source.Policy,
+			Rule:        source.Rule,
+			Annotations: annotations[source.Policy],
+			Message:     message,
+			Location:    locator.Locate(source.APIVersion, source.Kind, source.Namespace, source.Name, sarif.FieldPath(row.Message)),
+		}
+		if source.Kind != "" {
+			finding.Resource = fmt.Sprintf("%s/%s/%s", source.Namespace, source.Kind, source.Name)
+		} else {
+			finding.Resource = source.Name
+		}
+		if finding.Location == nil {
+			finding.Location = &sarif.PhysicalLocation{
+				ArtifactLocation: sarif.ArtifactLocation{URI: filepath.ToSlash(testCase.Path)},
+			}
+		}
+		builder.Add(finding)
+	}
+}
+
+// policyAnnotations returns the annotations of the loaded policies indexed by namespace/name, or name for cluster wide policies
+func policyAnnotations(results *policy.LoaderResults) map[string]map[string]string {
+	annotations := map[string]map[string]string{}
+	if results == nil {
+		return annotations
+	}
+	add := func(object metav1.Object) {
+		key := object.GetName()
+		if object.GetNamespace() != "" {
+			key = object.GetNamespace() + "/" + key
+		}
+		annotations[key] = object.GetAnnotations()
+	}
+	for _, pol := range results.Policies {
+		add(pol)
+	}
+	for i := range results.VAPs {
+		add(&results.VAPs[i])
+	}
+	for i := range results.MAPs {
+		add(&results.MAPs[i])
+	}
+	for i := range results.ValidatingPolicies {
+		add(&results.ValidatingPolicies[i])
+	}
+	for i := range results.ImageValidatingPolicies {
+		add(&results.ImageValidatingPolicies[i])
+	}
+	for i := range results.GeneratingPolicies {
+		add(&results.GeneratingPolicies[i])
+	}
+	for i := range results.DeletingPolicies {
+		add(&results.DeletingPolicies[i])
+	}
+	for i := range results.MutatingPolicies {
+		add(&results.MutatingPolicies[i])
+	}
+	return annotations
+}
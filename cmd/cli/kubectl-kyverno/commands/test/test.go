@@ -52,8 +52,10 @@ import (
 )
 
 type TestResponse struct {
-	Trigger map[string][]engineapi.EngineResponse
-	Target  map[string][]engineapi.EngineResponse
+	Trigger   map[string][]engineapi.EngineResponse
+	Target    map[string][]engineapi.EngineResponse
+	Policies  *policy.LoaderResults
+	Variables *variables.Variables
 }
 
 func runTest(out io.Writer, testCase test.TestCase, registryAccess bool) (*TestResponse, error) {
@@ -241,8 +243,10 @@ func runTest(out io.Writer, testCase test.TestCase, registryAccess bool) (*TestR
 	var engineResponses []engineapi.EngineResponse
 	var resultCounts processor.ResultCounts
 	testResponse := TestResponse{
-		Trigger: map[string][]engineapi.EngineResponse{},
-		Target:  map[string][]engineapi.EngineResponse{},
+		Trigger:   map[string][]engineapi.EngineResponse{},
+		Target:    map[string][]engineapi.EngineResponse{},
+		Policies:  results,
+		Variables: vars,
 	}
 	for _, resource := range uniques {
 		// the policy processor is for multiple policies at once
@@ -428,6 +432,7 @@ func applyImageValidatingPolicies(
 		matching.NewMatcher(),
 		lister,
 		[]imagedataloader.Option{imagedataloader.WithLocalCredentials(registryAccess)},
+		nil,
 	)
 	restMapper, err := utils.GetRESTMapper(dclient, true)
 	if err != nil {
@@ -519,7 +524,7 @@ func applyImageValidatingPolicies(
 				}
 			} else {
 				resp.PolicyResponse.Rules = []engineapi.RuleResponse{
-					*engineapi.RuleFail(p, engineapi.ImageVerify, rslt.Message, nil),
+					*engineapi.RuleFail(p, engineapi.ImageVerify, rslt.Message, nil).WithValidationIndex(rslt.Index),
 				}
 			}
 			resp = resp.WithPolicy(engineapi.NewImageValidatingPolicy(pMap[p]))
@@ -648,6 +653,7 @@ func newContextProvider(dclient dclient.Interface, restMapper meta.RESTMapper, c
 		return libs.NewContextProvider(
 			dclient,
 			[]imagedataloader.Option{imagedataloader.WithLocalCredentials(registryAccess)},
+			nil,
 			gctxstore.New(),
 			true,
 		)
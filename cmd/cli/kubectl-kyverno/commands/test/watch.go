@@ -0,0 +1,147 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/table"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/path"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/test"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/watch"
+)
+
+// record stores the results of a test case, keyed by test file
+func (r *testRunner) record(testCase test.TestCase, resultsTable table.Table) {
+	key := absPath(testCase.Path)
+	results := r.results[key]
+	if results == nil {
+		results = watch.Results{}
+		r.results[key] = results
+	}
+	for _, row := range resultsTable.RawRows {
+		name := watch.Key(row.Policy, row.Rule, row.Resource)
+		if testCase.Test.Name != "" {
+			name = testCase.Test.Name + ": " + name
+		}
+		results[name] = fmt.Sprintf("%s (%s)", row.Result, row.Reason)
+	}
+}
+
+// watch re-runs the tests affected by file changes until the context is cancelled
+func (r *testRunner) watch(ctx context.Context, dirPath []string, fileName string, tests test.TestCases) error {
+	cases := map[string]test.TestCases{}
+	for _, tc := range tests {
+		key := absPath(tc.Path)
+		cases[key] = append(cases[key], tc)
+	}
+	return watch.Run(ctx, r.out, dirPath, watch.DefaultDebounce, func(changed []string) {
+		affected := map[string]struct{}{}
+		for _, file := range changed {
+			if filepath.Base(file) == fileName {
+				if _, err := os.Stat(file); err != nil {
+					delete(cases, file)
+				} else {
+					cases[file] = test.LoadTest(nil, file)
+				}
+				affected[file] = struct{}{}
+			} else if info, err := os.Stat(file); err == nil && info.IsDir() {
+				// a new directory may contain test files
+				loaded, err := test.LoadTests(file, fileName)
+				if err != nil {
+					fmt.Fprintln(r.out, "Error loading tests:", err)
+					continue
+				}
+				for _, tc := range loaded {
+					key := absPath(tc.Path)
+					if _, ok := affected[key]; !ok {
+						delete(cases, key)
+						affected[key] = struct{}{}
+					}
+					cases[key] = append(cases[key], tc)
+				}
+			}
+		}
+		for key, tcs := range cases {
+			for _, tc := range tcs {
+				if tc.Err == nil && watch.Affects(changed, dependencies(tc)...) {
+					affected[key] = struct{}{}
+				}
+			}
+		}
+		if len(affected) == 0 {
+			return
+		}
+		r.rerun(cases, affected)
+	})
+}
+
+// rerun runs the affected test files and prints the result changes compared to the previous run
+func (r *testRunner) rerun(cases map[string]test.TestCases, affected map[string]struct{}) {
+	out := r.out
+	keys := make([]string, 0, len(affected))
+	for key := range affected {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	previous, current := watch.Results{}, watch.Results{}
+	rc := &resultCounts{}
+	var fullTable table.Table
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "Change detected, re-running", len(keys), "test file(s) ...")
+	for _, key := range keys {
+		for name, result := range r.results[key] {
+			previous[name] = result
+		}
+		delete(r.results, key)
+		for _, tc := range cases[key] {
+			if tc.Err != nil {
+				fmt.Fprintln(out, "  Path:", tc.Path)
+				fmt.Fprintln(out, "    Error:", tc.Err)
+				continue
+			}
+			resultsTable, err := r.run(tc, rc)
+			if err != nil {
+				fmt.Fprintln(out, "Error:", err)
+				continue
+			}
+			if resultsTable != nil {
+				fullTable.AddFailed(resultsTable.RawRows...)
+			}
+		}
+		for name, result := range r.results[key] {
+			current[name] = result
+		}
+	}
+	if err := r.summary(rc, fullTable, coverageOptions{}); err != nil {
+		fmt.Fprintln(out, "Error:", err)
+	}
+	current.Diff(out, previous)
+	fmt.Fprintln(out)
+}
+
+// dependencies returns the files and directories a test case depends on
+func dependencies(tc test.TestCase) []string {
+	t := tc.Test
+	dir := tc.Dir()
+	deps := []string{tc.Path}
+	deps = append(deps, path.GetFullPaths(t.Policies, dir, false)...)
+	deps = append(deps, path.GetFullPaths(t.Resources, dir, false)...)
+	deps = append(deps, path.GetFullPaths(t.TargetResources, dir, false)...)
+	deps = append(deps, path.GetFullPaths(t.PolicyExceptions, dir, false)...)
+	for _, file := range []string{t.JSONPayload, t.Variables, t.UserInfo, t.Context} {
+		if file != "" {
+			deps = append(deps, path.GetFullPaths([]string{file}, dir, false)...)
+		}
+	}
+	return deps
+}
+
+func absPath(file string) string {
+	if abs, err := filepath.Abs(file); err == nil {
+		return abs
+	}
+	return file
+}
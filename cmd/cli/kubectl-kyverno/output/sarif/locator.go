@@ -0,0 +1,181 @@
+package sarif
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	gitutils "github.com/kyverno/kyverno/pkg/utils/git"
+	"gopkg.in/yaml.v3"
+)
+
+// failedPath extracts the path of the offending field from pattern validation messages
+var failedPath = regexp.MustCompile(`failed at path (/\S*)`)
+
+// FieldPath returns the path of the offending field reported in a rule message, if any
+func FieldPath(message string) string {
+	if match := failedPath.FindStringSubmatch(message); match != nil {
+		return match[1]
+	}
+	return ""
+}
+
+type document struct {
+	file       string
+	node       *yaml.Node
+	apiVersion string
+	kind       string
+	namespace  string
+	name       string
+}
+
+// Locator resolves the location of resources and resource fields in YAML files
+type Locator struct {
+	documents []document
+}
+
+// AddPaths indexes the YAML files found in the given local files and directories, other paths are ignored
+func (l *Locator) AddPaths(paths ...string) {
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		if !info.IsDir() {
+			l.addFile(path)
+			continue
+		}
+		_ = filepath.WalkDir(path, func(file string, entry fs.DirEntry, err error) error {
+			if err != nil || entry.IsDir() {
+				return nil
+			}
+			if info, err := entry.Info(); err == nil && gitutils.IsYaml(info) {
+				l.addFile(file)
+			}
+			return nil
+		})
+	}
+}
+
+func (l *Locator) addFile(file string) {
+	content, err := os.ReadFile(filepath.Clean(file))
+	if err != nil {
+		return
+	}
+	_ = l.Add(file, content)
+}
+
+// Add indexes the resources of a YAML file
+func (l *Locator) Add(file string, content []byte) error {
+	decoder := yaml.NewDecoder(strings.NewReader(string(content)))
+	for {
+		var node yaml.Node
+		if err := decoder.Decode(&node); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if node.Kind != yaml.DocumentNode || len(node.Content) == 0 || node.Content[0].Kind != yaml.MappingNode {
+			continue
+		}
+		root := node.Content[0]
+		doc := document{
+			file:       filepath.ToSlash(file),
+			node:       root,
+			apiVersion: scalar(child(root, "apiVersion")),
+			kind:       scalar(child(root, "kind")),
+		}
+		metadata := child(root, "metadata")
+		doc.namespace = scalar(child(metadata, "namespace"))
+		doc.name = scalar(child(metadata, "name"))
+		if doc.kind == "" || doc.name == "" {
+			continue
+		}
+		l.documents = append(l.documents, doc)
+	}
+}
+
+// Locate returns the location of a resource, or of the given field of the resource when it can be resolved.
+// The field path uses the slash separated format of Kyverno messages.
+func (l *Locator) Locate(apiVersion, kind, namespace, name, fieldPath string) *PhysicalLocation {
+	if l == nil {
+		return nil
+	}
+	for _, doc := range l.documents {
+		if doc.kind != kind || doc.name != name {
+			continue
+		}
+		if apiVersion != "" && doc.apiVersion != apiVersion {
+			continue
+		}
+		// resources without namespace are loaded in the default namespace
+		if doc.namespace != namespace && doc.namespace != "" {
+			continue
+		}
+		node := locateField(doc.node, fieldPath)
+		return &PhysicalLocation{
+			ArtifactLocation: ArtifactLocation{URI: doc.file},
+			Region:           &Region{StartLine: node.Line, StartColumn: node.Column},
+		}
+	}
+	return nil
+}
+
+// locateField returns the node of the deepest field of the path that exists, the key node is returned for mapping fields
+func locateField(root *yaml.Node, path string) *yaml.Node {
+	located := root
+	current := root
+	for _, segment := range strings.Split(path, "/") {
+		if segment == "" {
+			continue
+		}
+		switch current.Kind {
+		case yaml.MappingNode:
+			key, value := entry(current, segment)
+			if key == nil {
+				return located
+			}
+			located, current = key, value
+		case yaml.SequenceNode:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= len(current.Content) {
+				return located
+			}
+			current = current.Content[index]
+			located = current
+		default:
+			return located
+		}
+	}
+	return located
+}
+
+func entry(node *yaml.Node, key string) (*yaml.Node, *yaml.Node) {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i], node.Content[i+1]
+		}
+	}
+	return nil, nil
+}
+
+func child(node *yaml.Node, key string) *yaml.Node {
+	_, value := entry(node, key)
+	return value
+}
+
+func scalar(node *yaml.Node) string {
+	if node == nil || node.Kind != yaml.ScalarNode {
+		return ""
+	}
+	return node.Value
+}
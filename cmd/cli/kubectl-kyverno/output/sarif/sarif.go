@@ -0,0 +1,240 @@
+package sarif
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	"github.com/kyverno/kyverno/pkg/version"
+)
+
+const (
+	schema         = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion   = "2.1.0"
+	informationURI = "https://kyverno.io"
+)
+
+const (
+	LevelError   = "error"
+	LevelWarning = "warning"
+	LevelNote    = "note"
+)
+
+// Log is the root object of a SARIF file
+type Log struct {
+	Version string `json:"version"`
+	Schema  string `json:"$schema"`
+	Runs    []Run  `json:"runs"`
+}
+
+type Run struct {
+	Tool    Tool     `json:"tool"`
+	Results []Result `json:"results"`
+}
+
+type Tool struct {
+	Driver Driver `json:"driver"`
+}
+
+type Driver struct {
+	Name           string `json:"name"`
+	InformationURI string `json:"informationUri"`
+	Version        string `json:"version,omitempty"`
+	Rules          []Rule `json:"rules"`
+}
+
+// Rule describes a policy rule
+type Rule struct {
+	ID                   string         `json:"id"`
+	Name                 string         `json:"name,omitempty"`
+	ShortDescription     *Message       `json:"shortDescription,omitempty"`
+	FullDescription      *Message       `json:"fullDescription,omitempty"`
+	DefaultConfiguration *Configuration `json:"defaultConfiguration,omitempty"`
+	Properties           map[string]any `json:"properties,omitempty"`
+}
+
+type Configuration struct {
+	Level string `json:"level"`
+}
+
+type Message struct {
+	Text string `json:"text"`
+}
+
+// Result is a failed rule on a resource
+type Result struct {
+	RuleID    string     `json:"ruleId"`
+	RuleIndex int        `json:"ruleIndex"`
+	Level     string     `json:"level"`
+	Message   Message    `json:"message"`
+	Locations []Location `json:"locations,omitempty"`
+}
+
+type Location struct {
+	PhysicalLocation *PhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []LogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type PhysicalLocation struct {
+	ArtifactLocation ArtifactLocation `json:"artifactLocation"`
+	Region           *Region          `json:"region,omitempty"`
+}
+
+type ArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type Region struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+type LogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind,omitempty"`
+}
+
+// Finding is a rule of a policy that failed on a resource
+type Finding struct {
+	Policy string
+	Rule   string
+	// Annotations are the annotations of the policy, the title, description, category and severity are reported in the rule metadata
+	Annotations map[string]string
+	// Warning is true when the failure is reported as a warning, the level is derived from the severity otherwise
+	Warning  bool
+	Message  string
+	Resource string
+	// Location is the location of the resource in its source file, if resolved
+	Location *PhysicalLocation
+}
+
+// Builder builds a SARIF log with a single run
+type Builder struct {
+	run   Run
+	rules map[string]int
+}
+
+func NewBuilder() *Builder {
+	return &Builder{
+		run: Run{
+			Tool: Tool{
+				Driver: Driver{
+					Name:           "kyverno",
+					InformationURI: informationURI,
+					Version:        version.Version(),
+					Rules:          []Rule{},
+				},
+			},
+			Results: []Result{},
+		},
+		rules: map[string]int{},
+	}
+}
+
+// Add adds the result of a finding, the rule is declared the first time it is seen
+func (b *Builder) Add(finding Finding) {
+	index := b.rule(finding.Policy, finding.Rule, finding.Annotations)
+	level := LevelWarning
+	if !finding.Warning {
+		level = severityLevel(finding.Annotations[kyverno.AnnotationPolicySeverity])
+	}
+	result := Result{
+		RuleID:    b.run.Tool.Driver.Rules[index].ID,
+		RuleIndex: index,
+		Level:     level,
+		Message:   Message{Text: finding.Message},
+	}
+	if finding.Location != nil || finding.Resource != "" {
+		location := Location{PhysicalLocation: finding.Location}
+		if finding.Resource != "" {
+			location.LogicalLocations = []LogicalLocation{{FullyQualifiedName: finding.Resource, Kind: "resource"}}
+		}
+		result.Locations = []Location{location}
+	}
+	b.run.Results = append(b.run.Results, result)
+}
+
+func (b *Builder) rule(policy, rule string, annotations map[string]string) int {
+	id := policy
+	if rule != "" && rule != policy {
+		id = policy + "/" + rule
+	}
+	if index, ok := b.rules[id]; ok {
+		return index
+	}
+	r := Rule{
+		ID:   id,
+		Name: rule,
+		DefaultConfiguration: &Configuration{
+			Level: severityLevel(annotations[kyverno.AnnotationPolicySeverity]),
+		},
+	}
+	if r.Name == "" {
+		r.Name = policy
+	}
+	if title := annotations[kyverno.AnnotationPolicyTitle]; title != "" {
+		r.ShortDescription = &Message{Text: title}
+	}
+	if description := strings.TrimSpace(annotations[kyverno.AnnotationPolicyDescription]); description != "" {
+		r.FullDescription = &Message{Text: description}
+	}
+	properties := map[string]any{}
+	if category := annotations[kyverno.AnnotationPolicyCategory]; category != "" {
+		var tags []string
+		for _, tag := range strings.Split(category, ",") {
+			if tag := strings.TrimSpace(tag); tag != "" {
+				tags = append(tags, tag)
+			}
+		}
+		properties["category"] = category
+		properties["tags"] = tags
+	}
+	if severity := annotations[kyverno.AnnotationPolicySeverity]; severity != "" {
+		properties["severity"] = severity
+		if score, ok := securitySeverity[strings.ToLower(severity)]; ok {
+			properties["security-severity"] = score
+		}
+	}
+	if len(properties) != 0 {
+		r.Properties = properties
+	}
+	b.rules[id] = len(b.run.Tool.Driver.Rules)
+	b.run.Tool.Driver.Rules = append(b.run.Tool.Driver.Rules, r)
+	return b.rules[id]
+}
+
+// Log returns the SARIF log
+func (b *Builder) Log() Log {
+	return Log{
+		Version: sarifVersion,
+		Schema:  schema,
+		Runs:    []Run{b.run},
+	}
+}
+
+// Write writes the SARIF log in JSON format
+func (b *Builder) Write(out io.Writer) error {
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(b.Log())
+}
+
+// securitySeverity maps policy severities to the scores used by code scanning tools to rank security findings
+var securitySeverity = map[string]string{
+	"critical": "9.5",
+	"high":     "8.0",
+	"medium":   "5.5",
+	"low":      "3.0",
+	"info":     "0.0",
+}
+
+func severityLevel(severity string) string {
+	switch strings.ToLower(severity) {
+	case "medium":
+		return LevelWarning
+	case "low", "info":
+		return LevelNote
+	}
+	return LevelError
+}
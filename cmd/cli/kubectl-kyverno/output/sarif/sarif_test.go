@@ -0,0 +1,147 @@
+package sarif
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuilder(t *testing.T) {
+	annotations := map[string]string{
+		"policies.kyverno.io/title":       "Require labels",
+		"policies.kyverno.io/description": "Pods must be labeled.\n",
+		"policies.kyverno.io/category":    "Best Practices, Other",
+		"policies.kyverno.io/severity":    "low",
+	}
+	builder := NewBuilder()
+	builder.Add(Finding{Policy: "require-labels", Rule: "check-team", Annotations: annotations, Message: "label team is required", Resource: "default/Pod/foo"})
+	builder.Add(Finding{Policy: "require-labels", Rule: "check-team", Annotations: annotations, Warning: true, Message: "label team is required", Resource: "default/Pod/bar"})
+	builder.Add(Finding{Policy: "disallow-latest", Message: "latest tag is not allowed"})
+	log := builder.Log()
+	require.Len(t, log.Runs, 1)
+	rules := log.Runs[0].Tool.Driver.Rules
+	require.Len(t, rules, 2)
+	assert.Equal(t, "require-labels/check-team", rules[0].ID)
+	assert.Equal(t, "Require labels", rules[0].ShortDescription.Text)
+	assert.Equal(t, "Pods must be labeled.", rules[0].FullDescription.Text)
+	assert.Equal(t, LevelNote, rules[0].DefaultConfiguration.Level)
+	assert.Equal(t, []string{"Best Practices", "Other"}, rules[0].Properties["tags"])
+	assert.Equal(t, "3.0", rules[0].Properties["security-severity"])
+	assert.Equal(t, "disallow-latest", rules[1].ID)
+	assert.Nil(t, rules[1].Properties)
+	results := log.Runs[0].Results
+	require.Len(t, results, 3)
+	assert.Equal(t, LevelNote, results[0].Level)
+	assert.Equal(t, "default/Pod/foo", results[0].Locations[0].LogicalLocations[0].FullyQualifiedName)
+	assert.Equal(t, LevelWarning, results[1].Level)
+	assert.Equal(t, 0, results[1].RuleIndex)
+	assert.Equal(t, LevelError, results[2].Level)
+	assert.Equal(t, 1, results[2].RuleIndex)
+	assert.Empty(t, results[2].Locations)
+
+	var out bytes.Buffer
+	require.NoError(t, builder.Write(&out))
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+	assert.Equal(t, "2.1.0", decoded["version"])
+}
+
+func TestFieldPath(t *testing.T) {
+	assert.Equal(t, "/spec/containers/0/image/", FieldPath("validation error: latest tag is not allowed. rule check-tag failed at path /spec/containers/0/image/"))
+	assert.Equal(t, "", FieldPath("label team is required"))
+}
+
+const resources = `apiVersion: v1
+kind: Pod
+metadata:
+  name: foo
+  namespace: default
+spec:
+  containers:
+  - name: nginx
+    image: nginx:latest
+---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: prod
+`
+
+func TestLocator(t *testing.T) {
+	var locator Locator
+	require.NoError(t, locator.Add("resources.yaml", []byte(resources)))
+	tests := []struct {
+		name       string
+		apiVersion string
+		kind       string
+		namespace  string
+		resource   string
+		fieldPath  string
+		wantLine   int
+		wantColumn int
+		wantNil    bool
+	}{{
+		name:       "resource",
+		apiVersion: "v1",
+		kind:       "Pod",
+		namespace:  "default",
+		resource:   "foo",
+		wantLine:   1,
+		wantColumn: 1,
+	}, {
+		name:       "field",
+		apiVersion: "v1",
+		kind:       "Pod",
+		namespace:  "default",
+		resource:   "foo",
+		fieldPath:  "/spec/containers/0/image/",
+		wantLine:   9,
+		wantColumn: 5,
+	}, {
+		name:       "missing field",
+		apiVersion: "v1",
+		kind:       "Pod",
+		namespace:  "default",
+		resource:   "foo",
+		fieldPath:  "/spec/securityContext/runAsNonRoot/",
+		wantLine:   6,
+		wantColumn: 1,
+	}, {
+		name:       "cluster wide resource",
+		apiVersion: "v1",
+		kind:       "Namespace",
+		resource:   "prod",
+		wantLine:   11,
+		wantColumn: 1,
+	}, {
+		name:       "other namespace",
+		apiVersion: "v1",
+		kind:       "Pod",
+		namespace:  "prod",
+		resource:   "foo",
+		wantNil:    true,
+	}, {
+		name:       "other version",
+		apiVersion: "v2",
+		kind:       "Pod",
+		namespace:  "default",
+		resource:   "foo",
+		wantNil:    true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			location := locator.Locate(tt.apiVersion, tt.kind, tt.namespace, tt.resource, tt.fieldPath)
+			if tt.wantNil {
+				assert.Nil(t, location)
+				return
+			}
+			require.NotNil(t, location)
+			assert.Equal(t, "resources.yaml", location.ArtifactLocation.URI)
+			assert.Equal(t, tt.wantLine, location.Region.StartLine)
+			assert.Equal(t, tt.wantColumn, location.Region.StartColumn)
+		})
+	}
+}
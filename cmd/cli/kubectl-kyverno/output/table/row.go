@@ -3,4 +3,16 @@ package table
 type Row struct {
 	RowCompact `header:"inline"`
 	Message    string `header:"message"`
+	// Source identifies the policy and resource of the row, it is not printed
+	Source RowSource
+}
+
+// RowSource identifies the policy and resource a row was computed from
+type RowSource struct {
+	Policy     string
+	Rule       string
+	APIVersion string
+	Kind       string
+	Namespace  string
+	Name       string
 }
@@ -12,6 +12,7 @@ import (
 
 	"github.com/go-git/go-billy/v5"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
 	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
 	policiesv1alpha1 "github.com/kyverno/kyverno/api/policies.kyverno.io/v1alpha1"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/data"
@@ -40,6 +41,8 @@ var (
 	mpV1alpha1         = policiesv1alpha1.SchemeGroupVersion.WithKind("MutatingPolicy")
 	mapV1alpha1        = admissionregistrationv1alpha1.SchemeGroupVersion.WithKind("MutatingAdmissionPolicy")
 	mapBindingV1alpha1 = admissionregistrationv1alpha1.SchemeGroupVersion.WithKind("MutatingAdmissionPolicyBinding")
+	cleanupPolicyV2    = kyvernov2.SchemeGroupVersion.WithKind("CleanupPolicy")
+	clusterCleanupV2   = kyvernov2.SchemeGroupVersion.WithKind("ClusterCleanupPolicy")
 	defaultLoader      = kubectlValidateLoader
 )
 
@@ -59,6 +62,7 @@ type LoaderResults struct {
 	GeneratingPolicies      []policiesv1alpha1.GeneratingPolicy
 	DeletingPolicies        []policiesv1alpha1.DeletingPolicy
 	MutatingPolicies        []policiesv1alpha1.MutatingPolicy
+	CleanupPolicies         []kyvernov2.CleanupPolicyInterface
 	NonFatalErrors          []LoaderError
 }
 
@@ -77,6 +81,7 @@ func (l *LoaderResults) merge(results *LoaderResults) {
 	l.NonFatalErrors = append(l.NonFatalErrors, results.NonFatalErrors...)
 	l.DeletingPolicies = append(l.DeletingPolicies, results.DeletingPolicies...)
 	l.MutatingPolicies = append(l.MutatingPolicies, results.MutatingPolicies...)
+	l.CleanupPolicies = append(l.CleanupPolicies, results.CleanupPolicies...)
 }
 
 func (l *LoaderResults) addError(path string, err error) {
@@ -217,6 +222,18 @@ func kubectlValidateLoader(path string, content []byte) (*LoaderResults, error)
 				return nil, err
 			}
 			results.MutatingPolicies = append(results.MutatingPolicies, *typed)
+		case cleanupPolicyV2:
+			typed, err := convert.To[kyvernov2.CleanupPolicy](untyped)
+			if err != nil {
+				return nil, err
+			}
+			results.CleanupPolicies = append(results.CleanupPolicies, typed)
+		case clusterCleanupV2:
+			typed, err := convert.To[kyvernov2.ClusterCleanupPolicy](untyped)
+			if err != nil {
+				return nil, err
+			}
+			results.CleanupPolicies = append(results.CleanupPolicies, typed)
 		default:
 			return nil, fmt.Errorf("policy type not supported %s", gvk)
 		}
@@ -1,13 +1,18 @@
 package processor
 
 import (
+	"context"
+
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	clicontext "github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/context"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/variables"
 	"github.com/kyverno/kyverno/pkg/cel/libs"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	gctxstore "github.com/kyverno/kyverno/pkg/globalcontext/store"
 	"github.com/kyverno/kyverno/pkg/imageverification/imagedataloader"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func policyHasValidateOrVerifyImageChecks(policy kyvernov1.PolicyInterface) bool {
@@ -20,12 +25,16 @@ func policyHasValidateOrVerifyImageChecks(policy kyvernov1.PolicyInterface) bool
 	return false
 }
 
-func NewContextProvider(dclient dclient.Interface, restMapper meta.RESTMapper, contextPath string, registryAccess bool, isFake bool) (libs.Context, error) {
+func NewContextProvider(dclient dclient.Interface, restMapper meta.RESTMapper, contextPath string, registryAccess bool, isFake bool, gctx gctxstore.Store) (libs.Context, error) {
 	if dclient != nil && !isFake {
+		if gctx == nil {
+			gctx = gctxstore.New()
+		}
 		return libs.NewContextProvider(
 			dclient,
 			[]imagedataloader.Option{imagedataloader.WithLocalCredentials(registryAccess)},
-			gctxstore.New(),
+			nil,
+			gctx,
 			true,
 		)
 	}
@@ -50,3 +59,23 @@ func NewContextProvider(dclient dclient.Interface, restMapper meta.RESTMapper, c
 	}
 	return fakeContextProvider, nil
 }
+
+// NamespaceProvider returns a function looking up namespaces in the values first,
+// and in the cluster when running against a cluster
+func NamespaceProvider(vars *variables.Variables, dclient dclient.Interface, cluster bool) func(string) *corev1.Namespace {
+	return func(name string) *corev1.Namespace {
+		if vars != nil {
+			if ns := vars.Namespace(name); ns != nil {
+				return ns
+			}
+		}
+		if !cluster || dclient == nil {
+			return nil
+		}
+		ns, err := dclient.GetKubeClient().CoreV1().Namespaces().Get(context.TODO(), name, metav1.GetOptions{})
+		if err != nil {
+			return nil
+		}
+		return ns
+	}
+}
@@ -0,0 +1,360 @@
+package source
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/data"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/log"
+	yamlutils "github.com/kyverno/kyverno/ext/yaml"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/event"
+	"github.com/kyverno/kyverno/pkg/globalcontext/k8sresource"
+	gctxstore "github.com/kyverno/kyverno/pkg/globalcontext/store"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	kubescheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/restmapper"
+	"sigs.k8s.io/yaml"
+)
+
+var crdGVK = schema.GroupVersionKind{Group: "apiextensions.k8s.io", Version: "v1", Kind: "CustomResourceDefinition"}
+
+// Snapshot holds the resources of an offline cluster snapshot,
+// typically `kubectl get -o yaml` dumps taken from a cluster without API access
+type Snapshot struct {
+	Objects []*unstructured.Unstructured
+}
+
+// IsArchive returns true if the path looks like a (compressed) tar archive
+func IsArchive(path string) bool {
+	return strings.HasSuffix(path, ".tar") || isGzip(path)
+}
+
+func isGzip(path string) bool {
+	return strings.HasSuffix(path, ".tar.gz") || strings.HasSuffix(path, ".tgz")
+}
+
+func isManifest(path string) bool {
+	ext := filepath.Ext(path)
+	return ext == ".yaml" || ext == ".yml" || ext == ".json"
+}
+
+// LoadSnapshot loads a snapshot from a directory or a tar archive of yaml or json resource dumps,
+// lists (`kind: List` or `kind: <Kind>List`) are flattened into their items
+func LoadSnapshot(path string) (*Snapshot, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := &Snapshot{}
+	if info.IsDir() {
+		err := filepath.WalkDir(path, func(file string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() || !isManifest(file) {
+				return nil
+			}
+			content, err := os.ReadFile(file) // #nosec G304
+			if err != nil {
+				return err
+			}
+			if err := snapshot.Add(content); err != nil {
+				return fmt.Errorf("failed to load snapshot file %s (%w)", file, err)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return snapshot, nil
+	}
+	if !IsArchive(path) {
+		content, err := os.ReadFile(path) // #nosec G304
+		if err != nil {
+			return nil, err
+		}
+		if err := snapshot.Add(content); err != nil {
+			return nil, err
+		}
+		return snapshot, nil
+	}
+	file, err := os.Open(path) // #nosec G304
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	var reader io.Reader = file
+	if isGzip(path) {
+		gz, err := gzip.NewReader(file)
+		if err != nil {
+			return nil, err
+		}
+		defer gz.Close()
+		reader = gz
+	}
+	archive := tar.NewReader(reader)
+	for {
+		header, err := archive.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag != tar.TypeReg || !isManifest(header.Name) {
+			continue
+		}
+		content, err := io.ReadAll(archive)
+		if err != nil {
+			return nil, err
+		}
+		if err := snapshot.Add(content); err != nil {
+			return nil, fmt.Errorf("failed to load snapshot file %s (%w)", header.Name, err)
+		}
+	}
+	return snapshot, nil
+}
+
+// Add adds the resources of a yaml or json document stream to the snapshot
+func (s *Snapshot) Add(content []byte) error {
+	documents, err := yamlutils.SplitDocuments(content)
+	if err != nil {
+		return err
+	}
+	for _, document := range documents {
+		jsonBytes, err := yaml.YAMLToJSON(document)
+		if err != nil {
+			return err
+		}
+		if strings.TrimSpace(string(jsonBytes)) == "null" {
+			continue
+		}
+		obj, err := kubeutils.BytesToUnstructured(jsonBytes)
+		if err != nil {
+			return err
+		}
+		if obj.IsList() {
+			if err := obj.EachListItem(func(item runtime.Object) error {
+				s.Objects = append(s.Objects, item.(*unstructured.Unstructured))
+				return nil
+			}); err != nil {
+				return err
+			}
+		} else {
+			s.Objects = append(s.Objects, obj)
+		}
+	}
+	return nil
+}
+
+// groupResources returns the API resources served by the snapshot, built-in resources are always
+// served, custom resources are discovered from the CRDs in the snapshot or guessed from the objects
+func (s *Snapshot) groupResources() ([]*restmapper.APIGroupResources, error) {
+	groups, err := data.APIGroupResources()
+	if err != nil {
+		return nil, err
+	}
+	byName := map[string]*restmapper.APIGroupResources{}
+	for _, group := range groups {
+		byName[group.Group.Name] = group
+	}
+	add := func(gv schema.GroupVersion, resource metav1.APIResource) {
+		group := byName[gv.Group]
+		if group == nil {
+			group = &restmapper.APIGroupResources{
+				Group: metav1.APIGroup{
+					Name:             gv.Group,
+					PreferredVersion: metav1.GroupVersionForDiscovery{GroupVersion: gv.String(), Version: gv.Version},
+				},
+				VersionedResources: map[string][]metav1.APIResource{},
+			}
+			byName[gv.Group] = group
+			groups = append(groups, group)
+		}
+		if _, ok := group.VersionedResources[gv.Version]; !ok {
+			group.Group.Versions = append(group.Group.Versions, metav1.GroupVersionForDiscovery{GroupVersion: gv.String(), Version: gv.Version})
+		}
+		for _, existing := range group.VersionedResources[gv.Version] {
+			if existing.Name == resource.Name {
+				return
+			}
+		}
+		group.VersionedResources[gv.Version] = append(group.VersionedResources[gv.Version], resource)
+	}
+	verbs := metav1.Verbs{"get", "list", "watch"}
+	for _, obj := range s.Objects {
+		if obj.GroupVersionKind() != crdGVK {
+			continue
+		}
+		group, _, _ := unstructured.NestedString(obj.Object, "spec", "group")
+		scope, _, _ := unstructured.NestedString(obj.Object, "spec", "scope")
+		names, _, _ := unstructured.NestedStringMap(obj.Object, "spec", "names")
+		versions, _, _ := unstructured.NestedSlice(obj.Object, "spec", "versions")
+		for _, v := range versions {
+			version, ok := v.(map[string]any)
+			if !ok {
+				continue
+			}
+			if served, ok := version["served"].(bool); ok && !served {
+				continue
+			}
+			name, _ := version["name"].(string)
+			add(schema.GroupVersion{Group: group, Version: name}, metav1.APIResource{
+				Name:         names["plural"],
+				SingularName: names["singular"],
+				Namespaced:   scope == "Namespaced",
+				Kind:         names["kind"],
+				Verbs:        verbs,
+			})
+		}
+	}
+	mapper := restmapper.NewDiscoveryRESTMapper(groups)
+	for _, obj := range s.Objects {
+		gvk := obj.GroupVersionKind()
+		if _, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version); err == nil {
+			continue
+		}
+		plural, singular := meta.UnsafeGuessKindToResource(gvk)
+		add(gvk.GroupVersion(), metav1.APIResource{
+			Name:         plural.Resource,
+			SingularName: singular.Resource,
+			Namespaced:   obj.GetNamespace() != "",
+			Kind:         gvk.Kind,
+			Verbs:        verbs,
+		})
+	}
+	return groups, nil
+}
+
+// Client returns a client serving the snapshot resources, it behaves like a client connected
+// to the cluster the snapshot was taken from, including discovery, list and label selectors
+func (s *Snapshot) Client(ctx context.Context) (dclient.Interface, error) {
+	groups, err := s.groupResources()
+	if err != nil {
+		return nil, err
+	}
+	scheme := runtime.NewScheme()
+	gvrToListKind := map[schema.GroupVersionResource]string{}
+	var resources []*metav1.APIResourceList
+	for _, group := range groups {
+		versions := make([]string, 0, len(group.VersionedResources))
+		for version := range group.VersionedResources {
+			versions = append(versions, version)
+		}
+		sort.Strings(versions)
+		for _, version := range versions {
+			gv := schema.GroupVersion{Group: group.Group.Name, Version: version}
+			list := &metav1.APIResourceList{GroupVersion: gv.String()}
+			for _, resource := range group.VersionedResources[version] {
+				list.APIResources = append(list.APIResources, resource)
+				if strings.Contains(resource.Name, "/") {
+					continue
+				}
+				gvrToListKind[gv.WithResource(resource.Name)] = resource.Kind + "List"
+				scheme.AddKnownTypeWithName(gv.WithKind(resource.Kind), &unstructured.Unstructured{})
+				scheme.AddKnownTypeWithName(gv.WithKind(resource.Kind+"List"), &unstructured.UnstructuredList{})
+			}
+			resources = append(resources, list)
+		}
+	}
+	dyn := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(scheme, gvrToListKind)
+	kube := kubefake.NewSimpleClientset()
+	kube.Resources = resources
+	mapper := restmapper.NewDiscoveryRESTMapper(groups)
+	for _, obj := range s.Objects {
+		gvk := obj.GroupVersionKind()
+		mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+		if err != nil {
+			return nil, err
+		}
+		obj := obj.DeepCopy()
+		namespace := ""
+		if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+			namespace = obj.GetNamespace()
+			if namespace == "" {
+				namespace = metav1.NamespaceDefault
+				obj.SetNamespace(namespace)
+			}
+		} else {
+			obj.SetNamespace("")
+		}
+		if err := dyn.Tracker().Create(mapping.Resource, obj, namespace); err != nil {
+			return nil, fmt.Errorf("failed to add %s %s/%s to the snapshot (%w)", gvk.Kind, namespace, obj.GetName(), err)
+		}
+		// built-in resources are also served by the typed client used by informers and listers
+		if typed, err := kubescheme.Scheme.New(gvk); err == nil {
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, typed); err != nil {
+				return nil, err
+			}
+			if err := kube.Tracker().Create(mapping.Resource, typed, namespace); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return dclient.NewClient(ctx, dyn, kube, 15*time.Minute, false, nil)
+}
+
+// GlobalContext returns a global context store populated from the kubernetes resource
+// global context entries of the snapshot, api call entries can't be evaluated offline and are ignored
+func (s *Snapshot) GlobalContext(ctx context.Context, client dclient.Interface) (gctxstore.Store, error) {
+	store := gctxstore.New()
+	jp := jmespath.New(config.NewDefaultConfiguration(false))
+	for _, obj := range s.Objects {
+		if obj.GroupVersionKind() != kyvernov2alpha1.SchemeGroupVersion.WithKind("GlobalContextEntry") {
+			continue
+		}
+		var gce kyvernov2alpha1.GlobalContextEntry
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &gce); err != nil {
+			return nil, err
+		}
+		resource := gce.Spec.KubernetesResource
+		if resource == nil {
+			log.Log.V(2).Info("ignoring global context entry with an api call in snapshot", "name", gce.Name)
+			continue
+		}
+		gvr := schema.GroupVersionResource{
+			Group:    resource.Group,
+			Version:  resource.Version,
+			Resource: resource.Resource,
+		}
+		entry, err := k8sresource.New(
+			ctx,
+			&gce,
+			event.NewFake(),
+			client.GetKubeClient(),
+			client.GetDynamicInterface(),
+			nil,
+			log.Log,
+			gvr,
+			resource,
+			false,
+			jp,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load global context entry %s (%w)", gce.Name, err)
+		}
+		store.Set(gce.Name, entry)
+	}
+	return store, nil
+}
@@ -0,0 +1,131 @@
+package source
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const snapshotResources = `
+apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: Namespace
+  metadata:
+    name: prod
+    labels:
+      env: prod
+- apiVersion: v1
+  kind: Pod
+  metadata:
+    name: nginx
+    namespace: prod
+    labels:
+      app: nginx
+  spec:
+    containers:
+    - name: nginx
+      image: nginx
+- apiVersion: v1
+  kind: Pod
+  metadata:
+    name: busybox
+    namespace: prod
+  spec:
+    containers:
+    - name: busybox
+      image: busybox
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: widgets.example.com
+spec:
+  group: example.com
+  scope: Namespaced
+  names:
+    kind: Widget
+    plural: widgets
+    singular: widget
+  versions:
+  - name: v1
+    served: true
+    storage: true
+---
+apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: widget
+  namespace: prod
+---
+apiVersion: kyverno.io/v2alpha1
+kind: GlobalContextEntry
+metadata:
+  name: pods
+spec:
+  kubernetesResource:
+    version: v1
+    resource: pods
+    namespace: prod
+`
+
+func TestLoadSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "core"), 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "core", "resources.yaml"), []byte(snapshotResources), 0o600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("not a manifest"), 0o600))
+	snapshot, err := LoadSnapshot(dir)
+	assert.NoError(t, err)
+	assert.Len(t, snapshot.Objects, 6)
+
+	_, err = LoadSnapshot(filepath.Join(dir, "missing"))
+	assert.Error(t, err)
+}
+
+func TestSnapshot_Client(t *testing.T) {
+	snapshot := &Snapshot{}
+	assert.NoError(t, snapshot.Add([]byte(snapshotResources)))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client, err := snapshot.Client(ctx)
+	assert.NoError(t, err)
+
+	ns, err := client.GetResource(ctx, "v1", "Namespace", "", "prod")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"env": "prod"}, ns.GetLabels())
+
+	pods, err := client.ListResource(ctx, "v1", "Pod", "prod", &metav1.LabelSelector{MatchLabels: map[string]string{"app": "nginx"}})
+	assert.NoError(t, err)
+	assert.Len(t, pods.Items, 1)
+
+	widget, err := client.GetResource(ctx, "example.com/v1", "Widget", "prod", "widget")
+	assert.NoError(t, err)
+	assert.Equal(t, "widget", widget.GetName())
+
+	typed, err := client.GetKubeClient().CoreV1().Pods("prod").List(ctx, metav1.ListOptions{LabelSelector: labels.Everything().String()})
+	assert.NoError(t, err)
+	assert.Len(t, typed.Items, 2)
+}
+
+func TestSnapshot_GlobalContext(t *testing.T) {
+	snapshot := &Snapshot{}
+	assert.NoError(t, snapshot.Add([]byte(snapshotResources)))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	client, err := snapshot.Client(ctx)
+	assert.NoError(t, err)
+	store, err := snapshot.GlobalContext(ctx, client)
+	assert.NoError(t, err)
+	entry, ok := store.Get("pods")
+	assert.True(t, ok)
+	data, err := entry.Get("")
+	assert.NoError(t, err)
+	assert.Len(t, data, 2)
+}
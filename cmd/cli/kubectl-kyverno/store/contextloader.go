@@ -11,8 +11,12 @@ import (
 )
 
 func ContextLoaderFactory(s *Store, cmResolver engineapi.ConfigmapResolver) engineapi.ContextLoaderFactory {
+	var opts []factories.ContextLoaderFactoryOptions
+	if gctx := s.GetGlobalContext(); gctx != nil {
+		opts = append(opts, factories.WithGlobalContextStore(gctx))
+	}
 	if !s.IsLocal() {
-		return factories.DefaultContextLoaderFactory(cmResolver)
+		return factories.DefaultContextLoaderFactory(cmResolver, opts...)
 	}
 	return func(policy kyvernov1.PolicyInterface, rule kyvernov1.Rule) engineapi.ContextLoader {
 		init := func(jsonContext enginecontext.Interface) error {
@@ -34,7 +38,7 @@ func ContextLoaderFactory(s *Store, cmResolver engineapi.ConfigmapResolver) engi
 			}
 			return nil
 		}
-		factory := factories.DefaultContextLoaderFactory(cmResolver, factories.WithInitializer(init))
+		factory := factories.DefaultContextLoaderFactory(cmResolver, append(opts, factories.WithInitializer(init))...)
 		return wrapper{
 			store: s,
 			inner: factory(policy, rule),
@@ -1,6 +1,7 @@
 package store
 
 import (
+	gctxstore "github.com/kyverno/kyverno/pkg/globalcontext/store"
 	"github.com/kyverno/kyverno/pkg/registryclient"
 )
 
@@ -25,6 +26,7 @@ type Store struct {
 	allowApiCalls  bool
 	policies       []Policy
 	foreachElement int
+	globalContext  gctxstore.Store
 }
 
 // SetLocal sets local (clusterless) execution for the CLI
@@ -59,6 +61,16 @@ func (s *Store) GetRegistryClient() registryclient.Client {
 	return s.registryClient
 }
 
+// SetGlobalContext sets the global context store used to resolve global context entries
+func (s *Store) SetGlobalContext(store gctxstore.Store) {
+	s.globalContext = store
+}
+
+// GetGlobalContext returns the global context store, it is nil unless global context entries are available
+func (s *Store) GetGlobalContext() gctxstore.Store {
+	return s.globalContext
+}
+
 func (s *Store) SetPolicies(p ...Policy) {
 	s.policies = p
 }
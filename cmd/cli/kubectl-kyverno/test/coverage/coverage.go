@@ -0,0 +1,273 @@
+package coverage
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	admissionregistrationv1alpha1 "k8s.io/api/admissionregistration/v1alpha1"
+)
+
+// Item is a coverable element of a policy, a rule for kyverno policies
+// or a match condition, validation, mutation, generation or condition for CEL policies.
+type Item struct {
+	Name  string `json:"name"`
+	Pass  int    `json:"pass"`
+	Fail  int    `json:"fail"`
+	Warn  int    `json:"warn"`
+	Skip  int    `json:"skip"`
+	Error int    `json:"error"`
+}
+
+// Covered returns true if the item was evaluated at least once,
+// skipped items were not evaluated and don't count as covered
+func (i *Item) Covered() bool {
+	return i.hits() > 0
+}
+
+func (i *Item) hits() int {
+	return i.Pass + i.Fail + i.Warn + i.Error
+}
+
+func (i *Item) record(status engineapi.RuleStatus) {
+	switch status {
+	case engineapi.RuleStatusPass:
+		i.Pass++
+	case engineapi.RuleStatusFail:
+		i.Fail++
+	case engineapi.RuleStatusWarn:
+		i.Warn++
+	case engineapi.RuleStatusSkip:
+		i.Skip++
+	case engineapi.RuleStatusError:
+		i.Error++
+	}
+}
+
+// Policy holds the coverage of the items of a policy
+type Policy struct {
+	Kind      string  `json:"kind"`
+	Namespace string  `json:"namespace,omitempty"`
+	Name      string  `json:"name"`
+	Items     []*Item `json:"items"`
+	// index of the first expression item (after the match conditions) for CEL policies
+	expressions int
+	cel         bool
+}
+
+// Covered returns the number of covered items
+func (p *Policy) Covered() int {
+	covered := 0
+	for _, item := range p.Items {
+		if item.Covered() {
+			covered++
+		}
+	}
+	return covered
+}
+
+func (p *Policy) item(name string) *Item {
+	for _, item := range p.Items {
+		if item.Name == name {
+			return item
+		}
+	}
+	return nil
+}
+
+// Report tracks the coverage of a set of policies across test runs
+type Report struct {
+	policies map[string]*Policy
+}
+
+func NewReport() *Report {
+	return &Report{
+		policies: map[string]*Policy{},
+	}
+}
+
+func key(kind, namespace, name string) string {
+	return strings.Join([]string{kind, namespace, name}, "/")
+}
+
+func (r *Report) add(policy *Policy) {
+	k := key(policy.Kind, policy.Namespace, policy.Name)
+	if _, ok := r.policies[k]; !ok {
+		r.policies[k] = policy
+	}
+}
+
+// AddPolicies registers the coverable items of the loaded policies,
+// policies already registered by a previous test keep their coverage
+func (r *Report) AddPolicies(results *policy.LoaderResults) {
+	if results == nil {
+		return
+	}
+	for _, pol := range results.Policies {
+		p := &Policy{
+			Kind:      pol.GetKind(),
+			Namespace: pol.GetNamespace(),
+			Name:      pol.GetName(),
+		}
+		for _, rule := range pol.GetSpec().Rules {
+			p.Items = append(p.Items, &Item{Name: rule.Name})
+		}
+		r.add(p)
+	}
+	for i := range results.VAPs {
+		pol := &results.VAPs[i]
+		r.add(newCELPolicy("ValidatingAdmissionPolicy", pol.Namespace, pol.Name, pol.Spec.MatchConditions, "validations", len(pol.Spec.Validations)))
+	}
+	for i := range results.MAPs {
+		pol := &results.MAPs[i]
+		r.add(newCELPolicy("MutatingAdmissionPolicy", pol.Namespace, pol.Name, alphaMatchConditions(pol.Spec.MatchConditions), "mutations", len(pol.Spec.Mutations)))
+	}
+	for i := range results.ValidatingPolicies {
+		pol := &results.ValidatingPolicies[i]
+		r.add(newCELPolicy("ValidatingPolicy", pol.Namespace, pol.Name, pol.Spec.MatchConditions, "validations", len(pol.Spec.Validations)))
+	}
+	for i := range results.ImageValidatingPolicies {
+		pol := &results.ImageValidatingPolicies[i]
+		r.add(newCELPolicy("ImageValidatingPolicy", pol.Namespace, pol.Name, pol.Spec.MatchConditions, "validations", len(pol.Spec.Validations)))
+	}
+	for i := range results.MutatingPolicies {
+		pol := &results.MutatingPolicies[i]
+		r.add(newCELPolicy("MutatingPolicy", pol.Namespace, pol.Name, alphaMatchConditions(pol.Spec.MatchConditions), "mutations", len(pol.Spec.Mutations)))
+	}
+	for i := range results.GeneratingPolicies {
+		pol := &results.GeneratingPolicies[i]
+		r.add(newCELPolicy("GeneratingPolicy", pol.Namespace, pol.Name, pol.Spec.MatchConditions, "generate", len(pol.Spec.Generation)))
+	}
+	for i := range results.DeletingPolicies {
+		pol := &results.DeletingPolicies[i]
+		r.add(newCELPolicy("DeletingPolicy", pol.Namespace, pol.Name, nil, "conditions", len(pol.Spec.Conditions)))
+	}
+}
+
+func newCELPolicy(kind, namespace, name string, matchConditions []admissionregistrationv1.MatchCondition, field string, expressions int) *Policy {
+	p := &Policy{
+		Kind:      kind,
+		Namespace: namespace,
+		Name:      name,
+		cel:       true,
+	}
+	for _, condition := range matchConditions {
+		p.Items = append(p.Items, &Item{Name: fmt.Sprintf("matchConditions[%s]", condition.Name)})
+	}
+	p.expressions = len(p.Items)
+	for i := 0; i < expressions; i++ {
+		p.Items = append(p.Items, &Item{Name: fmt.Sprintf("%s[%d]", field, i)})
+	}
+	return p
+}
+
+func alphaMatchConditions(conditions []admissionregistrationv1alpha1.MatchCondition) []admissionregistrationv1.MatchCondition {
+	out := make([]admissionregistrationv1.MatchCondition, 0, len(conditions))
+	for _, condition := range conditions {
+		out = append(out, admissionregistrationv1.MatchCondition(condition))
+	}
+	return out
+}
+
+// Record updates the coverage from the engine responses of a test run
+func (r *Report) Record(responses ...engineapi.EngineResponse) {
+	for _, response := range responses {
+		pol := response.Policy()
+		if pol == nil {
+			continue
+		}
+		p := r.policies[key(pol.GetKind(), pol.GetNamespace(), pol.GetName())]
+		if p == nil {
+			continue
+		}
+		for _, rule := range response.PolicyResponse.Rules {
+			if p.cel {
+				p.recordCEL(rule)
+			} else if item := p.item(trimAutogen(rule.Name())); item != nil {
+				item.record(rule.Status())
+			}
+		}
+	}
+}
+
+func trimAutogen(name string) string {
+	name = strings.TrimPrefix(name, "autogen-cronjob-")
+	return strings.TrimPrefix(name, "autogen-")
+}
+
+// recordCEL infers which expressions were evaluated from the outcome of a CEL policy,
+// match conditions are evaluated first and expressions are evaluated in order until one fails
+func (p *Policy) recordCEL(rule engineapi.RuleResponse) {
+	// exceptions are checked before any expression of the policy
+	if rule.IsException() {
+		return
+	}
+	matchConditions := p.Items[:p.expressions]
+	expressions := p.Items[p.expressions:]
+	switch rule.Status() {
+	case engineapi.RuleStatusSkip:
+		for _, item := range matchConditions {
+			item.record(engineapi.RuleStatusSkip)
+		}
+	case engineapi.RuleStatusPass, engineapi.RuleStatusWarn:
+		for _, item := range p.Items {
+			item.record(rule.Status())
+		}
+	case engineapi.RuleStatusFail:
+		for _, item := range matchConditions {
+			item.record(engineapi.RuleStatusPass)
+		}
+		failed := p.failedExpression(rule)
+		if failed < 0 {
+			return
+		}
+		for i := 0; i < failed; i++ {
+			expressions[i].record(engineapi.RuleStatusPass)
+		}
+		expressions[failed].record(engineapi.RuleStatusFail)
+	case engineapi.RuleStatusError:
+		for _, item := range matchConditions {
+			item.record(engineapi.RuleStatusError)
+		}
+	}
+}
+
+// failedExpression returns the index of the failing expression, or -1 if it cannot be determined
+func (p *Policy) failedExpression(rule engineapi.RuleResponse) int {
+	expressions := len(p.Items) - p.expressions
+	if index, ok := rule.ValidationIndex(); ok && index >= 0 && index < expressions {
+		return index
+	}
+	if expressions == 1 {
+		return 0
+	}
+	return -1
+}
+
+// Policies returns the policies sorted by kind, namespace and name
+func (r *Report) Policies() []*Policy {
+	policies := make([]*Policy, 0, len(r.policies))
+	for _, p := range r.policies {
+		policies = append(policies, p)
+	}
+	sort.Slice(policies, func(i, j int) bool {
+		return key(policies[i].Kind, policies[i].Namespace, policies[i].Name) < key(policies[j].Kind, policies[j].Namespace, policies[j].Name)
+	})
+	return policies
+}
+
+// Summary returns the number of covered items, the total number of items and the coverage percentage
+func (r *Report) Summary() (int, int, float64) {
+	covered, total := 0, 0
+	for _, p := range r.policies {
+		covered += p.Covered()
+		total += len(p.Items)
+	}
+	if total == 0 {
+		return 0, 0, 100
+	}
+	return covered, total, float64(covered) * 100 / float64(total)
+}
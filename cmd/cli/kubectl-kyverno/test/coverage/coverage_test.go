@@ -0,0 +1,138 @@
+package coverage
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	policiesv1alpha1 "github.com/kyverno/kyverno/api/policies.kyverno.io/v1alpha1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/stretchr/testify/assert"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func newResults() (*policy.LoaderResults, *kyvernov1.ClusterPolicy, *policiesv1alpha1.ValidatingPolicy) {
+	cpol := &kyvernov1.ClusterPolicy{
+		TypeMeta:   metav1.TypeMeta{Kind: "ClusterPolicy"},
+		ObjectMeta: metav1.ObjectMeta{Name: "cpol"},
+		Spec: kyvernov1.Spec{
+			Rules: []kyvernov1.Rule{{Name: "first"}, {Name: "second"}},
+		},
+	}
+	vpol := policiesv1alpha1.ValidatingPolicy{
+		TypeMeta:   metav1.TypeMeta{Kind: "ValidatingPolicy"},
+		ObjectMeta: metav1.ObjectMeta{Name: "vpol"},
+		Spec: policiesv1alpha1.ValidatingPolicySpec{
+			MatchConditions: []admissionregistrationv1.MatchCondition{{Name: "is-pod"}},
+			Validations: []admissionregistrationv1.Validation{
+				{Message: "first failed"},
+				{Message: "second failed"},
+				{Message: "third failed"},
+			},
+		},
+	}
+	results := &policy.LoaderResults{
+		Policies:           []kyvernov1.PolicyInterface{cpol},
+		ValidatingPolicies: []policiesv1alpha1.ValidatingPolicy{vpol},
+	}
+	return results, cpol, &results.ValidatingPolicies[0]
+}
+
+func response(pol engineapi.GenericPolicy, rules ...engineapi.RuleResponse) engineapi.EngineResponse {
+	return engineapi.NewEngineResponse(unstructured.Unstructured{}, pol, nil).WithPolicyResponse(engineapi.PolicyResponse{Rules: rules})
+}
+
+func TestReport_Record(t *testing.T) {
+	results, cpol, vpol := newResults()
+	report := NewReport()
+	report.AddPolicies(results)
+	report.Record(
+		response(engineapi.NewKyvernoPolicy(cpol), *engineapi.RulePass("autogen-first", engineapi.Validation, "", nil)),
+		response(engineapi.NewValidatingPolicy(vpol), *engineapi.RuleFail("", engineapi.Validation, "dynamic message", nil).WithValidationIndex(1)),
+	)
+	covered, total, percentage := report.Summary()
+	assert.Equal(t, 4, covered)
+	assert.Equal(t, 6, total)
+	assert.InDelta(t, 66.66, percentage, 0.01)
+	policies := report.Policies()
+	assert.Len(t, policies, 2)
+	assert.Equal(t, "ClusterPolicy", policies[0].Kind)
+	assert.Equal(t, 1, policies[0].Items[0].Pass)
+	assert.False(t, policies[0].Items[1].Covered())
+	assert.Equal(t, "matchConditions[is-pod]", policies[1].Items[0].Name)
+	assert.Equal(t, 1, policies[1].Items[0].Pass)
+	assert.Equal(t, 1, policies[1].Items[1].Pass)
+	assert.Equal(t, 1, policies[1].Items[2].Fail)
+	assert.False(t, policies[1].Items[3].Covered())
+}
+
+func TestReport_RecordCELSkipAndException(t *testing.T) {
+	results, _, vpol := newResults()
+	report := NewReport()
+	report.AddPolicies(results)
+	report.Record(
+		response(engineapi.NewValidatingPolicy(vpol), *engineapi.RuleSkip("", engineapi.Validation, "skip", nil)),
+		response(engineapi.NewValidatingPolicy(vpol), *engineapi.RuleSkip("exception", engineapi.Validation, "skip", nil).WithExceptions([]engineapi.GenericException{engineapi.NewCELPolicyException(&policiesv1alpha1.PolicyException{})})),
+		response(engineapi.NewValidatingPolicy(vpol), *engineapi.RuleFail("", engineapi.Validation, "dynamic message", nil)),
+	)
+	p := report.Policies()[1]
+	assert.Equal(t, 1, p.Items[0].Skip)
+	assert.Equal(t, 1, p.Items[0].Pass)
+	for _, item := range p.Items[1:] {
+		assert.False(t, item.Covered())
+	}
+}
+
+func TestReport_RecordSkip(t *testing.T) {
+	results, cpol, _ := newResults()
+	report := NewReport()
+	report.AddPolicies(results)
+	report.Record(response(engineapi.NewKyvernoPolicy(cpol), *engineapi.RuleSkip("first", engineapi.Validation, "preconditions not met", nil)))
+	item := report.Policies()[0].Items[0]
+	assert.Equal(t, 1, item.Skip)
+	assert.False(t, item.Covered())
+	covered, total, _ := report.Summary()
+	assert.Equal(t, 0, covered)
+	assert.Equal(t, 6, total)
+}
+
+func TestReport_AddPoliciesKeepsCoverage(t *testing.T) {
+	results, cpol, _ := newResults()
+	report := NewReport()
+	report.AddPolicies(results)
+	report.Record(response(engineapi.NewKyvernoPolicy(cpol), *engineapi.RulePass("first", engineapi.Validation, "", nil)))
+	report.AddPolicies(results)
+	assert.Equal(t, 1, report.Policies()[0].Items[0].Pass)
+}
+
+func TestReport_Write(t *testing.T) {
+	results, cpol, _ := newResults()
+	report := NewReport()
+	report.AddPolicies(results)
+	report.Record(response(engineapi.NewKyvernoPolicy(cpol), *engineapi.RulePass("first", engineapi.Validation, "", nil)))
+
+	var text bytes.Buffer
+	assert.NoError(t, report.Write(&text, FormatText))
+	assert.True(t, strings.HasPrefix(text.String(), "Coverage Summary: 1/6 items covered (16.67%)"))
+
+	var out bytes.Buffer
+	assert.NoError(t, report.Write(&out, FormatJSON))
+	var decoded jsonReport
+	assert.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+	assert.Equal(t, 1, decoded.Covered)
+	assert.Equal(t, 6, decoded.Total)
+	assert.Len(t, decoded.Policies, 2)
+
+	var xml bytes.Buffer
+	assert.NoError(t, report.Write(&xml, FormatCobertura))
+	assert.Contains(t, xml.String(), `<coverage line-rate="0.1667" lines-covered="1" lines-valid="6" version="kyverno">`)
+	assert.Contains(t, xml.String(), `<package name="ClusterPolicy" line-rate="0.5000">`)
+	assert.Contains(t, xml.String(), `<line number="1" hits="1" name="first"></line>`)
+
+	assert.Error(t, report.Write(&out, "html"))
+}
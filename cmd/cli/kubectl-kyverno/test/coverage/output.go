@@ -0,0 +1,160 @@
+package coverage
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"text/tabwriter"
+)
+
+const (
+	FormatText      = "text"
+	FormatJSON      = "json"
+	FormatCobertura = "cobertura"
+)
+
+var Formats = []string{FormatText, FormatJSON, FormatCobertura}
+
+// Write writes the coverage report in the given format
+func (r *Report) Write(out io.Writer, format string) error {
+	switch format {
+	case "", FormatText:
+		return r.WriteText(out)
+	case FormatJSON:
+		return r.WriteJSON(out)
+	case FormatCobertura:
+		return r.WriteCobertura(out)
+	default:
+		return fmt.Errorf("invalid coverage format %s, expected one of %v", format, Formats)
+	}
+}
+
+// WriteText writes a human readable coverage summary
+func (r *Report) WriteText(out io.Writer) error {
+	covered, total, percentage := r.Summary()
+	fmt.Fprintf(out, "Coverage Summary: %d/%d items covered (%.2f%%)\n\n", covered, total, percentage)
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "KIND\tPOLICY\tITEM\tPASS\tFAIL\tWARN\tSKIP\tERROR\tCOVERED")
+	for _, p := range r.Policies() {
+		name := p.Name
+		if p.Namespace != "" {
+			name = p.Namespace + "/" + p.Name
+		}
+		for _, item := range p.Items {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%d\t%d\t%d\t%d\t%t\n", p.Kind, name, item.Name, item.Pass, item.Fail, item.Warn, item.Skip, item.Error, item.Covered())
+		}
+	}
+	return w.Flush()
+}
+
+type jsonReport struct {
+	Covered    int       `json:"covered"`
+	Total      int       `json:"total"`
+	Percentage float64   `json:"percentage"`
+	Policies   []*Policy `json:"policies"`
+}
+
+// WriteJSON writes the coverage report as JSON
+func (r *Report) WriteJSON(out io.Writer) error {
+	covered, total, percentage := r.Summary()
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(jsonReport{
+		Covered:    covered,
+		Total:      total,
+		Percentage: percentage,
+		Policies:   r.Policies(),
+	})
+}
+
+type coberturaCoverage struct {
+	XMLName      xml.Name           `xml:"coverage"`
+	LineRate     string             `xml:"line-rate,attr"`
+	LinesCovered int                `xml:"lines-covered,attr"`
+	LinesValid   int                `xml:"lines-valid,attr"`
+	Version      string             `xml:"version,attr"`
+	Packages     []coberturaPackage `xml:"packages>package"`
+}
+
+type coberturaPackage struct {
+	Name     string           `xml:"name,attr"`
+	LineRate string           `xml:"line-rate,attr"`
+	Classes  []coberturaClass `xml:"classes>class"`
+}
+
+type coberturaClass struct {
+	Name     string          `xml:"name,attr"`
+	Filename string          `xml:"filename,attr"`
+	LineRate string          `xml:"line-rate,attr"`
+	Lines    []coberturaLine `xml:"lines>line"`
+}
+
+type coberturaLine struct {
+	Number int    `xml:"number,attr"`
+	Hits   int    `xml:"hits,attr"`
+	Name   string `xml:"name,attr"`
+}
+
+func lineRate(covered, total int) string {
+	if total == 0 {
+		return "1"
+	}
+	return fmt.Sprintf("%.4f", float64(covered)/float64(total))
+}
+
+// WriteCobertura writes the coverage report in a Cobertura like XML format,
+// policy kinds are reported as packages, policies as classes and items as lines
+func (r *Report) WriteCobertura(out io.Writer) error {
+	covered, total, _ := r.Summary()
+	report := coberturaCoverage{
+		LineRate:     lineRate(covered, total),
+		LinesCovered: covered,
+		LinesValid:   total,
+		Version:      "kyverno",
+	}
+	var pkg *coberturaPackage
+	pkgCovered, pkgTotal := 0, 0
+	for _, p := range r.Policies() {
+		if pkg == nil || pkg.Name != p.Kind {
+			if pkg != nil {
+				pkg.LineRate = lineRate(pkgCovered, pkgTotal)
+			}
+			report.Packages = append(report.Packages, coberturaPackage{Name: p.Kind})
+			pkg = &report.Packages[len(report.Packages)-1]
+			pkgCovered, pkgTotal = 0, 0
+		}
+		name := p.Name
+		if p.Namespace != "" {
+			name = p.Namespace + "/" + p.Name
+		}
+		class := coberturaClass{
+			Name:     name,
+			Filename: name,
+			LineRate: lineRate(p.Covered(), len(p.Items)),
+		}
+		for i, item := range p.Items {
+			class.Lines = append(class.Lines, coberturaLine{
+				Number: i + 1,
+				Hits:   item.hits(),
+				Name:   item.Name,
+			})
+		}
+		pkg.Classes = append(pkg.Classes, class)
+		pkgCovered += p.Covered()
+		pkgTotal += len(p.Items)
+	}
+	if pkg != nil {
+		pkg.LineRate = lineRate(pkgCovered, pkgTotal)
+	}
+	if _, err := io.WriteString(out, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(out)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+	_, err := io.WriteString(out, "\n")
+	return err
+}
@@ -0,0 +1,47 @@
+package watch
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+// Results maps a result key (policy, rule and resource) to its outcome
+type Results map[string]string
+
+// Diff prints the results that were added, removed or changed compared to the previous run
+func (r Results) Diff(out io.Writer, previous Results) {
+	var lines []string
+	for key, result := range r {
+		if before, ok := previous[key]; !ok {
+			lines = append(lines, fmt.Sprintf("  + %s: %s", key, result))
+		} else if before != result {
+			lines = append(lines, fmt.Sprintf("  ~ %s: %s -> %s", key, before, result))
+		}
+	}
+	for key, result := range previous {
+		if _, ok := r[key]; !ok {
+			lines = append(lines, fmt.Sprintf("  - %s: %s", key, result))
+		}
+	}
+	if len(lines) == 0 {
+		fmt.Fprintln(out, "No result changes since the previous run")
+		return
+	}
+	// sort by key rather than by change type
+	sort.Slice(lines, func(i, j int) bool {
+		return lines[i][4:] < lines[j][4:]
+	})
+	fmt.Fprintln(out, "Result changes since the previous run:")
+	for _, line := range lines {
+		fmt.Fprintln(out, line)
+	}
+}
+
+// Key builds a result key
+func Key(policy, rule, resource string) string {
+	if rule == "" {
+		return fmt.Sprintf("%s -> %s", policy, resource)
+	}
+	return fmt.Sprintf("%s/%s -> %s", policy, rule, resource)
+}
@@ -0,0 +1,131 @@
+package watch
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// DefaultDebounce is the quiet period after the last file system event before a run is triggered
+const DefaultDebounce = 300 * time.Millisecond
+
+// Run watches the given files and directories and calls fn with the changed files each time they change,
+// events are debounced so that an editor saving several files triggers a single run.
+// Run blocks until the context is cancelled.
+func Run(ctx context.Context, out io.Writer, paths []string, debounce time.Duration, fn func(changed []string)) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create file watcher (%w)", err)
+	}
+	defer watcher.Close()
+	var roots []string
+	for _, path := range paths {
+		path, err := filepath.Abs(path)
+		if err != nil {
+			return err
+		}
+		roots = append(roots, path)
+		if err := add(watcher, path); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintln(out, "Watching for changes, press Ctrl+C to stop ...")
+	changed := map[string]struct{}{}
+	timer := time.NewTimer(debounce)
+	timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			fmt.Fprintln(out, "Watch error:", err)
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if !watched(roots, event.Name) || event.Has(fsnotify.Chmod) && !event.Has(fsnotify.Write) {
+				continue
+			}
+			// watch new directories
+			if event.Has(fsnotify.Create) {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					if err := add(watcher, event.Name); err != nil {
+						fmt.Fprintln(out, "Watch error:", err)
+					}
+				}
+			}
+			changed[event.Name] = struct{}{}
+			timer.Reset(debounce)
+		case <-timer.C:
+			files := make([]string, 0, len(changed))
+			for file := range changed {
+				files = append(files, file)
+			}
+			sort.Strings(files)
+			changed = map[string]struct{}{}
+			fn(files)
+		}
+	}
+}
+
+// add watches a directory recursively, files are watched through their parent directory
+// so that editors replacing files on save are supported
+func add(watcher *fsnotify.Watcher, path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return watcher.Add(filepath.Dir(path))
+	}
+	root := path
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			// skip hidden directories like .git
+			if path != root && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return watcher.Add(path)
+		}
+		return nil
+	})
+}
+
+func watched(roots []string, name string) bool {
+	for _, root := range roots {
+		if name == root || strings.HasPrefix(name, root+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Affects returns true if one of the changed files is one of the given paths or is inside it
+func Affects(changed []string, paths ...string) bool {
+	var roots []string
+	for _, path := range paths {
+		if path, err := filepath.Abs(path); err == nil {
+			roots = append(roots, path)
+		}
+	}
+	for _, name := range changed {
+		if watched(roots, name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+package watch
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResults_Diff(t *testing.T) {
+	tests := []struct {
+		name     string
+		current  Results
+		previous Results
+		want     string
+	}{{
+		name:     "no changes",
+		current:  Results{"a": "pass"},
+		previous: Results{"a": "pass"},
+		want:     "No result changes since the previous run\n",
+	}, {
+		name:     "changes",
+		current:  Results{"a": "fail", "c": "pass"},
+		previous: Results{"a": "pass", "b": "pass"},
+		want: `Result changes since the previous run:
+  ~ a: pass -> fail
+  - b: pass
+  + c: pass
+`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			tt.current.Diff(&out, tt.previous)
+			assert.Equal(t, tt.want, out.String())
+		})
+	}
+}
+
+func TestKey(t *testing.T) {
+	assert.Equal(t, "pol/rule -> default/Pod/nginx", Key("pol", "rule", "default/Pod/nginx"))
+	assert.Equal(t, "pol -> default/Pod/nginx", Key("pol", "", "default/Pod/nginx"))
+}
+
+func TestAffects(t *testing.T) {
+	dir := t.TempDir()
+	changed := []string{filepath.Join(dir, "policies", "policy.yaml")}
+	assert.True(t, Affects(changed, filepath.Join(dir, "policies")))
+	assert.True(t, Affects(changed, filepath.Join(dir, "resources"), filepath.Join(dir, "policies", "policy.yaml")))
+	assert.False(t, Affects(changed, filepath.Join(dir, "pol")))
+	assert.False(t, Affects(changed))
+}
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "policy.yaml")
+	assert.NoError(t, os.WriteFile(file, []byte("a"), 0o600))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	calls := make(chan []string, 1)
+	done := make(chan error)
+	go func() {
+		done <- Run(ctx, io.Discard, []string{dir}, 50*time.Millisecond, func(changed []string) {
+			calls <- changed
+		})
+	}()
+	// give the watcher some time to start
+	time.Sleep(200 * time.Millisecond)
+	assert.NoError(t, os.WriteFile(file, []byte("b"), 0o600))
+	assert.NoError(t, os.WriteFile(file, []byte("c"), 0o600))
+	select {
+	case changed := <-calls:
+		assert.Equal(t, []string{file}, changed)
+	case <-ctx.Done():
+		t.Fatal("no change notified")
+	}
+	cancel()
+	assert.NoError(t, <-done)
+}
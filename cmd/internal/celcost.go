@@ -0,0 +1,33 @@
+package internal
+
+import (
+	"sync"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/cel/compiler"
+	"github.com/kyverno/kyverno/pkg/config"
+)
+
+func setupCELCostLimits(logger logr.Logger, configuration config.Configuration) {
+	logger = logger.WithName("cel-cost")
+	limits := func() compiler.CostLimits {
+		return compiler.CostLimits{
+			Expression: uint64(configuration.GetCELExpressionCostLimit()),
+			Policy:     uint64(configuration.GetCELPolicyCostLimit()),
+		}
+	}
+	current := limits()
+	logger.V(2).Info("setup cel cost limits...", "expression", current.Expression, "policy", current.Policy)
+	compiler.SetDefaultCostLimits(limits)
+	// compiled policies keep the limits they were compiled with, they are compiled again when the limits changed
+	var lock sync.Mutex
+	configuration.OnChanged(func() {
+		lock.Lock()
+		defer lock.Unlock()
+		if updated := limits(); updated != current {
+			logger.V(2).Info("cel cost limits changed, compiling policies again...", "expression", updated.Expression, "policy", updated.Policy)
+			current = updated
+			compiler.NotifyDefaultCostLimitsChanged()
+		}
+	})
+}
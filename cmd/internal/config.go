@@ -16,6 +16,8 @@ type Configuration interface {
 	UsesCosign() bool
 	UsesRegistryClient() bool
 	UsesImageVerifyCache() bool
+	UsesHTTPGuard() bool
+	UsesResultSinks() bool
 	UsesLeaderElection() bool
 	UsesKyvernoClient() bool
 	UsesDynamicClient() bool
@@ -101,6 +103,18 @@ func WithImageVerifyCache() ConfigurationOption {
 	}
 }
 
+func WithHTTPGuard() ConfigurationOption {
+	return func(c *configuration) {
+		c.usesHTTPGuard = true
+	}
+}
+
+func WithResultSinks() ConfigurationOption {
+	return func(c *configuration) {
+		c.usesResultSinks = true
+	}
+}
+
 func WithLeaderElection() ConfigurationOption {
 	return func(c *configuration) {
 		c.usesLeaderElection = true
@@ -180,6 +194,8 @@ type configuration struct {
 	usesCosign               bool
 	usesRegistryClient       bool
 	usesImageVerifyCache     bool
+	usesHTTPGuard            bool
+	usesResultSinks          bool
 	usesLeaderElection       bool
 	usesKyvernoClient        bool
 	usesDynamicClient        bool
@@ -237,6 +253,14 @@ func (c *configuration) UsesImageVerifyCache() bool {
 	return c.usesImageVerifyCache
 }
 
+func (c *configuration) UsesHTTPGuard() bool {
+	return c.usesHTTPGuard
+}
+
+func (c *configuration) UsesResultSinks() bool {
+	return c.usesResultSinks
+}
+
 func (c *configuration) UsesLeaderElection() bool {
 	return c.usesLeaderElection
 }
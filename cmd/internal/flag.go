@@ -59,6 +59,26 @@ var (
 	imageVerifyCacheEnabled     bool
 	imageVerifyCacheTTLDuration time.Duration
 	imageVerifyCacheMaxSize     int64
+	imageVerifyCacheStorePath   string
+	// http guard
+	httpTimeout        time.Duration
+	httpCacheTTL       time.Duration
+	httpCacheMaxSize   int
+	httpRateLimitQPS   float64
+	httpRateLimitBurst int
+	// result sinks
+	resultSinkFile           string
+	resultSinkFileMaxSize    int64
+	resultSinkFileMaxBackups int
+	resultSinkWebhookURL     string
+	resultSinkWebhookHeaders string
+	resultSinkOTLPEndpoint   string
+	resultSinkOTLPHeaders    string
+	resultSinkBatchSize      int
+	resultSinkFlushInterval  time.Duration
+	resultSinkQueueSize      int
+	resultSinkMaxRetries     int
+	resultSinkTimeout        time.Duration
 	// global context
 	enableGlobalContext bool
 	// reporting
@@ -113,6 +133,7 @@ func initKubeconfigFlags(qps float64, burst int, eventsQPS float64, eventsBurst
 func initPolicyExceptionsFlags() {
 	flag.StringVar(&exceptionNamespace, "exceptionNamespace", "", "Configure the namespace to accept PolicyExceptions. If it is set to '*', exceptions are allowed in all namespaces.")
 	flag.BoolVar(&enablePolicyException, "enablePolicyException", false, "Enable PolicyException feature.")
+	flag.Func(toggle.RequireExceptionApprovalFlagName, toggle.RequireExceptionApprovalDescription, toggle.RequireExceptionApproval.Parse)
 }
 
 func initConfigMapCachingFlags() {
@@ -140,6 +161,7 @@ func initImageVerifyCacheFlags() {
 	flag.BoolVar(&imageVerifyCacheEnabled, "imageVerifyCacheEnabled", true, "Enable a TTL cache for verified images.")
 	flag.Int64Var(&imageVerifyCacheMaxSize, "imageVerifyCacheMaxSize", 1000, "Maximum number of keys that can be stored in the TTL cache. Keys are a combination of policy elements along with the image reference. Default is 1000. 0 sets the value to default.")
 	flag.DurationVar(&imageVerifyCacheTTLDuration, "imageVerifyCacheTTLDuration", 60*time.Minute, "Maximum TTL value for a cache expressed as duration. Default is 60m. 0 sets the value to default.")
+	flag.StringVar(&imageVerifyCacheStorePath, "imageVerifyCacheStorePath", "", "Path of a file used to persist the image verify cache across restarts, it can be shared by replicas mounting the same volume. The cache is kept in memory when empty.")
 }
 
 func initLeaderElectionFlags() {
@@ -196,6 +218,29 @@ func WithDefaultBurst(burst int) Option {
 	}
 }
 
+func initHTTPGuardFlags() {
+	flag.DurationVar(&httpTimeout, "httpTimeout", 0, "Default timeout of the HTTP calls made by policies, including the time spent waiting for the rate limiter. No timeout is applied if zero.")
+	flag.DurationVar(&httpCacheTTL, "httpCacheTTL", 0, "Duration the successful responses of the HTTP calls made by policies are cached for. Responses are not cached if zero.")
+	flag.IntVar(&httpCacheMaxSize, "httpCacheMaxSize", 1000, "Maximum number of cached responses of the HTTP calls made by policies.")
+	flag.Float64Var(&httpRateLimitQPS, "httpRateLimitQPS", 0, "Maximum QPS of the HTTP calls made by policies per destination host. No rate limit is applied if zero.")
+	flag.IntVar(&httpRateLimitBurst, "httpRateLimitBurst", 10, "Maximum burst of the HTTP calls made by policies per destination host.")
+}
+
+func initResultSinkFlags() {
+	flag.StringVar(&resultSinkFile, "resultSinkFile", "", "Path of a file policy results are appended to as JSON lines. Results are not written to a file when empty.")
+	flag.Int64Var(&resultSinkFileMaxSize, "resultSinkFileMaxSize", 100*1024*1024, "Size in bytes after which the policy results file is rotated.")
+	flag.IntVar(&resultSinkFileMaxBackups, "resultSinkFileMaxBackups", 3, "Maximum number of rotated policy results files kept.")
+	flag.StringVar(&resultSinkWebhookURL, "resultSinkWebhookURL", "", "URL batches of policy results are posted to as JSON arrays. Results are not posted when empty.")
+	flag.StringVar(&resultSinkWebhookHeaders, "resultSinkWebhookHeaders", "", "Comma separated list of key=value headers added to the policy results webhook requests.")
+	flag.StringVar(&resultSinkOTLPEndpoint, "resultSinkOtlpEndpoint", "", "OTLP/HTTP logs endpoint policy results are exported to, e.g. http://opentelemetrycollector.kyverno.svc.cluster.local:4318/v1/logs. Results are not exported when empty.")
+	flag.StringVar(&resultSinkOTLPHeaders, "resultSinkOtlpHeaders", "", "Comma separated list of key=value headers added to the policy results OTLP requests.")
+	flag.IntVar(&resultSinkBatchSize, "resultSinkBatchSize", 100, "Maximum number of policy results delivered at once by the result sinks.")
+	flag.DurationVar(&resultSinkFlushInterval, "resultSinkFlushInterval", 5*time.Second, "Maximum duration policy results wait before being delivered by the result sinks.")
+	flag.IntVar(&resultSinkQueueSize, "resultSinkQueueSize", 10000, "Maximum number of policy results waiting to be delivered by each result sink, results are dropped when the queue is full.")
+	flag.IntVar(&resultSinkMaxRetries, "resultSinkMaxRetries", 5, "Number of times a failed delivery of policy results is retried before the results are dropped.")
+	flag.DurationVar(&resultSinkTimeout, "resultSinkTimeout", 10*time.Second, "Timeout of the requests made by the webhook and OTLP sinks.")
+}
+
 func initFlags(config Configuration, opts ...Option) {
 	options := newOptions()
 	for _, o := range opts {
@@ -245,6 +290,14 @@ func initFlags(config Configuration, opts ...Option) {
 	if config.UsesImageVerifyCache() {
 		initImageVerifyCacheFlags()
 	}
+	// http guard
+	if config.UsesHTTPGuard() {
+		initHTTPGuardFlags()
+	}
+	// result sinks
+	if config.UsesResultSinks() {
+		initResultSinkFlags()
+	}
 	// leader election
 	if config.UsesLeaderElection() {
 		initLeaderElectionFlags()
@@ -0,0 +1,18 @@
+package internal
+
+import (
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/httpguard"
+)
+
+func setupHTTPGuard(logger logr.Logger) {
+	logger = logger.WithName("http-guard").WithValues("timeout", httpTimeout, "cachettl", httpCacheTTL, "cachemaxsize", httpCacheMaxSize, "qps", httpRateLimitQPS, "burst", httpRateLimitBurst)
+	logger.V(2).Info("setup http guard...")
+	httpguard.SetDefault(httpguard.New(httpguard.Options{
+		Timeout:   httpTimeout,
+		CacheTTL:  httpCacheTTL,
+		CacheSize: httpCacheMaxSize,
+		QPS:       httpRateLimitQPS,
+		Burst:     httpRateLimitBurst,
+	}))
+}
@@ -6,13 +6,14 @@ import (
 )
 
 func setupImageVerifyCache(logger logr.Logger) imageverifycache.Client {
-	logger = logger.WithName("image-verify-cache").WithValues("enabled", imageVerifyCacheEnabled, "maxsize", imageVerifyCacheMaxSize, "ttl", imageVerifyCacheTTLDuration)
+	logger = logger.WithName("image-verify-cache").WithValues("enabled", imageVerifyCacheEnabled, "maxsize", imageVerifyCacheMaxSize, "ttl", imageVerifyCacheTTLDuration, "storepath", imageVerifyCacheStorePath)
 	logger.V(2).Info("setup image verify cache...")
 	opts := []imageverifycache.Option{
 		imageverifycache.WithLogger(logger),
 		imageverifycache.WithCacheEnableFlag(imageVerifyCacheEnabled),
 		imageverifycache.WithMaxSize(imageVerifyCacheMaxSize),
 		imageverifycache.WithTTLDuration(imageVerifyCacheTTLDuration),
+		imageverifycache.WithPersistentStore(imageVerifyCacheStorePath),
 	}
 	imageVerifyCache, err := imageverifycache.New(opts...)
 	checkError(logger, err, "failed to create image verify cache client")
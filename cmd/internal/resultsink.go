@@ -0,0 +1,71 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/resultsink"
+)
+
+func setupResultSinks(logger logr.Logger, name string) (resultsink.Sink, context.CancelFunc) {
+	logger = logger.WithName("result-sinks").WithValues("file", resultSinkFile, "webhook", resultSinkWebhookURL, "otlp", resultSinkOTLPEndpoint)
+	logger.V(2).Info("setup result sinks...")
+	options := resultsink.BatchOptions{
+		BatchSize:     resultSinkBatchSize,
+		FlushInterval: resultSinkFlushInterval,
+		QueueSize:     resultSinkQueueSize,
+		MaxRetries:    resultSinkMaxRetries,
+		RetryBackoff:  time.Second,
+	}
+	client := &http.Client{Timeout: resultSinkTimeout}
+	var sinks []resultsink.Sink
+	if resultSinkFile != "" {
+		sink, err := resultsink.NewFileSink(logger.WithName("file"), resultSinkFile, resultSinkFileMaxSize, resultSinkFileMaxBackups, options)
+		checkError(logger, err, "failed to create file result sink")
+		sinks = append(sinks, sink)
+	}
+	if resultSinkWebhookURL != "" {
+		headers, err := parseHeaders(resultSinkWebhookHeaders)
+		checkError(logger, err, "failed to parse webhook result sink headers")
+		sink, err := resultsink.NewWebhookSink(logger.WithName("webhook"), resultSinkWebhookURL, headers, client, options)
+		checkError(logger, err, "failed to create webhook result sink")
+		sinks = append(sinks, sink)
+	}
+	if resultSinkOTLPEndpoint != "" {
+		headers, err := parseHeaders(resultSinkOTLPHeaders)
+		checkError(logger, err, "failed to parse otlp result sink headers")
+		sink, err := resultsink.NewOTLPSink(logger.WithName("otlp"), resultSinkOTLPEndpoint, name, headers, client, options)
+		checkError(logger, err, "failed to create otlp result sink")
+		sinks = append(sinks, sink)
+	}
+	sink := resultsink.NewMultiSink(sinks...)
+	if sink == nil {
+		return nil, nil
+	}
+	return sink, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := sink.Close(ctx); err != nil {
+			logger.Error(err, "failed to close result sinks")
+		}
+	}
+}
+
+func parseHeaders(value string) (map[string]string, error) {
+	headers := map[string]string{}
+	for _, header := range strings.Split(value, ",") {
+		if header = strings.TrimSpace(header); header == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(header, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("invalid header %q, expected key=value", header)
+		}
+		headers[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	return headers, nil
+}
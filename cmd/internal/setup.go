@@ -16,6 +16,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/imageverifycache"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	"github.com/kyverno/kyverno/pkg/registryclient"
+	"github.com/kyverno/kyverno/pkg/resultsink"
 	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
 	openreportsclient "github.com/openreports/reports-api/pkg/client/clientset/versioned/typed/openreports.io/v1alpha1"
 	eventsv1 "k8s.io/client-go/kubernetes/typed/events/v1"
@@ -53,6 +54,7 @@ type SetupResult struct {
 	KyvernoDynamicClient   dclient.Interface
 	EventsClient           eventsv1.EventsV1Interface
 	ReportingConfiguration reportutils.ReportingConfiguration
+	ResultSink             resultsink.Sink
 	ResyncPeriod           time.Duration
 	RestConfig             *rest.Config
 }
@@ -71,6 +73,7 @@ func Setup(config Configuration, name string, skipResourceFilters bool) (context
 	metricsManager, sdownMetrics := SetupMetrics(ctx, logger, metricsConfiguration, client)
 	client = client.WithMetrics(metricsManager, metrics.KubeClient)
 	configuration := startConfigController(ctx, logger, client, skipResourceFilters)
+	setupCELCostLimits(logger, configuration)
 	sdownTracing := SetupTracing(logger, name, client)
 	var registryClient registryclient.Client
 	var registrySecretLister corev1listers.SecretNamespaceLister
@@ -81,6 +84,9 @@ func Setup(config Configuration, name string, skipResourceFilters bool) (context
 	if config.UsesImageVerifyCache() {
 		imageVerifyCache = setupImageVerifyCache(logger)
 	}
+	if config.UsesHTTPGuard() {
+		setupHTTPGuard(logger)
+	}
 	if config.UsesCosign() {
 		setupSigstoreTUF(ctx, logger)
 	}
@@ -116,6 +122,11 @@ func Setup(config Configuration, name string, skipResourceFilters bool) (context
 	if config.UsesReporting() {
 		reportingConfig = setupReporting(logger)
 	}
+	var resultSink resultsink.Sink
+	var sdownResultSinks context.CancelFunc
+	if config.UsesResultSinks() {
+		resultSink, sdownResultSinks = setupResultSinks(logger, name)
+	}
 	var restConfig *rest.Config
 	if config.UsesRestConfig() {
 		restConfig = createClientConfig(logger, clientRateLimitQPS, clientRateLimitBurst)
@@ -152,8 +163,9 @@ func Setup(config Configuration, name string, skipResourceFilters bool) (context
 			KyvernoDynamicClient:   dClient,
 			EventsClient:           eventsClient,
 			ReportingConfiguration: reportingConfig,
+			ResultSink:             resultSink,
 			ResyncPeriod:           resyncPeriod,
 			RestConfig:             restConfig,
 		},
-		shutdown(logger.WithName("shutdown"), sdownMaxProcs, sdownMetrics, sdownTracing, sdownSignals)
+		shutdown(logger.WithName("shutdown"), sdownMaxProcs, sdownMetrics, sdownTracing, sdownResultSinks, sdownSignals)
 }
@@ -28,6 +28,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/controllers/admissionpolicygenerator"
 	"github.com/kyverno/kyverno/pkg/controllers/certmanager"
+	exceptionstatuscontroller "github.com/kyverno/kyverno/pkg/controllers/exceptionstatus"
 	genericloggingcontroller "github.com/kyverno/kyverno/pkg/controllers/generic/logging"
 	genericwebhookcontroller "github.com/kyverno/kyverno/pkg/controllers/generic/webhook"
 	globalcontextcontroller "github.com/kyverno/kyverno/pkg/controllers/globalcontext"
@@ -38,6 +39,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/engine/apicall"
 	"github.com/kyverno/kyverno/pkg/event"
 	"github.com/kyverno/kyverno/pkg/globalcontext/store"
+	"github.com/kyverno/kyverno/pkg/httpguard"
 	"github.com/kyverno/kyverno/pkg/informers"
 	"github.com/kyverno/kyverno/pkg/leaderelection"
 	"github.com/kyverno/kyverno/pkg/logging"
@@ -52,6 +54,7 @@ import (
 	webhookscelexception "github.com/kyverno/kyverno/pkg/webhooks/celexception"
 	webhooksexception "github.com/kyverno/kyverno/pkg/webhooks/exception"
 	webhooksglobalcontext "github.com/kyverno/kyverno/pkg/webhooks/globalcontext"
+	"github.com/kyverno/kyverno/pkg/webhooks/handlers"
 	webhookspolicy "github.com/kyverno/kyverno/pkg/webhooks/policy"
 	webhooksresource "github.com/kyverno/kyverno/pkg/webhooks/resource"
 	"github.com/kyverno/kyverno/pkg/webhooks/resource/gpol"
@@ -147,6 +150,7 @@ func createrLeaderControllers(
 	configuration config.Configuration,
 	eventGenerator event.Interface,
 	stateRecorder webhookcontroller.StateRecorder,
+	exceptionExpiringWindow time.Duration,
 ) ([]internal.Controller, func(context.Context) error, error) {
 	var leaderControllers []internal.Controller
 	certManager := certmanager.NewController(
@@ -301,6 +305,16 @@ func createrLeaderControllers(
 	leaderControllers = append(leaderControllers, internal.NewController(celExceptionWebhookControllerName, celExceptionWebhookController, 1))
 	leaderControllers = append(leaderControllers, internal.NewController(gctxWebhookControllerName, gctxWebhookController, 1))
 	leaderControllers = append(leaderControllers, internal.NewController(policystatuscontroller.ControllerName, policyStatusController, policystatuscontroller.Workers))
+	if internal.PolicyExceptionEnabled() {
+		exceptionStatusController := exceptionstatuscontroller.NewController(
+			kyvernoClient,
+			kyvernoInformer.Kyverno().V2().PolicyExceptions(),
+			kyvernoInformer.Policies().V1alpha1().PolicyExceptions(),
+			eventGenerator,
+			exceptionExpiringWindow,
+		)
+		leaderControllers = append(leaderControllers, internal.NewController(exceptionstatuscontroller.ControllerName, exceptionStatusController, exceptionstatuscontroller.Workers))
+	}
 
 	generateVAPs := toggle.FromContext(context.TODO()).GenerateValidatingAdmissionPolicy()
 	generateMAPs := toggle.FromContext(context.TODO()).GenerateMutatingAdmissionPolicy()
@@ -355,6 +369,10 @@ func main() {
 		webhookRegistrationTimeout      time.Duration
 		admissionReports                bool
 		dumpPayload                     bool
+		dumpPayloadFile                 string
+		dumpPayloadDir                  string
+		dumpPayloadMaxSize              int64
+		dumpPayloadMaxBackups           int
 		servicePort                     int
 		webhookServerPort               int
 		backgroundServiceAccountName    string
@@ -365,12 +383,17 @@ func main() {
 		maxAuditCapacity                int
 		maxAdmissionReports             int
 		controllerRuntimeMetricsAddress string
+		exceptionExpiringWindow         time.Duration
 	)
 	flagset := flag.NewFlagSet("kyverno", flag.ExitOnError)
 	flagset.BoolVar(&dumpPayload, "dumpPayload", false, "Set this flag to activate/deactivate debug mode.")
+	flagset.StringVar(&dumpPayloadFile, "dumpPayloadFile", "", "Record the redacted admission payloads to this NDJSON file, the recorded payloads can be replayed with the kyverno replay command.")
+	flagset.StringVar(&dumpPayloadDir, "dumpPayloadDir", "", "Record the redacted admission payloads to this directory, one file per admission request.")
+	flagset.Int64Var(&dumpPayloadMaxSize, "dumpPayloadMaxSize", 100*1024*1024, "Maximum size in bytes of the admission payloads file before it gets rotated.")
+	flagset.IntVar(&dumpPayloadMaxBackups, "dumpPayloadMaxBackups", 5, "Maximum number of rotated admission payloads files to keep.")
 	flagset.IntVar(&webhookTimeout, "webhookTimeout", webhookcontroller.DefaultWebhookTimeout, "Timeout for webhook configurations (number of seconds, integer).")
 	flagset.IntVar(&maxQueuedEvents, "maxQueuedEvents", 1000, "Maximum events to be queued.")
-	flagset.StringVar(&omitEvents, "omitEvents", "", "Set this flag to a comma sperated list of PolicyViolation, PolicyApplied, PolicyError, PolicySkipped to disable events, e.g. --omitEvents=PolicyApplied,PolicyViolation")
+	flagset.StringVar(&omitEvents, "omitEvents", "", "Set this flag to a comma sperated list of PolicyViolation, PolicyApplied, PolicyError, PolicySkipped, PolicyExceptionExpired to disable events, e.g. --omitEvents=PolicyApplied,PolicyViolation")
 	flagset.StringVar(&serverIP, "serverIP", "", "IP address where Kyverno controller runs. Only required if out-of-cluster.")
 	flagset.BoolVar(&autoUpdateWebhooks, "autoUpdateWebhooks", true, "Set this flag to 'false' to disable auto-configuration of the webhook.")
 	flagset.BoolVar(&autoDeleteWebhooks, "autoDeleteWebhooks", false, "Set this flag to 'true' to enable autodeletion of webhook configurations using finalizers (requires extra permissions).")
@@ -389,9 +412,10 @@ func main() {
 	flagset.StringVar(&tlsSecretName, "tlsSecretName", "", "Name of the secret containing TLS pair.")
 	flagset.Int64Var(&maxAPICallResponseLength, "maxAPICallResponseLength", 10*1000*1000, "Configure the value of maximum allowed GET response size from API Calls")
 	flagset.DurationVar(&renewBefore, "renewBefore", 15*24*time.Hour, "The certificate renewal time before expiration")
-	flagset.IntVar(&maxAuditWorkers, "maxAuditWorkers", 8, "Maximum number of workers for audit policy processing")
-	flagset.IntVar(&maxAuditCapacity, "maxAuditCapacity", 1000, "Maximum capacity of the audit policy task queue")
+	flagset.IntVar(&maxAuditWorkers, "maxAuditWorkers", 8, "Maximum number of workers for audit and shadow policy processing")
+	flagset.IntVar(&maxAuditCapacity, "maxAuditCapacity", 1000, "Maximum capacity of the audit and shadow policy task queues")
 	flagset.IntVar(&maxAdmissionReports, "maxAdmissionReports", 10000, "Maximum number of admission reports before we stop creating new ones")
+	flagset.DurationVar(&exceptionExpiringWindow, "exceptionExpiringWindow", 7*24*time.Hour, "Policy exceptions expiring within this window are reported in the kyverno_policy_exception_expiring_seconds metric.")
 	flagset.StringVar(&controllerRuntimeMetricsAddress, "controllerRuntimeMetricsAddress", "", `Bind address for controller-runtime metrics server. It will be defaulted to ":8080" if unspecified. Set this to "0" to disable the metrics server.`)
 	// config
 	appConfig := internal.NewConfiguration(
@@ -404,6 +428,7 @@ func main() {
 		internal.WithDeferredLoading(),
 		internal.WithCosign(),
 		internal.WithRegistryClient(),
+		internal.WithHTTPGuard(),
 		internal.WithImageVerifyCache(),
 		internal.WithLeaderElection(),
 		internal.WithKyvernoClient(),
@@ -414,6 +439,7 @@ func main() {
 		internal.WithMetadataClient(),
 		internal.WithFlagSets(flagset),
 		internal.WithReporting(),
+		internal.WithResultSinks(),
 		internal.WithRestConfig(),
 	)
 	// parse flags
@@ -431,6 +457,25 @@ func main() {
 			setup.Logger.Error(errors.New("exiting... tlsSecretName is a required flag"), "exiting... tlsSecretName is a required flag")
 			os.Exit(1)
 		}
+		var dumpWriter handlers.DumpWriter
+		if dumpPayloadFile != "" && dumpPayloadDir != "" {
+			setup.Logger.Error(errors.New("exiting... dumpPayloadFile and dumpPayloadDir are mutually exclusive"), "exiting... dumpPayloadFile and dumpPayloadDir are mutually exclusive")
+			os.Exit(1)
+		} else if dumpPayloadFile != "" {
+			writer, err := handlers.NewDumpFileWriter(dumpPayloadFile, dumpPayloadMaxSize, dumpPayloadMaxBackups)
+			if err != nil {
+				setup.Logger.Error(err, "failed to create admission payloads file writer")
+				os.Exit(1)
+			}
+			dumpWriter = writer
+		} else if dumpPayloadDir != "" {
+			writer, err := handlers.NewDumpDirWriter(dumpPayloadDir)
+			if err != nil {
+				setup.Logger.Error(err, "failed to create admission payloads directory writer")
+				os.Exit(1)
+			}
+			dumpWriter = writer
+		}
 		// check if mutating admission policies are registered in the API server
 		generateMutatingAdmissionPolicy := toggle.FromContext(context.TODO()).GenerateMutatingAdmissionPolicy()
 		if generateMutatingAdmissionPolicy {
@@ -545,7 +590,7 @@ func main() {
 			setup.KubeClient,
 			setup.KyvernoClient,
 			setup.RegistrySecretLister,
-			apicall.NewAPICallConfiguration(maxAPICallResponseLength),
+			apicall.NewAPICallConfiguration(maxAPICallResponseLength).WithGuard(httpguard.Default()),
 			polexCache,
 			gcstore,
 		)
@@ -603,6 +648,7 @@ func main() {
 					setup.Configuration,
 					eventGenerator,
 					stateRecorder,
+					exceptionExpiringWindow,
 				)
 				if err != nil {
 					logger.Error(err, "failed to create leader controllers")
@@ -649,6 +695,7 @@ func main() {
 		contextProvider, err := libs.NewContextProvider(
 			setup.KyvernoDynamicClient,
 			nil,
+			setup.RegistryClient,
 			gcstore,
 			// []imagedataloader.Option{imagedataloader.WithLocalCredentials(c.RegistryAccess)},
 			false,
@@ -738,6 +785,7 @@ func main() {
 				matching.NewMatcher(),
 				setup.KubeClient.CoreV1().Secrets(""),
 				nil,
+				setup.ImageVerifyCacheClient,
 			)
 			mpolEngine = mpolengine.NewEngine(
 				mpolProvider,
@@ -813,6 +861,7 @@ func main() {
 			maxAuditWorkers,
 			maxAuditCapacity,
 			setup.ReportingConfiguration,
+			setup.ResultSink,
 		)
 		voplHandlers := vpol.New(
 			vpolEngine,
@@ -820,17 +869,21 @@ func main() {
 			setup.KyvernoClient,
 			admissionReports,
 			setup.ReportingConfiguration,
+			setup.ResultSink,
+			maxAuditWorkers,
+			maxAuditCapacity,
 		)
 		ivpolHandlers := ivpol.New(
 			ivpolEngine,
 			contextProvider,
+			setup.ResultSink,
 		)
 		gpolHandlers := gpol.New(urgen, kyvernoInformer.Policies().V1alpha1().GeneratingPolicies().Lister())
 		exceptionHandlers := webhooksexception.NewHandlers(exception.ValidationOptions{
 			Enabled:   internal.PolicyExceptionEnabled(),
 			Namespace: internal.ExceptionNamespace(),
 		})
-		mpolHandlers := mpol.New(contextProvider, mpolEngine, setup.KyvernoClient, setup.ReportingConfiguration, urgen, backgroundServiceAccountName)
+		mpolHandlers := mpol.New(contextProvider, mpolEngine, setup.KyvernoClient, setup.ReportingConfiguration, urgen, backgroundServiceAccountName, setup.ResultSink)
 		celExceptionHandlers := webhookscelexception.NewHandlers(exception.ValidationOptions{
 			Enabled: internal.PolicyExceptionEnabled(),
 		})
@@ -863,6 +916,7 @@ func main() {
 			setup.MetricsManager,
 			webhooks.DebugModeOptions{
 				DumpPayload: dumpPayload,
+				DumpWriter:  dumpWriter,
 			},
 			func() ([]byte, []byte, error) {
 				secret, err := tlsSecret.Lister().Secrets(config.KyvernoNamespace()).Get(tlsSecretName)
@@ -20,13 +20,16 @@ import (
 	aggregatereportcontroller "github.com/kyverno/kyverno/pkg/controllers/report/aggregate"
 	backgroundscancontroller "github.com/kyverno/kyverno/pkg/controllers/report/background"
 	resourcereportcontroller "github.com/kyverno/kyverno/pkg/controllers/report/resource"
+	summarycontroller "github.com/kyverno/kyverno/pkg/controllers/report/summary"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/engine/apicall"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 	"github.com/kyverno/kyverno/pkg/event"
 	"github.com/kyverno/kyverno/pkg/globalcontext/store"
+	"github.com/kyverno/kyverno/pkg/httpguard"
 	"github.com/kyverno/kyverno/pkg/leaderelection"
 	"github.com/kyverno/kyverno/pkg/logging"
+	"github.com/kyverno/kyverno/pkg/resultsink"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
 	openreportsclient "github.com/openreports/reports-api/pkg/client/clientset/versioned/typed/openreports.io/v1alpha1"
@@ -66,6 +69,8 @@ func createReportControllers(
 	policyReports bool,
 	validatingAdmissionPolicyReports bool,
 	mutatingAdmissionPolicyReports bool,
+	complianceSummaries bool,
+	complianceSummaryHistory int,
 	aggregationWorkers int,
 	backgroundScanWorkers int,
 	client dclient.Interface,
@@ -80,6 +85,7 @@ func createReportControllers(
 	jp jmespath.Interface,
 	eventGenerator event.Interface,
 	reportsConfig reportutils.ReportingConfiguration,
+	resultSink resultsink.Sink,
 	gcstore store.Store,
 	typeConverter patch.TypeConverterManager,
 ) ([]internal.Controller, func(context.Context) error) {
@@ -139,6 +145,18 @@ func createReportControllers(
 				),
 				aggregationWorkers,
 			))
+			if complianceSummaries {
+				ctrls = append(ctrls, internal.NewController(
+					summarycontroller.ControllerName,
+					summarycontroller.NewController(
+						kyvernoClient,
+						orClient,
+						kyvernoInformer.Reports().V1().ComplianceSummaries(),
+						complianceSummaryHistory,
+					),
+					summarycontroller.Workers,
+				))
+			}
 		}
 		if backgroundScan {
 			backgroundScanController := backgroundscancontroller.NewController(
@@ -165,6 +183,7 @@ func createReportControllers(
 				eventGenerator,
 				policyReports,
 				reportsConfig,
+				resultSink,
 				gcstore,
 				typeConverter,
 			)
@@ -190,10 +209,13 @@ func createrLeaderControllers(
 	backgroundScan bool,
 	admissionReports bool,
 	reportsConfig reportutils.ReportingConfiguration,
+	resultSink resultsink.Sink,
 	aggregateReports bool,
 	policyReports bool,
 	validatingAdmissionPolicyReports bool,
 	mutatingAdmissionPolicyReports bool,
+	complianceSummaries bool,
+	complianceSummaryHistory int,
 	aggregationWorkers int,
 	backgroundScanWorkers int,
 	kubeInformer kubeinformers.SharedInformerFactory,
@@ -218,6 +240,8 @@ func createrLeaderControllers(
 		policyReports,
 		validatingAdmissionPolicyReports,
 		mutatingAdmissionPolicyReports,
+		complianceSummaries,
+		complianceSummaryHistory,
 		aggregationWorkers,
 		backgroundScanWorkers,
 		dynamicClient,
@@ -232,6 +256,7 @@ func createrLeaderControllers(
 		jp,
 		eventGenerator,
 		reportsConfig,
+		resultSink,
 		gcstore,
 		typeConverter,
 	)
@@ -246,6 +271,8 @@ func main() {
 		policyReports                    bool
 		validatingAdmissionPolicyReports bool
 		mutatingAdmissionPolicyReports   bool
+		complianceSummaries              bool
+		complianceSummaryHistory         int
 		reportsCRDsSanityChecks          bool
 		backgroundScanWorkers            int
 		backgroundScanInterval           time.Duration
@@ -263,6 +290,8 @@ func main() {
 	flagset.BoolVar(&policyReports, "policyReports", true, "Enable or disable policy reports.")
 	flagset.BoolVar(&validatingAdmissionPolicyReports, "validatingAdmissionPolicyReports", true, "Enable or disable ValidatingAdmissionPolicy reports.")
 	flagset.BoolVar(&mutatingAdmissionPolicyReports, "mutatingAdmissionPolicyReports", false, "Enable or disable MutatingAdmissionPolicy reports.")
+	flagset.BoolVar(&complianceSummaries, "complianceSummaries", false, "Enable or disable per namespace compliance summaries (requires aggregated policy reports).")
+	flagset.IntVar(&complianceSummaryHistory, "complianceSummaryHistory", 30, "Configure the number of daily snapshots kept in compliance summaries.")
 	flagset.IntVar(&aggregationWorkers, "aggregationWorkers", aggregatereportcontroller.Workers, "Configure the number of ephemeral reports aggregation workers.")
 	flagset.IntVar(&backgroundScanWorkers, "backgroundScanWorkers", backgroundscancontroller.Workers, "Configure the number of background scan workers.")
 	flagset.DurationVar(&backgroundScanInterval, "backgroundScanInterval", time.Hour, "Configure background scan interval.")
@@ -283,6 +312,7 @@ func main() {
 		internal.WithDeferredLoading(),
 		internal.WithCosign(),
 		internal.WithRegistryClient(),
+		internal.WithHTTPGuard(),
 		internal.WithImageVerifyCache(),
 		internal.WithLeaderElection(),
 		internal.WithKyvernoClient(),
@@ -293,6 +323,7 @@ func main() {
 		internal.WithApiServerClient(),
 		internal.WithFlagSets(flagset),
 		internal.WithReporting(),
+		internal.WithResultSinks(),
 		internal.WithOpenreports(),
 		internal.WithMetadataClient(),
 	)
@@ -367,7 +398,7 @@ func main() {
 			setup.KubeClient,
 			setup.KyvernoClient,
 			setup.RegistrySecretLister,
-			apicall.NewAPICallConfiguration(maxAPICallResponseLength),
+			apicall.NewAPICallConfiguration(maxAPICallResponseLength).WithGuard(httpguard.Default()),
 			polexCache,
 			gcstore,
 		)
@@ -432,10 +463,13 @@ func main() {
 					backgroundScan,
 					admissionReports,
 					setup.ReportingConfiguration,
+					setup.ResultSink,
 					aggregateReports,
 					policyReports,
 					validatingAdmissionPolicyReports,
 					mutatingAdmissionPolicyReports,
+					complianceSummaries,
+					complianceSummaryHistory,
 					aggregationWorkers,
 					backgroundScanWorkers,
 					kubeInformer,
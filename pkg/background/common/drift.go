@@ -0,0 +1,228 @@
+package common
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kyverno/kyverno/pkg/breaker"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/event"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// DriftProperty is the policy report result property holding the drifted fields
+const DriftProperty = "drift"
+
+// DriftRuleName is the rule name of the drift results of policies without rules, like generating policies
+const DriftRuleName = "drift"
+
+// Drift describes a field of a generated resource that differs from what the policy produces
+type Drift struct {
+	// Path is the JSON pointer of the field
+	Path string `json:"path"`
+	// Expected is the value produced by the policy, nil if the field is not expected
+	Expected any `json:"expected,omitempty"`
+	// Actual is the value found in the generated resource, nil if the field is missing
+	Actual any `json:"actual,omitempty"`
+}
+
+// ignoredMetadata are the metadata fields managed by the API server
+var ignoredMetadata = []string{
+	"creationTimestamp",
+	"deletionGracePeriodSeconds",
+	"deletionTimestamp",
+	"generation",
+	"managedFields",
+	"resourceVersion",
+	"selfLink",
+	"uid",
+}
+
+// ComputeDrift returns the fields defined in the expected resource that differ in the actual resource.
+// Fields that are only present in the actual resource are not considered as a drift, lists are compared as a whole.
+// The status and the metadata fields managed by the API server are ignored.
+func ComputeDrift(expected, actual map[string]any) []Drift {
+	expected = pruneManagedFields(expected)
+	actual = pruneManagedFields(actual)
+	var drifts []Drift
+	computeDrift("", expected, actual, &drifts)
+	return drifts
+}
+
+func pruneManagedFields(obj map[string]any) map[string]any {
+	pruned := make(map[string]any, len(obj))
+	for key, value := range obj {
+		if key != "status" {
+			pruned[key] = value
+		}
+	}
+	if metadata, ok := obj["metadata"].(map[string]any); ok {
+		prunedMetadata := make(map[string]any, len(metadata))
+		for key, value := range metadata {
+			prunedMetadata[key] = value
+		}
+		for _, key := range ignoredMetadata {
+			delete(prunedMetadata, key)
+		}
+		pruned["metadata"] = prunedMetadata
+	}
+	return pruned
+}
+
+func computeDrift(path string, expected, actual any, drifts *[]Drift) {
+	expectedMap, ok := expected.(map[string]any)
+	if !ok {
+		if !equalValues(expected, actual) {
+			*drifts = append(*drifts, Drift{Path: path, Expected: expected, Actual: actual})
+		}
+		return
+	}
+	actualMap, ok := actual.(map[string]any)
+	if !ok {
+		*drifts = append(*drifts, Drift{Path: path, Expected: expected, Actual: actual})
+		return
+	}
+	keys := make([]string, 0, len(expectedMap))
+	for key := range expectedMap {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		computeDrift(path+"/"+escapePointerToken(key), expectedMap[key], actualMap[key], drifts)
+	}
+}
+
+// equalValues compares values through their JSON representation so that numbers of different types are considered equal
+func equalValues(a, b any) bool {
+	aJSON, err := json.Marshal(a)
+	if err != nil {
+		return false
+	}
+	bJSON, err := json.Marshal(b)
+	if err != nil {
+		return false
+	}
+	return string(aJSON) == string(bJSON)
+}
+
+// DriftDigest returns a digest identifying a set of drifts, it is empty when there is no drift
+func DriftDigest(drifts []Drift) (string, error) {
+	if len(drifts) == 0 {
+		return "", nil
+	}
+	data, err := json.Marshal(drifts)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// MissingDrift returns the drift of a generated resource that does not exist, the whole resource is expected
+func MissingDrift(expected map[string]any) []Drift {
+	return []Drift{{Path: "", Expected: pruneManagedFields(expected)}}
+}
+
+// IsMissing returns true when the drifts describe a generated resource that does not exist
+func IsMissing(drifts []Drift) bool {
+	return len(drifts) == 1 && drifts[0].Path == "" && drifts[0].Actual == nil
+}
+
+func escapePointerToken(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+// DriftReporter records the drift of generated resources in events and policy reports
+type DriftReporter interface {
+	// Report records the drift of a generated resource, no drift means the resource matches the policy
+	Report(ctx context.Context, policy engineapi.GenericPolicy, rule string, resource unstructured.Unstructured, drifts []Drift) error
+	// ReportMissing records that a generated resource does not exist, the result is reported for the trigger resource
+	ReportMissing(ctx context.Context, policy engineapi.GenericPolicy, rule string, trigger, resource unstructured.Unstructured) error
+}
+
+type driftReporter struct {
+	kyvernoClient versioned.Interface
+	eventGen      event.Interface
+	reportsConfig reportutils.ReportingConfiguration
+}
+
+// NewDriftReporter returns a DriftReporter emitting events and creating ephemeral reports for the generated resources
+func NewDriftReporter(kyvernoClient versioned.Interface, eventGen event.Interface, reportsConfig reportutils.ReportingConfiguration) DriftReporter {
+	return &driftReporter{
+		kyvernoClient: kyvernoClient,
+		eventGen:      eventGen,
+		reportsConfig: reportsConfig,
+	}
+}
+
+func (r *driftReporter) Report(ctx context.Context, policy engineapi.GenericPolicy, rule string, resource unstructured.Unstructured, drifts []Drift) error {
+	var ruleResponse *engineapi.RuleResponse
+	if len(drifts) == 0 {
+		ruleResponse = engineapi.RulePass(rule, engineapi.Generation, "generated resource matches the policy", nil)
+	} else {
+		paths := make([]string, 0, len(drifts))
+		for _, drift := range drifts {
+			paths = append(paths, drift.Path)
+		}
+		if r.eventGen != nil {
+			r.eventGen.Add(event.NewResourceDriftEvent(event.GeneratePolicyController, policy, rule, resource, paths))
+		}
+		data, err := json.Marshal(drifts)
+		if err != nil {
+			return fmt.Errorf("failed to serialize drift: %w", err)
+		}
+		ruleResponse = engineapi.RuleWarn(
+			rule,
+			engineapi.Generation,
+			fmt.Sprintf("generated resource drifted from the policy, %d field(s) differ", len(drifts)),
+			map[string]string{DriftProperty: string(data)},
+		)
+	}
+	return r.createReport(ctx, policy, resource, *ruleResponse)
+}
+
+func (r *driftReporter) ReportMissing(ctx context.Context, policy engineapi.GenericPolicy, rule string, trigger, resource unstructured.Unstructured) error {
+	if r.eventGen != nil {
+		r.eventGen.Add(event.NewResourceMissingEvent(event.GeneratePolicyController, policy, rule, resource))
+	}
+	data, err := json.Marshal(MissingDrift(resource.Object))
+	if err != nil {
+		return fmt.Errorf("failed to serialize drift: %w", err)
+	}
+	key := resource.GetKind() + "/" + resource.GetName()
+	if resource.GetNamespace() != "" {
+		key = resource.GetKind() + "/" + resource.GetNamespace() + "/" + resource.GetName()
+	}
+	ruleResponse := engineapi.RuleWarn(
+		rule,
+		engineapi.Generation,
+		fmt.Sprintf("generated resource %s is missing", key),
+		map[string]string{DriftProperty: string(data)},
+	)
+	return r.createReport(ctx, policy, trigger, *ruleResponse)
+}
+
+// createReport records the rule response in an ephemeral report of the resource
+func (r *driftReporter) createReport(ctx context.Context, policy engineapi.GenericPolicy, resource unstructured.Unstructured, ruleResponse engineapi.RuleResponse) error {
+	if r.kyvernoClient == nil || r.reportsConfig == nil || !r.reportsConfig.GenerateReportsEnabled() {
+		return nil
+	}
+	if !reportutils.IsGvkSupported(resource.GroupVersionKind()) {
+		return nil
+	}
+	engineResponse := engineapi.NewEngineResponse(resource, policy, nil).WithPolicyResponse(engineapi.PolicyResponse{
+		Rules: []engineapi.RuleResponse{ruleResponse},
+	})
+	report := reportutils.BuildGenerateReport(resource.GetNamespace(), resource.GroupVersionKind(), resource.GetName(), resource.GetUID(), engineResponse)
+	return breaker.GetReportsBreaker().Do(ctx, func(ctx context.Context) error {
+		_, err := reportutils.CreateEphemeralReport(ctx, report, r.kyvernoClient)
+		return err
+	})
+}
@@ -0,0 +1,140 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeDrift(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected map[string]any
+		actual   map[string]any
+		want     []Drift
+	}{{
+		name: "no drift",
+		expected: map[string]any{
+			"kind": "ConfigMap",
+			"data": map[string]any{"foo": "bar"},
+		},
+		actual: map[string]any{
+			"kind": "ConfigMap",
+			"data": map[string]any{"foo": "bar"},
+		},
+	}, {
+		name: "extra fields are ignored",
+		expected: map[string]any{
+			"data": map[string]any{"foo": "bar"},
+		},
+		actual: map[string]any{
+			"data": map[string]any{"foo": "bar", "extra": "value"},
+			"metadata": map[string]any{
+				"annotations": map[string]any{"kubectl.kubernetes.io/last-applied-configuration": "{}"},
+			},
+		},
+	}, {
+		name: "server managed fields are ignored",
+		expected: map[string]any{
+			"metadata": map[string]any{
+				"name":            "cm",
+				"resourceVersion": "1",
+				"uid":             "a",
+			},
+			"status": map[string]any{"phase": "Pending"},
+		},
+		actual: map[string]any{
+			"metadata": map[string]any{
+				"name":            "cm",
+				"resourceVersion": "2",
+				"uid":             "b",
+			},
+			"status": map[string]any{"phase": "Running"},
+		},
+	}, {
+		name: "changed and missing fields",
+		expected: map[string]any{
+			"data": map[string]any{"a": "1", "b": "2"},
+			"metadata": map[string]any{
+				"labels": map[string]any{"app.kubernetes.io/name": "test"},
+			},
+		},
+		actual: map[string]any{
+			"data": map[string]any{"a": "changed"},
+			"metadata": map[string]any{
+				"labels": map[string]any{"app.kubernetes.io/name": "other"},
+			},
+		},
+		want: []Drift{
+			{Path: "/data/a", Expected: "1", Actual: "changed"},
+			{Path: "/data/b", Expected: "2"},
+			{Path: "/metadata/labels/app.kubernetes.io~1name", Expected: "test", Actual: "other"},
+		},
+	}, {
+		name: "lists are compared as a whole",
+		expected: map[string]any{
+			"spec": map[string]any{"ports": []any{int64(80)}},
+		},
+		actual: map[string]any{
+			"spec": map[string]any{"ports": []any{float64(80), int64(443)}},
+		},
+		want: []Drift{
+			{Path: "/spec/ports", Expected: []any{int64(80)}, Actual: []any{float64(80), int64(443)}},
+		},
+	}, {
+		name: "numbers of different types are equal",
+		expected: map[string]any{
+			"spec": map[string]any{"replicas": int64(2)},
+		},
+		actual: map[string]any{
+			"spec": map[string]any{"replicas": float64(2)},
+		},
+	}, {
+		name: "type mismatch",
+		expected: map[string]any{
+			"spec": map[string]any{"selector": map[string]any{"app": "test"}},
+		},
+		actual: map[string]any{
+			"spec": map[string]any{"selector": "app=test"},
+		},
+		want: []Drift{
+			{Path: "/spec/selector", Expected: map[string]any{"app": "test"}, Actual: "app=test"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ComputeDrift(tt.expected, tt.actual))
+		})
+	}
+}
+
+func TestDriftDigest(t *testing.T) {
+	digest, err := DriftDigest(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, digest)
+	drifts := []Drift{{Path: "/data/foo", Expected: "bar", Actual: "baz"}}
+	digest, err = DriftDigest(drifts)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, digest)
+	same, err := DriftDigest([]Drift{{Path: "/data/foo", Expected: "bar", Actual: "baz"}})
+	assert.NoError(t, err)
+	assert.Equal(t, digest, same)
+	other, err := DriftDigest([]Drift{{Path: "/data/foo", Expected: "bar", Actual: "qux"}})
+	assert.NoError(t, err)
+	assert.NotEqual(t, digest, other)
+}
+
+func TestMissingDrift(t *testing.T) {
+	expected := map[string]any{
+		"kind":     "ConfigMap",
+		"metadata": map[string]any{"name": "test", "resourceVersion": "1"},
+	}
+	drifts := MissingDrift(expected)
+	assert.Equal(t, []Drift{{Path: "", Expected: map[string]any{
+		"kind":     "ConfigMap",
+		"metadata": map[string]any{"name": "test"},
+	}}}, drifts)
+	assert.True(t, IsMissing(drifts))
+	assert.False(t, IsMissing(nil))
+	assert.False(t, IsMissing(ComputeDrift(expected, map[string]any{"kind": "Secret"})))
+}
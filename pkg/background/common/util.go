@@ -28,10 +28,9 @@ func UpdateStatus(client versioned.Interface, urLister kyvernov2listers.UpdateRe
 		latest.Status.GeneratedResources = genResources
 	}
 
+	// the update request controller abandons the request once it failed too many times
 	if state == kyvernov2.Failed {
-		if latest, err = retryOrDeleteOnFailure(client, latest, 3); err != nil {
-			return nil, err
-		}
+		latest.Status.RetryCount++
 	}
 	new, err := client.KyvernoV2().UpdateRequests(config.KyvernoNamespace()).UpdateStatus(context.TODO(), latest, metav1.UpdateOptions{})
 	if err != nil {
@@ -59,19 +58,6 @@ func ResourceSpecFromUnstructured(obj unstructured.Unstructured) kyvernov1.Resou
 	}
 }
 
-func retryOrDeleteOnFailure(kyvernoClient versioned.Interface, ur *kyvernov2.UpdateRequest, limit int) (latest *kyvernov2.UpdateRequest, err error) {
-	if ur.Status.RetryCount > limit {
-		err = kyvernoClient.KyvernoV2().UpdateRequests(config.KyvernoNamespace()).Delete(context.TODO(), ur.GetName(), metav1.DeleteOptions{})
-		if err != nil {
-			return nil, errors.Wrapf(err, "exceeds retry limit, failed to delete the UR: %s, retry: %v, resourceVersion: %s", ur.Name, ur.Status.RetryCount, ur.GetResourceVersion())
-		}
-	} else {
-		ur.Status.RetryCount++
-	}
-
-	return ur, nil
-}
-
 func FindDownstream(client dclient.Interface, apiVersion, kind string, labels map[string]string) (*unstructured.UnstructuredList, error) {
 	selector := &metav1.LabelSelector{MatchLabels: labels}
 	return client.ListResource(context.TODO(), apiVersion, kind, "", selector)
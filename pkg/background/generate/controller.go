@@ -57,6 +57,7 @@ type GenerateController struct {
 	jp  jmespath.Interface
 
 	reportsConfig reportutils.ReportingConfiguration
+	driftReporter common.DriftReporter
 }
 
 // NewGenerateController returns an instance of the Generate-Request Controller
@@ -89,6 +90,7 @@ func NewGenerateController(
 		log:           log,
 		jp:            jp,
 		reportsConfig: reportsConfig,
+		driftReporter: common.NewDriftReporter(kyvernoClient, eventGen, reportsConfig),
 	}
 	return &c
 }
@@ -180,7 +182,7 @@ func (c *GenerateController) applyGenerate(trigger unstructured.Unstructured, ur
 	}
 
 	// Apply the generate rule on resource
-	genResourcesMap, err := c.ApplyGeneratePolicy(logger, policyContext, applicableRules)
+	genResourcesMap, err := c.applyGeneratePolicy(logger, policyContext, applicableRules, ruleContext.DriftCheck)
 	if err != nil {
 		return nil, err
 	}
@@ -239,6 +241,11 @@ func (c *GenerateController) getPolicyObject(ur kyvernov2.UpdateRequest) (kyvern
 }
 
 func (c *GenerateController) ApplyGeneratePolicy(log logr.Logger, policyContext *engine.PolicyContext, applicableRules []string) (map[string][]kyvernov1.ResourceSpec, error) {
+	return c.applyGeneratePolicy(log, policyContext, applicableRules, false)
+}
+
+// applyGeneratePolicy applies the generate rules, with driftCheck the downstream resources are only checked for drift
+func (c *GenerateController) applyGeneratePolicy(log logr.Logger, policyContext *engine.PolicyContext, applicableRules []string, driftCheck bool) (map[string][]kyvernov1.ResourceSpec, error) {
 	genResources := make(map[string][]kyvernov1.ResourceSpec)
 	policy := policyContext.Policy()
 	resource := policyContext.NewResource()
@@ -289,11 +296,12 @@ func (c *GenerateController) ApplyGeneratePolicy(log logr.Logger, policyContext
 			return nil, fmt.Errorf("failed to load rule level context: %v", err)
 		}
 
+		reportDrift := c.driftHandler(logger, policy, rule.Name, resource)
 		if rule.Generation.ForEachGeneration != nil {
-			g := newForeachGenerator(c.client, logger, policyContext, policy, rule, rule.Context, rule.GetAnyAllConditions(), policyContext.NewResource(), rule.Generation.ForEachGeneration, contextLoader)
+			g := newForeachGenerator(c.client, logger, policyContext, policy, rule, rule.Context, rule.GetAnyAllConditions(), policyContext.NewResource(), rule.Generation.ForEachGeneration, contextLoader, reportDrift, driftCheck)
 			genResource, err = g.generateForeach()
 		} else {
-			g := newGenerator(c.client, logger, policyContext, policy, rule, rule.Context, rule.GetAnyAllConditions(), policyContext.NewResource(), rule.Generation.GeneratePattern, contextLoader)
+			g := newGenerator(c.client, logger, policyContext, policy, rule, rule.Context, rule.GetAnyAllConditions(), policyContext.NewResource(), rule.Generation.GeneratePattern, contextLoader, reportDrift, driftCheck)
 			genResource, err = g.generate()
 		}
 
@@ -309,6 +317,25 @@ func (c *GenerateController) ApplyGeneratePolicy(log logr.Logger, policyContext
 	return genResources, nil
 }
 
+// driftHandler returns the handler reporting the drift of the resources generated by a rule,
+// missing resources are reported for the trigger
+func (c *GenerateController) driftHandler(logger logr.Logger, policy kyvernov1.PolicyInterface, rule string, trigger unstructured.Unstructured) driftHandler {
+	if c.driftReporter == nil {
+		return nil
+	}
+	return func(resource *unstructured.Unstructured, drifts []common.Drift) {
+		var err error
+		if common.IsMissing(drifts) {
+			err = c.driftReporter.ReportMissing(context.TODO(), engineapi.NewKyvernoPolicy(policy), rule, trigger, *resource)
+		} else {
+			err = c.driftReporter.Report(context.TODO(), engineapi.NewKyvernoPolicy(policy), rule, *resource, drifts)
+		}
+		if err != nil {
+			logger.Error(err, "failed to report drift", "kind", resource.GetKind(), "namespace", resource.GetNamespace(), "name", resource.GetName())
+		}
+	}
+}
+
 // NewGenerateControllerWithOnlyClient returns an instance of Controller with only the client.
 func NewGenerateControllerWithOnlyClient(client dclient.Interface, engine engineapi.Engine) *GenerateController {
 	c := GenerateController{
@@ -30,8 +30,13 @@ type generator struct {
 	forEach          []kyvernov1.ForEachGeneration
 	pattern          kyvernov1.GeneratePattern
 	contextLoader    engineapi.EngineContextLoader
+	reportDrift      driftHandler
+	driftCheck       bool
 }
 
+// driftHandler is called with the drift of a generated resource when drift detection is enabled
+type driftHandler func(resource *unstructured.Unstructured, drifts []common.Drift)
+
 func newGenerator(client dclient.Interface,
 	logger logr.Logger,
 	policyContext engineapi.PolicyContext,
@@ -42,6 +47,8 @@ func newGenerator(client dclient.Interface,
 	trigger unstructured.Unstructured,
 	pattern kyvernov1.GeneratePattern,
 	contextLoader engineapi.EngineContextLoader,
+	reportDrift driftHandler,
+	driftCheck bool,
 ) *generator {
 	return &generator{
 		client:           client,
@@ -54,6 +61,8 @@ func newGenerator(client dclient.Interface,
 		trigger:          trigger,
 		pattern:          pattern,
 		contextLoader:    contextLoader,
+		reportDrift:      reportDrift,
+		driftCheck:       driftCheck,
 	}
 }
 
@@ -67,6 +76,8 @@ func newForeachGenerator(client dclient.Interface,
 	trigger unstructured.Unstructured,
 	forEach []kyvernov1.ForEachGeneration,
 	contextLoader engineapi.EngineContextLoader,
+	reportDrift driftHandler,
+	driftCheck bool,
 ) *generator {
 	return &generator{
 		client:           client,
@@ -79,6 +90,8 @@ func newForeachGenerator(client dclient.Interface,
 		trigger:          trigger,
 		forEach:          forEach,
 		contextLoader:    contextLoader,
+		reportDrift:      reportDrift,
+		driftCheck:       driftCheck,
 	}
 }
 
@@ -121,7 +134,7 @@ func (g *generator) generate() ([]kyvernov1.ResourceSpec, error) {
 	} else if len(pattern.CloneList.Kinds) != 0 {
 		responses = manageCloneList(logger.WithValues("type", "cloneList"), target.GetNamespace(), g.policy.GetSpec().UseServerSideApply, *pattern, g.client)
 	} else {
-		resp := manageData(logger.WithValues("type", "data"), target, pattern.RawData, g.rule.Generation.Synchronize, g.client)
+		resp := manageData(logger.WithValues("type", "data"), target, pattern.RawData, g.rule.Generation.Synchronize || g.rule.Generation.DriftDetection, g.client)
 		responses = append(responses, resp)
 	}
 
@@ -152,6 +165,10 @@ func (g *generator) generate() ([]kyvernov1.ResourceSpec, error) {
 
 		newResource.SetAPIVersion(targetMeta.GetAPIVersion())
 		common.ManageLabels(newResource, g.trigger, g.policy, g.rule.Name)
+		if response.GetAction() == Create && g.driftCheck {
+			g.detectMissing(logger, newResource)
+			continue
+		}
 		if response.GetAction() == Create {
 			newResource.SetResourceVersion("")
 			if g.policy.GetSpec().UseServerSideApply {
@@ -168,6 +185,10 @@ func (g *generator) generate() ([]kyvernov1.ResourceSpec, error) {
 			newGenResources = append(newGenResources, targetMeta)
 		} else if response.GetAction() == Update {
 			generatedObj, err := g.client.GetResource(context.TODO(), targetMeta.GetAPIVersion(), targetMeta.GetKind(), targetMeta.GetNamespace(), targetMeta.GetName())
+			if err != nil && g.driftCheck && apierrors.IsNotFound(err) {
+				g.detectMissing(logger, newResource)
+				continue
+			}
 			if err != nil {
 				logger.V(2).Info("creating new target due to the failure when fetching", "err", err.Error())
 				if g.policy.GetSpec().UseServerSideApply {
@@ -180,6 +201,10 @@ func (g *generator) generate() ([]kyvernov1.ResourceSpec, error) {
 				}
 				newGenResources = append(newGenResources, targetMeta)
 			} else {
+				if g.rule.Generation.DriftDetection {
+					g.detectDrift(logger, newResource, generatedObj)
+					continue
+				}
 				if !g.rule.Generation.Synchronize {
 					logger.V(4).Info("synchronize disabled, skip syncing changes")
 					continue
@@ -216,6 +241,27 @@ func (g *generator) generate() ([]kyvernov1.ResourceSpec, error) {
 	return newGenResources, nil
 }
 
+// detectDrift compares the generated resource with the resource produced by the policy, the generated resource is left untouched
+func (g *generator) detectDrift(logger logr.Logger, newResource, generatedObj *unstructured.Unstructured) {
+	drifts := common.ComputeDrift(newResource.Object, generatedObj.Object)
+	if len(drifts) == 0 {
+		logger.V(4).Info("no drift detected for generate target resource")
+	} else {
+		logger.V(2).Info("drift detected for generate target resource", "fields", len(drifts))
+	}
+	if g.reportDrift != nil {
+		g.reportDrift(generatedObj, drifts)
+	}
+}
+
+// detectMissing reports a generated resource that does not exist, the resource is not created
+func (g *generator) detectMissing(logger logr.Logger, newResource *unstructured.Unstructured) {
+	logger.V(2).Info("generate target resource is missing")
+	if g.reportDrift != nil {
+		g.reportDrift(newResource, common.MissingDrift(newResource.Object))
+	}
+}
+
 func (g *generator) generateForeach() ([]kyvernov1.ResourceSpec, error) {
 	var errors []error
 	var genResources []kyvernov1.ResourceSpec
@@ -265,7 +311,9 @@ func (g *generator) generateElements(foreach kyvernov1.ForEachGeneration, elemen
 			foreach.AnyAllConditions,
 			g.trigger,
 			foreach.GeneratePattern,
-			g.contextLoader).
+			g.contextLoader,
+			g.reportDrift,
+			g.driftCheck).
 			generate()
 		if err != nil {
 			errors = append(errors, fmt.Errorf("failed to process %v element: %v", index, err))
@@ -11,6 +11,7 @@ import (
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/background/common"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -29,6 +30,10 @@ type Resource struct {
 	Hash      string
 	Labels    map[string]string
 	Data      *unstructured.Unstructured
+	// DetectDrift reports changes to the resource instead of reverting them.
+	DetectDrift bool
+	// DriftDigest identifies the drift last reported for the resource, empty when no drift was reported.
+	DriftDigest string
 }
 
 type WatchManager struct {
@@ -44,6 +49,10 @@ type WatchManager struct {
 	policyRefs map[string][]schema.GroupVersionResource
 	// refCount tracks the number of policies that generates the same resource.
 	refCount map[schema.GroupVersionResource]int
+	// driftPolicies maps the name of the policies with drift detection enabled to the policy.
+	driftPolicies map[string]engineapi.GenericPolicy
+
+	driftReporter common.DriftReporter
 
 	log  logr.Logger
 	lock sync.Mutex
@@ -54,7 +63,7 @@ type watcher struct {
 	metadataCache map[types.UID]Resource
 }
 
-func NewWatchManager(log logr.Logger, client dclient.Interface) *WatchManager {
+func NewWatchManager(log logr.Logger, client dclient.Interface, driftReporter common.DriftReporter) *WatchManager {
 	apiGroupResources, _ := restmapper.GetAPIGroupResources(client.GetKubeClient().Discovery())
 	restMapper := restmapper.NewDiscoveryRESTMapper(apiGroupResources)
 	return &WatchManager{
@@ -64,13 +73,31 @@ func NewWatchManager(log logr.Logger, client dclient.Interface) *WatchManager {
 		dynamicWatchers: map[schema.GroupVersionResource]*watcher{},
 		policyRefs:      map[string][]schema.GroupVersionResource{},
 		refCount:        map[schema.GroupVersionResource]int{},
+		driftPolicies:   map[string]engineapi.GenericPolicy{},
+		driftReporter:   driftReporter,
 	}
 }
 
+// SyncWatchers watches the resources generated by a policy and keeps them in sync with the policy.
 func (wm *WatchManager) SyncWatchers(policyName string, generatedResources []*unstructured.Unstructured) error {
 	wm.lock.Lock()
 	defer wm.lock.Unlock()
 
+	delete(wm.driftPolicies, policyName)
+	return wm.syncWatchers(policyName, generatedResources, false)
+}
+
+// DetectDrift watches the resources generated by a policy and reports their drift from the policy,
+// changes to the generated resources are not reverted.
+func (wm *WatchManager) DetectDrift(policy engineapi.GenericPolicy, generatedResources []*unstructured.Unstructured) error {
+	wm.lock.Lock()
+	defer wm.lock.Unlock()
+
+	wm.driftPolicies[policy.GetName()] = policy
+	return wm.syncWatchers(policy.GetName(), generatedResources, true)
+}
+
+func (wm *WatchManager) syncWatchers(policyName string, generatedResources []*unstructured.Unstructured, detectDrift bool) error {
 	logger := wm.log
 	newGVRs := make(map[schema.GroupVersionResource]bool)
 	// start a new watcher for each generated resource
@@ -88,13 +115,20 @@ func (wm *WatchManager) SyncWatchers(policyName string, generatedResources []*un
 		// if the watcher for this GVR already exists, skip it
 		if wm.dynamicWatchers[gvr] != nil {
 			logger.V(2).Info("watcher already exists for GVR", "gvr", gvr)
+			// the drift already reported for the resource is kept to not report it again
+			var driftDigest string
+			if cached, ok := wm.dynamicWatchers[gvr].metadataCache[resource.GetUID()]; ok && detectDrift && cached.DetectDrift {
+				driftDigest = cached.DriftDigest
+			}
 			// add the resource to the metadata cache
 			wm.dynamicWatchers[gvr].metadataCache[resource.GetUID()] = Resource{
-				Name:      resource.GetName(),
-				Namespace: resource.GetNamespace(),
-				Labels:    resource.GetLabels(),
-				Hash:      reportutils.CalculateResourceHash(*resource),
-				Data:      resource,
+				Name:        resource.GetName(),
+				Namespace:   resource.GetNamespace(),
+				Labels:      resource.GetLabels(),
+				Hash:        reportutils.CalculateResourceHash(*resource),
+				Data:        resource,
+				DetectDrift: detectDrift,
+				DriftDigest: driftDigest,
 			}
 			continue
 		}
@@ -107,11 +141,12 @@ func (wm *WatchManager) SyncWatchers(policyName string, generatedResources []*un
 		wm.dynamicWatchers[gvr] = w
 		// add the resource to the metadata cache
 		wm.dynamicWatchers[gvr].metadataCache[resource.GetUID()] = Resource{
-			Name:      resource.GetName(),
-			Namespace: resource.GetNamespace(),
-			Labels:    resource.GetLabels(),
-			Hash:      reportutils.CalculateResourceHash(*resource),
-			Data:      resource,
+			Name:        resource.GetName(),
+			Namespace:   resource.GetNamespace(),
+			Labels:      resource.GetLabels(),
+			Hash:        reportutils.CalculateResourceHash(*resource),
+			Data:        resource,
+			DetectDrift: detectDrift,
 		}
 	}
 
@@ -301,6 +336,7 @@ func (wm *WatchManager) RemoveWatchersForPolicy(policyName string, deleteDownstr
 		}
 		// Clean up the policy reference
 		delete(wm.policyRefs, policyName)
+		delete(wm.driftPolicies, policyName)
 	} else {
 		logger.V(4).Info("no watchers found for policy")
 	}
@@ -316,6 +352,7 @@ func (wm *WatchManager) StopWatchers() {
 	wm.dynamicWatchers = map[schema.GroupVersionResource]*watcher{}
 	wm.policyRefs = map[string][]schema.GroupVersionResource{}
 	wm.refCount = map[schema.GroupVersionResource]int{}
+	wm.driftPolicies = map[string]engineapi.GenericPolicy{}
 }
 
 // startWatcher starts a new watcher for the given resource and GVR.
@@ -406,17 +443,24 @@ func (wm *WatchManager) handleUpdate(obj *unstructured.Unstructured, gvr schema.
 			}
 			for _, downstream := range downstreams.Items {
 				// if the downstream doesn't exist in the metadata cache, it means sync is disabled.
-				if _, exists := watcher.metadataCache[downstream.GetUID()]; !exists {
+				cached, exists := watcher.metadataCache[downstream.GetUID()]
+				if !exists {
 					continue
 				}
 				// update the downstream resources with the source information.
 				newResource := &unstructured.Unstructured{}
-				newResource.SetUnstructuredContent(source.UnstructuredContent())
+				newResource.SetUnstructuredContent(source.DeepCopy().UnstructuredContent())
 				newResource.SetName(downstream.GetName())
 				newResource.SetNamespace(downstream.GetNamespace())
 				newResource.SetKind(downstream.GetKind())
 				newResource.SetAPIVersion(downstream.GetAPIVersion())
 				newResource.SetLabels(downstream.GetLabels())
+				if cached.DetectDrift {
+					// the downstream is expected to match the updated source from now on
+					cached.Data = newResource
+					watcher.metadataCache[downstream.GetUID()] = wm.reportDrift(cached, &downstream)
+					continue
+				}
 				_, err := wm.client.UpdateResource(context.TODO(), downstream.GetAPIVersion(), downstream.GetKind(), downstream.GetNamespace(), newResource, false)
 				if err != nil {
 					wm.log.Error(err, "failed to update downstream resource", "name", downstream.GetName(), "namespace", downstream.GetNamespace())
@@ -440,6 +484,11 @@ func (wm *WatchManager) handleUpdate(obj *unstructured.Unstructured, gvr schema.
 			// if the hash of the resource is different from the one in the cache
 			// then we need to revert the downstream resource as it means that it has been updated by the user.
 			if hash != watcher.metadataCache[uid].Hash {
+				if watcher.metadataCache[uid].DetectDrift {
+					wm.log.V(4).Info("downstream resource updated by user, reporting drift", "name", obj.GetName(), "namespace", obj.GetNamespace())
+					watcher.metadataCache[uid] = wm.reportDrift(watcher.metadataCache[uid], obj)
+					return
+				}
 				wm.log.V(4).Info("downstream resource updated by user, reverting changes", "name", obj.GetName(), "namespace", obj.GetNamespace())
 				downstream := watcher.metadataCache[uid].Data
 				// clean up parameters that shouldn't be copied
@@ -483,7 +532,12 @@ func (wm *WatchManager) handleDelete(obj *unstructured.Unstructured, gvr schema.
 			} else {
 				for _, downstream := range downstreams.Items {
 					// if the downstream doesn't exist in the metadata cache, it means sync is disabled.
-					if _, exists := watcher.metadataCache[downstream.GetUID()]; !exists {
+					cached, exists := watcher.metadataCache[downstream.GetUID()]
+					if !exists {
+						continue
+					}
+					// with drift detection, the downstream is left untouched
+					if cached.DetectDrift {
 						continue
 					}
 					err := wm.client.DeleteResource(context.TODO(), downstream.GetAPIVersion(), downstream.GetKind(), downstream.GetNamespace(), downstream.GetName(), false, metav1.DeleteOptions{})
@@ -497,8 +551,13 @@ func (wm *WatchManager) handleDelete(obj *unstructured.Unstructured, gvr schema.
 				}
 			}
 		} else {
-			if _, ok := watcher.metadataCache[uid]; ok {
+			if cached, ok := watcher.metadataCache[uid]; ok {
 				wm.log.V(4).Info("downstream resource deleted", "name", obj.GetName(), "namespace", obj.GetNamespace())
+				// with drift detection, the deleted downstream is not recreated
+				if cached.DetectDrift {
+					delete(watcher.metadataCache, uid)
+					return
+				}
 				// if the resource is already in the cache, then it is the downstream resource that has been deleted by the user.
 				// we need to revert it back.
 				downstream := watcher.metadataCache[uid].Data
@@ -518,3 +577,33 @@ func (wm *WatchManager) handleDelete(obj *unstructured.Unstructured, gvr schema.
 		}
 	}
 }
+
+// reportDrift reports the drift of a downstream resource from the resource generated by the policy when it differs
+// from the drift reported last, it returns the cached resource updated with the hash of the downstream and the reported drift.
+func (wm *WatchManager) reportDrift(cached Resource, actual *unstructured.Unstructured) Resource {
+	// the hash of the downstream is stored so that updates not changing it are ignored
+	cached.Hash = reportutils.CalculateResourceHash(*actual)
+	if wm.driftReporter == nil {
+		return cached
+	}
+	policyName := cached.Labels[common.GeneratePolicyLabel]
+	policy, ok := wm.driftPolicies[policyName]
+	if !ok {
+		return cached
+	}
+	drifts := common.ComputeDrift(cached.Data.Object, actual.Object)
+	digest, err := common.DriftDigest(drifts)
+	if err != nil {
+		wm.log.Error(err, "failed to compute drift digest", "name", actual.GetName(), "namespace", actual.GetNamespace())
+		return cached
+	}
+	if digest == cached.DriftDigest {
+		return cached
+	}
+	if err := wm.driftReporter.Report(context.TODO(), policy, common.DriftRuleName, *actual, drifts); err != nil {
+		wm.log.Error(err, "failed to report drift", "name", actual.GetName(), "namespace", actual.GetNamespace())
+		return cached
+	}
+	cached.DriftDigest = digest
+	return cached
+}
@@ -9,8 +9,10 @@ import (
 	"testing"
 
 	v1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/api/policies.kyverno.io/v1alpha1"
 	"github.com/kyverno/kyverno/pkg/background/common"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/logging"
 	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
 	"github.com/stretchr/testify/assert"
@@ -57,6 +59,7 @@ func (m *mockRESTMapper) ResourceSingularizer(resource string) (string, error) {
 
 type MockClient struct {
 	deleted  []string
+	updated  []string
 	err      error
 	deleteFn func(ctx context.Context, apiVersion, kind, namespace, name string, dryRun bool, options metav1.DeleteOptions) error
 }
@@ -106,6 +109,9 @@ func (m *MockClient) CreateResource(ctx context.Context, apiVersion string, kind
 	return nil, nil
 }
 func (m *MockClient) UpdateResource(ctx context.Context, apiVersion string, kind string, namespace string, obj interface{}, dryRun bool, subresource ...string) (*unstructured.Unstructured, error) {
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		m.updated = append(m.updated, fmt.Sprintf("%s/%s/%s", kind, namespace, u.GetName()))
+	}
 	if m.err != nil {
 		return nil, m.err
 	}
@@ -142,7 +148,7 @@ func makeUnstructured(res, group, version, kind, name, ns string, uid types.UID,
 func TestNewWatchManager(t *testing.T) {
 	client := dclient.NewEmptyFakeClient()
 	log := logging.WithName("test-logging")
-	wm := NewWatchManager(log, client)
+	wm := NewWatchManager(log, client, nil)
 	assert.NotNil(t, &wm)
 }
 
@@ -893,4 +899,71 @@ func TestHandleUpdate(t *testing.T) {
 		obj := makeObj("uid", "pod", "default", nil)
 		wm.handleUpdate(obj, gvr)
 	})
+
+	t.Run("downstream changed by user with drift detection gets reported", func(t *testing.T) {
+		mockClient := &MockClient{}
+		reporter := &mockDriftReporter{}
+		downstream := makeObj("down-uid", "down-pod", "default", map[string]string{common.GeneratePolicyLabel: "gpol"})
+		hashOld := reportutils.CalculateResourceHash(*downstream)
+		downstreamModified := downstream.DeepCopy()
+		downstreamModified.SetLabels(map[string]string{common.GeneratePolicyLabel: "changed"})
+
+		wm := &WatchManager{
+			client:        mockClient,
+			driftReporter: reporter,
+			driftPolicies: map[string]engineapi.GenericPolicy{
+				"gpol": engineapi.NewGeneratingPolicy(&v1alpha1.GeneratingPolicy{ObjectMeta: metav1.ObjectMeta{Name: "gpol"}}),
+			},
+			dynamicWatchers: map[schema.GroupVersionResource]*watcher{
+				gvr: {metadataCache: map[types.UID]Resource{
+					"down-uid": {
+						Name:        downstream.GetName(),
+						Namespace:   downstream.GetNamespace(),
+						Labels:      downstream.GetLabels(),
+						Hash:        hashOld,
+						Data:        downstream,
+						DetectDrift: true,
+					},
+				}},
+			},
+		}
+
+		wm.handleUpdate(downstreamModified, gvr)
+		assert.Empty(t, mockClient.updated)
+		assert.Equal(t, []common.Drift{{
+			Path:     "/metadata/labels/generate.kyverno.io~1policy-name",
+			Expected: "gpol",
+			Actual:   "changed",
+		}}, reporter.drifts)
+		assert.Equal(t, []string{common.DriftRuleName}, reporter.rules)
+		// updates that don't change the drift are not reported again
+		statusOnly := downstreamModified.DeepCopy()
+		assert.NoError(t, unstructured.SetNestedField(statusOnly.Object, "Running", "status", "phase"))
+		wm.handleUpdate(statusOnly, gvr)
+		wm.handleUpdate(downstreamModified, gvr)
+		assert.Len(t, reporter.rules, 1)
+		// reverting the change reports that the drift is gone
+		wm.handleUpdate(downstream.DeepCopy(), gvr)
+		assert.Len(t, reporter.rules, 2)
+		assert.Len(t, reporter.drifts, 1)
+		wm.handleUpdate(downstream.DeepCopy(), gvr)
+		assert.Len(t, reporter.rules, 2)
+	})
+}
+
+type mockDriftReporter struct {
+	rules  []string
+	drifts []common.Drift
+}
+
+func (m *mockDriftReporter) Report(_ context.Context, _ engineapi.GenericPolicy, rule string, _ unstructured.Unstructured, drifts []common.Drift) error {
+	m.rules = append(m.rules, rule)
+	m.drifts = append(m.drifts, drifts...)
+	return nil
+}
+
+func (m *mockDriftReporter) ReportMissing(_ context.Context, _ engineapi.GenericPolicy, rule string, _, resource unstructured.Unstructured) error {
+	m.rules = append(m.rules, rule)
+	m.drifts = append(m.drifts, common.MissingDrift(resource.Object)...)
+	return nil
 }
@@ -3,25 +3,33 @@ package gpol
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	policiesv1alpha1 "github.com/kyverno/kyverno/api/policies.kyverno.io/v1alpha1"
 	"github.com/kyverno/kyverno/pkg/background/common"
 	"github.com/kyverno/kyverno/pkg/breaker"
 	celengine "github.com/kyverno/kyverno/pkg/cel/engine"
 	"github.com/kyverno/kyverno/pkg/cel/libs"
+	"github.com/kyverno/kyverno/pkg/cel/libs/oci"
 	gpolengine "github.com/kyverno/kyverno/pkg/cel/policies/gpol/engine"
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	datautils "github.com/kyverno/kyverno/pkg/utils/data"
 	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
 	"go.uber.org/multierr"
 	admissionv1 "k8s.io/api/admission/v1"
 	authenticationv1 "k8s.io/api/authentication/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/client-go/restmapper"
+	"k8s.io/client-go/util/retry"
 )
 
 // CELGenerateController is used to process URs that are generated as a result of an event from the trigger resource.
@@ -125,6 +133,7 @@ func (c *CELGenerateController) ProcessUR(ur *kyvernov2.UpdateRequest) error {
 			continue
 		}
 		isSync := policy.Policy.Spec.SynchronizationEnabled()
+		detectDrift := policy.Policy.Spec.DriftDetectionEnabled()
 		gpolResponse, err := c.engine.Handle(request, policy, ur.Spec.RuleContext[i].CacheRestore)
 		if err != nil {
 			logger.Error(err, "failed to generate resources for gpol", "gpol", ur.Spec.GetPolicyKey())
@@ -135,6 +144,14 @@ func (c *CELGenerateController) ProcessUR(ur *kyvernov2.UpdateRequest) error {
 			PolicyResponse: engineapi.PolicyResponse{},
 		}
 		for _, res := range gpolResponse.Policies {
+			if len(res.Sources) != 0 {
+				// the status is only updated when the evaluation resolved different digests
+				if sources := mergeSources(res.Policy.Status.Sources, res.Policy.GetGeneration(), res.Sources); !datautils.DeepEqual(res.Policy.Status.Sources, sources) {
+					if err := c.updateSources(context.TODO(), res.Policy.GetName(), res.Sources); err != nil {
+						logger.Error(err, "failed to update gpol sources status", "gpol", ur.Spec.GetPolicyKey())
+					}
+				}
+			}
 			if res.Result == nil {
 				logger.V(4).Info("no resources generated by gpol", "gpol", ur.Spec.GetPolicyKey(), "policy", res.Policy.GetName())
 				continue
@@ -160,6 +177,14 @@ func (c *CELGenerateController) ProcessUR(ur *kyvernov2.UpdateRequest) error {
 						logger.V(4).Info("synced watchers for generated resources", "gpol", ur.Spec.GetPolicyKey(), "resources", res.Result.GeneratedResources())
 					}
 				}()
+			} else if detectDrift {
+				go func() {
+					if err := c.watchManager.DetectDrift(engineapi.NewGeneratingPolicy(&res.Policy), res.Result.GeneratedResources()); err != nil {
+						logger.Error(err, "failed to watch generated resources for drift", "gpol", ur.Spec.GetPolicyKey())
+					} else {
+						logger.V(4).Info("watching generated resources for drift", "gpol", ur.Spec.GetPolicyKey(), "resources", res.Result.GeneratedResources())
+					}
+				}()
 			}
 		}
 		// generate reports if enabled
@@ -190,6 +215,56 @@ func (c *CELGenerateController) createReports(
 	return nil
 }
 
+// updateSources records the digests the OCI artifacts fetched by the policy resolved to in the policy status
+func (c *CELGenerateController) updateSources(ctx context.Context, name string, fetched []oci.Source) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		policy, err := c.kyvernoClient.PoliciesV1alpha1().GeneratingPolicies().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		return controllerutils.UpdateStatus(
+			ctx,
+			policy,
+			c.kyvernoClient.PoliciesV1alpha1().GeneratingPolicies(),
+			func(policy *policiesv1alpha1.GeneratingPolicy) error {
+				policy.Status.Sources = mergeSources(policy.Status.Sources, policy.GetGeneration(), fetched)
+				return nil
+			},
+			func(a, b *policiesv1alpha1.GeneratingPolicy) bool {
+				return datautils.DeepEqual(a.Status, b.Status)
+			},
+		)
+	})
+}
+
+// mergeSources merges the sources fetched by an evaluation of the policy into the recorded ones.
+// A trigger may only fetch some of the references of the policy, the digests of the other references are kept
+// as long as they were recorded for the current generation of the policy, entries recorded for a previous
+// generation are dropped as the policy spec changed since. The result is unique and sorted by reference.
+func mergeSources(recorded []policiesv1alpha1.GeneratingPolicySource, generation int64, fetched []oci.Source) []policiesv1alpha1.GeneratingPolicySource {
+	out := make([]policiesv1alpha1.GeneratingPolicySource, 0, len(recorded)+len(fetched))
+	for _, source := range recorded {
+		if source.PolicyGeneration == generation {
+			out = append(out, source)
+		}
+	}
+	for _, source := range fetched {
+		index := slices.IndexFunc(out, func(s policiesv1alpha1.GeneratingPolicySource) bool {
+			return s.Reference == source.Reference
+		})
+		entry := policiesv1alpha1.GeneratingPolicySource{Reference: source.Reference, Digest: source.Digest, PolicyGeneration: generation}
+		if index < 0 {
+			out = append(out, entry)
+		} else {
+			out[index] = entry
+		}
+	}
+	slices.SortFunc(out, func(a, b policiesv1alpha1.GeneratingPolicySource) int {
+		return strings.Compare(a.Reference, b.Reference)
+	})
+	return out
+}
+
 func updateURStatus(statusControl common.StatusControlInterface, ur kyvernov2.UpdateRequest, err error, genResources []kyvernov1.ResourceSpec) error {
 	if err != nil {
 		if _, err := statusControl.Failed(ur.GetName(), err.Error(), genResources); err != nil {
@@ -0,0 +1,65 @@
+package gpol
+
+import (
+	"testing"
+
+	policiesv1alpha1 "github.com/kyverno/kyverno/api/policies.kyverno.io/v1alpha1"
+	"github.com/kyverno/kyverno/pkg/cel/libs/oci"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeSources(t *testing.T) {
+	tests := []struct {
+		name       string
+		recorded   []policiesv1alpha1.GeneratingPolicySource
+		generation int64
+		fetched    []oci.Source
+		want       []policiesv1alpha1.GeneratingPolicySource
+	}{{
+		name:       "sorted by reference",
+		generation: 1,
+		fetched:    []oci.Source{{Reference: "ghcr.io/acme/kit:v2", Digest: "sha256:b"}, {Reference: "ghcr.io/acme/base:v1", Digest: "sha256:a"}},
+		want: []policiesv1alpha1.GeneratingPolicySource{
+			{Reference: "ghcr.io/acme/base:v1", Digest: "sha256:a", PolicyGeneration: 1},
+			{Reference: "ghcr.io/acme/kit:v2", Digest: "sha256:b", PolicyGeneration: 1},
+		},
+	}, {
+		name:       "last fetched digest wins",
+		generation: 1,
+		fetched: []oci.Source{
+			{Reference: "ghcr.io/acme/kit:latest", Digest: "sha256:b"},
+			{Reference: "ghcr.io/acme/kit:latest", Digest: "sha256:c"},
+		},
+		want: []policiesv1alpha1.GeneratingPolicySource{
+			{Reference: "ghcr.io/acme/kit:latest", Digest: "sha256:c", PolicyGeneration: 1},
+		},
+	}, {
+		name: "references not fetched by the trigger are kept",
+		recorded: []policiesv1alpha1.GeneratingPolicySource{
+			{Reference: "ghcr.io/acme/base:v1", Digest: "sha256:a", PolicyGeneration: 2},
+			{Reference: "ghcr.io/acme/kit:latest", Digest: "sha256:b", PolicyGeneration: 2},
+		},
+		generation: 2,
+		fetched:    []oci.Source{{Reference: "ghcr.io/acme/kit:latest", Digest: "sha256:c"}},
+		want: []policiesv1alpha1.GeneratingPolicySource{
+			{Reference: "ghcr.io/acme/base:v1", Digest: "sha256:a", PolicyGeneration: 2},
+			{Reference: "ghcr.io/acme/kit:latest", Digest: "sha256:c", PolicyGeneration: 2},
+		},
+	}, {
+		name: "references recorded for a previous generation are dropped",
+		recorded: []policiesv1alpha1.GeneratingPolicySource{
+			{Reference: "ghcr.io/acme/base:v1", Digest: "sha256:a", PolicyGeneration: 2},
+			{Reference: "ghcr.io/acme/kit:latest", Digest: "sha256:b", PolicyGeneration: 2},
+		},
+		generation: 3,
+		fetched:    []oci.Source{{Reference: "ghcr.io/acme/kit:latest", Digest: "sha256:b"}},
+		want: []policiesv1alpha1.GeneratingPolicySource{
+			{Reference: "ghcr.io/acme/kit:latest", Digest: "sha256:b", PolicyGeneration: 3},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, mergeSources(tt.recorded, tt.generation, tt.fetched))
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package background
+
+import (
+	"context"
+	"time"
+
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// newQueueMetrics reports the depth of the update request queue and the age of its oldest item per request type
+func newQueueMetrics(queue *fairQueue) {
+	meter := otel.GetMeterProvider().Meter(metrics.MeterName)
+	depthMetric, err := meter.Int64ObservableGauge(
+		"kyverno_update_request_queue_depth",
+		metric.WithDescription("can be used to track the number of update requests waiting to be processed per request type"),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_update_request_queue_depth")
+		return
+	}
+	ageMetric, err := meter.Float64ObservableGauge(
+		"kyverno_update_request_queue_age_seconds",
+		metric.WithDescription("can be used to track the time the oldest update request has been waiting to be processed per request type"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_update_request_queue_age_seconds")
+		return
+	}
+	_, err = meter.RegisterCallback(func(_ context.Context, observer metric.Observer) error {
+		for requestType, stats := range queue.Stats(time.Now()) {
+			attributes := metric.WithAttributes(attribute.String("request_type", string(requestType)))
+			observer.ObserveInt64(depthMetric, int64(stats.depth), attributes)
+			observer.ObserveFloat64(ageMetric, stats.oldest.Seconds(), attributes)
+		}
+		return nil
+	}, depthMetric, ageMetric)
+	if err != nil {
+		logger.Error(err, "failed to register callback")
+	}
+}
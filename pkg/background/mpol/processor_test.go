@@ -19,6 +19,7 @@ import (
 
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/background/common"
+	"github.com/kyverno/kyverno/pkg/cel/libs/oci"
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 
@@ -49,7 +50,10 @@ func (f *fakeContext) GetGeneratedResources() []*unstructured.Unstructured { ret
 func (f *fakeContext) PostResource(apiVersion, resource, namespace string, data map[string]any) (*unstructured.Unstructured, error) {
 	return &unstructured.Unstructured{}, nil
 }
-func (f *fakeContext) ClearGeneratedResources() {}
+func (f *fakeContext) ClearGeneratedResources()                        {}
+func (f *fakeContext) FetchManifests(string) ([]map[string]any, error) { return nil, nil }
+func (f *fakeContext) GetFetchedSources() []oci.Source                 { return nil }
+func (f *fakeContext) ClearFetchedSources()                            {}
 func (f *fakeContext) SetGenerateContext(polName, triggerName, triggerNamespace, triggerAPIVersion, triggerGroup, triggerKind, triggerUID string, restoreCache bool) {
 	panic("not implemented")
 }
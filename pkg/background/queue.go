@@ -0,0 +1,296 @@
+package background
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/workqueue"
+)
+
+// requestTypes are the known update request types, in the order they are served
+var requestTypes = []kyvernov2.RequestType{
+	kyvernov2.Mutate,
+	kyvernov2.Generate,
+	kyvernov2.CELMutate,
+	kyvernov2.CELGenerate,
+}
+
+// queueItem identifies an update request along with the attributes used to schedule it
+type queueItem struct {
+	key         string
+	requestType kyvernov2.RequestType
+	policy      string
+}
+
+// queueStats describes the items waiting in the queue for a request type
+type queueStats struct {
+	depth  int
+	oldest time.Duration
+}
+
+// lane holds the items of a request type, grouped by policy
+type lane struct {
+	// policies with pending items, served in turn
+	policies []string
+	items    map[string][]queueItem
+}
+
+func (l *lane) push(item queueItem) {
+	if len(l.items[item.policy]) == 0 {
+		l.policies = append(l.policies, item.policy)
+	}
+	l.items[item.policy] = append(l.items[item.policy], item)
+}
+
+func (l *lane) pop() (queueItem, bool) {
+	if len(l.policies) == 0 {
+		return queueItem{}, false
+	}
+	policy := l.policies[0]
+	l.policies = l.policies[1:]
+	items := l.items[policy]
+	item := items[0]
+	if len(items) == 1 {
+		delete(l.items, policy)
+	} else {
+		l.items[policy] = items[1:]
+		// move the policy at the end so that other policies are served first
+		l.policies = append(l.policies, policy)
+	}
+	return item, true
+}
+
+// fairQueue is a work queue serving request types proportionally to their weight,
+// policies of the same request type are served in turn so that a burst of update requests
+// coming from a single policy doesn't starve the others.
+// Like client-go work queues, an item is never processed concurrently and is never queued twice.
+type fairQueue struct {
+	lock         sync.Mutex
+	cond         *sync.Cond
+	weights      map[kyvernov2.RequestType]int
+	order        []kyvernov2.RequestType
+	lanes        map[kyvernov2.RequestType]*lane
+	current      int
+	credits      int
+	queued       map[queueItem]time.Time
+	dirty        map[queueItem]time.Time
+	processing   sets.Set[queueItem]
+	rateLimiter  workqueue.TypedRateLimiter[queueItem]
+	shuttingDown bool
+}
+
+func newFairQueue(weights map[kyvernov2.RequestType]int, rateLimiter workqueue.TypedRateLimiter[queueItem]) *fairQueue {
+	q := &fairQueue{
+		weights:     map[kyvernov2.RequestType]int{},
+		lanes:       map[kyvernov2.RequestType]*lane{},
+		queued:      map[queueItem]time.Time{},
+		dirty:       map[queueItem]time.Time{},
+		processing:  sets.New[queueItem](),
+		rateLimiter: rateLimiter,
+	}
+	q.cond = sync.NewCond(&q.lock)
+	for _, requestType := range requestTypes {
+		q.addLane(requestType, weights[requestType])
+	}
+	q.credits = q.weights[q.order[0]]
+	return q
+}
+
+func (q *fairQueue) addLane(requestType kyvernov2.RequestType, weight int) {
+	if _, ok := q.lanes[requestType]; ok {
+		return
+	}
+	if weight <= 0 {
+		weight = 1
+	}
+	q.weights[requestType] = weight
+	q.order = append(q.order, requestType)
+	q.lanes[requestType] = &lane{items: map[string][]queueItem{}}
+}
+
+// Add queues an item, unless it is already queued
+func (q *fairQueue) Add(item queueItem) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	if q.shuttingDown {
+		return
+	}
+	if _, ok := q.queued[item]; ok {
+		return
+	}
+	if q.processing.Has(item) {
+		// queued again when processing is done
+		if _, ok := q.dirty[item]; !ok {
+			q.dirty[item] = time.Now()
+		}
+		return
+	}
+	q.push(item, time.Now())
+}
+
+func (q *fairQueue) push(item queueItem, added time.Time) {
+	q.addLane(item.requestType, 1)
+	q.queued[item] = added
+	q.lanes[item.requestType].push(item)
+	q.cond.Signal()
+}
+
+// AddAfter queues an item once the delay expired
+func (q *fairQueue) AddAfter(item queueItem, delay time.Duration) {
+	if delay <= 0 {
+		q.Add(item)
+		return
+	}
+	time.AfterFunc(delay, func() { q.Add(item) })
+}
+
+// AddRateLimited queues an item after the delay given by the rate limiter
+func (q *fairQueue) AddRateLimited(item queueItem) {
+	q.AddAfter(item, q.rateLimiter.When(item))
+}
+
+// Forget resets the rate limiter of an item
+func (q *fairQueue) Forget(item queueItem) {
+	q.rateLimiter.Forget(item)
+}
+
+// NumRequeues returns how many times an item was rate limited
+func (q *fairQueue) NumRequeues(item queueItem) int {
+	return q.rateLimiter.NumRequeues(item)
+}
+
+// Get blocks until an item can be processed, it returns true when the queue is shutting down
+func (q *fairQueue) Get() (queueItem, bool) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	for {
+		for len(q.queued) == 0 && !q.shuttingDown {
+			q.cond.Wait()
+		}
+		if len(q.queued) == 0 {
+			return queueItem{}, true
+		}
+		if item, ok := q.pop(); ok {
+			delete(q.queued, item)
+			q.processing.Insert(item)
+			return item, false
+		}
+		logger.Error(errors.New("fair queue is inconsistent"), "queued items are missing from the request type lanes, rebuilding them", "queued", len(q.queued))
+		q.reindex()
+	}
+}
+
+// pop returns the next item with a weighted round robin over the request types,
+// it returns false when no lane holds an item
+func (q *fairQueue) pop() (queueItem, bool) {
+	// every lane is visited at most twice, the first visit may happen with no credit left
+	for range 2*len(q.order) + 1 {
+		if q.credits > 0 {
+			if item, ok := q.lanes[q.order[q.current]].pop(); ok {
+				q.credits--
+				return item, true
+			}
+		}
+		q.current = (q.current + 1) % len(q.order)
+		q.credits = q.weights[q.order[q.current]]
+	}
+	return queueItem{}, false
+}
+
+// reindex rebuilds the request type lanes from the queued items, oldest items first
+func (q *fairQueue) reindex() {
+	items := make([]queueItem, 0, len(q.queued))
+	for item := range q.queued {
+		items = append(items, item)
+	}
+	slices.SortFunc(items, func(a, b queueItem) int {
+		return q.queued[a].Compare(q.queued[b])
+	})
+	for _, l := range q.lanes {
+		l.policies = nil
+		l.items = map[string][]queueItem{}
+	}
+	for _, item := range items {
+		q.addLane(item.requestType, 1)
+		q.lanes[item.requestType].push(item)
+	}
+	q.current = 0
+	q.credits = q.weights[q.order[0]]
+}
+
+// Done marks an item as processed, it is queued again if it was added during processing
+func (q *fairQueue) Done(item queueItem) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	q.processing.Delete(item)
+	if added, ok := q.dirty[item]; ok {
+		delete(q.dirty, item)
+		if !q.shuttingDown {
+			q.push(item, added)
+		}
+	}
+}
+
+// ShutDown stops the queue, workers blocked in Get are released
+func (q *fairQueue) ShutDown() {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	q.shuttingDown = true
+	q.cond.Broadcast()
+}
+
+// Len returns the number of queued items
+func (q *fairQueue) Len() int {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return len(q.queued)
+}
+
+// Stats returns the depth and the age of the oldest queued item per request type
+func (q *fairQueue) Stats(now time.Time) map[kyvernov2.RequestType]queueStats {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	stats := map[kyvernov2.RequestType]queueStats{}
+	for _, requestType := range q.order {
+		stats[requestType] = queueStats{}
+	}
+	for item, added := range q.queued {
+		s := stats[item.requestType]
+		s.depth++
+		s.oldest = max(s.oldest, now.Sub(added))
+		stats[item.requestType] = s
+	}
+	return stats
+}
+
+// ParseQueueWeights parses request type weights formatted as a comma separated list of type=weight,
+// e.g. mutate=2,generate=1
+func ParseQueueWeights(in string) (map[kyvernov2.RequestType]int, error) {
+	weights := map[kyvernov2.RequestType]int{}
+	for _, entry := range strings.Split(in, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		requestType, value, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid queue weight %q, expected type=weight", entry)
+		}
+		if !slices.Contains(requestTypes, kyvernov2.RequestType(requestType)) {
+			return nil, fmt.Errorf("invalid queue weight %q, unknown request type %s", entry, requestType)
+		}
+		weight, err := strconv.Atoi(value)
+		if err != nil || weight <= 0 {
+			return nil, fmt.Errorf("invalid queue weight %q, weight must be a positive integer", entry)
+		}
+		weights[kyvernov2.RequestType(requestType)] = weight
+	}
+	return weights, nil
+}
@@ -0,0 +1,160 @@
+package background
+
+import (
+	"testing"
+	"time"
+
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/util/workqueue"
+)
+
+func newTestQueue(weights map[kyvernov2.RequestType]int) *fairQueue {
+	return newFairQueue(weights, workqueue.NewTypedItemExponentialFailureRateLimiter[queueItem](time.Millisecond, time.Second))
+}
+
+func drain(t *testing.T, q *fairQueue) []string {
+	var keys []string
+	for q.Len() != 0 {
+		item, quit := q.Get()
+		require.False(t, quit)
+		keys = append(keys, item.key)
+		q.Done(item)
+	}
+	return keys
+}
+
+func Test_fairQueue_weights(t *testing.T) {
+	q := newTestQueue(map[kyvernov2.RequestType]int{kyvernov2.Mutate: 2})
+	for _, key := range []string{"g1", "g2", "g3", "g4"} {
+		q.Add(queueItem{key: key, requestType: kyvernov2.Generate, policy: "generate"})
+	}
+	for _, key := range []string{"m1", "m2", "m3", "m4"} {
+		q.Add(queueItem{key: key, requestType: kyvernov2.Mutate, policy: "mutate"})
+	}
+	assert.Equal(t, []string{"m1", "m2", "g1", "m3", "m4", "g2", "g3", "g4"}, drain(t, q))
+}
+
+func Test_fairQueue_policies(t *testing.T) {
+	q := newTestQueue(nil)
+	for _, key := range []string{"a1", "a2", "a3"} {
+		q.Add(queueItem{key: key, requestType: kyvernov2.Generate, policy: "a"})
+	}
+	for _, key := range []string{"b1", "b2"} {
+		q.Add(queueItem{key: key, requestType: kyvernov2.Generate, policy: "b"})
+	}
+	q.Add(queueItem{key: "c1", requestType: kyvernov2.Generate, policy: "c"})
+	assert.Equal(t, []string{"a1", "b1", "c1", "a2", "b2", "a3"}, drain(t, q))
+}
+
+func Test_fairQueue_dedup(t *testing.T) {
+	q := newTestQueue(nil)
+	item := queueItem{key: "ur", requestType: kyvernov2.Mutate, policy: "pol"}
+	q.Add(item)
+	q.Add(item)
+	assert.Equal(t, 1, q.Len())
+	got, quit := q.Get()
+	require.False(t, quit)
+	assert.Equal(t, item, got)
+	// added while processing, queued again once done
+	q.Add(item)
+	assert.Equal(t, 0, q.Len())
+	q.Done(got)
+	assert.Equal(t, 1, q.Len())
+	assert.Equal(t, []string{"ur"}, drain(t, q))
+}
+
+func Test_fairQueue_inconsistent(t *testing.T) {
+	q := newTestQueue(nil)
+	q.Add(queueItem{key: "g1", requestType: kyvernov2.Generate, policy: "pol"})
+	q.Add(queueItem{key: "m1", requestType: kyvernov2.Mutate, policy: "pol"})
+	// the lanes lost track of the queued items
+	for _, l := range q.lanes {
+		l.policies = nil
+		l.items = map[string][]queueItem{}
+	}
+	_, ok := q.pop()
+	assert.False(t, ok)
+	assert.Equal(t, []string{"m1", "g1"}, drain(t, q))
+}
+
+func Test_fairQueue_stats(t *testing.T) {
+	q := newTestQueue(nil)
+	q.Add(queueItem{key: "g1", requestType: kyvernov2.Generate, policy: "pol"})
+	q.Add(queueItem{key: "g2", requestType: kyvernov2.Generate, policy: "pol"})
+	stats := q.Stats(time.Now().Add(time.Minute))
+	assert.Equal(t, 2, stats[kyvernov2.Generate].depth)
+	assert.GreaterOrEqual(t, stats[kyvernov2.Generate].oldest, time.Minute)
+	assert.Equal(t, queueStats{}, stats[kyvernov2.Mutate])
+}
+
+func Test_fairQueue_shutdown(t *testing.T) {
+	q := newTestQueue(nil)
+	done := make(chan bool)
+	go func() {
+		_, quit := q.Get()
+		done <- quit
+	}()
+	q.ShutDown()
+	assert.True(t, <-done)
+	q.Add(queueItem{key: "ur", requestType: kyvernov2.Mutate})
+	assert.Equal(t, 0, q.Len())
+}
+
+func TestParseQueueWeights(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    map[kyvernov2.RequestType]int
+		wantErr bool
+	}{{
+		name: "empty",
+		in:   "",
+		want: map[kyvernov2.RequestType]int{},
+	}, {
+		name: "weights",
+		in:   "mutate=3, generate=1,cel-generate=2",
+		want: map[kyvernov2.RequestType]int{
+			kyvernov2.Mutate:      3,
+			kyvernov2.Generate:    1,
+			kyvernov2.CELGenerate: 2,
+		},
+	}, {
+		name:    "unknown type",
+		in:      "foo=1",
+		wantErr: true,
+	}, {
+		name:    "invalid weight",
+		in:      "mutate=0",
+		wantErr: true,
+	}, {
+		name:    "missing weight",
+		in:      "mutate",
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseQueueWeights(tt.in)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestQueueConfiguration_retryDelay(t *testing.T) {
+	config := QueueConfiguration{
+		RetryBackoff:    time.Second,
+		MaxRetryBackoff: 10 * time.Second,
+	}
+	assert.Equal(t, time.Second, config.retryDelay(0))
+	assert.Equal(t, time.Second, config.retryDelay(1))
+	assert.Equal(t, 2*time.Second, config.retryDelay(2))
+	assert.Equal(t, 8*time.Second, config.retryDelay(4))
+	assert.Equal(t, 10*time.Second, config.retryDelay(5))
+	assert.Equal(t, 10*time.Second, config.retryDelay(100))
+}
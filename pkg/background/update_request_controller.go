@@ -41,6 +41,29 @@ const (
 	maxRetries = 10
 )
 
+// QueueConfiguration configures how update requests are scheduled and retried
+type QueueConfiguration struct {
+	// Weights are the relative shares of the workers given to each request type
+	Weights map[kyvernov2.RequestType]int
+	// RetryBackoff is the delay before retrying a failed update request, it doubles with every attempt
+	RetryBackoff time.Duration
+	// MaxRetryBackoff caps the delay between two attempts
+	MaxRetryBackoff time.Duration
+	// MaxAttempts is the number of failed attempts after which an update request is abandoned
+	MaxAttempts int
+	// AbandonedTTL is the time abandoned update requests are kept before being deleted, zero keeps them forever
+	AbandonedTTL time.Duration
+}
+
+// retryDelay returns the delay before the next attempt of an update request that failed the given number of times
+func (c QueueConfiguration) retryDelay(failures int) time.Duration {
+	delay := c.RetryBackoff
+	for i := 1; i < failures && delay < c.MaxRetryBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, c.MaxRetryBackoff)
+}
+
 type Controller interface {
 	// Run starts workers
 	Run(context.Context, int)
@@ -62,7 +85,8 @@ type controller struct {
 	informersSynced []cache.InformerSynced
 
 	// queue
-	queue workqueue.TypedRateLimitingInterface[any]
+	queue       *fairQueue
+	queueConfig QueueConfiguration
 
 	context      libs.Context
 	gpolEngine   gpolengine.Engine
@@ -96,6 +120,7 @@ func NewController(
 	configuration config.Configuration,
 	jp jmespath.Interface,
 	reportsConfig reportutils.ReportingConfiguration,
+	queueConfig QueueConfiguration,
 ) Controller {
 	urLister := urInformer.Lister().UpdateRequests(config.KyvernoNamespace())
 	c := controller{
@@ -106,10 +131,11 @@ func NewController(
 		polLister:     polInformer.Lister(),
 		urLister:      urLister,
 		nsLister:      namespaceInformer.Lister(),
-		queue: workqueue.NewTypedRateLimitingQueueWithConfig(
-			workqueue.DefaultTypedControllerRateLimiter[any](),
-			workqueue.TypedRateLimitingQueueConfig[any]{Name: "background"},
+		queue: newFairQueue(
+			queueConfig.Weights,
+			workqueue.NewTypedItemExponentialFailureRateLimiter[queueItem](queueConfig.RetryBackoff, queueConfig.MaxRetryBackoff),
 		),
+		queueConfig:   queueConfig,
 		context:       context,
 		gpolEngine:    gpolEngine,
 		gpolProvider:  gpolProvider,
@@ -126,6 +152,8 @@ func NewController(
 		UpdateFunc: c.updateUR,
 	})
 
+	newQueueMetrics(c.queue)
+
 	c.informersSynced = []cache.InformerSynced{cpolInformer.Informer().HasSynced, polInformer.Informer().HasSynced, urInformer.Informer().HasSynced, namespaceInformer.Informer().HasSynced}
 
 	return &c
@@ -157,40 +185,42 @@ func (c *controller) worker(ctx context.Context) {
 }
 
 func (c *controller) processNextWorkItem() bool {
-	key, quit := c.queue.Get()
+	item, quit := c.queue.Get()
 	if quit {
 		return false
 	}
 
-	defer c.queue.Done(key)
-	err := c.syncUpdateRequest(key.(string))
-	c.handleErr(err, key)
+	defer c.queue.Done(item)
+	err := c.syncUpdateRequest(item)
+	c.handleErr(err, item)
 	return true
 }
 
-func (c *controller) handleErr(err error, key interface{}) {
+func (c *controller) handleErr(err error, item queueItem) {
+	key := item.key
 	if err == nil {
-		c.queue.Forget(key)
+		c.queue.Forget(item)
 		return
 	}
 
 	if apierrors.IsNotFound(err) {
-		c.queue.Forget(key)
+		c.queue.Forget(item)
 		logger.V(4).Info("Dropping update request from the queue", "key", key, "error", err.Error())
 		return
 	}
 
-	if c.queue.NumRequeues(key) < maxRetries {
+	if c.queue.NumRequeues(item) < maxRetries {
 		logger.V(3).Info("retrying update request", "key", key, "error", err.Error())
-		c.queue.AddAfter(key, time.Second)
+		c.queue.AddRateLimited(item)
 		return
 	}
 
 	logger.Error(err, "failed to process update request", "key", key)
-	c.queue.Forget(key)
+	c.queue.Forget(item)
 }
 
-func (c *controller) syncUpdateRequest(key string) error {
+func (c *controller) syncUpdateRequest(item queueItem) error {
+	key := item.key
 	startTime := time.Now()
 	logger.V(4).Info("started sync", "key", key, "startTime", startTime)
 	_, urName, err := cache.SplitMetaNamespaceKey(key)
@@ -204,19 +234,26 @@ func (c *controller) syncUpdateRequest(key string) error {
 
 	// Deep-copy otherwise we are mutating our cache.
 	ur = ur.DeepCopy()
+	if ur.Status.State == kyvernov2.Abandoned {
+		return c.cleanupAbandoned(item, ur)
+	}
 	if _, err := c.getPolicy(ur.Spec.Policy); err != nil && apierrors.IsNotFound(err) {
 		if ur.Spec.GetRequestType() == kyvernov2.Mutate {
 			return c.handleMutatePolicyAbsence(ur)
 		}
 	}
 
-	if ur.Status.State == kyvernov2.Pending {
+	// failed update requests are queued again by the controller once their backoff expired
+	if ur.Status.State == kyvernov2.Pending || ur.Status.State == kyvernov2.Failed {
+		if ur.Status.State == kyvernov2.Failed && ur.Status.RetryCount >= c.queueConfig.MaxAttempts {
+			return c.abandon(item, ur)
+		}
 		if err := c.processUR(ur); err != nil {
 			return fmt.Errorf("failed to process UR %s: %v", key, err)
 		}
 	}
 
-	urStatus, err := c.reconcileURStatus(ur)
+	urStatus, err := c.reconcileURStatus(item, ur)
 	if err != nil {
 		return err
 	}
@@ -231,8 +268,13 @@ func (c *controller) enqueueUpdateRequest(obj interface{}) {
 		logger.Error(err, "failed to extract name")
 		return
 	}
+	ur := obj.(*kyvernov2.UpdateRequest)
 	logger.V(5).Info("enqueued update request", "ur", key)
-	c.queue.Add(key)
+	c.queue.Add(queueItem{
+		key:         key,
+		requestType: ur.Spec.GetRequestType(),
+		policy:      ur.Spec.GetPolicyKey(),
+	})
 }
 
 func (c *controller) addUR(obj interface{}) {
@@ -242,7 +284,9 @@ func (c *controller) addUR(obj interface{}) {
 
 func (c *controller) updateUR(_, cur interface{}) {
 	curUr := cur.(*kyvernov2.UpdateRequest)
-	if curUr.Status.State == kyvernov2.Skip || curUr.Status.State == kyvernov2.Completed {
+	switch curUr.Status.State {
+	// failed update requests are retried after a backoff, see reconcileURStatus
+	case kyvernov2.Skip, kyvernov2.Completed, kyvernov2.Failed, kyvernov2.Abandoned:
 		return
 	}
 	c.enqueueUpdateRequest(curUr)
@@ -267,7 +311,7 @@ func (c *controller) processUR(ur *kyvernov2.UpdateRequest) error {
 	return nil
 }
 
-func (c *controller) reconcileURStatus(ur *kyvernov2.UpdateRequest) (kyvernov2.UpdateRequestState, error) {
+func (c *controller) reconcileURStatus(item queueItem, ur *kyvernov2.UpdateRequest) (kyvernov2.UpdateRequestState, error) {
 	new, err := c.kyvernoClient.KyvernoV2().UpdateRequests(config.KyvernoNamespace()).Get(context.TODO(), ur.GetName(), metav1.GetOptions{})
 	if err != nil {
 		logger.V(3).Info("cannot fetch latest UR, fallback to the existing one", "reason", err.Error())
@@ -279,12 +323,52 @@ func (c *controller) reconcileURStatus(ur *kyvernov2.UpdateRequest) (kyvernov2.U
 	case kyvernov2.Completed:
 		errUpdate = c.kyvernoClient.KyvernoV2().UpdateRequests(config.KyvernoNamespace()).Delete(context.TODO(), ur.GetName(), metav1.DeleteOptions{})
 	case kyvernov2.Failed:
-		new.Status.State = kyvernov2.Pending
-		_, errUpdate = c.kyvernoClient.KyvernoV2().UpdateRequests(config.KyvernoNamespace()).UpdateStatus(context.TODO(), new, metav1.UpdateOptions{})
+		if new.Status.RetryCount >= c.queueConfig.MaxAttempts {
+			errUpdate = c.abandon(item, new)
+		} else {
+			delay := c.queueConfig.retryDelay(new.Status.RetryCount)
+			logger.V(3).Info("update request failed, scheduling retry", "key", item.key, "attempts", new.Status.RetryCount, "delay", delay.String())
+			c.queue.AddAfter(item, delay)
+		}
 	}
 	return new.Status.State, errUpdate
 }
 
+// abandon moves an update request that failed too many times to the terminal Abandoned state,
+// the message keeps the last error
+func (c *controller) abandon(item queueItem, ur *kyvernov2.UpdateRequest) error {
+	ur.Status.State = kyvernov2.Abandoned
+	abandonedAt := metav1.Now()
+	ur.Status.AbandonedAt = &abandonedAt
+	if _, err := c.kyvernoClient.KyvernoV2().UpdateRequests(config.KyvernoNamespace()).UpdateStatus(context.TODO(), ur, metav1.UpdateOptions{}); err != nil {
+		return err
+	}
+	logger.Info("abandoned update request", "name", ur.GetName(), "type", ur.Spec.GetRequestType(), "policy", ur.Spec.GetPolicyKey(), "attempts", ur.Status.RetryCount, "error", ur.Status.Message)
+	c.eventGen.Add(event.NewUpdateRequestAbandonedEvent(*ur))
+	if c.queueConfig.AbandonedTTL > 0 {
+		c.queue.AddAfter(item, c.queueConfig.AbandonedTTL)
+	}
+	return nil
+}
+
+// cleanupAbandoned deletes an abandoned update request once its time to live expired,
+// requests abandoned before the time was recorded expire relative to their creation
+func (c *controller) cleanupAbandoned(item queueItem, ur *kyvernov2.UpdateRequest) error {
+	if c.queueConfig.AbandonedTTL <= 0 {
+		return nil
+	}
+	abandonedAt := ur.GetCreationTimestamp()
+	if ur.Status.AbandonedAt != nil {
+		abandonedAt = *ur.Status.AbandonedAt
+	}
+	if remaining := time.Until(abandonedAt.Add(c.queueConfig.AbandonedTTL)); remaining > 0 {
+		c.queue.AddAfter(item, remaining)
+		return nil
+	}
+	logger.V(3).Info("deleting abandoned update request", "name", ur.GetName(), "abandonedAt", abandonedAt.String())
+	return c.kyvernoClient.KyvernoV2().UpdateRequests(config.KyvernoNamespace()).Delete(context.TODO(), ur.GetName(), metav1.DeleteOptions{})
+}
+
 func (c *controller) getPolicy(key string) (kyvernov1.PolicyInterface, error) {
 	namespace, name, err := cache.SplitMetaNamespaceKey(key)
 	if err != nil {
@@ -0,0 +1,72 @@
+package background
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	kyvernov2listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func newAbandonedUR(name string, abandonedAt time.Time) *kyvernov2.UpdateRequest {
+	at := metav1.NewTime(abandonedAt)
+	return &kyvernov2.UpdateRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: config.KyvernoNamespace(),
+		},
+		Spec: kyvernov2.UpdateRequestSpec{
+			Type:   kyvernov2.Generate,
+			Policy: "policy",
+		},
+		Status: kyvernov2.UpdateRequestStatus{
+			State:       kyvernov2.Abandoned,
+			AbandonedAt: &at,
+		},
+	}
+}
+
+func Test_controller_cleanupAbandoned(t *testing.T) {
+	expired := newAbandonedUR("expired", time.Now().Add(-2*time.Hour))
+	recent := newAbandonedUR("recent", time.Now().Add(-time.Minute))
+	client := fake.NewSimpleClientset(expired, recent)
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	require.NoError(t, indexer.Add(expired))
+	require.NoError(t, indexer.Add(recent))
+	c := &controller{
+		kyvernoClient: client,
+		urLister:      kyvernov2listers.NewUpdateRequestLister(indexer).UpdateRequests(config.KyvernoNamespace()),
+		queue:         newTestQueue(nil),
+		queueConfig:   QueueConfiguration{AbandonedTTL: time.Hour},
+	}
+	defer c.queue.ShutDown()
+	for _, ur := range []*kyvernov2.UpdateRequest{expired, recent} {
+		key, err := cache.MetaNamespaceKeyFunc(ur)
+		require.NoError(t, err)
+		assert.NoError(t, c.syncUpdateRequest(queueItem{key: key, requestType: kyvernov2.Generate, policy: "policy"}))
+	}
+	// the expired request is deleted, the recent one is kept until its time to live expired
+	_, err := client.KyvernoV2().UpdateRequests(config.KyvernoNamespace()).Get(context.TODO(), "expired", metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err))
+	_, err = client.KyvernoV2().UpdateRequests(config.KyvernoNamespace()).Get(context.TODO(), "recent", metav1.GetOptions{})
+	assert.NoError(t, err)
+	// without time to live abandoned requests are kept
+	old := newAbandonedUR("old", time.Now().Add(-48*time.Hour))
+	_, err = client.KyvernoV2().UpdateRequests(config.KyvernoNamespace()).Create(context.TODO(), old, metav1.CreateOptions{})
+	require.NoError(t, err)
+	require.NoError(t, indexer.Add(old))
+	c.queueConfig.AbandonedTTL = 0
+	key, err := cache.MetaNamespaceKeyFunc(old)
+	require.NoError(t, err)
+	assert.NoError(t, c.syncUpdateRequest(queueItem{key: key, requestType: kyvernov2.Generate, policy: "policy"}))
+	_, err = client.KyvernoV2().UpdateRequests(config.KyvernoNamespace()).Get(context.TODO(), "old", metav1.GetOptions{})
+	assert.NoError(t, err)
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/gobwas/glob"
 	"github.com/google/cel-go/cel"
 	"github.com/google/cel-go/common/types"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
 	"github.com/kyverno/kyverno/api/policies.kyverno.io/v1alpha1"
 	policiesv1alpha1 "github.com/kyverno/kyverno/api/policies.kyverno.io/v1alpha1"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
@@ -195,6 +196,40 @@ func CompileMatchImageReferences(path *field.Path, env *cel.Env, matches ...v1al
 	return result, allErrs
 }
 
+func CompileProjection(path *field.Path, env *cel.Env, projection kyvernov2alpha1.GlobalContextEntryProjection) (cel.Program, field.ErrorList) {
+	var allErrs field.ErrorList
+	{
+		path := path.Child("expression")
+		ast, issues := env.Compile(projection.Expression)
+		if err := issues.Err(); err != nil {
+			return nil, append(allErrs, field.Invalid(path, projection.Expression, err.Error()))
+		}
+		prog, err := env.Program(ast)
+		if err != nil {
+			return nil, append(allErrs, field.Invalid(path, projection.Expression, err.Error()))
+		}
+		return prog, allErrs
+	}
+}
+
+func CompileProjections(path *field.Path, env *cel.Env, projections ...kyvernov2alpha1.GlobalContextEntryProjection) (result map[string]cel.Program, allErrs field.ErrorList) {
+	if len(projections) == 0 {
+		return nil, nil
+	}
+	result = make(map[string]cel.Program, len(projections))
+	for i, projection := range projections {
+		if !projection.IsCEL() {
+			continue
+		}
+		prog, errs := CompileProjection(path.Index(i), env, projection)
+		allErrs = append(allErrs, errs...)
+		if prog != nil {
+			result[projection.Name] = prog
+		}
+	}
+	return result, allErrs
+}
+
 func compileGeneration(path *field.Path, env *cel.Env, generation policiesv1alpha1.Generation) (cel.Program, field.ErrorList) {
 	var allErrs field.ErrorList
 	{
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/google/cel-go/cel"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
 	policiesv1alpgha1 "github.com/kyverno/kyverno/api/policies.kyverno.io/v1alpha1"
 	"github.com/kyverno/kyverno/pkg/cel/libs/generator"
 	"github.com/stretchr/testify/assert"
@@ -708,3 +709,58 @@ generator.Apply(
 		})
 	}
 }
+
+func TestCompileProjections(t *testing.T) {
+	tests := []struct {
+		name        string
+		projections []kyvernov2alpha1.GlobalContextEntryProjection
+		wantProgs   int
+		wantErrs    field.ErrorList
+	}{{
+		name:        "empty",
+		projections: []kyvernov2alpha1.GlobalContextEntryProjection{},
+		wantProgs:   0,
+	}, {
+		name: "jmespath only",
+		projections: []kyvernov2alpha1.GlobalContextEntryProjection{{
+			Name:     "names",
+			JMESPath: "[].metadata.name",
+		}},
+		wantProgs: 0,
+	}, {
+		name: "valid",
+		projections: []kyvernov2alpha1.GlobalContextEntryProjection{{
+			Name:       "names",
+			Expression: "data.map(o, o.metadata.name)",
+		}, {
+			Name:     "count",
+			JMESPath: "length(@)",
+		}},
+		wantProgs: 1,
+	}, {
+		name: "invalid",
+		projections: []kyvernov2alpha1.GlobalContextEntryProjection{{
+			Name:     "count",
+			JMESPath: "length(@)",
+		}, {
+			Name:       "names",
+			Expression: "object.map(o, o.metadata.name)",
+		}},
+		wantProgs: 0,
+		wantErrs: field.ErrorList{{
+			Type:     field.ErrorTypeInvalid,
+			Field:    "[1].expression",
+			BadValue: "object.map(o, o.metadata.name)",
+			Detail:   "ERROR: <input>:1:1: undeclared reference to 'object' (in container '')\n | object.map(o, o.metadata.name)\n | ^",
+		}},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env, err := NewGlobalContextProjectionEnv()
+			assert.NoError(t, err)
+			gotProgs, gotErrs := CompileProjections(nil, env, tt.projections...)
+			assert.Equal(t, tt.wantErrs, gotErrs)
+			assert.Equal(t, tt.wantProgs, len(gotProgs))
+		})
+	}
+}
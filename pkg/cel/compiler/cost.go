@@ -0,0 +1,222 @@
+package compiler
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/checker"
+	"github.com/google/cel-go/common/types/ref"
+	policiesv1alpha1 "github.com/kyverno/kyverno/api/policies.kyverno.io/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	celconfig "k8s.io/apiserver/pkg/apis/cel"
+	"k8s.io/apiserver/pkg/cel/library"
+)
+
+// estimatedMaxSize is the size assumed for lists, maps and strings of unknown size when estimating
+// the cost of an expression, resources are untyped so their fields have no known bounds.
+const estimatedMaxSize = 1000
+
+// CostLimits bounds the runtime cost of the CEL expressions of a policy, zero values disable the corresponding limit.
+type CostLimits struct {
+	// Expression is the maximum runtime cost of a single expression evaluation.
+	Expression uint64
+	// Policy is the maximum runtime cost of all the expressions evaluated by a policy for a single resource.
+	Policy uint64
+}
+
+var defaultCostLimits = func() CostLimits {
+	return CostLimits{
+		Expression: celconfig.PerCallLimit,
+		Policy:     celconfig.RuntimeCELCostBudget,
+	}
+}
+
+// DefaultCostLimits returns the cluster wide cost limits.
+func DefaultCostLimits() CostLimits { return defaultCostLimits() }
+
+// SetDefaultCostLimits replaces the provider of the cluster wide cost limits, it is meant to be called once at startup.
+// The provider is called every time a policy is compiled.
+func SetDefaultCostLimits(provider func() CostLimits) { defaultCostLimits = provider }
+
+var (
+	costLimitsLock      sync.Mutex
+	costLimitsCallbacks []func()
+)
+
+// OnDefaultCostLimitsChanged adds a callback invoked when the cluster wide cost limits changed,
+// policies compiled with the previous limits are expected to be compiled again.
+func OnDefaultCostLimitsChanged(callback func()) {
+	costLimitsLock.Lock()
+	defer costLimitsLock.Unlock()
+	costLimitsCallbacks = append(costLimitsCallbacks, callback)
+}
+
+// NotifyDefaultCostLimitsChanged invokes the callbacks registered with OnDefaultCostLimitsChanged.
+func NotifyDefaultCostLimitsChanged() {
+	costLimitsLock.Lock()
+	callbacks := slices.Clone(costLimitsCallbacks)
+	costLimitsLock.Unlock()
+	for _, callback := range callbacks {
+		callback()
+	}
+}
+
+// PolicyCostLimits returns the cost limits of a policy, the limits set in the policy override the cluster wide ones.
+func PolicyCostLimits(config *policiesv1alpha1.CostConfiguration) CostLimits {
+	limits := DefaultCostLimits()
+	if config == nil {
+		return limits
+	}
+	if config.ExpressionLimit != nil && *config.ExpressionLimit > 0 {
+		limits.Expression = uint64(*config.ExpressionLimit)
+	}
+	if config.PolicyLimit != nil && *config.PolicyLimit > 0 {
+		limits.Policy = uint64(*config.PolicyLimit)
+	}
+	return limits
+}
+
+// CostProgramOptions returns the program options tracking the runtime cost of an expression,
+// the evaluation is interrupted when it exceeds the limit unless the limit is zero.
+func CostProgramOptions(limit uint64) []cel.ProgramOption {
+	options := []cel.ProgramOption{
+		cel.CostTracking(&library.CostEstimator{}),
+	}
+	if limit > 0 {
+		options = append(options, cel.CostLimit(limit))
+	}
+	return options
+}
+
+type costLib struct {
+	limit uint64
+}
+
+// CostLib returns a library applying CostProgramOptions to all the programs of an environment.
+func CostLib(limit uint64) cel.EnvOption {
+	return cel.Lib(&costLib{limit: limit})
+}
+
+func (*costLib) LibraryName() string {
+	return "kyverno.cost"
+}
+
+func (*costLib) CompileOptions() []cel.EnvOption {
+	return nil
+}
+
+func (l *costLib) ProgramOptions() []cel.ProgramOption {
+	return CostProgramOptions(l.limit)
+}
+
+// Budget accumulates the runtime cost of the expressions evaluated by a policy for a single resource.
+type Budget struct {
+	limit uint64
+	cost  atomic.Uint64
+}
+
+// NewBudget returns a budget failing evaluations once the limit is exceeded, zero disables the limit.
+func NewBudget(limit uint64) *Budget {
+	return &Budget{limit: limit}
+}
+
+// ContextEval evaluates a program and charges its cost to the budget.
+func (b *Budget) ContextEval(ctx context.Context, program cel.Program, input any) (ref.Val, *cel.EvalDetails, error) {
+	out, details, err := program.ContextEval(ctx, input)
+	if details != nil && details.ActualCost() != nil {
+		if err := b.Charge(*details.ActualCost()); err != nil {
+			return nil, details, err
+		}
+	}
+	return out, details, err
+}
+
+// Charge charges the cost of an evaluation made outside of the budget, it fails once the limit is exceeded.
+func (b *Budget) Charge(cost uint64) error {
+	total := b.cost.Add(cost)
+	if b.limit > 0 && total > b.limit {
+		return fmt.Errorf("policy runtime cost budget exceeded, cost %d exceeds the limit %d", total, b.limit)
+	}
+	return nil
+}
+
+// Cost returns the cost charged to the budget.
+func (b *Budget) Cost() uint64 {
+	return b.cost.Load()
+}
+
+// CostEstimate is the static cost estimate of an expression.
+type CostEstimate struct {
+	Path       *field.Path
+	Expression string
+	checker.CostEstimate
+}
+
+// CostEstimates are the static cost estimates of the expressions of a policy.
+type CostEstimates []CostEstimate
+
+// EstimateCost compiles an expression and estimates its cost.
+func EstimateCost(path *field.Path, env *cel.Env, expression string) (CostEstimate, error) {
+	ast, issues := env.Compile(expression)
+	if err := issues.Err(); err != nil {
+		return CostEstimate{}, err
+	}
+	estimate, err := env.EstimateCost(ast, &library.CostEstimator{SizeEstimator: sizeEstimator{}})
+	if err != nil {
+		return CostEstimate{}, err
+	}
+	return CostEstimate{Path: path, Expression: expression, CostEstimate: estimate}, nil
+}
+
+// Check compares the estimates with the limits, an expression that always exceeds its limit is an error
+// and an expression that may exceed it is a warning.
+func (e CostEstimates) Check(limits CostLimits) ([]string, field.ErrorList) {
+	var warnings []string
+	var errs field.ErrorList
+	var policy checker.CostEstimate
+	for _, estimate := range e {
+		policy = policy.Add(estimate.CostEstimate)
+		if limits.Expression == 0 {
+			continue
+		}
+		if estimate.Min > limits.Expression {
+			errs = append(errs, field.Invalid(estimate.Path, estimate.Expression, fmt.Sprintf("estimated cost %d exceeds the expression cost limit %d", estimate.Min, limits.Expression)))
+		} else if estimate.Max > limits.Expression {
+			warnings = append(warnings, fmt.Sprintf("%s: estimated worst case cost %s exceeds the expression cost limit %d", estimate.Path, formatCost(estimate.Max), limits.Expression))
+		}
+	}
+	if limits.Policy > 0 {
+		if policy.Min > limits.Policy {
+			errs = append(errs, field.Forbidden(field.NewPath("spec"), fmt.Sprintf("estimated cost %d exceeds the policy cost limit %d", policy.Min, limits.Policy)))
+		} else if policy.Max > limits.Policy {
+			warnings = append(warnings, fmt.Sprintf("spec: estimated worst case cost %s exceeds the policy cost limit %d", formatCost(policy.Max), limits.Policy))
+		}
+	}
+	return warnings, errs
+}
+
+func formatCost(cost uint64) string {
+	if cost == math.MaxUint64 {
+		return "(unbounded)"
+	}
+	return strconv.FormatUint(cost, 10)
+}
+
+type sizeEstimator struct{}
+
+func (sizeEstimator) EstimateSize(element checker.AstNode) *checker.SizeEstimate {
+	if element.ComputedSize() != nil {
+		return nil
+	}
+	return &checker.SizeEstimate{Min: 0, Max: estimatedMaxSize}
+}
+
+func (sizeEstimator) EstimateCallCost(function, overloadID string, target *checker.AstNode, args []checker.AstNode) *checker.CallEstimate {
+	return nil
+}
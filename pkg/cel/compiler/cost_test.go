@@ -0,0 +1,160 @@
+package compiler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/cel-go/cel"
+	policiesv1alpha1 "github.com/kyverno/kyverno/api/policies.kyverno.io/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+)
+
+func newCostEnv(t *testing.T, limit uint64) *cel.Env {
+	base, err := NewBaseEnv()
+	assert.NoError(t, err)
+	env, err := base.Extend(
+		cel.Variable(ObjectKey, cel.DynType),
+		CostLib(limit),
+	)
+	assert.NoError(t, err)
+	return env
+}
+
+func compileProgram(t *testing.T, env *cel.Env, expression string) cel.Program {
+	ast, issues := env.Compile(expression)
+	assert.NoError(t, issues.Err())
+	program, err := env.Program(ast)
+	assert.NoError(t, err)
+	return program
+}
+
+func TestPolicyCostLimits(t *testing.T) {
+	defaults := DefaultCostLimits()
+	tests := []struct {
+		name   string
+		config *policiesv1alpha1.CostConfiguration
+		want   CostLimits
+	}{{
+		name: "nil",
+		want: defaults,
+	}, {
+		name:   "expression override",
+		config: &policiesv1alpha1.CostConfiguration{ExpressionLimit: ptr.To[int64](100)},
+		want:   CostLimits{Expression: 100, Policy: defaults.Policy},
+	}, {
+		name:   "both overrides",
+		config: &policiesv1alpha1.CostConfiguration{ExpressionLimit: ptr.To[int64](100), PolicyLimit: ptr.To[int64](200)},
+		want:   CostLimits{Expression: 100, Policy: 200},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, PolicyCostLimits(tt.config))
+		})
+	}
+}
+
+func TestOnDefaultCostLimitsChanged(t *testing.T) {
+	var calls int
+	OnDefaultCostLimitsChanged(func() { calls++ })
+	NotifyDefaultCostLimitsChanged()
+	NotifyDefaultCostLimitsChanged()
+	assert.Equal(t, 2, calls)
+}
+
+func TestCostLib(t *testing.T) {
+	data := map[string]any{ObjectKey: map[string]any{"items": []any{1, 2, 3, 4, 5}}}
+	// the limit interrupts the evaluation
+	program := compileProgram(t, newCostEnv(t, 5), `object.items.all(x, object.items.all(y, x + y > 0))`)
+	_, _, err := program.ContextEval(context.TODO(), data)
+	assert.ErrorContains(t, err, "cost limit exceeded")
+	// a zero limit only tracks the cost
+	program = compileProgram(t, newCostEnv(t, 0), `object.items.all(x, object.items.all(y, x + y > 0))`)
+	out, details, err := program.ContextEval(context.TODO(), data)
+	assert.NoError(t, err)
+	assert.Equal(t, true, out.Value())
+	assert.NotNil(t, details.ActualCost())
+	assert.Greater(t, *details.ActualCost(), uint64(5))
+}
+
+func TestBudget(t *testing.T) {
+	env := newCostEnv(t, 0)
+	program := compileProgram(t, env, `object.items.all(x, x > 0)`)
+	data := map[string]any{ObjectKey: map[string]any{"items": []any{1, 2, 3}}}
+	budget := NewBudget(0)
+	_, _, err := budget.ContextEval(context.TODO(), program, data)
+	assert.NoError(t, err)
+	cost := budget.Cost()
+	assert.NotZero(t, cost)
+	// the second evaluation exceeds a budget allowing a single one
+	budget = NewBudget(cost + 1)
+	_, _, err = budget.ContextEval(context.TODO(), program, data)
+	assert.NoError(t, err)
+	_, _, err = budget.ContextEval(context.TODO(), program, data)
+	assert.ErrorContains(t, err, "policy runtime cost budget exceeded")
+	assert.Equal(t, 2*cost, budget.Cost())
+	// costs charged outside of the budget count towards the limit
+	budget = NewBudget(10)
+	assert.NoError(t, budget.Charge(10))
+	assert.ErrorContains(t, budget.Charge(1), "policy runtime cost budget exceeded")
+	assert.Equal(t, uint64(11), budget.Cost())
+	// the recorded cost is reported to the recorder of the context
+	var recorded []uint64
+	ctx := WithCostRecorder(context.TODO(), func(policyType string, policyName string, cost uint64) {
+		assert.Equal(t, "ValidatingPolicy", policyType)
+		assert.Equal(t, "policy", policyName)
+		recorded = append(recorded, cost)
+	})
+	budget.Record(ctx, "ValidatingPolicy", "policy")
+	budget.Record(context.TODO(), "ValidatingPolicy", "policy")
+	assert.Equal(t, []uint64{11}, recorded)
+}
+
+func TestCostEstimatesCheck(t *testing.T) {
+	env := newCostEnv(t, 0)
+	estimate := func(expression string) CostEstimate {
+		estimate, err := EstimateCost(field.NewPath("spec", "validations").Index(0).Child("expression"), env, expression)
+		assert.NoError(t, err)
+		return estimate
+	}
+	cheap := estimate(`object.metadata.name == "foo"`)
+	nested := estimate(`object.spec.containers.all(x, object.spec.containers.all(y, x.name != y.name || x == y))`)
+	literal := estimate(`[1, 2, 3, 4, 5, 6, 7, 8, 9, 10].all(x, x > 0)`)
+	tests := []struct {
+		name         string
+		estimates    CostEstimates
+		limits       CostLimits
+		wantWarnings int
+		wantErrs     int
+	}{{
+		name:      "cheap",
+		estimates: CostEstimates{cheap},
+		limits:    CostLimits{Expression: 1000000, Policy: 10000000},
+	}, {
+		name:         "nested comprehension may exceed the limits",
+		estimates:    CostEstimates{cheap, nested},
+		limits:       CostLimits{Expression: 1000000, Policy: 10000000},
+		wantWarnings: 2,
+	}, {
+		name:      "disabled limits",
+		estimates: CostEstimates{cheap, nested},
+	}, {
+		name:      "literal always exceeds the limit",
+		estimates: CostEstimates{literal},
+		limits:    CostLimits{Expression: 5},
+		wantErrs:  1,
+	}, {
+		name:      "policy always exceeds the limit",
+		estimates: CostEstimates{literal, literal},
+		limits:    CostLimits{Policy: literal.Min + 1},
+		wantErrs:  1,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings, errs := tt.estimates.Check(tt.limits)
+			assert.Len(t, warnings, tt.wantWarnings)
+			assert.Len(t, errs, tt.wantErrs)
+		})
+	}
+}
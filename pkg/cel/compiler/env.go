@@ -4,6 +4,7 @@ import (
 	"github.com/google/cel-go/cel"
 	"github.com/google/cel-go/ext"
 	"github.com/kyverno/kyverno/pkg/cel/libs/image"
+	"github.com/kyverno/kyverno/pkg/cel/libs/pss"
 	"k8s.io/apiserver/pkg/cel/library"
 )
 
@@ -31,6 +32,8 @@ func NewBaseEnv() (*cel.Env, error) {
 		library.Lists(),
 		library.Regex(),
 		library.URLs(),
+		// register kyverno libs
+		pss.Lib(),
 	)
 }
 
@@ -44,3 +47,13 @@ func NewMatchImageEnv() (*cel.Env, error) {
 		image.Lib(),
 	)
 }
+
+func NewGlobalContextProjectionEnv() (*cel.Env, error) {
+	base, err := NewBaseEnv()
+	if err != nil {
+		return nil, err
+	}
+	return base.Extend(
+		cel.Variable(DataKey, cel.DynType),
+	)
+}
@@ -17,3 +17,9 @@ func TestNewMatchImageEnv(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, got)
 }
+
+func TestNewGlobalContextProjectionEnv(t *testing.T) {
+	got, err := NewGlobalContextProjectionEnv()
+	assert.NoError(t, err)
+	assert.NotNil(t, got)
+}
@@ -3,12 +3,14 @@ package compiler
 const (
 	AttestationsKey    = "attestations"
 	AttestorsKey       = "attestors"
+	DataKey            = "data"
 	GlobalContextKey   = "globalContext"
 	HttpKey            = "http"
 	ImageDataKey       = "image"
 	ImageRefKey        = "ref"
 	ImagesKey          = "images"
 	NamespaceObjectKey = "namespaceObject"
+	OCIKey             = "oci"
 	ObjectKey          = "object"
 	OldObjectKey       = "oldObject"
 	RequestKey         = "request"
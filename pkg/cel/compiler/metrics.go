@@ -0,0 +1,51 @@
+package compiler
+
+import (
+	"context"
+	"sync"
+
+	"github.com/kyverno/kyverno/pkg/logging"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+var policyCost = sync.OnceValue(func() metric.Int64Histogram {
+	logger := logging.WithName("cel-cost")
+	meter := otel.GetMeterProvider().Meter(metrics.MeterName)
+	policyCost, err := meter.Int64Histogram(
+		"kyverno_cel_policy_cost",
+		metric.WithDescription("can be used to track the runtime cost of the CEL expressions evaluated by a policy for a resource"),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_cel_policy_cost")
+	}
+	return policyCost
+})
+
+// CostRecorder receives the cost charged to the budget of a policy evaluation.
+type CostRecorder func(policyType string, policyName string, cost uint64)
+
+type costRecorderKey struct{}
+
+// WithCostRecorder returns a context in which the cost recorded by the budgets is also reported to the recorder.
+func WithCostRecorder(ctx context.Context, recorder CostRecorder) context.Context {
+	return context.WithValue(ctx, costRecorderKey{}, recorder)
+}
+
+// Record records the cost charged to the budget in the policy cost histogram and the cost recorder of the context.
+func (b *Budget) Record(ctx context.Context, policyType string, policyName string) {
+	if recorder, ok := ctx.Value(costRecorderKey{}).(CostRecorder); ok && recorder != nil {
+		recorder(policyType, policyName, b.Cost())
+	}
+	histogram := policyCost()
+	if histogram == nil {
+		return
+	}
+	// the request context may be expired, metrics must still be recorded
+	histogram.Record(context.WithoutCancel(ctx), int64(b.Cost()), metric.WithAttributes(
+		attribute.String("policy_type", policyType),
+		attribute.String("policy_name", policyName),
+	))
+}
@@ -0,0 +1,39 @@
+package engine
+
+import (
+	"context"
+
+	"github.com/kyverno/kyverno/pkg/cel/compiler"
+	"github.com/kyverno/kyverno/pkg/logging"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// CostLimitsSource returns a source reconciling all the policies of the list type when the cluster wide cost limits changed,
+// it lets the providers caching compiled policies compile them again with the new limits.
+// The policies are added to the queue directly so that nothing blocks once the source is stopped.
+func CostLimitsSource(c client.Reader, list client.ObjectList) source.Source {
+	return source.Func(func(ctx context.Context, queue workqueue.TypedRateLimitingInterface[reconcile.Request]) error {
+		compiler.OnDefaultCostLimitsChanged(func() {
+			if ctx.Err() != nil {
+				return
+			}
+			go func() {
+				list := list.DeepCopyObject().(client.ObjectList)
+				if err := c.List(ctx, list); err != nil {
+					logging.Error(err, "failed to list policies to compile with the new cost limits")
+					return
+				}
+				_ = meta.EachListItem(list, func(object runtime.Object) error {
+					queue.Add(reconcile.Request{NamespacedName: client.ObjectKeyFromObject(object.(client.Object))})
+					return nil
+				})
+			}()
+		})
+		return nil
+	})
+}
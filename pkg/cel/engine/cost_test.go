@@ -0,0 +1,47 @@
+package engine
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	policiesv1alpha1 "github.com/kyverno/kyverno/api/policies.kyverno.io/v1alpha1"
+	"github.com/kyverno/kyverno/pkg/cel/compiler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestCostLimitsSource(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, policiesv1alpha1.AddToScheme(scheme))
+	client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&policiesv1alpha1.ValidatingPolicy{ObjectMeta: metav1.ObjectMeta{Name: "first"}},
+		&policiesv1alpha1.ValidatingPolicy{ObjectMeta: metav1.ObjectMeta{Name: "second"}},
+	).Build()
+	source := CostLimitsSource(client, &policiesv1alpha1.ValidatingPolicyList{})
+	queue := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+	defer queue.ShutDown()
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	require.NoError(t, source.Start(ctx, queue))
+	// nothing is reconciled until the limits changed
+	assert.Never(t, func() bool { return queue.Len() != 0 }, 100*time.Millisecond, 10*time.Millisecond)
+	compiler.NotifyDefaultCostLimitsChanged()
+	assert.Eventually(t, func() bool { return queue.Len() == 2 }, time.Second, 10*time.Millisecond)
+	var names []string
+	for queue.Len() != 0 {
+		request, _ := queue.Get()
+		names = append(names, request.Name)
+		queue.Done(request)
+	}
+	assert.ElementsMatch(t, []string{"first", "second"}, names)
+	// nothing is queued once the source is stopped
+	cancel()
+	compiler.NotifyDefaultCostLimitsChanged()
+	assert.Never(t, func() bool { return queue.Len() != 0 }, 100*time.Millisecond, 10*time.Millisecond)
+}
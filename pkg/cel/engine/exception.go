@@ -1,7 +1,11 @@
 package engine
 
 import (
+	"context"
+	"time"
+
 	policiesv1alpha1 "github.com/kyverno/kyverno/api/policies.kyverno.io/v1alpha1"
+	"github.com/kyverno/kyverno/pkg/toggle"
 	"k8s.io/apimachinery/pkg/labels"
 )
 
@@ -14,8 +18,14 @@ func ListExceptions(lister policyExceptionLister, kind, name string) ([]*policie
 	if err != nil {
 		return nil, err
 	}
+	now := time.Now()
+	approvalRequired := toggle.FromContext(context.TODO()).RequireExceptionApproval()
 	var out []*policiesv1alpha1.PolicyException
 	for _, exception := range exceptions {
+		// expired, not yet valid or unapproved exceptions are not applied
+		if !exception.IsActive(now, approvalRequired) {
+			continue
+		}
 		for _, ref := range exception.Spec.PolicyRefs {
 			if ref.Name == name && ref.Kind == kind {
 				out = append(out, exception)
@@ -3,9 +3,11 @@ package engine
 import (
 	"errors"
 	"testing"
+	"time"
 
 	policiesv1alpha1 "github.com/kyverno/kyverno/api/policies.kyverno.io/v1alpha1"
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 )
 
@@ -82,6 +84,56 @@ func TestListExceptions(t *testing.T) {
 				}},
 			},
 		}},
+	}, {
+		name: "expired",
+		lister: &fakePolicyExceptionLister{
+			exceptions: []*policiesv1alpha1.PolicyException{{
+				Spec: policiesv1alpha1.PolicyExceptionSpec{
+					PolicyRefs: []policiesv1alpha1.PolicyRef{{
+						Kind: "foo",
+						Name: "bar",
+					}},
+					NotAfter: &metav1.Time{Time: time.Now().Add(-time.Hour)},
+				},
+			}},
+		},
+		policyKind: "foo",
+		policyName: "bar",
+	}, {
+		name: "not yet valid",
+		lister: &fakePolicyExceptionLister{
+			exceptions: []*policiesv1alpha1.PolicyException{{
+				Spec: policiesv1alpha1.PolicyExceptionSpec{
+					PolicyRefs: []policiesv1alpha1.PolicyRef{{
+						Kind: "foo",
+						Name: "bar",
+					}},
+					NotBefore: &metav1.Time{Time: time.Now().Add(time.Hour)},
+				},
+			}},
+		},
+		policyKind: "foo",
+		policyName: "bar",
+	}, {
+		name: "rejected",
+		lister: &fakePolicyExceptionLister{
+			exceptions: []*policiesv1alpha1.PolicyException{{
+				Spec: policiesv1alpha1.PolicyExceptionSpec{
+					PolicyRefs: []policiesv1alpha1.PolicyRef{{
+						Kind: "foo",
+						Name: "bar",
+					}},
+				},
+				Status: policiesv1alpha1.PolicyExceptionStatus{
+					Conditions: []metav1.Condition{{
+						Type:   policiesv1alpha1.PolicyExceptionConditionApproved,
+						Status: metav1.ConditionFalse,
+					}},
+				},
+			}},
+		},
+		policyKind: "foo",
+		policyName: "bar",
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
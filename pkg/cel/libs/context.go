@@ -3,12 +3,14 @@ package libs
 import (
 	"context"
 	"errors"
+	"slices"
 
 	"github.com/kyverno/kyverno/api/kyverno"
 	"github.com/kyverno/kyverno/pkg/background/common"
 	"github.com/kyverno/kyverno/pkg/cel/libs/generator"
 	"github.com/kyverno/kyverno/pkg/cel/libs/globalcontext"
 	"github.com/kyverno/kyverno/pkg/cel/libs/imagedata"
+	"github.com/kyverno/kyverno/pkg/cel/libs/oci"
 	"github.com/kyverno/kyverno/pkg/cel/libs/resource"
 	"github.com/kyverno/kyverno/pkg/cel/utils"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
@@ -16,6 +18,7 @@ import (
 	gctxstore "github.com/kyverno/kyverno/pkg/globalcontext/store"
 	"github.com/kyverno/kyverno/pkg/imageverification/imagedataloader"
 	"github.com/kyverno/kyverno/pkg/logging"
+	"github.com/kyverno/kyverno/pkg/registryclient"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -30,9 +33,12 @@ type Context interface {
 	imagedata.ContextInterface
 	resource.ContextInterface
 	generator.ContextInterface
+	oci.ContextInterface
 
 	GetGeneratedResources() []*unstructured.Unstructured
 	ClearGeneratedResources()
+	GetFetchedSources() []oci.Source
+	ClearFetchedSources()
 	SetGenerateContext(polName, triggerName, triggerNamespace, triggerAPIVersion, triggerGroup, triggerKind, triggerUID string, restoreCache bool)
 }
 
@@ -50,8 +56,10 @@ type generateContext struct {
 type contextProvider struct {
 	client             dclient.Interface
 	imagedata          imagedataloader.Fetcher
+	oci                oci.Fetcher
 	gctxStore          gctxstore.Store
 	generatedResources []*unstructured.Unstructured
+	fetchedSources     []oci.Source
 	genCtx             generateContext
 	cliEvaluation      bool
 }
@@ -59,6 +67,7 @@ type contextProvider struct {
 func NewContextProvider(
 	client dclient.Interface,
 	imageOpts []imagedataloader.Option,
+	rclient registryclient.Client,
 	gctxStore gctxstore.Store,
 	cliEvaluation bool,
 ) (Context, error) {
@@ -66,9 +75,17 @@ func NewContextProvider(
 	if err != nil {
 		return nil, err
 	}
+	// fallback to an anonymous registry client
+	if rclient == nil {
+		rclient, err = registryclient.New()
+		if err != nil {
+			return nil, err
+		}
+	}
 	return &contextProvider{
 		client:             client,
 		imagedata:          idl,
+		oci:                oci.NewFetcher(rclient, oci.DefaultResolutionTTL),
 		gctxStore:          gctxStore,
 		cliEvaluation:      cliEvaluation,
 		generatedResources: make([]*unstructured.Unstructured, 0),
@@ -203,6 +220,17 @@ func (cp *contextProvider) GenerateResources(namespace string, dataList []map[st
 	return nil
 }
 
+func (cp *contextProvider) FetchManifests(ref string) ([]map[string]any, error) {
+	artifact, err := cp.oci.Fetch(context.TODO(), ref)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(cp.fetchedSources, artifact.Source) {
+		cp.fetchedSources = append(cp.fetchedSources, artifact.Source)
+	}
+	return artifact.Manifests, nil
+}
+
 func (cp *contextProvider) addGenerateLabels(obj *unstructured.Unstructured) {
 	labels := obj.GetLabels()
 	if labels == nil {
@@ -248,6 +276,14 @@ func (cp *contextProvider) ClearGeneratedResources() {
 	cp.generatedResources = make([]*unstructured.Unstructured, 0)
 }
 
+func (cp *contextProvider) GetFetchedSources() []oci.Source {
+	return cp.fetchedSources
+}
+
+func (cp *contextProvider) ClearFetchedSources() {
+	cp.fetchedSources = nil
+}
+
 func (cp *contextProvider) getResourceClient(groupVersion schema.GroupVersion, resource string, namespace string) dynamic.ResourceInterface {
 	client := cp.client.GetDynamicInterface().Resource(groupVersion.WithResource(resource))
 	if namespace != "" {
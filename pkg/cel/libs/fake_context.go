@@ -3,6 +3,7 @@ package libs
 import (
 	"fmt"
 
+	"github.com/kyverno/kyverno/pkg/cel/libs/oci"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -119,6 +120,16 @@ func (cp *FakeContextProvider) GenerateResources(namespace string, dataList []ma
 	return nil
 }
 
+func (cp *FakeContextProvider) FetchManifests(string) ([]map[string]any, error) {
+	panic("not implemented")
+}
+
+func (cp *FakeContextProvider) GetFetchedSources() []oci.Source {
+	return nil
+}
+
+func (cp *FakeContextProvider) ClearFetchedSources() {}
+
 func (cp *FakeContextProvider) GetGeneratedResources() []*unstructured.Unstructured {
 	return cp.generatedResources
 }
@@ -9,7 +9,9 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"time"
 
+	"github.com/kyverno/kyverno/pkg/httpguard"
 	"github.com/kyverno/kyverno/pkg/tracing"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
@@ -19,27 +21,35 @@ type ClientInterface interface {
 }
 
 type contextImpl struct {
-	client ClientInterface
+	client   ClientInterface
+	caBundle string
+	timeout  time.Duration
 }
 
-func NewHTTP(client ClientInterface) ContextInterface {
+type Option func(*contextImpl)
+
+// WithTimeout sets the timeout of the requests, overriding the default timeout of the http guard.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *contextImpl) {
+		c.timeout = timeout
+	}
+}
+
+func NewHTTP(client ClientInterface, opts ...Option) ContextInterface {
 	if client == nil {
 		client = http.DefaultClient
 	}
-	return &contextImpl{
+	c := &contextImpl{
 		client: client,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
 func (r *contextImpl) Get(url string, headers map[string]string) (any, error) {
-	req, err := http.NewRequestWithContext(context.TODO(), "GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %v", err)
-	}
-	for h, v := range headers {
-		req.Header.Add(h, v)
-	}
-	return r.executeRequest(r.client, req)
+	return r.executeRequest(r.client, "GET", url, nil, headers)
 }
 
 func (r *contextImpl) Post(url string, data any, headers map[string]string) (any, error) {
@@ -47,27 +57,46 @@ func (r *contextImpl) Post(url string, data any, headers map[string]string) (any
 	if err != nil {
 		return nil, fmt.Errorf("failed to encode request data: %v", err)
 	}
-	req, err := http.NewRequestWithContext(context.TODO(), "POST", url, body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %v", err)
-	}
-	for h, v := range headers {
-		req.Header.Add(h, v)
-	}
-	return r.executeRequest(r.client, req)
+	return r.executeRequest(r.client, "POST", url, body, headers)
 }
 
-func (r *contextImpl) executeRequest(client ClientInterface, req *http.Request) (any, error) {
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("request failed: %v", err)
+func (r *contextImpl) executeRequest(client ClientInterface, method string, url string, data []byte, headers map[string]string) (any, error) {
+	request := httpguard.Request{
+		Engine:   httpguard.EngineCEL,
+		Method:   method,
+		URL:      url,
+		Body:     data,
+		Headers:  headers,
+		CABundle: r.caBundle,
+		Timeout:  r.timeout,
 	}
-	defer resp.Body.Close()
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("HTTP %s", resp.Status)
+	raw, err := httpguard.Default().Do(context.TODO(), request, func(ctx context.Context) ([]byte, error) {
+		var body io.Reader
+		if data != nil {
+			body = bytes.NewReader(data)
+		}
+		req, err := http.NewRequestWithContext(ctx, method, url, body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %v", err)
+		}
+		for h, v := range headers {
+			req.Header.Add(h, v)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("request failed: %v", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return nil, fmt.Errorf("HTTP %s", resp.Status)
+		}
+		return io.ReadAll(resp.Body)
+	})
+	if err != nil {
+		return nil, err
 	}
 	var body any
-	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+	if err := json.Unmarshal(raw, &body); err != nil {
 		return nil, fmt.Errorf("Unable to decode JSON body %v", err)
 	}
 	return body, nil
@@ -91,13 +120,15 @@ func (r *contextImpl) Client(caBundle string) (ContextInterface, error) {
 		client: &http.Client{
 			Transport: tracing.Transport(transport, otelhttp.WithFilter(tracing.RequestFilterIsInSpan)),
 		},
+		caBundle: caBundle,
+		timeout:  r.timeout,
 	}, nil
 }
 
-func buildRequestData(data any) (io.Reader, error) {
+func buildRequestData(data any) ([]byte, error) {
 	buffer := new(bytes.Buffer)
 	if err := json.NewEncoder(buffer).Encode(data); err != nil {
 		return nil, fmt.Errorf("failed to encode HTTP POST data %v: %w", data, err)
 	}
-	return buffer, nil
+	return buffer.Bytes(), nil
 }
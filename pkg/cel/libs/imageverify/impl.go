@@ -14,6 +14,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/imageverification/imagedataloader"
 	"github.com/kyverno/kyverno/pkg/imageverification/imageverifiers/cosign"
 	"github.com/kyverno/kyverno/pkg/imageverification/imageverifiers/notary"
+	"github.com/kyverno/kyverno/pkg/imageverifycache"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	k8scorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 )
@@ -23,6 +24,8 @@ type ivfuncs struct {
 
 	logger          logr.Logger
 	imgCtx          imagedataloader.ImageContext
+	ivpol           *v1alpha1.ImageValidatingPolicy
+	ivCache         imageverifycache.Client
 	creds           *v1alpha1.Credentials
 	imgRules        []compiler.MatchImageReference
 	attestationList map[string]v1alpha1.Attestation
@@ -35,6 +38,7 @@ func ImageVerifyCELFuncs(
 	imgCtx imagedataloader.ImageContext,
 	ivpol *v1alpha1.ImageValidatingPolicy,
 	lister k8scorev1.SecretInterface,
+	ivCache imageverifycache.Client,
 	adapter types.Adapter,
 ) (*ivfuncs, error) {
 	if ivpol == nil {
@@ -50,7 +54,10 @@ func ImageVerifyCELFuncs(
 	}
 	return &ivfuncs{
 		Adapter:         adapter,
+		logger:          logger,
 		imgCtx:          imgCtx,
+		ivpol:           ivpol,
+		ivCache:         ivCache,
 		creds:           ivpol.Spec.Credentials,
 		imgRules:        imgRules,
 		attestationList: attestationMap(ivpol),
@@ -73,6 +80,11 @@ func (f *ivfuncs) verify_image_signature_string_stringarray(image ref.Val, attes
 			return f.NativeToValue(count)
 		}
 		for _, attestor := range attestors {
+			ruleName := "signature/" + attestor.Name
+			if f.getCache(ctx, ruleName, attestor, image) {
+				count += 1
+				continue
+			}
 			opts := GetRemoteOptsFromPolicy(f.creds)
 			img, err := f.imgCtx.Get(ctx, image, opts...)
 			if err != nil {
@@ -84,6 +96,7 @@ func (f *ivfuncs) verify_image_signature_string_stringarray(image ref.Val, attes
 					f.logger.Info("failed to verify image cosign: %v", err)
 				} else {
 					count += 1
+					f.setCache(ctx, ruleName, attestor, image)
 				}
 			} else if attestor.IsNotary() {
 				var certs, tsaCerts string
@@ -97,6 +110,7 @@ func (f *ivfuncs) verify_image_signature_string_stringarray(image ref.Val, attes
 					f.logger.Info("failed to verify image notary: %v", err)
 				} else {
 					count += 1
+					f.setCache(ctx, ruleName, attestor, image)
 				}
 			}
 		}
@@ -127,6 +141,11 @@ func (f *ivfuncs) verify_image_attestations_string_string_stringarray(args ...re
 			if !ok {
 				return types.NewErr("attestation not found in policy: %s", attestation)
 			}
+			ruleName := "attestation/" + attestation + "/" + attestor.Name
+			if f.getCache(ctx, ruleName, []any{attest, attestor}, image) {
+				count += 1
+				continue
+			}
 			opts := GetRemoteOptsFromPolicy(f.creds)
 			img, err := f.imgCtx.Get(ctx, image, opts...)
 			if err != nil {
@@ -137,6 +156,7 @@ func (f *ivfuncs) verify_image_attestations_string_string_stringarray(args ...re
 					f.logger.Info("failed to verify attestation cosign: %v", err)
 				} else {
 					count += 1
+					f.setCache(ctx, ruleName, []any{attest, attestor}, image)
 				}
 			} else if attestor.IsNotary() {
 				if attest.Referrer == nil {
@@ -153,6 +173,7 @@ func (f *ivfuncs) verify_image_attestations_string_string_stringarray(args ...re
 					f.logger.Info("failed to verify attestation notary: %v", err)
 				} else {
 					count += 1
+					f.setCache(ctx, ruleName, []any{attest, attestor}, image)
 				}
 			}
 		}
@@ -160,6 +181,27 @@ func (f *ivfuncs) verify_image_attestations_string_string_stringarray(args ...re
 	}
 }
 
+func (f *ivfuncs) getCache(ctx context.Context, ruleName string, attestors any, image string) bool {
+	if f.ivCache == nil {
+		return false
+	}
+	found, err := f.ivCache.Get(ctx, f.ivpol, ruleName, attestors, image, true)
+	if err != nil {
+		f.logger.Error(err, "error occurred during cache get", "image", image)
+		return false
+	}
+	return found
+}
+
+func (f *ivfuncs) setCache(ctx context.Context, ruleName string, attestors any, image string) {
+	if f.ivCache == nil {
+		return
+	}
+	if _, err := f.ivCache.Set(ctx, f.ivpol, ruleName, attestors, image, true); err != nil {
+		f.logger.Error(err, "error occurred during cache set", "image", image)
+	}
+}
+
 func (f *ivfuncs) payload_string_string(image ref.Val, attestation ref.Val) ref.Val {
 	ctx := context.TODO()
 	if image, err := utils.ConvertToNative[string](image); err != nil {
@@ -6,6 +6,7 @@ import (
 	"github.com/google/cel-go/common/types"
 	"github.com/kyverno/kyverno/api/policies.kyverno.io/v1alpha1"
 	"github.com/kyverno/kyverno/pkg/imageverification/imagedataloader"
+	"github.com/kyverno/kyverno/pkg/imageverifycache"
 	apiservercel "k8s.io/apiserver/pkg/cel"
 	k8scorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 )
@@ -13,18 +14,20 @@ import (
 const libraryName = "kyverno.imageverify"
 
 type lib struct {
-	logger logr.Logger
-	imgCtx imagedataloader.ImageContext
-	ivpol  *v1alpha1.ImageValidatingPolicy
-	lister k8scorev1.SecretInterface
+	logger  logr.Logger
+	imgCtx  imagedataloader.ImageContext
+	ivpol   *v1alpha1.ImageValidatingPolicy
+	lister  k8scorev1.SecretInterface
+	ivCache imageverifycache.Client
 }
 
-func Lib(imgCtx imagedataloader.ImageContext, ivpol *v1alpha1.ImageValidatingPolicy, lister k8scorev1.SecretInterface) cel.EnvOption {
+func Lib(imgCtx imagedataloader.ImageContext, ivpol *v1alpha1.ImageValidatingPolicy, lister k8scorev1.SecretInterface, ivCache imageverifycache.Client) cel.EnvOption {
 	// create the cel lib env option
 	return cel.Lib(&lib{
-		imgCtx: imgCtx,
-		ivpol:  ivpol,
-		lister: lister,
+		imgCtx:  imgCtx,
+		ivpol:   ivpol,
+		lister:  lister,
+		ivCache: ivCache,
 	})
 }
 
@@ -48,7 +51,7 @@ func (*lib) ProgramOptions() []cel.ProgramOption {
 
 func (c *lib) extendEnv(env *cel.Env) (*cel.Env, error) {
 	// create implementation, recording the envoy types aware adapter
-	impl, err := ImageVerifyCELFuncs(c.logger, c.imgCtx, c.ivpol, c.lister, env.CELTypeAdapter())
+	impl, err := ImageVerifyCELFuncs(c.logger, c.imgCtx, c.ivpol, c.lister, c.ivCache, env.CELTypeAdapter())
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,272 @@
+package oci
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	gcrremote "github.com/google/go-containerregistry/pkg/v1/remote"
+	extyaml "github.com/kyverno/kyverno/ext/yaml"
+	"github.com/kyverno/kyverno/pkg/registryclient"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/utils/lru"
+)
+
+const (
+	// SourceAnnotation is set on the manifests fetched from an artifact, it holds the artifact reference pinned to its digest
+	SourceAnnotation = "generate.kyverno.io/source"
+	// DefaultResolutionTTL is the default duration a tag resolution is cached for
+	DefaultResolutionTTL = 5 * time.Minute
+	defaultCacheSize     = 100
+	maxLayerSize         = 10 << 20
+)
+
+// Source describes an artifact reference and the digest it resolved to
+type Source struct {
+	Reference string
+	Digest    string
+}
+
+// Artifact holds the manifests stored in an artifact
+type Artifact struct {
+	Source
+	Manifests []map[string]any
+}
+
+// Fetcher resolves artifact references and loads the manifests they contain
+type Fetcher interface {
+	// Fetch resolves the reference to a digest and returns the manifests stored in the artifact.
+	// References containing a digest are pinned, the artifact is rejected if its content does not match the digest.
+	Fetch(ctx context.Context, ref string) (*Artifact, error)
+}
+
+type resolution struct {
+	digest  string
+	expires time.Time
+}
+
+type fetcher struct {
+	client      registryclient.Client
+	ttl         time.Duration
+	now         func() time.Time
+	resolutions *lru.Cache
+	manifests   *lru.Cache
+}
+
+// NewFetcher returns a Fetcher using the given registry client.
+// Tag resolutions are cached for the given duration, manifests are cached by digest as they never change.
+func NewFetcher(client registryclient.Client, ttl time.Duration) Fetcher {
+	return &fetcher{
+		client:      client,
+		ttl:         ttl,
+		now:         time.Now,
+		resolutions: lru.New(defaultCacheSize),
+		manifests:   lru.New(defaultCacheSize),
+	}
+}
+
+func (f *fetcher) Fetch(ctx context.Context, ref string) (*Artifact, error) {
+	parsed, err := name.ParseReference(ref, f.client.NameOptions()...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse reference %s: %w", ref, err)
+	}
+	digest, err := f.resolve(ctx, ref, parsed)
+	if err != nil {
+		return nil, err
+	}
+	pinned := parsed.Context().Digest(digest)
+	manifests, err := f.load(ctx, pinned)
+	if err != nil {
+		return nil, err
+	}
+	artifact := Artifact{
+		Source: Source{
+			Reference: ref,
+			Digest:    digest,
+		},
+		Manifests: make([]map[string]any, 0, len(manifests)),
+	}
+	// cached manifests are shared, callers get their own copy
+	for _, manifest := range manifests {
+		artifact.Manifests = append(artifact.Manifests, runtime.DeepCopyJSON(manifest))
+	}
+	return &artifact, nil
+}
+
+func (f *fetcher) resolve(ctx context.Context, ref string, parsed name.Reference) (string, error) {
+	if digest, ok := parsed.(name.Digest); ok {
+		return digest.DigestStr(), nil
+	}
+	key := parsed.Name()
+	if cached, ok := f.resolutions.Get(key); ok {
+		if resolution := cached.(resolution); f.now().Before(resolution.expires) {
+			return resolution.digest, nil
+		}
+	}
+	desc, err := f.client.FetchImageDescriptor(ctx, ref)
+	if err != nil {
+		return "", err
+	}
+	digest := desc.Digest.String()
+	f.resolutions.Add(key, resolution{digest: digest, expires: f.now().Add(f.ttl)})
+	return digest, nil
+}
+
+func (f *fetcher) load(ctx context.Context, ref name.Digest) ([]map[string]any, error) {
+	key := ref.String()
+	if cached, ok := f.manifests.Get(key); ok {
+		return cached.([]map[string]any), nil
+	}
+	remoteOpts, err := f.client.Options(ctx)
+	if err != nil {
+		return nil, err
+	}
+	image, err := gcrremote.Image(ref, remoteOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch artifact %s: %w", key, err)
+	}
+	layers, err := image.Layers()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch artifact %s layers: %w", key, err)
+	}
+	var manifests []map[string]any
+	for _, layer := range layers {
+		out, err := readLayer(layer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read artifact %s: %w", key, err)
+		}
+		manifests = append(manifests, out...)
+	}
+	for _, manifest := range manifests {
+		annotate(manifest, key)
+	}
+	f.manifests.Add(key, manifests)
+	return manifests, nil
+}
+
+// readLayer reads the manifests from a layer, the layer can be a YAML or JSON stream or a tar archive (optionally gzipped)
+// containing YAML and JSON files
+func readLayer(layer v1.Layer) ([]map[string]any, error) {
+	reader, err := layer.Compressed()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	data, err := io.ReadAll(io.LimitReader(reader, maxLayerSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxLayerSize {
+		return nil, fmt.Errorf("layer exceeds the maximum size of %d bytes", maxLayerSize)
+	}
+	if bytes.HasPrefix(data, []byte{0x1f, 0x8b}) {
+		gz, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		defer gz.Close()
+		data, err = io.ReadAll(io.LimitReader(gz, maxLayerSize+1))
+		if err != nil {
+			return nil, err
+		}
+		if len(data) > maxLayerSize {
+			return nil, fmt.Errorf("layer exceeds the maximum size of %d bytes", maxLayerSize)
+		}
+	}
+	if isTar(data) {
+		return readTar(data)
+	}
+	return readDocuments(data)
+}
+
+func isTar(data []byte) bool {
+	return len(data) >= 262 && string(data[257:262]) == "ustar"
+}
+
+func readTar(data []byte) ([]map[string]any, error) {
+	var manifests []map[string]any
+	reader := tar.NewReader(bytes.NewReader(data))
+	for {
+		header, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		switch filepath.Ext(header.Name) {
+		case ".yaml", ".yml", ".json":
+		default:
+			continue
+		}
+		content, err := io.ReadAll(reader)
+		if err != nil {
+			return nil, err
+		}
+		out, err := readDocuments(content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", header.Name, err)
+		}
+		manifests = append(manifests, out...)
+	}
+	return manifests, nil
+}
+
+func readDocuments(data []byte) ([]map[string]any, error) {
+	documents, err := extyaml.SplitDocuments(data)
+	if err != nil {
+		return nil, err
+	}
+	var manifests []map[string]any
+	for _, document := range documents {
+		jsonBytes, err := yaml.ToJSON(document)
+		if err != nil {
+			return nil, err
+		}
+		var manifest map[string]any
+		if err := json.Unmarshal(jsonBytes, &manifest); err != nil {
+			return nil, err
+		}
+		if len(manifest) == 0 {
+			continue
+		}
+		if manifest["apiVersion"] == nil || manifest["kind"] == nil {
+			return nil, errors.New("document is not a kubernetes manifest, apiVersion and kind are required")
+		}
+		manifests = append(manifests, manifest)
+	}
+	return manifests, nil
+}
+
+func annotate(manifest map[string]any, source string) {
+	if items, ok := manifest["items"].([]any); ok {
+		for _, item := range items {
+			if item, ok := item.(map[string]any); ok {
+				annotate(item, source)
+			}
+		}
+	}
+	metadata, _ := manifest["metadata"].(map[string]any)
+	if metadata == nil {
+		metadata = map[string]any{}
+		manifest["metadata"] = metadata
+	}
+	annotations, _ := metadata["annotations"].(map[string]any)
+	if annotations == nil {
+		annotations = map[string]any{}
+		metadata["annotations"] = annotations
+	}
+	annotations[SourceAnnotation] = source
+}
@@ -0,0 +1,183 @@
+package oci
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	gcrremote "github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/kyverno/kyverno/pkg/registryclient"
+	"github.com/stretchr/testify/assert"
+)
+
+const kit = `
+apiVersion: v1
+kind: ResourceQuota
+metadata:
+  name: quota
+spec:
+  hard:
+    pods: 10
+---
+# comment only document
+---
+apiVersion: networking.k8s.io/v1
+kind: NetworkPolicy
+metadata:
+  name: deny-all
+  annotations:
+    owner: platform
+spec:
+  podSelector: {}
+`
+
+func newRegistry(t *testing.T) string {
+	server := httptest.NewServer(registry.New())
+	t.Cleanup(server.Close)
+	return strings.TrimPrefix(server.URL, "http://")
+}
+
+func push(t *testing.T, ref string, layers ...v1.Layer) string {
+	image, err := mutate.AppendLayers(empty.Image, layers...)
+	assert.NoError(t, err)
+	parsed, err := name.ParseReference(ref)
+	assert.NoError(t, err)
+	assert.NoError(t, gcrremote.Write(parsed, image))
+	digest, err := image.Digest()
+	assert.NoError(t, err)
+	return digest.String()
+}
+
+func tarGz(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		assert.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0o600, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+		_, err := tw.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, tw.Close())
+	assert.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+func TestFetcher_Fetch(t *testing.T) {
+	host := newRegistry(t)
+	digest := push(t, host+"/kit:v1", static.NewLayer([]byte(kit), types.MediaType("application/yaml")))
+	client, err := registryclient.New()
+	assert.NoError(t, err)
+	f := NewFetcher(client, DefaultResolutionTTL)
+	artifact, err := f.Fetch(context.TODO(), host+"/kit:v1")
+	assert.NoError(t, err)
+	assert.Equal(t, Source{Reference: host + "/kit:v1", Digest: digest}, artifact.Source)
+	assert.Len(t, artifact.Manifests, 2)
+	assert.Equal(t, "ResourceQuota", artifact.Manifests[0]["kind"])
+	assert.Equal(t, int64(10), artifact.Manifests[0]["spec"].(map[string]any)["hard"].(map[string]any)["pods"])
+	assert.Equal(t, map[string]any{
+		SourceAnnotation: host + "/kit@" + digest,
+	}, artifact.Manifests[0]["metadata"].(map[string]any)["annotations"])
+	assert.Equal(t, map[string]any{
+		"owner":          "platform",
+		SourceAnnotation: host + "/kit@" + digest,
+	}, artifact.Manifests[1]["metadata"].(map[string]any)["annotations"])
+	// callers get their own copy of the cached manifests
+	artifact.Manifests[0]["kind"] = "changed"
+	artifact, err = f.Fetch(context.TODO(), host+"/kit@"+digest)
+	assert.NoError(t, err)
+	assert.Equal(t, digest, artifact.Digest)
+	assert.Equal(t, "ResourceQuota", artifact.Manifests[0]["kind"])
+}
+
+func TestFetcher_Fetch_Archive(t *testing.T) {
+	host := newRegistry(t)
+	layer := static.NewLayer(tarGz(t, map[string]string{
+		"kit/quota.yaml": kit,
+		"kit/role.json":  `{"apiVersion":"rbac.authorization.k8s.io/v1","kind":"Role","metadata":{"name":"viewer"}}`,
+		"README.md":      "not a manifest",
+	}), types.DockerLayer)
+	push(t, host+"/kit:v1", layer)
+	client, err := registryclient.New()
+	assert.NoError(t, err)
+	artifact, err := NewFetcher(client, DefaultResolutionTTL).Fetch(context.TODO(), host+"/kit:v1")
+	assert.NoError(t, err)
+	var kinds []string
+	for _, manifest := range artifact.Manifests {
+		kinds = append(kinds, manifest["kind"].(string))
+	}
+	assert.ElementsMatch(t, []string{"ResourceQuota", "NetworkPolicy", "Role"}, kinds)
+}
+
+func TestFetcher_Fetch_Resolution(t *testing.T) {
+	host := newRegistry(t)
+	v1Digest := push(t, host+"/kit:latest", static.NewLayer([]byte(kit), types.MediaType("application/yaml")))
+	client, err := registryclient.New()
+	assert.NoError(t, err)
+	f := NewFetcher(client, time.Minute).(*fetcher)
+	now := time.Now()
+	f.now = func() time.Time { return now }
+	artifact, err := f.Fetch(context.TODO(), host+"/kit:latest")
+	assert.NoError(t, err)
+	assert.Equal(t, v1Digest, artifact.Digest)
+	v2Digest := push(t, host+"/kit:latest", static.NewLayer([]byte(strings.ReplaceAll(kit, "quota", "quota-v2")), types.MediaType("application/yaml")))
+	// the tag resolution is cached
+	artifact, err = f.Fetch(context.TODO(), host+"/kit:latest")
+	assert.NoError(t, err)
+	assert.Equal(t, v1Digest, artifact.Digest)
+	// the tag is resolved again once the resolution expired
+	now = now.Add(2 * time.Minute)
+	artifact, err = f.Fetch(context.TODO(), host+"/kit:latest")
+	assert.NoError(t, err)
+	assert.Equal(t, v2Digest, artifact.Digest)
+	assert.Equal(t, "quota-v2", artifact.Manifests[0]["metadata"].(map[string]any)["name"])
+}
+
+func TestFetcher_Fetch_Errors(t *testing.T) {
+	host := newRegistry(t)
+	digest := push(t, host+"/kit:v1", static.NewLayer([]byte(kit), types.MediaType("application/yaml")))
+	push(t, host+"/invalid:v1", static.NewLayer([]byte("foo: bar"), types.MediaType("application/yaml")))
+	client, err := registryclient.New()
+	assert.NoError(t, err)
+	f := NewFetcher(client, DefaultResolutionTTL)
+	tests := []struct {
+		name    string
+		ref     string
+		wantErr string
+	}{{
+		name:    "invalid reference",
+		ref:     "INVALID",
+		wantErr: "failed to parse reference INVALID",
+	}, {
+		name:    "unknown tag",
+		ref:     host + "/kit:v2",
+		wantErr: "failed to fetch image reference",
+	}, {
+		name:    "digest mismatch",
+		ref:     host + "/kit:v1@sha256:" + strings.Repeat("0", 64),
+		wantErr: "failed to fetch artifact",
+	}, {
+		name:    "not a manifest",
+		ref:     host + "/invalid:v1",
+		wantErr: "apiVersion and kind are required",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := f.Fetch(context.TODO(), tt.ref)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+	_, err = f.Fetch(context.TODO(), host+"/kit:v1@"+digest)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,28 @@
+package oci
+
+import (
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/kyverno/kyverno/pkg/cel/utils"
+)
+
+type impl struct {
+	types.Adapter
+}
+
+func (c *impl) fetch_oci_string(args ...ref.Val) ref.Val {
+	if len(args) != 2 {
+		return types.NewErr("expected 2 arguments, got %d", len(args))
+	}
+	if self, err := utils.GetArg[Context](args, 0); err != nil {
+		return err
+	} else if reference, err := utils.GetArg[string](args, 1); err != nil {
+		return err
+	} else {
+		manifests, err := self.FetchManifests(reference)
+		if err != nil {
+			return types.NewErr("failed to fetch manifests: %v", err)
+		}
+		return c.NativeToValue(manifests)
+	}
+}
@@ -0,0 +1,94 @@
+package oci
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/kyverno/kyverno/pkg/cel/compiler"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_fetch_oci_string(t *testing.T) {
+	base, err := compiler.NewBaseEnv()
+	assert.NoError(t, err)
+	assert.NotNil(t, base)
+	env, err := base.Extend(
+		cel.Variable("oci", ContextType),
+		Lib(),
+	)
+	assert.NoError(t, err)
+	assert.NotNil(t, env)
+	ast, issues := env.Compile(`oci.Fetch("ghcr.io/acme/kit:v1.2.0").map(m, m.kind)`)
+	assert.Nil(t, issues)
+	assert.NotNil(t, ast)
+	prog, err := env.Program(ast)
+	assert.NoError(t, err)
+	assert.NotNil(t, prog)
+	data := map[string]any{
+		"oci": Context{&ContextMock{
+			FetchManifestsFunc: func(ref string) ([]map[string]any, error) {
+				assert.Equal(t, "ghcr.io/acme/kit:v1.2.0", ref)
+				return []map[string]any{{
+					"apiVersion": "v1",
+					"kind":       "ResourceQuota",
+				}, {
+					"apiVersion": "networking.k8s.io/v1",
+					"kind":       "NetworkPolicy",
+				}}, nil
+			},
+		}},
+	}
+	out, _, err := prog.Eval(data)
+	assert.NoError(t, err)
+	kinds, err := out.ConvertToNative(reflect.TypeFor[[]string]())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ResourceQuota", "NetworkPolicy"}, kinds)
+}
+
+func Test_fetch_oci_string_error(t *testing.T) {
+	base, err := compiler.NewBaseEnv()
+	assert.NoError(t, err)
+	assert.NotNil(t, base)
+	env, err := base.Extend(
+		cel.Variable("oci", ContextType),
+		Lib(),
+	)
+	assert.NoError(t, err)
+	assert.NotNil(t, env)
+	tests := []struct {
+		name string
+		args []ref.Val
+		want ref.Val
+	}{{
+		name: "not enough args",
+		args: nil,
+		want: types.NewErr("expected 2 arguments, got %d", 0),
+	}, {
+		name: "bad arg 1",
+		args: []ref.Val{types.String("foo"), types.String("ghcr.io/acme/kit:v1.2.0")},
+		want: types.NewErr("invalid arg 0: unsupported native conversion from string to 'oci.Context'"),
+	}, {
+		name: "bad arg 2",
+		args: []ref.Val{env.CELTypeAdapter().NativeToValue(Context{}), types.Bool(false)},
+		want: types.NewErr("invalid arg 1: type conversion error from bool to 'string'"),
+	}, {
+		name: "fetch error",
+		args: []ref.Val{env.CELTypeAdapter().NativeToValue(Context{&ContextMock{
+			FetchManifestsFunc: func(string) ([]map[string]any, error) {
+				return nil, errors.New("not found")
+			},
+		}}), types.String("ghcr.io/acme/kit:v1.2.0")},
+		want: types.NewErr("failed to fetch manifests: not found"),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &impl{}
+			got := c.fetch_oci_string(tt.args...)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,9 @@
+package oci
+
+type ContextMock struct {
+	FetchManifestsFunc func(string) ([]map[string]any, error)
+}
+
+func (mock *ContextMock) FetchManifests(ref string) ([]map[string]any, error) {
+	return mock.FetchManifestsFunc(ref)
+}
@@ -0,0 +1,15 @@
+package oci
+
+import (
+	"github.com/google/cel-go/common/types"
+)
+
+var ContextType = types.NewOpaqueType("oci.Context")
+
+type ContextInterface interface {
+	FetchManifests(ref string) ([]map[string]any, error)
+}
+
+type Context struct {
+	ContextInterface
+}
@@ -0,0 +1,162 @@
+package pss
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/cel/utils"
+	evaluator "github.com/kyverno/kyverno/pkg/pss"
+	pssutils "github.com/kyverno/kyverno/pkg/pss/utils"
+	"google.golang.org/protobuf/types/known/structpb"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/pod-security-admission/api"
+)
+
+// controlNames maps check ids to pod security control names
+var controlNames = func() map[string]string {
+	names := map[string]string{}
+	for name, ids := range pssutils.PSS_control_name_to_ids {
+		for _, id := range ids {
+			names[id] = name
+		}
+	}
+	return names
+}()
+
+type impl struct {
+	types.Adapter
+}
+
+func (c *impl) evaluate(args ...ref.Val) ref.Val {
+	if object, err := utils.GetArg[*structpb.Value](args, 0); err != nil {
+		return err
+	} else if level, err := utils.GetArg[string](args, 1); err != nil {
+		return err
+	} else if version, err := utils.GetArg[string](args, 2); err != nil {
+		return err
+	} else {
+		return c.evaluatePod(object.AsInterface(), level, version, nil)
+	}
+}
+
+func (c *impl) evaluate_with_exclusions(args ...ref.Val) ref.Val {
+	if object, err := utils.GetArg[*structpb.Value](args, 0); err != nil {
+		return err
+	} else if level, err := utils.GetArg[string](args, 1); err != nil {
+		return err
+	} else if version, err := utils.GetArg[string](args, 2); err != nil {
+		return err
+	} else if exclusions, err := utils.GetArg[*structpb.Value](args, 3); err != nil {
+		return err
+	} else {
+		data, err := json.Marshal(exclusions.AsInterface())
+		if err != nil {
+			return types.WrapErr(err)
+		}
+		var excludes []kyvernov1.PodSecurityStandard
+		if err := json.Unmarshal(data, &excludes); err != nil {
+			return types.NewErr("invalid exclusions: %v", err)
+		}
+		return c.evaluatePod(object.AsInterface(), level, version, excludes)
+	}
+}
+
+func (c *impl) evaluatePod(object any, level, version string, excludes []kyvernov1.PodSecurityStandard) ref.Val {
+	result, err := Evaluate(object, level, version, excludes)
+	if err != nil {
+		return types.WrapErr(err)
+	}
+	// round trip through json to expose the result as a map
+	data, err := json.Marshal(result)
+	if err != nil {
+		return types.WrapErr(err)
+	}
+	var value map[string]any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return types.WrapErr(err)
+	}
+	return c.NativeToValue(value)
+}
+
+// Evaluate evaluates a pod, or the pod template of a pod controller, against a pod security level and version,
+// the excludes exempt controls the same way they do in kyverno policies
+func Evaluate(object any, level, version string, excludes []kyvernov1.PodSecurityStandard) (Result, error) {
+	pod, err := podFromObject(object)
+	if err != nil {
+		return Result{}, err
+	}
+	parsedLevel, err := api.ParseLevel(level)
+	if err != nil {
+		return Result{}, err
+	}
+	levelVersion, err := evaluator.ParseVersion(parsedLevel, version)
+	if err != nil {
+		return Result{}, err
+	}
+	allowed, checkResults := evaluator.EvaluatePod(levelVersion, excludes, pod)
+	result := Result{
+		Allowed: allowed,
+		Checks:  []Check{},
+	}
+	for _, checkResult := range checkResults {
+		check := Check{
+			ID:      checkResult.ID,
+			Control: controlNames[checkResult.ID],
+			Reason:  checkResult.CheckResult.ForbiddenReason,
+			Detail:  checkResult.CheckResult.ForbiddenDetail,
+			Fields:  []Field{},
+		}
+		if checkResult.CheckResult.ErrList != nil {
+			for _, fieldErr := range *checkResult.CheckResult.ErrList {
+				badValues := evaluator.BadValues(fieldErr)
+				if badValues == nil {
+					badValues = []string{}
+				}
+				check.Fields = append(check.Fields, Field{
+					Path:      fieldErr.Field,
+					BadValues: badValues,
+				})
+			}
+		}
+		result.Checks = append(result.Checks, check)
+	}
+	// exclusions don't preserve the order of the checks
+	slices.SortFunc(result.Checks, func(a, b Check) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+	return result, nil
+}
+
+// podFromObject builds a pod from a pod or from the pod template of a pod controller
+func podFromObject(object any) (*corev1.Pod, error) {
+	data, ok := object.(map[string]any)
+	if !ok {
+		return nil, errors.New("object is expected to be a pod or a pod controller")
+	}
+	// cronjobs
+	if template, found, _ := unstructured.NestedFieldNoCopy(data, "spec", "jobTemplate", "spec", "template"); found {
+		if template, ok := template.(map[string]any); ok {
+			data = template
+		}
+	} else if template, found, _ := unstructured.NestedFieldNoCopy(data, "spec", "template"); found {
+		// deployments, daemonsets, statefulsets, replicasets, jobs and replication controllers
+		if template, ok := template.(map[string]any); ok {
+			data = template
+		}
+	}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	var pod corev1.Pod
+	if err := json.Unmarshal(raw, &pod); err != nil {
+		return nil, err
+	}
+	return &pod, nil
+}
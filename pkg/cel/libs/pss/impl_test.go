@@ -0,0 +1,167 @@
+package pss
+
+import (
+	"testing"
+
+	"github.com/google/cel-go/cel"
+	"github.com/kyverno/kyverno/pkg/cel/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func pod(containers ...map[string]any) map[string]any {
+	var list []any
+	for _, container := range containers {
+		list = append(list, container)
+	}
+	return map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata": map[string]any{
+			"name": "test",
+		},
+		"spec": map[string]any{
+			"containers": list,
+		},
+	}
+}
+
+func container(name, image string, privileged bool) map[string]any {
+	return map[string]any{
+		"name":  name,
+		"image": image,
+		"securityContext": map[string]any{
+			"privileged": privileged,
+		},
+	}
+}
+
+func deployment(template map[string]any) map[string]any {
+	delete(template, "apiVersion")
+	delete(template, "kind")
+	return map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]any{
+			"name": "test",
+		},
+		"spec": map[string]any{
+			"template": template,
+		},
+	}
+}
+
+func cronjob(template map[string]any) map[string]any {
+	delete(template, "apiVersion")
+	delete(template, "kind")
+	return map[string]any{
+		"apiVersion": "batch/v1",
+		"kind":       "CronJob",
+		"metadata": map[string]any{
+			"name": "test",
+		},
+		"spec": map[string]any{
+			"jobTemplate": map[string]any{
+				"spec": map[string]any{
+					"template": template,
+				},
+			},
+		},
+	}
+}
+
+func Test_impl_evaluate(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		object     map[string]any
+		want       bool
+	}{{
+		name:       "privileged pod is not baseline",
+		expression: `pss.Evaluate(object, "baseline", "latest").allowed`,
+		object:     pod(container("nginx", "nginx", true)),
+		want:       false,
+	}, {
+		name:       "failed check details",
+		expression: `pss.Evaluate(object, "baseline", "latest").checks.exists(c, c.id == "privileged" && c.control == "Privileged Containers" && c.fields[0].path == "spec.containers[0].securityContext.privileged" && c.fields[0].badValues == ["true"])`,
+		object:     pod(container("nginx", "nginx", true)),
+		want:       true,
+	}, {
+		name:       "unprivileged pod is baseline",
+		expression: `pss.Evaluate(object, "baseline", "v1.29").allowed`,
+		object:     pod(container("nginx", "nginx", false)),
+		want:       true,
+	}, {
+		name:       "unprivileged pod is not restricted",
+		expression: `pss.Evaluate(object, "restricted", "latest").checks.map(c, c.id).exists(id, id == "runAsNonRoot")`,
+		object:     pod(container("nginx", "nginx", false)),
+		want:       true,
+	}, {
+		name:       "deployment",
+		expression: `!pss.Evaluate(object, "baseline", "latest").allowed`,
+		object:     deployment(pod(container("nginx", "nginx", true))),
+		want:       true,
+	}, {
+		name:       "cronjob",
+		expression: `!pss.Evaluate(object, "baseline", "latest").allowed`,
+		object:     cronjob(pod(container("nginx", "nginx", true))),
+		want:       true,
+	}, {
+		name:       "exclusion matching the image",
+		expression: `pss.Evaluate(object, "baseline", "latest", [{"controlName": "Privileged Containers", "images": ["nginx*"], "restrictedField": "spec.containers[*].securityContext.privileged", "values": ["true"]}]).allowed`,
+		object:     pod(container("nginx", "nginx:latest", true)),
+		want:       true,
+	}, {
+		name:       "exclusion not matching the image",
+		expression: `pss.Evaluate(object, "baseline", "latest", [{"controlName": "Privileged Containers", "images": ["nginx*"], "restrictedField": "spec.containers[*].securityContext.privileged", "values": ["true"]}]).allowed`,
+		object:     pod(container("nginx", "nginx:latest", true), container("busybox", "busybox", true)),
+		want:       false,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env, err := cel.NewEnv(Lib(), cel.Variable("object", cel.DynType))
+			require.NoError(t, err)
+			ast, issues := env.Compile(tt.expression)
+			require.NoError(t, issues.Err())
+			prog, err := env.Program(ast)
+			require.NoError(t, err)
+			out, _, err := prog.Eval(map[string]any{"object": tt.object})
+			require.NoError(t, err)
+			got, err := utils.ConvertToNative[bool](out)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_impl_evaluate_error(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		wantErr    string
+	}{{
+		name:       "invalid level",
+		expression: `pss.Evaluate(object, "foo", "latest")`,
+		wantErr:    "must be one of privileged, baseline, restricted",
+	}, {
+		name:       "invalid version",
+		expression: `pss.Evaluate(object, "baseline", "foo")`,
+		wantErr:    `must be "latest" or "v1.x"`,
+	}, {
+		name:       "not an object",
+		expression: `pss.Evaluate("foo", "baseline", "latest")`,
+		wantErr:    "object is expected to be a pod or a pod controller",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env, err := cel.NewEnv(Lib(), cel.Variable("object", cel.DynType))
+			require.NoError(t, err)
+			ast, issues := env.Compile(tt.expression)
+			require.NoError(t, issues.Err())
+			prog, err := env.Program(ast)
+			require.NoError(t, err)
+			_, _, err = prog.Eval(map[string]any{"object": pod(container("nginx", "nginx", false))})
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package pss
+
+import (
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+)
+
+const libraryName = "kyverno.pss"
+
+type lib struct{}
+
+func Lib() cel.EnvOption {
+	// create the cel lib env option
+	return cel.Lib(&lib{})
+}
+
+func (*lib) LibraryName() string {
+	return libraryName
+}
+
+func (c *lib) CompileOptions() []cel.EnvOption {
+	return []cel.EnvOption{
+		c.extendEnv,
+	}
+}
+
+func (*lib) ProgramOptions() []cel.ProgramOption {
+	return []cel.ProgramOption{}
+}
+
+func (c *lib) extendEnv(env *cel.Env) (*cel.Env, error) {
+	// create implementation, recording the envoy types aware adapter
+	impl := impl{
+		Adapter: env.CELTypeAdapter(),
+	}
+	// build our function overloads
+	libraryDecls := map[string][]cel.FunctionOpt{
+		"pss.Evaluate": {
+			cel.Overload(
+				"pss_evaluate_dyn_string_string",
+				[]*cel.Type{types.DynType, types.StringType, types.StringType},
+				ResultType,
+				cel.FunctionBinding(impl.evaluate),
+			),
+			cel.Overload(
+				"pss_evaluate_dyn_string_string_list",
+				[]*cel.Type{types.DynType, types.StringType, types.StringType, types.NewListType(types.DynType)},
+				ResultType,
+				cel.FunctionBinding(impl.evaluate_with_exclusions),
+			),
+		},
+	}
+	// create env options corresponding to our function overloads
+	options := []cel.EnvOption{}
+	for name, overloads := range libraryDecls {
+		options = append(options, cel.Function(name, overloads...))
+	}
+	// extend environment with our function overloads
+	return env.Extend(options...)
+}
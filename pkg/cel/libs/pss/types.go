@@ -0,0 +1,37 @@
+package pss
+
+import "github.com/google/cel-go/common/types"
+
+// ResultType is the cel type of an evaluation result, results are exposed as maps because registering
+// native types in the base environment would prevent policy compilers from declaring their own types
+var ResultType = types.NewMapType(types.StringType, types.DynType)
+
+// Result is the outcome of the evaluation of a pod against a pod security level
+type Result struct {
+	// Allowed is true when no control failed
+	Allowed bool `json:"allowed"`
+	// Checks contains the failed checks
+	Checks []Check `json:"checks"`
+}
+
+// Check is a failed pod security check
+type Check struct {
+	// ID is the check identifier, e.g. privileged
+	ID string `json:"id"`
+	// Control is the pod security control name, e.g. Privileged Containers
+	Control string `json:"control"`
+	// Reason is the reason the check forbids the pod
+	Reason string `json:"reason"`
+	// Detail describes the violation
+	Detail string `json:"detail"`
+	// Fields contains the failing fields
+	Fields []Field `json:"fields"`
+}
+
+// Field is a failing field of a pod security check
+type Field struct {
+	// Path is the path of the field, e.g. spec.containers[0].securityContext.privileged
+	Path string `json:"path"`
+	// BadValues contains the forbidden values found in the field
+	BadValues []string `json:"badValues"`
+}
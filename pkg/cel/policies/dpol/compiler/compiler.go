@@ -2,6 +2,7 @@ package compiler
 
 import (
 	"github.com/google/cel-go/cel"
+	"github.com/kyverno/kyverno/api/kyverno"
 	policiesv1alpha1 "github.com/kyverno/kyverno/api/policies.kyverno.io/v1alpha1"
 	"github.com/kyverno/kyverno/pkg/cel/compiler"
 	"github.com/kyverno/kyverno/pkg/cel/libs/globalcontext"
@@ -9,6 +10,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/cel/libs/image"
 	"github.com/kyverno/kyverno/pkg/cel/libs/imagedata"
 	"github.com/kyverno/kyverno/pkg/cel/libs/resource"
+	"github.com/kyverno/kyverno/pkg/httpguard"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	apiservercel "k8s.io/apiserver/pkg/cel"
 )
@@ -28,6 +30,11 @@ func (c *compilerImpl) Compile(policy *policiesv1alpha1.DeletingPolicy, exceptio
 		return nil, field.ErrorList{field.Required(field.NewPath("policy"), "policy must not be nil")}
 	}
 	var allErrs field.ErrorList
+	httpTimeout, err := httpguard.PolicyTimeout(policy)
+	if err != nil {
+		path := field.NewPath("metadata", "annotations").Key(kyverno.AnnotationPolicyHTTPTimeout)
+		return nil, field.ErrorList{field.Invalid(path, policy.GetAnnotations()[kyverno.AnnotationPolicyHTTPTimeout], err.Error())}
+	}
 	base, err := compiler.NewBaseEnv()
 	if err != nil {
 		return nil, append(allErrs, field.InternalError(nil, err))
@@ -90,6 +97,7 @@ func (c *compilerImpl) Compile(policy *policiesv1alpha1.DeletingPolicy, exceptio
 		})
 	}
 	return &Policy{
+		httpTimeout:               httpTimeout,
 		deletionPropagationPolicy: policy.Spec.DeletionPropagationPolicy,
 		schedule:                  policy.Spec.Schedule,
 		conditions:                conditions,
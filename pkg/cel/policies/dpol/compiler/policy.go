@@ -2,6 +2,7 @@ package compiler
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/cel-go/cel"
 	"github.com/google/cel-go/common/types"
@@ -21,6 +22,7 @@ import (
 )
 
 type Policy struct {
+	httpTimeout               time.Duration
 	deletionPropagationPolicy *metav1.DeletionPropagation
 	schedule                  string
 	conditions                []cel.Program
@@ -32,7 +34,7 @@ func (p *Policy) Evaluate(ctx context.Context, object unstructured.Unstructured,
 	vars := lazy.NewMapValue(compiler.VariablesType)
 	dataNew := map[string]any{
 		compiler.GlobalContextKey: globalcontext.Context{ContextInterface: context},
-		compiler.HttpKey:          http.Context{ContextInterface: http.NewHTTP(nil)},
+		compiler.HttpKey:          http.Context{ContextInterface: http.NewHTTP(nil, http.WithTimeout(p.httpTimeout))},
 		compiler.ImageDataKey:     imagedata.Context{ContextInterface: context},
 		compiler.ObjectKey:        object.UnstructuredContent(),
 		compiler.ResourceKey:      resource.Context{ContextInterface: context},
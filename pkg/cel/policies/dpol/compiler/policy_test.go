@@ -10,6 +10,7 @@ import (
 	"github.com/google/cel-go/common/types/ref"
 	policiesv1alpha1 "github.com/kyverno/kyverno/api/policies.kyverno.io/v1alpha1"
 	"github.com/kyverno/kyverno/pkg/cel/compiler"
+	"github.com/kyverno/kyverno/pkg/cel/libs/oci"
 	"github.com/stretchr/testify/require"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
@@ -73,7 +74,10 @@ func (f *fakeContext) GetGeneratedResources() []*unstructured.Unstructured { ret
 func (f *fakeContext) PostResource(apiVersion, resource, namespace string, data map[string]any) (*unstructured.Unstructured, error) {
 	return &unstructured.Unstructured{}, nil
 }
-func (f *fakeContext) ClearGeneratedResources() {}
+func (f *fakeContext) ClearGeneratedResources()                        {}
+func (f *fakeContext) FetchManifests(string) ([]map[string]any, error) { return nil, nil }
+func (f *fakeContext) GetFetchedSources() []oci.Source                 { return nil }
+func (f *fakeContext) ClearFetchedSources()                            {}
 func (f *fakeContext) SetGenerateContext(polName, triggerName, triggerNamespace, triggerAPIVersion, triggerGroup, triggerKind, triggerUID string, restoreCache bool) {
 	panic("not implemented")
 }
@@ -2,12 +2,15 @@ package compiler
 
 import (
 	"github.com/google/cel-go/cel"
+	"github.com/kyverno/kyverno/api/kyverno"
 	policiesv1alpha1 "github.com/kyverno/kyverno/api/policies.kyverno.io/v1alpha1"
 	"github.com/kyverno/kyverno/pkg/cel/compiler"
 	"github.com/kyverno/kyverno/pkg/cel/libs/generator"
 	"github.com/kyverno/kyverno/pkg/cel/libs/globalcontext"
 	"github.com/kyverno/kyverno/pkg/cel/libs/http"
+	"github.com/kyverno/kyverno/pkg/cel/libs/oci"
 	"github.com/kyverno/kyverno/pkg/cel/libs/resource"
+	"github.com/kyverno/kyverno/pkg/httpguard"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	apiservercel "k8s.io/apiserver/pkg/cel"
 )
@@ -24,6 +27,12 @@ type compilerImpl struct{}
 
 func (c *compilerImpl) Compile(policy *policiesv1alpha1.GeneratingPolicy, exceptions []*policiesv1alpha1.PolicyException) (*Policy, field.ErrorList) {
 	var allErrs field.ErrorList
+	httpTimeout, err := httpguard.PolicyTimeout(policy)
+	if err != nil {
+		path := field.NewPath("metadata", "annotations").Key(kyverno.AnnotationPolicyHTTPTimeout)
+		return nil, field.ErrorList{field.Invalid(path, policy.GetAnnotations()[kyverno.AnnotationPolicyHTTPTimeout], err.Error())}
+	}
+	costLimits := compiler.PolicyCostLimits(policy.Spec.CostConfiguration())
 	base, err := compiler.NewBaseEnv()
 	if err != nil {
 		return nil, append(allErrs, field.InternalError(nil, err))
@@ -33,6 +42,7 @@ func (c *compilerImpl) Compile(policy *policiesv1alpha1.GeneratingPolicy, except
 		cel.Variable(compiler.ObjectKey, cel.DynType),
 		cel.Variable(compiler.OldObjectKey, cel.DynType),
 		cel.Variable(compiler.RequestKey, compiler.RequestType.CelType()),
+		compiler.CostLib(costLimits.Expression),
 	}
 	var declTypes []*apiservercel.DeclType
 	declTypes = append(declTypes, compiler.NamespaceType, compiler.RequestType)
@@ -65,11 +75,13 @@ func (c *compilerImpl) Compile(policy *policiesv1alpha1.GeneratingPolicy, except
 		cel.Variable(compiler.ResourceKey, resource.ContextType),
 		cel.Variable(compiler.GlobalContextKey, globalcontext.ContextType),
 		cel.Variable(compiler.HttpKey, http.ContextType),
+		cel.Variable(compiler.OCIKey, oci.ContextType),
 		cel.Variable(compiler.VariablesKey, compiler.VariablesType),
 		generator.Lib(),
 		resource.Lib(),
 		globalcontext.Lib(),
 		http.Lib(),
+		oci.Lib(),
 	)
 	if err != nil {
 		return nil, append(allErrs, field.InternalError(nil, err))
@@ -87,6 +99,10 @@ func (c *compilerImpl) Compile(policy *policiesv1alpha1.GeneratingPolicy, except
 		}
 		generations = append(generations, programs...)
 	}
+	costEstimates, errs := estimateCosts(path, env, policy)
+	if errs != nil {
+		return nil, append(allErrs, errs...)
+	}
 	// exceptions' match conditions
 	compiledExceptions := make([]compiler.Exception, 0, len(exceptions))
 	for _, polex := range exceptions {
@@ -100,9 +116,37 @@ func (c *compilerImpl) Compile(policy *policiesv1alpha1.GeneratingPolicy, except
 		})
 	}
 	return &Policy{
+		name:            policy.GetName(),
+		httpTimeout:     httpTimeout,
 		matchConditions: matchConditions,
 		variables:       variables,
 		generations:     generations,
 		exceptions:      compiledExceptions,
+		costLimits:      costLimits,
+		costEstimates:   costEstimates,
 	}, nil
 }
+
+// estimateCosts estimates the cost of the policy expressions, the environment must declare the policy variables
+func estimateCosts(path *field.Path, env *cel.Env, policy *policiesv1alpha1.GeneratingPolicy) (compiler.CostEstimates, field.ErrorList) {
+	var estimates compiler.CostEstimates
+	var allErrs field.ErrorList
+	estimate := func(path *field.Path, expression string) {
+		cost, err := compiler.EstimateCost(path, env, expression)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(path, expression, err.Error()))
+			return
+		}
+		estimates = append(estimates, cost)
+	}
+	for i, matchCondition := range policy.Spec.MatchConditions {
+		estimate(path.Child("matchConditions").Index(i).Child("expression"), matchCondition.Expression)
+	}
+	for i, variable := range policy.Spec.Variables {
+		estimate(path.Child("variables").Index(i).Child("expression"), variable.Expression)
+	}
+	for i, generation := range policy.Spec.Generation {
+		estimate(path.Child("generate").Index(i).Child("expression"), generation.Expression)
+	}
+	return estimates, allErrs
+}
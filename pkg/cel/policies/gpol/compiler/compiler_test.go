@@ -2,10 +2,13 @@ package compiler
 
 import (
 	"testing"
+	"time"
 
+	"github.com/kyverno/kyverno/api/kyverno"
 	"github.com/kyverno/kyverno/api/policies.kyverno.io/v1alpha1"
 	"github.com/stretchr/testify/assert"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestCompile(t *testing.T) {
@@ -78,6 +81,28 @@ func TestCompile(t *testing.T) {
 		assert.NotNil(t, errs)
 	})
 
+	t.Run("should_compile_successfully_when_generating_from_oci_artifact", func(t *testing.T) {
+		pol := &v1alpha1.GeneratingPolicy{
+			Spec: v1alpha1.GeneratingPolicySpec{
+				Variables: []admissionregistrationv1.Variable{
+					{
+						Name:       "kit",
+						Expression: `oci.Fetch("ghcr.io/acme/kit:v1.2.0")`,
+					},
+				},
+				Generation: []v1alpha1.Generation{
+					{
+						Expression: "generator.Apply(object.metadata.name, variables.kit)",
+					},
+				},
+			},
+		}
+		comp := NewCompiler()
+		res, errs := comp.Compile(pol, nil)
+		assert.NotNil(t, res)
+		assert.Nil(t, errs)
+	})
+
 	t.Run("should_fail_when_match_condition_in_policy_exception_is_invalid", func(t *testing.T) {
 		pol := &v1alpha1.GeneratingPolicy{
 			Spec: v1alpha1.GeneratingPolicySpec{},
@@ -121,4 +146,28 @@ func TestCompile(t *testing.T) {
 		assert.NotNil(t, res)
 		assert.Nil(t, errs)
 	})
+
+	t.Run("should_apply_http_timeout_annotation", func(t *testing.T) {
+		pol := &v1alpha1.GeneratingPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{kyverno.AnnotationPolicyHTTPTimeout: "3s"},
+			},
+		}
+		comp := NewCompiler()
+		res, errs := comp.Compile(pol, nil)
+		assert.Nil(t, errs)
+		assert.Equal(t, 3*time.Second, res.httpTimeout)
+	})
+
+	t.Run("should_fail_when_http_timeout_annotation_is_invalid", func(t *testing.T) {
+		pol := &v1alpha1.GeneratingPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{kyverno.AnnotationPolicyHTTPTimeout: "soon"},
+			},
+		}
+		comp := NewCompiler()
+		res, errs := comp.Compile(pol, nil)
+		assert.Nil(t, res)
+		assert.NotNil(t, errs)
+	})
 }
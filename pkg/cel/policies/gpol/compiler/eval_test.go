@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/kyverno/kyverno/pkg/cel/engine"
+	"github.com/kyverno/kyverno/pkg/cel/libs/oci"
 	"github.com/stretchr/testify/assert"
 	admissionv1 "k8s.io/api/admission/v1"
 	authenticationv1 "k8s.io/api/authentication/v1"
@@ -38,7 +39,10 @@ func (f *fakeContext) GetGeneratedResources() []*unstructured.Unstructured { ret
 func (f *fakeContext) PostResource(apiVersion, resource, namespace string, data map[string]any) (*unstructured.Unstructured, error) {
 	return &unstructured.Unstructured{}, nil
 }
-func (f *fakeContext) ClearGeneratedResources() {}
+func (f *fakeContext) ClearGeneratedResources()                        {}
+func (f *fakeContext) FetchManifests(string) ([]map[string]any, error) { return nil, nil }
+func (f *fakeContext) GetFetchedSources() []oci.Source                 { return nil }
+func (f *fakeContext) ClearFetchedSources()                            {}
 func (f *fakeContext) SetGenerateContext(polName, triggerName, triggerNamespace, triggerAPIVersion, triggerGroup, triggerKind, triggerUID string, restoreCache bool) {
 	panic("not implemented")
 }
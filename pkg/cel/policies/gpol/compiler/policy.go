@@ -2,6 +2,7 @@ package compiler
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/cel-go/cel"
 	"github.com/google/cel-go/common/types"
@@ -12,6 +13,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/cel/libs/generator"
 	"github.com/kyverno/kyverno/pkg/cel/libs/globalcontext"
 	"github.com/kyverno/kyverno/pkg/cel/libs/http"
+	"github.com/kyverno/kyverno/pkg/cel/libs/oci"
 	"github.com/kyverno/kyverno/pkg/cel/libs/resource"
 	"github.com/kyverno/kyverno/pkg/cel/utils"
 	"go.uber.org/multierr"
@@ -23,10 +25,24 @@ import (
 )
 
 type Policy struct {
+	name            string
+	httpTimeout     time.Duration
 	matchConditions []cel.Program
 	variables       map[string]cel.Program
 	generations     []cel.Program
 	exceptions      []compiler.Exception
+	costLimits      compiler.CostLimits
+	costEstimates   compiler.CostEstimates
+}
+
+// CostLimits returns the cost limits the policy was compiled with
+func (p *Policy) CostLimits() compiler.CostLimits {
+	return p.costLimits
+}
+
+// CostEstimates returns the static cost estimates of the policy expressions
+func (p *Policy) CostEstimates() compiler.CostEstimates {
+	return p.costEstimates
 }
 
 func (p *Policy) Evaluate(
@@ -40,11 +56,13 @@ func (p *Policy) Evaluate(
 	if err != nil {
 		return nil, nil, err
 	}
+	budget := compiler.NewBudget(p.costLimits.Policy)
+	defer budget.Record(ctx, "GeneratingPolicy", p.name)
 	// check if the resource matches an exception
 	if len(p.exceptions) > 0 {
 		matchedExceptions := make([]*policiesv1alpha1.PolicyException, 0)
 		for _, polex := range p.exceptions {
-			match, err := p.match(ctx, data.Namespace, data.Object, data.OldObject, data.Request, polex.MatchConditions)
+			match, err := p.match(ctx, budget, data.Namespace, data.Object, data.OldObject, data.Request, polex.MatchConditions)
 			if err != nil {
 				return nil, nil, err
 			}
@@ -56,7 +74,7 @@ func (p *Policy) Evaluate(
 			return nil, matchedExceptions, nil
 		}
 	}
-	match, err := p.match(ctx, data.Namespace, data.Object, data.OldObject, data.Request, p.matchConditions)
+	match, err := p.match(ctx, budget, data.Namespace, data.Object, data.OldObject, data.Request, p.matchConditions)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -66,8 +84,9 @@ func (p *Policy) Evaluate(
 	vars := lazy.NewMapValue(compiler.VariablesType)
 	dataNew := map[string]any{
 		compiler.GlobalContextKey:   globalcontext.Context{ContextInterface: data.Context},
-		compiler.HttpKey:            http.Context{ContextInterface: http.NewHTTP(nil)},
+		compiler.HttpKey:            http.Context{ContextInterface: http.NewHTTP(nil, http.WithTimeout(p.httpTimeout))},
 		compiler.NamespaceObjectKey: data.Namespace,
+		compiler.OCIKey:             oci.Context{ContextInterface: data.Context},
 		compiler.ObjectKey:          data.Object,
 		compiler.OldObjectKey:       data.OldObject,
 		compiler.RequestKey:         data.Request,
@@ -77,7 +96,7 @@ func (p *Policy) Evaluate(
 	}
 	for name, variable := range p.variables {
 		vars.Append(name, func(*lazy.MapValue) ref.Val {
-			out, _, err := variable.ContextEval(ctx, dataNew)
+			out, _, err := budget.ContextEval(ctx, variable, dataNew)
 			if out != nil {
 				return out
 			}
@@ -88,7 +107,7 @@ func (p *Policy) Evaluate(
 		})
 	}
 	for _, generation := range p.generations {
-		_, _, err := generation.ContextEval(ctx, dataNew)
+		_, _, err := budget.ContextEval(ctx, generation, dataNew)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -101,6 +120,7 @@ func (p *Policy) Evaluate(
 
 func (p *Policy) match(
 	ctx context.Context,
+	budget *compiler.Budget,
 	namespaceVal any,
 	objectVal any,
 	oldObjectVal any,
@@ -116,7 +136,7 @@ func (p *Policy) match(
 	var errs []error
 	for _, matchCondition := range matchConditions {
 		// evaluate the condition
-		out, _, err := matchCondition.ContextEval(ctx, data)
+		out, _, err := budget.ContextEval(ctx, matchCondition, data)
 		// check error
 		if err != nil {
 			errs = append(errs, err)
@@ -104,7 +104,10 @@ func (e *Engine) generate(
 		}
 	}
 	context.SetGenerateContext(policy.Policy.Name, request.Name, attr.GetNamespace(), request.Kind.Version, request.Kind.Group, request.Kind.Kind, triggerUID, cacheRestore)
+	context.ClearFetchedSources()
 	generatedResources, exceptions, err := policy.CompiledPolicy.Evaluate(ctx, attr, request, namespace, context)
+	response.Sources = context.GetFetchedSources()
+	context.ClearFetchedSources()
 	if err != nil {
 		response.Result = engineapi.RuleError(policy.Policy.Name, engineapi.Generation, "failed to evaluate policy", err, nil)
 		return response
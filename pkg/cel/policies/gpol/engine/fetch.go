@@ -4,9 +4,9 @@ import (
 	"context"
 
 	policiesv1alpha1 "github.com/kyverno/kyverno/api/policies.kyverno.io/v1alpha1"
+	"github.com/kyverno/kyverno/pkg/cel/engine"
 	"github.com/kyverno/kyverno/pkg/cel/policies/gpol/compiler"
 	policiesv1alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/policies.kyverno.io/v1alpha1"
-	"k8s.io/apimachinery/pkg/labels"
 )
 
 type Provider interface {
@@ -41,20 +41,14 @@ func (fp *fetchProvider) Get(ctx context.Context, name string) (Policy, error) {
 	if err != nil {
 		return Policy{}, err
 	}
-	var exceptions, matchedExceptions []*policiesv1alpha1.PolicyException
+	var matchedExceptions []*policiesv1alpha1.PolicyException
 	if fp.polexLister != nil {
-		exceptions, err = fp.polexLister.List(labels.Everything())
+		// only active exceptions are applied
+		matchedExceptions, err = engine.ListExceptions(fp.polexLister, policy.GetKind(), name)
 		if err != nil {
 			return Policy{}, err
 		}
 	}
-	for _, polex := range exceptions {
-		for _, ref := range polex.Spec.PolicyRefs {
-			if ref.Name == policy.GetName() && ref.Kind == policy.GetKind() {
-				matchedExceptions = append(matchedExceptions, polex)
-			}
-		}
-	}
 	compiled, errList := fp.compiler.Compile(policy, matchedExceptions)
 	if errList != nil {
 		return Policy{}, errList.ToAggregate()
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	policiesv1alpha1 "github.com/kyverno/kyverno/api/policies.kyverno.io/v1alpha1"
 	"github.com/kyverno/kyverno/pkg/cel/policies/gpol/compiler"
@@ -73,6 +74,40 @@ func TestGet(t *testing.T) {
 		assert.NotNil(t, policy.CompiledPolicy)
 	})
 
+	t.Run("expired exception", func(t *testing.T) {
+		comp := compiler.NewCompiler()
+		gpol := &policiesv1alpha1.GeneratingPolicy{
+			ObjectMeta: v1.ObjectMeta{
+				Name: "test-policy",
+			},
+		}
+		gpol.TypeMeta.Kind = "GeneratingPolicy"
+
+		expired := v1.NewTime(time.Now().Add(-time.Hour))
+		exception := &policiesv1alpha1.PolicyException{
+			Spec: policiesv1alpha1.PolicyExceptionSpec{
+				PolicyRefs: []policiesv1alpha1.PolicyRef{
+					{
+						Name: "test-policy",
+						Kind: "GeneratingPolicy",
+					},
+				},
+				NotAfter: &expired,
+			},
+		}
+
+		fp := NewFetchProvider(
+			comp,
+			&fakeGpolLister{policy: gpol},
+			&fakePolexLister{exceptions: []*policiesv1alpha1.PolicyException{exception}},
+			true,
+		)
+
+		policy, err := fp.Get(context.Background(), "test-policy")
+		assert.NoError(t, err)
+		assert.Empty(t, policy.Exceptions)
+	})
+
 	t.Run("", func(t *testing.T) {
 		comp := compiler.NewCompiler()
 
@@ -2,6 +2,7 @@ package engine
 
 import (
 	policiesv1alpha1 "github.com/kyverno/kyverno/api/policies.kyverno.io/v1alpha1"
+	"github.com/kyverno/kyverno/pkg/cel/libs/oci"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
@@ -14,4 +15,6 @@ type EngineResponse struct {
 type GeneratingPolicyResponse struct {
 	Policy policiesv1alpha1.GeneratingPolicy
 	Result *engineapi.RuleResponse
+	// Sources are the OCI artifacts fetched while evaluating the policy
+	Sources []oci.Source
 }
@@ -10,19 +10,33 @@ func Validate(gpol *v1alpha1.GeneratingPolicy) ([]string, error) {
 	warnings := make([]string, 0)
 	err := make(field.ErrorList, 0)
 
+	var costWarnings []string
 	compiler := compiler.NewCompiler()
-	_, errList := compiler.Compile(gpol, nil)
+	compiled, errList := compiler.Compile(gpol, nil)
 	if errList != nil {
 		err = errList
 	}
+	if compiled != nil {
+		var costErrs field.ErrorList
+		costWarnings, costErrs = compiled.CostEstimates().Check(compiled.CostLimits())
+		err = append(err, costErrs...)
+	}
 
 	if gpol.Spec.MatchConstraints == nil || len(gpol.Spec.MatchConstraints.ResourceRules) == 0 {
 		err = append(err, field.Required(field.NewPath("spec").Child("matchConstraints"), "a matchConstraints with at least one resource rule is required"))
 	}
 
+	if gpol.Spec.SynchronizationEnabled() && gpol.Spec.DriftDetectionEnabled() {
+		err = append(err, field.Forbidden(field.NewPath("spec").Child("evaluation", "driftDetection"), "drift detection can not be used together with synchronization"))
+	}
+
 	if len(err) == 0 {
+		if len(costWarnings) != 0 {
+			return costWarnings, nil
+		}
 		return nil, nil
 	}
+	warnings = append(warnings, costWarnings...)
 
 	for _, e := range err.ToAggregate().Errors() {
 		warnings = append(warnings, e.Error())
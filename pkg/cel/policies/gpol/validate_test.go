@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/api/admissionregistration/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 )
 
 func TestValidate(t *testing.T) {
@@ -85,6 +86,33 @@ func TestValidate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "synchronization and drift detection",
+			pol: &v1alpha1.GeneratingPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "sync-and-drift",
+				},
+				Spec: v1alpha1.GeneratingPolicySpec{
+					MatchConstraints: &v1.MatchResources{
+						ResourceRules: []v1.NamedRuleWithOperations{
+							{
+								RuleWithOperations: v1.RuleWithOperations{
+									Rule: v1.Rule{
+										APIGroups: []string{"apps"},
+										Resources: []string{"deployments"},
+									},
+								},
+							},
+						},
+					},
+					EvaluationConfiguration: &v1alpha1.GeneratingPolicyEvaluationConfiguration{
+						SynchronizationConfiguration: &v1alpha1.SynchronizationConfiguration{Enabled: ptr.To(true)},
+						DriftDetectionConfiguration:  &v1alpha1.DriftDetectionConfiguration{Enabled: ptr.To(true)},
+					},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "invalid policy",
 			pol: &v1alpha1.GeneratingPolicy{
@@ -159,3 +187,73 @@ func TestValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateCost(t *testing.T) {
+	policy := func(expression string, cost *v1alpha1.CostConfiguration) *v1alpha1.GeneratingPolicy {
+		return &v1alpha1.GeneratingPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "cost",
+			},
+			Spec: v1alpha1.GeneratingPolicySpec{
+				MatchConstraints: &v1.MatchResources{
+					ResourceRules: []v1.NamedRuleWithOperations{
+						{
+							RuleWithOperations: v1.RuleWithOperations{
+								Rule: v1.Rule{
+									APIGroups: []string{""},
+									Resources: []string{"pods"},
+								},
+							},
+						},
+					},
+				},
+				MatchConditions: []v1.MatchCondition{
+					{
+						Name:       "cost",
+						Expression: expression,
+					},
+				},
+				EvaluationConfiguration: &v1alpha1.GeneratingPolicyEvaluationConfiguration{
+					Cost: cost,
+				},
+			},
+		}
+	}
+	tests := []struct {
+		name         string
+		pol          *v1alpha1.GeneratingPolicy
+		wantWarnings bool
+		wantErr      bool
+	}{
+		{
+			name: "within the limits",
+			pol:  policy(`object.metadata.name == "foo"`, nil),
+		},
+		{
+			name:         "nested comprehension may exceed the limits",
+			pol:          policy(`object.spec.containers.all(x, object.spec.containers.all(y, x.name != y.name || x == y))`, nil),
+			wantWarnings: true,
+		},
+		{
+			name:         "always exceeds the policy expression limit",
+			pol:          policy(`[1, 2, 3, 4, 5, 6, 7, 8, 9, 10].all(x, x > 0)`, &v1alpha1.CostConfiguration{ExpressionLimit: ptr.To[int64](5)}),
+			wantWarnings: true,
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings, err := Validate(tt.pol)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			if tt.wantWarnings {
+				assert.NotEmpty(t, warnings)
+			} else {
+				assert.Empty(t, warnings)
+			}
+		})
+	}
+}
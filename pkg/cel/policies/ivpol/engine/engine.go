@@ -15,6 +15,7 @@ import (
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	eval "github.com/kyverno/kyverno/pkg/imageverification/evaluator"
 	"github.com/kyverno/kyverno/pkg/imageverification/imagedataloader"
+	"github.com/kyverno/kyverno/pkg/imageverifycache"
 	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
 	"golang.org/x/exp/maps"
 	"gomodules.xyz/jsonpatch/v2"
@@ -47,6 +48,7 @@ type engineImpl struct {
 	matcher      matching.Matcher
 	lister       k8scorev1.SecretInterface
 	registryOpts []imagedataloader.Option
+	ivCache      imageverifycache.Client
 }
 
 func NewEngine(
@@ -55,6 +57,7 @@ func NewEngine(
 	matcher matching.Matcher,
 	lister k8scorev1.SecretInterface,
 	registryOpts []imagedataloader.Option,
+	ivCache imageverifycache.Client,
 ) Engine {
 	return &engineImpl{
 		provider:     provider,
@@ -62,6 +65,7 @@ func NewEngine(
 		matcher:      matcher,
 		lister:       lister,
 		registryOpts: registryOpts,
+		ivCache:      ivCache,
 	}
 }
 
@@ -237,7 +241,7 @@ func (e *engineImpl) handleMutation(
 	if err != nil {
 		return nil, nil, err
 	}
-	c := eval.NewCompiler(ictx, e.lister, request.RequestResource)
+	c := eval.NewCompiler(ictx, e.lister, request.RequestResource, e.ivCache)
 	for _, ivpol := range filteredPolicies {
 		response := eval.ImageVerifyPolicyResponse{
 			Policy:     ivpol.Policy,
@@ -271,7 +275,7 @@ func (e *engineImpl) handleMutation(
 					} else if result.Result {
 						response.Result = *engineapi.RulePass(ruleName, engineapi.ImageVerify, "success", nil)
 					} else {
-						response.Result = *engineapi.RuleFail(ruleName, engineapi.ImageVerify, result.Message, result.AuditAnnotations)
+						response.Result = *engineapi.RuleFail(ruleName, engineapi.ImageVerify, result.Message, result.AuditAnnotations).WithValidationIndex(result.Index)
 					}
 				}
 
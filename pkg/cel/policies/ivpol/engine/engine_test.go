@@ -46,7 +46,7 @@ var (
 					},
 				},
 			},
-			EvaluationConfiguration: &policiesv1alpha1.EvaluationConfiguration{
+			EvaluationConfiguration: &policiesv1alpha1.ImageValidatingPolicyEvaluationConfiguration{
 				Mode: policiesv1alpha1.EvaluationModeKubernetes,
 			},
 			MatchImageReferences: []policiesv1alpha1.MatchImageReference{
@@ -162,7 +162,7 @@ func Test_ImageVerifyEngine(t *testing.T) {
 		},
 		Context: libs.NewFakeContextProvider(),
 	}
-	engine := NewEngine(ProviderFunc(providerFunc), nsResolver, matching.NewMatcher(), nil, nil)
+	engine := NewEngine(ProviderFunc(providerFunc), nsResolver, matching.NewMatcher(), nil, nil, nil)
 
 	resp, patches, err := engine.HandleMutating(context.Background(), engineRequest, nil)
 	assert.NoError(t, err)
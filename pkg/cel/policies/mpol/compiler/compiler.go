@@ -1,7 +1,10 @@
 package compiler
 
 import (
+	"math"
+
 	cel "github.com/google/cel-go/cel"
+	"github.com/kyverno/kyverno/api/kyverno"
 	policiesv1alpha1 "github.com/kyverno/kyverno/api/policies.kyverno.io/v1alpha1"
 	compiler "github.com/kyverno/kyverno/pkg/cel/compiler"
 	"github.com/kyverno/kyverno/pkg/cel/libs/globalcontext"
@@ -10,6 +13,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/cel/libs/imagedata"
 	"github.com/kyverno/kyverno/pkg/cel/libs/resource"
 	"github.com/kyverno/kyverno/pkg/cel/libs/user"
+	"github.com/kyverno/kyverno/pkg/httpguard"
 	admissionregistrationv1alpha1 "k8s.io/api/admissionregistration/v1alpha1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/apimachinery/pkg/util/version"
@@ -17,7 +21,11 @@ import (
 	"k8s.io/apiserver/pkg/admission/plugin/policy/mutating"
 	patch "k8s.io/apiserver/pkg/admission/plugin/policy/mutating/patch"
 	matchconditions "k8s.io/apiserver/pkg/admission/plugin/webhook/matchconditions"
+	apiservercel "k8s.io/apiserver/pkg/cel"
+	"k8s.io/apiserver/pkg/cel/common"
 	environment "k8s.io/apiserver/pkg/cel/environment"
+	"k8s.io/apiserver/pkg/cel/library"
+	"k8s.io/apiserver/pkg/cel/mutation"
 )
 
 type Compiler interface {
@@ -32,6 +40,17 @@ type compilerImpl struct{}
 
 func (c *compilerImpl) Compile(policy *policiesv1alpha1.MutatingPolicy, exceptions []*policiesv1alpha1.PolicyException) (*Policy, field.ErrorList) {
 	var allErrs field.ErrorList
+	httpTimeout, err := httpguard.PolicyTimeout(policy)
+	if err != nil {
+		path := field.NewPath("metadata", "annotations").Key(kyverno.AnnotationPolicyHTTPTimeout)
+		return nil, field.ErrorList{field.Invalid(path, policy.GetAnnotations()[kyverno.AnnotationPolicyHTTPTimeout], err.Error())}
+	}
+	costLimits := compiler.PolicyCostLimits(policy.Spec.CostConfiguration())
+	// the kubernetes environment sets its own limit, it must be overridden when the limit is disabled
+	expressionLimit := costLimits.Expression
+	if expressionLimit == 0 {
+		expressionLimit = math.MaxUint64
+	}
 
 	baseEnvSet := environment.MustBaseEnvSet(environment.DefaultCompatibilityVersion(), false)
 	extendedEnvSet, err := baseEnvSet.Extend(
@@ -56,6 +75,7 @@ func (c *compilerImpl) Compile(policy *policiesv1alpha1.MutatingPolicy, exceptio
 				resource.Lib(),
 				user.Lib(),
 			},
+			ProgramOptions: compiler.CostProgramOptions(expressionLimit),
 		},
 	)
 	if err != nil {
@@ -135,8 +155,70 @@ func (c *compilerImpl) Compile(policy *policiesv1alpha1.MutatingPolicy, exceptio
 			}
 		}
 	}
+	var costEstimates compiler.CostEstimates
+	if len(allErrs) == 0 {
+		var errs field.ErrorList
+		costEstimates, errs = estimateCosts(field.NewPath("spec"), compositedCompiler.CompositionEnv, policy)
+		allErrs = append(allErrs, errs...)
+	}
 	return &Policy{
-		evaluator:  mutating.PolicyEvaluator{Matcher: matcher, Mutators: patchers, CompositionEnv: compositedCompiler.CompositionEnv},
-		exceptions: compiledExceptions,
+		name:          policy.GetName(),
+		httpTimeout:   httpTimeout,
+		evaluator:     mutating.PolicyEvaluator{Matcher: matcher, Mutators: patchers, CompositionEnv: compositedCompiler.CompositionEnv},
+		exceptions:    compiledExceptions,
+		costLimits:    costLimits,
+		costEstimates: costEstimates,
 	}, allErrs
 }
+
+// estimateCosts estimates the cost of the policy expressions in the composition environment, extended with the patch types
+func estimateCosts(path *field.Path, compositionEnv *plugincel.CompositionEnv, policy *policiesv1alpha1.MutatingPolicy) (compiler.CostEstimates, field.ErrorList) {
+	// the declared types must be registered before the patch types resolver, as in the kubernetes environment
+	envSet, err := compositionEnv.Extend(environment.VersionedOptions{
+		IntroducedVersion: version.MajorMinor(1, 0),
+		DeclTypes: []*apiservercel.DeclType{
+			compiler.NamespaceType,
+			compiler.RequestType,
+		},
+	})
+	if err != nil {
+		return nil, field.ErrorList{field.InternalError(nil, err)}
+	}
+	envSet, err = envSet.Extend(environment.VersionedOptions{
+		IntroducedVersion: version.MajorMinor(1, 0),
+		EnvOptions: []cel.EnvOption{
+			common.ResolverEnvOption(&mutation.DynamicTypeResolver{}),
+			environment.UnversionedLib(library.JSONPatch),
+		},
+	})
+	if err != nil {
+		return nil, field.ErrorList{field.InternalError(nil, err)}
+	}
+	env := envSet.StoredExpressionsEnv()
+	var estimates compiler.CostEstimates
+	var allErrs field.ErrorList
+	estimate := func(path *field.Path, expression string) {
+		cost, err := compiler.EstimateCost(path, env, expression)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(path, expression, err.Error()))
+			return
+		}
+		estimates = append(estimates, cost)
+	}
+	for i, matchCondition := range policy.Spec.MatchConditions {
+		estimate(path.Child("matchConditions").Index(i).Child("expression"), matchCondition.Expression)
+	}
+	for i, variable := range policy.Spec.Variables {
+		estimate(path.Child("variables").Index(i).Child("expression"), variable.Expression)
+	}
+	for i, m := range policy.Spec.Mutations {
+		path := path.Child("mutations").Index(i)
+		if m.JSONPatch != nil {
+			estimate(path.Child("jsonPatch", "expression"), m.JSONPatch.Expression)
+		}
+		if m.ApplyConfiguration != nil {
+			estimate(path.Child("applyConfiguration", "expression"), m.ApplyConfiguration.Expression)
+		}
+	}
+	return estimates, allErrs
+}
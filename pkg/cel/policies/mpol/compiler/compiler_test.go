@@ -2,11 +2,14 @@ package compiler
 
 import (
 	"testing"
+	"time"
 
+	"github.com/kyverno/kyverno/api/kyverno"
 	"github.com/kyverno/kyverno/api/policies.kyverno.io/v1alpha1"
 	"github.com/stretchr/testify/assert"
 	admissionv1 "k8s.io/api/admissionregistration/v1"
 	admissionregistrationv1alpha1 "k8s.io/api/admissionregistration/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestCompile(t *testing.T) {
@@ -104,6 +107,29 @@ func TestCompile(t *testing.T) {
 			polex:   nil,
 			wantErr: false,
 		},
+		{
+			name: "matchCondition expression using request and namespace",
+			pol: &v1alpha1.MutatingPolicy{
+				Spec: v1alpha1.MutatingPolicySpec{
+					MatchConditions: []admissionregistrationv1alpha1.MatchCondition{
+						{
+							Name:       "ns-is-dev",
+							Expression: `request.namespace == 'dev' && namespaceObject.metadata.name == 'dev'`,
+						},
+					},
+					Mutations: []admissionregistrationv1alpha1.Mutation{
+						{
+							PatchType: admissionregistrationv1alpha1.PatchTypeJSONPatch,
+							JSONPatch: &admissionregistrationv1alpha1.JSONPatch{
+								Expression: `[JSONPatch{op: "add", path: "/metadata/labels", value: {"namespace": request.namespace}}]`,
+							},
+						},
+					},
+				},
+			},
+			polex:   nil,
+			wantErr: false,
+		},
 		{
 			name: "invalid matchCondition expression",
 			pol: &v1alpha1.MutatingPolicy{
@@ -119,6 +145,16 @@ func TestCompile(t *testing.T) {
 			polex:   nil,
 			wantErr: true,
 		},
+		{
+			name: "invalid http timeout annotation",
+			pol: &v1alpha1.MutatingPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{kyverno.AnnotationPolicyHTTPTimeout: "soon"},
+				},
+			},
+			polex:   nil,
+			wantErr: true,
+		},
 	}
 
 	compiler := NewCompiler()
@@ -134,3 +170,14 @@ func TestCompile(t *testing.T) {
 		})
 	}
 }
+
+func TestCompile_HTTPTimeout(t *testing.T) {
+	pol := &v1alpha1.MutatingPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{kyverno.AnnotationPolicyHTTPTimeout: "3s"},
+		},
+	}
+	compiled, errs := NewCompiler().Compile(pol, nil)
+	assert.Empty(t, errs)
+	assert.Equal(t, 3*time.Second, compiled.httpTimeout)
+}
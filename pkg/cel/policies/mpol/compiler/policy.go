@@ -3,6 +3,7 @@ package compiler
 import (
 	"context"
 	"fmt"
+	"math"
 	"reflect"
 	"time"
 
@@ -25,19 +26,46 @@ import (
 	cel "k8s.io/apiserver/pkg/admission/plugin/cel"
 	"k8s.io/apiserver/pkg/admission/plugin/policy/mutating"
 	"k8s.io/apiserver/pkg/admission/plugin/policy/mutating/patch"
-	celconfig "k8s.io/apiserver/pkg/apis/cel"
 	"k8s.io/apiserver/pkg/cel/lazy"
 )
 
 type Policy struct {
-	evaluator  mutating.PolicyEvaluator
-	exceptions []compiler.Exception
+	name          string
+	httpTimeout   time.Duration
+	evaluator     mutating.PolicyEvaluator
+	exceptions    []compiler.Exception
+	costLimits    compiler.CostLimits
+	costEstimates compiler.CostEstimates
+}
+
+// CostLimits returns the cost limits the policy was compiled with
+func (p *Policy) CostLimits() compiler.CostLimits {
+	return p.costLimits
+}
+
+// CostEstimates returns the static cost estimates of the policy expressions
+func (p *Policy) CostEstimates() compiler.CostEstimates {
+	return p.costEstimates
+}
+
+// runtimeCostBudget returns the cost budget of a mutation, the patchers don't report the cost they consumed
+// so every mutation is bounded by what remains of the policy limit after the exceptions and variables
+func (p *Policy) runtimeCostBudget(budget *compiler.Budget) int64 {
+	if p.costLimits.Policy == 0 || p.costLimits.Policy > math.MaxInt64 {
+		return math.MaxInt64
+	}
+	if budget.Cost() >= p.costLimits.Policy {
+		return 0
+	}
+	return int64(p.costLimits.Policy - budget.Cost())
 }
 
 type compositionContext struct {
 	ctx             context.Context //nolint:containedctx
 	evaluator       *mutating.PolicyEvaluator
 	contextProvider libs.Context
+	httpTimeout     time.Duration
+	budget          *compiler.Budget
 	accumulatedCost int64
 }
 
@@ -61,7 +89,7 @@ func (c *compositionContext) Variables(activation any) ref.Val {
 	// Set up context data for variable evaluation
 	ctxData := map[string]interface{}{
 		compiler.GlobalContextKey: globalcontext.Context{ContextInterface: c.contextProvider},
-		compiler.HttpKey:          http.Context{ContextInterface: http.NewHTTP(nil)},
+		compiler.HttpKey:          http.Context{ContextInterface: http.NewHTTP(nil, http.WithTimeout(c.httpTimeout))},
 		compiler.ImageDataKey:     imagedata.Context{ContextInterface: c.contextProvider},
 		compiler.ResourceKey:      resource.Context{ContextInterface: c.contextProvider},
 		compiler.VariablesKey:     lazyMap,
@@ -71,7 +99,12 @@ func (c *compositionContext) Variables(activation any) ref.Val {
 
 	for name, result := range c.evaluator.CompositionEnv.CompiledVariables {
 		lazyMap.Append(name, func(*lazy.MapValue) ref.Val {
-			out, _, err := result.Program.ContextEval(c.ctx, ctxData)
+			out, details, err := result.Program.ContextEval(c.ctx, ctxData)
+			// the cost of the variables is charged to the budget of the mutation, the patcher enforces the limit
+			if details != nil && details.ActualCost() != nil {
+				c.accumulatedCost += int64(*details.ActualCost())
+				_ = c.budget.Charge(*details.ActualCost())
+			}
 			if out != nil {
 				return out
 			}
@@ -138,8 +171,12 @@ func (p *Policy) Evaluate(
 		VersionedKind:   attr.GetKind(),
 	}
 
+	// the cost of the patch expressions is not reported by the patchers, only the exceptions and variables are recorded
+	budget := compiler.NewBudget(p.costLimits.Policy)
+	defer budget.Record(ctx, "MutatingPolicy", p.name)
+
 	if len(p.exceptions) > 0 {
-		matchedExceptions, err := p.matchExceptions(ctx, attr, request, namespace)
+		matchedExceptions, err := p.matchExceptions(ctx, budget, attr, request, namespace)
 		if err != nil {
 			return &EvaluationResult{Error: err}
 		}
@@ -162,6 +199,8 @@ func (p *Policy) Evaluate(
 		ctx:             ctx,
 		evaluator:       &p.evaluator,
 		contextProvider: contextProvider,
+		httpTimeout:     p.httpTimeout,
+		budget:          budget,
 	}
 
 	o := admission.NewObjectInterfacesFromScheme(runtime.NewScheme())
@@ -175,7 +214,7 @@ func (p *Policy) Evaluate(
 			TypeConverter:       tcm.GetTypeConverter(versionedAttributes.VersionedKind),
 		}
 
-		newVersionedObject, err := patcher.Patch(compositionCtx, patchRequest, celconfig.RuntimeCELCostBudget)
+		newVersionedObject, err := patcher.Patch(compositionCtx, patchRequest, p.runtimeCostBudget(budget))
 		if err != nil {
 			return &EvaluationResult{Error: err}
 		}
@@ -187,7 +226,7 @@ func (p *Policy) Evaluate(
 	return &EvaluationResult{PatchedResource: versionedAttributes.VersionedObject.(*unstructured.Unstructured)}
 }
 
-func (p *Policy) matchExceptions(ctx context.Context, attr admission.Attributes, request admissionv1.AdmissionRequest, namespace *corev1.Namespace) ([]*policiesv1alpha1.PolicyException, error) {
+func (p *Policy) matchExceptions(ctx context.Context, budget *compiler.Budget, attr admission.Attributes, request admissionv1.AdmissionRequest, namespace *corev1.Namespace) ([]*policiesv1alpha1.PolicyException, error) {
 	var errs []error
 	matchedExceptions := make([]*policiesv1alpha1.PolicyException, 0)
 	objectVal, err := utils.ObjectToResolveVal(attr.GetObject())
@@ -221,7 +260,7 @@ func (p *Policy) matchExceptions(ctx context.Context, attr admission.Attributes,
 	}
 	for _, polex := range p.exceptions {
 		for _, condition := range polex.MatchConditions {
-			out, _, err := condition.ContextEval(ctx, data)
+			out, _, err := budget.ContextEval(ctx, condition, data)
 			if err != nil {
 				errs = append(errs, err)
 				continue
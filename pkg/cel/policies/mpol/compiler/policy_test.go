@@ -11,6 +11,7 @@ import (
 	"github.com/google/cel-go/common/types"
 	policiesv1alpha1 "github.com/kyverno/kyverno/api/policies.kyverno.io/v1alpha1"
 	"github.com/kyverno/kyverno/pkg/cel/compiler"
+	"github.com/kyverno/kyverno/pkg/cel/libs/oci"
 	"github.com/stretchr/testify/assert"
 	admissionv1 "k8s.io/api/admission/v1"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
@@ -54,7 +55,10 @@ func (f *fakeContext) GetGeneratedResources() []*unstructured.Unstructured { ret
 func (f *fakeContext) PostResource(apiVersion, resource, namespace string, data map[string]any) (*unstructured.Unstructured, error) {
 	return &unstructured.Unstructured{}, nil
 }
-func (f *fakeContext) ClearGeneratedResources() {}
+func (f *fakeContext) ClearGeneratedResources()                        {}
+func (f *fakeContext) FetchManifests(string) ([]map[string]any, error) { return nil, nil }
+func (f *fakeContext) GetFetchedSources() []oci.Source                 { return nil }
+func (f *fakeContext) ClearFetchedSources()                            {}
 func (f *fakeContext) SetGenerateContext(polName, triggerName, triggerNamespace, triggerAPIVersion, triggerGroup, triggerKind, triggerUID string, restoreCache bool) {
 	panic("not implemented")
 }
@@ -480,7 +484,7 @@ func TestMatchExceptions_FullCoverage(t *testing.T) {
 				},
 			},
 		}
-		res, err := p.matchExceptions(ctx, attr, req, validNS)
+		res, err := p.matchExceptions(ctx, compiler.NewBudget(0), attr, req, validNS)
 		assert.NoError(t, err)
 		assert.NotNil(t, res)
 	})
@@ -507,7 +511,7 @@ func TestMatchExceptions_FullCoverage(t *testing.T) {
 				},
 			},
 		}
-		res, err := p.matchExceptions(ctx, attr, req, validNS)
+		res, err := p.matchExceptions(ctx, compiler.NewBudget(0), attr, req, validNS)
 		assert.Error(t, err)
 		assert.NotNil(t, res)
 	})
@@ -533,7 +537,7 @@ func TestMatchExceptions_FullCoverage(t *testing.T) {
 				},
 			},
 		}
-		res, err := p.matchExceptions(ctx, attr, req, validNS)
+		res, err := p.matchExceptions(ctx, compiler.NewBudget(0), attr, req, validNS)
 		assert.Error(t, err)
 		assert.NotNil(t, res)
 	})
@@ -551,7 +555,7 @@ func TestMatchExceptions_FullCoverage(t *testing.T) {
 				},
 			},
 		}
-		res, err := p.matchExceptions(ctx, attr, req, validNS)
+		res, err := p.matchExceptions(ctx, compiler.NewBudget(0), attr, req, validNS)
 		assert.NoError(t, err)
 		assert.Empty(t, res)
 	})
@@ -6,6 +6,7 @@ import (
 
 	"github.com/kyverno/kyverno/api/policies.kyverno.io/v1alpha1"
 	policiesv1alpha1 "github.com/kyverno/kyverno/api/policies.kyverno.io/v1alpha1"
+	"github.com/kyverno/kyverno/pkg/cel/engine"
 	"github.com/kyverno/kyverno/pkg/cel/matching"
 	"github.com/kyverno/kyverno/pkg/cel/policies/mpol/autogen"
 	"github.com/kyverno/kyverno/pkg/cel/policies/mpol/compiler"
@@ -87,6 +88,8 @@ func NewKubeProvider(
 		}
 		builder.Watches(&policiesv1alpha1.PolicyException{}, polexHandler)
 	}
+	// policies are compiled again when the cluster wide cost limits changed
+	builder = builder.WatchesRawSource(engine.CostLimitsSource(mgr.GetClient(), &policiesv1alpha1.MutatingPolicyList{}))
 	if err := builder.Complete(reconciler); err != nil {
 		return nil, typeConverter, fmt.Errorf("failed to construct mutatingpolicies manager: %w", err)
 	}
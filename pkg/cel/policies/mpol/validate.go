@@ -10,19 +10,29 @@ func Validate(mpol *v1alpha1.MutatingPolicy) ([]string, error) {
 	warnings := make([]string, 0)
 	err := make(field.ErrorList, 0)
 
+	var costWarnings []string
 	compiler := compiler.NewCompiler()
-	_, errList := compiler.Compile(mpol, nil)
+	compiled, errList := compiler.Compile(mpol, nil)
 	if errList != nil {
 		err = errList
 	}
+	if compiled != nil {
+		var costErrs field.ErrorList
+		costWarnings, costErrs = compiled.CostEstimates().Check(compiled.CostLimits())
+		err = append(err, costErrs...)
+	}
 
 	if mpol.Spec.MatchConstraints == nil || len(mpol.Spec.MatchConstraints.ResourceRules) == 0 {
 		err = append(err, field.Required(field.NewPath("spec").Child("matchConstraints"), "a matchConstraints with at least one resource rule is required"))
 	}
 
 	if len(err) == 0 {
+		if len(costWarnings) != 0 {
+			return costWarnings, nil
+		}
 		return nil, nil
 	}
+	warnings = append(warnings, costWarnings...)
 
 	for _, e := range err.ToAggregate().Errors() {
 		warnings = append(warnings, e.Error())
@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/api/admissionregistration/v1alpha1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 )
 
 func TestValidate(t *testing.T) {
@@ -163,3 +164,83 @@ func TestValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateCost(t *testing.T) {
+	policy := func(mutation v1.Mutation, cost *v1alpha1.CostConfiguration) *v1alpha1.MutatingPolicy {
+		return &v1alpha1.MutatingPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "cost",
+			},
+			Spec: v1alpha1.MutatingPolicySpec{
+				MatchConstraints: &v1.MatchResources{
+					ResourceRules: []v1.NamedRuleWithOperations{
+						{
+							RuleWithOperations: v1.RuleWithOperations{
+								Rule: v1.Rule{
+									APIGroups: []string{""},
+									Resources: []string{"pods"},
+								},
+							},
+						},
+					},
+				},
+				Mutations: []v1.Mutation{mutation},
+				EvaluationConfiguration: &v1alpha1.MutatingPolicyEvaluationConfiguration{
+					Cost: cost,
+				},
+			},
+		}
+	}
+	tests := []struct {
+		name         string
+		pol          *v1alpha1.MutatingPolicy
+		wantWarnings bool
+		wantErr      bool
+	}{
+		{
+			name: "apply configuration within the limits",
+			pol: policy(v1.Mutation{
+				PatchType: v1.PatchTypeApplyConfiguration,
+				ApplyConfiguration: &v1.ApplyConfiguration{
+					Expression: `Object{metadata: Object.metadata{labels: {"foo": "bar"}}}`,
+				},
+			}, nil),
+		},
+		{
+			name: "json patch may exceed the limits",
+			pol: policy(v1.Mutation{
+				PatchType: v1.PatchTypeJSONPatch,
+				JSONPatch: &v1.JSONPatch{
+					Expression: `object.spec.containers.filter(x, object.spec.containers.exists(y, x.name != y.name && x.image == y.image)).map(x, JSONPatch{op: "add", path: "/metadata/labels/" + jsonpatch.escapeKey(x.name), value: "duplicate"})`,
+				},
+			}, nil),
+			wantWarnings: true,
+		},
+		{
+			name: "always exceeds the policy expression limit",
+			pol: policy(v1.Mutation{
+				PatchType: v1.PatchTypeJSONPatch,
+				JSONPatch: &v1.JSONPatch{
+					Expression: `[1, 2, 3, 4, 5, 6, 7, 8, 9, 10].map(x, JSONPatch{op: "add", path: "/metadata/labels/" + string(x), value: "foo"})`,
+				},
+			}, &v1alpha1.CostConfiguration{ExpressionLimit: ptr.To[int64](5)}),
+			wantWarnings: true,
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings, err := Validate(tt.pol)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			if tt.wantWarnings {
+				assert.NotEmpty(t, warnings)
+			} else {
+				assert.Empty(t, warnings)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package compiler
 
 import (
 	"github.com/google/cel-go/cel"
+	"github.com/kyverno/kyverno/api/kyverno"
 	policiesv1alpha1 "github.com/kyverno/kyverno/api/policies.kyverno.io/v1alpha1"
 	"github.com/kyverno/kyverno/pkg/cel/compiler"
 	"github.com/kyverno/kyverno/pkg/cel/libs/globalcontext"
@@ -10,6 +11,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/cel/libs/imagedata"
 	"github.com/kyverno/kyverno/pkg/cel/libs/resource"
 	"github.com/kyverno/kyverno/pkg/cel/libs/user"
+	"github.com/kyverno/kyverno/pkg/httpguard"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	apiservercel "k8s.io/apiserver/pkg/cel"
 )
@@ -25,15 +27,29 @@ func NewCompiler() Compiler {
 type compilerImpl struct{}
 
 func (c *compilerImpl) Compile(policy *policiesv1alpha1.ValidatingPolicy, exceptions []*policiesv1alpha1.PolicyException) (*Policy, field.ErrorList) {
+	httpTimeout, err := httpguard.PolicyTimeout(policy)
+	if err != nil {
+		path := field.NewPath("metadata", "annotations").Key(kyverno.AnnotationPolicyHTTPTimeout)
+		return nil, field.ErrorList{field.Invalid(path, policy.GetAnnotations()[kyverno.AnnotationPolicyHTTPTimeout], err.Error())}
+	}
+	costLimits := compiler.PolicyCostLimits(policy.Spec.CostConfiguration())
+	var compiled *Policy
+	var errs field.ErrorList
 	switch policy.GetSpec().EvaluationMode() {
 	case policiesv1alpha1.EvaluationModeJSON:
-		return c.compileForJSON(policy, exceptions)
+		compiled, errs = c.compileForJSON(policy, exceptions, costLimits)
 	default:
-		return c.compileForKubernetes(policy, exceptions)
+		compiled, errs = c.compileForKubernetes(policy, exceptions, costLimits)
+	}
+	if compiled != nil {
+		compiled.name = policy.GetName()
+		compiled.httpTimeout = httpTimeout
+		compiled.costLimits = costLimits
 	}
+	return compiled, errs
 }
 
-func (c *compilerImpl) compileForJSON(policy *policiesv1alpha1.ValidatingPolicy, exceptions []*policiesv1alpha1.PolicyException) (*Policy, field.ErrorList) {
+func (c *compilerImpl) compileForJSON(policy *policiesv1alpha1.ValidatingPolicy, exceptions []*policiesv1alpha1.PolicyException, costLimits compiler.CostLimits) (*Policy, field.ErrorList) {
 	var allErrs field.ErrorList
 	base, err := compiler.NewBaseEnv()
 	if err != nil {
@@ -49,6 +65,7 @@ func (c *compilerImpl) compileForJSON(policy *policiesv1alpha1.ValidatingPolicy,
 
 	options := []cel.EnvOption{
 		cel.Variable(compiler.ObjectKey, cel.DynType),
+		compiler.CostLib(costLimits.Expression),
 	}
 
 	options = append(options, declOptions...)
@@ -93,6 +110,10 @@ func (c *compilerImpl) compileForJSON(policy *policiesv1alpha1.ValidatingPolicy,
 			validations = append(validations, program)
 		}
 	}
+	costEstimates, errs := estimateCosts(path, env, policy)
+	if errs != nil {
+		return nil, append(allErrs, errs...)
+	}
 
 	return &Policy{
 		mode:            policiesv1alpha1.EvaluationModeJSON,
@@ -100,10 +121,11 @@ func (c *compilerImpl) compileForJSON(policy *policiesv1alpha1.ValidatingPolicy,
 		matchConditions: matchConditions,
 		variables:       variables,
 		validations:     validations,
+		costEstimates:   costEstimates,
 	}, nil
 }
 
-func (c *compilerImpl) compileForKubernetes(policy *policiesv1alpha1.ValidatingPolicy, exceptions []*policiesv1alpha1.PolicyException) (*Policy, field.ErrorList) {
+func (c *compilerImpl) compileForKubernetes(policy *policiesv1alpha1.ValidatingPolicy, exceptions []*policiesv1alpha1.PolicyException, costLimits compiler.CostLimits) (*Policy, field.ErrorList) {
 	var allErrs field.ErrorList
 	base, err := compiler.NewBaseEnv()
 	if err != nil {
@@ -114,6 +136,7 @@ func (c *compilerImpl) compileForKubernetes(policy *policiesv1alpha1.ValidatingP
 		cel.Variable(compiler.ObjectKey, cel.DynType),
 		cel.Variable(compiler.OldObjectKey, cel.DynType),
 		cel.Variable(compiler.RequestKey, compiler.RequestType.CelType()),
+		compiler.CostLib(costLimits.Expression),
 	}
 	var declTypes []*apiservercel.DeclType
 	declTypes = append(declTypes, compiler.NamespaceType, compiler.RequestType)
@@ -178,6 +201,10 @@ func (c *compilerImpl) compileForKubernetes(policy *policiesv1alpha1.ValidatingP
 	if errs != nil {
 		return nil, append(allErrs, errs...)
 	}
+	costEstimates, errs := estimateCosts(path, env, policy)
+	if errs != nil {
+		return nil, append(allErrs, errs...)
+	}
 	// exceptions' match conditions
 	compiledExceptions := make([]compiler.Exception, 0, len(exceptions))
 	for _, polex := range exceptions {
@@ -198,5 +225,41 @@ func (c *compilerImpl) compileForKubernetes(policy *policiesv1alpha1.ValidatingP
 		validations:      validations,
 		auditAnnotations: auditAnnotations,
 		exceptions:       compiledExceptions,
+		costEstimates:    costEstimates,
 	}, nil
 }
+
+// estimateCosts estimates the cost of the policy expressions, the environment must declare the policy variables
+func estimateCosts(path *field.Path, env *cel.Env, policy *policiesv1alpha1.ValidatingPolicy) (compiler.CostEstimates, field.ErrorList) {
+	var estimates compiler.CostEstimates
+	var allErrs field.ErrorList
+	estimate := func(path *field.Path, expression string) {
+		if expression == "" {
+			return
+		}
+		cost, err := compiler.EstimateCost(path, env, expression)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(path, expression, err.Error()))
+			return
+		}
+		estimates = append(estimates, cost)
+	}
+	for i, matchCondition := range policy.Spec.MatchConditions {
+		estimate(path.Child("matchConditions").Index(i).Child("expression"), matchCondition.Expression)
+	}
+	for i, variable := range policy.Spec.Variables {
+		estimate(path.Child("variables").Index(i).Child("expression"), variable.Expression)
+	}
+	for i, validation := range policy.Spec.Validations {
+		estimate(path.Child("validations").Index(i).Child("expression"), validation.Expression)
+		estimate(path.Child("validations").Index(i).Child("messageExpression"), validation.MessageExpression)
+	}
+	// audit annotations are not evaluated in JSON mode
+	if policy.Spec.EvaluationMode() == policiesv1alpha1.EvaluationModeJSON {
+		return estimates, allErrs
+	}
+	for i, auditAnnotation := range policy.Spec.AuditAnnotations {
+		estimate(path.Child("auditAnnotations").Index(i).Child("valueExpression"), auditAnnotation.ValueExpression)
+	}
+	return estimates, allErrs
+}
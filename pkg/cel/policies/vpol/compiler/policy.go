@@ -3,6 +3,7 @@ package compiler
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/google/cel-go/cel"
 	"github.com/google/cel-go/common/types"
@@ -24,6 +25,7 @@ import (
 )
 
 type Policy struct {
+	name             string
 	mode             policiesv1alpha1.EvaluationMode
 	failurePolicy    admissionregistrationv1.FailurePolicyType
 	matchConditions  []cel.Program
@@ -31,6 +33,19 @@ type Policy struct {
 	validations      []compiler.Validation
 	auditAnnotations map[string]cel.Program
 	exceptions       []compiler.Exception
+	httpTimeout      time.Duration
+	costLimits       compiler.CostLimits
+	costEstimates    compiler.CostEstimates
+}
+
+// CostLimits returns the cost limits the policy was compiled with
+func (p *Policy) CostLimits() compiler.CostLimits {
+	return p.costLimits
+}
+
+// CostEstimates returns the static cost estimates of the policy expressions
+func (p *Policy) CostEstimates() compiler.CostEstimates {
+	return p.costEstimates
 }
 
 func (p *Policy) Evaluate(
@@ -78,11 +93,13 @@ func (p *Policy) evaluateWithData(
 	ctx context.Context,
 	data evaluationData,
 ) (*EvaluationResult, error) {
+	budget := compiler.NewBudget(p.costLimits.Policy)
+	defer budget.Record(ctx, "ValidatingPolicy", p.name)
 	// check if the resource matches an exception
 	if len(p.exceptions) > 0 {
 		matchedExceptions := make([]*policiesv1alpha1.PolicyException, 0)
 		for _, polex := range p.exceptions {
-			match, err := p.match(ctx, data.Namespace, data.Object, data.OldObject, data.Request, polex.MatchConditions)
+			match, err := p.match(ctx, budget, data.Namespace, data.Object, data.OldObject, data.Request, polex.MatchConditions)
 			if err != nil {
 				return nil, err
 			}
@@ -94,7 +111,7 @@ func (p *Policy) evaluateWithData(
 			return &EvaluationResult{Exceptions: matchedExceptions}, nil
 		}
 	}
-	match, err := p.match(ctx, data.Namespace, data.Object, data.OldObject, data.Request, p.matchConditions)
+	match, err := p.match(ctx, budget, data.Namespace, data.Object, data.OldObject, data.Request, p.matchConditions)
 	if err != nil {
 		return nil, err
 	}
@@ -104,7 +121,7 @@ func (p *Policy) evaluateWithData(
 	vars := lazy.NewMapValue(compiler.VariablesType)
 	dataNew := map[string]any{
 		compiler.GlobalContextKey:   globalcontext.Context{ContextInterface: data.Context},
-		compiler.HttpKey:            http.Context{ContextInterface: http.NewHTTP(nil)},
+		compiler.HttpKey:            http.Context{ContextInterface: http.NewHTTP(nil, http.WithTimeout(p.httpTimeout))},
 		compiler.ImageDataKey:       imagedata.Context{ContextInterface: data.Context},
 		compiler.NamespaceObjectKey: data.Namespace,
 		compiler.ObjectKey:          data.Object,
@@ -115,7 +132,7 @@ func (p *Policy) evaluateWithData(
 	}
 	for name, variable := range p.variables {
 		vars.Append(name, func(*lazy.MapValue) ref.Val {
-			out, _, err := variable.ContextEval(ctx, dataNew)
+			out, _, err := budget.ContextEval(ctx, variable, dataNew)
 			if out != nil {
 				return out
 			}
@@ -126,7 +143,7 @@ func (p *Policy) evaluateWithData(
 		})
 	}
 	for index, validation := range p.validations {
-		out, _, err := validation.Program.ContextEval(ctx, dataNew)
+		out, _, err := budget.ContextEval(ctx, validation.Program, dataNew)
 		if err != nil {
 			return nil, err
 		}
@@ -134,7 +151,7 @@ func (p *Policy) evaluateWithData(
 		if outcome, err := utils.ConvertToNative[bool](out); err == nil && !outcome {
 			message := validation.Message
 			if validation.MessageExpression != nil {
-				if out, _, err := validation.MessageExpression.ContextEval(ctx, dataNew); err != nil {
+				if out, _, err := budget.ContextEval(ctx, validation.MessageExpression, dataNew); err != nil {
 					message = fmt.Sprintf("failed to evaluate message expression: %s", err)
 				} else if msg, err := utils.ConvertToNative[string](out); err != nil {
 					message = fmt.Sprintf("failed to convert message expression to string: %s", err)
@@ -144,7 +161,7 @@ func (p *Policy) evaluateWithData(
 			}
 			auditAnnotations := make(map[string]string, 0)
 			for key, annotation := range p.auditAnnotations {
-				out, _, err := annotation.ContextEval(ctx, dataNew)
+				out, _, err := budget.ContextEval(ctx, annotation, dataNew)
 				if err != nil {
 					return nil, fmt.Errorf("failed to evaluate auditAnnotation '%s': %w", key, err)
 				}
@@ -172,6 +189,7 @@ func (p *Policy) evaluateWithData(
 
 func (p *Policy) match(
 	ctx context.Context,
+	budget *compiler.Budget,
 	namespaceVal any,
 	objectVal any,
 	oldObjectVal any,
@@ -187,7 +205,7 @@ func (p *Policy) match(
 	var errs []error
 	for _, matchCondition := range matchConditions {
 		// evaluate the condition
-		out, _, err := matchCondition.ContextEval(ctx, data)
+		out, _, err := budget.ContextEval(ctx, matchCondition, data)
 		// check error
 		if err != nil {
 			errs = append(errs, err)
@@ -0,0 +1,249 @@
+package convert
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/kyverno/kyverno/ext/wildcard"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+var (
+	identifier = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+	// reserved are the CEL keywords and reserved words that can't be used as field names in selections
+	reserved = sets.New(
+		"true", "false", "null", "in",
+		"as", "break", "const", "continue", "else", "for", "function", "if",
+		"import", "let", "loop", "package", "namespace", "return", "var", "void", "while",
+	)
+)
+
+func isIdentifier(name string) bool {
+	return identifier.MatchString(name) && !reserved.Has(name)
+}
+
+// quote returns a CEL string literal, single quotes are preferred because expressions end up in YAML documents
+func quote(value string) string {
+	if !strings.ContainsAny(value, `'\`) && !strings.ContainsFunc(value, func(r rune) bool { return !unicode.IsPrint(r) }) {
+		return "'" + value + "'"
+	}
+	return strconv.Quote(value)
+}
+
+// selectField returns the CEL expressions accessing a field of a map and testing its presence
+func selectField(base string, name string) (string, string) {
+	if isIdentifier(name) {
+		return base + "." + name, "has(" + base + "." + name + ")"
+	}
+	return base + "[" + quote(name) + "]", quote(name) + " in " + base
+}
+
+// literal returns the CEL literal of a JSON value
+func literal(value any) (string, error) {
+	switch typed := value.(type) {
+	case nil:
+		return "null", nil
+	case bool:
+		return strconv.FormatBool(typed), nil
+	case string:
+		return quote(typed), nil
+	case int:
+		return strconv.Itoa(typed), nil
+	case int64:
+		return strconv.FormatInt(typed, 10), nil
+	case float64:
+		if typed == math.Trunc(typed) && math.Abs(typed) < 1e15 {
+			return strconv.FormatInt(int64(typed), 10), nil
+		}
+		return strconv.FormatFloat(typed, 'g', -1, 64), nil
+	case []any:
+		items := make([]string, 0, len(typed))
+		for _, item := range typed {
+			item, err := literal(item)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, item)
+		}
+		return "[" + strings.Join(items, ", ") + "]", nil
+	case map[string]any:
+		keys := make([]string, 0, len(typed))
+		for key := range typed {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+		entries := make([]string, 0, len(typed))
+		for _, key := range keys {
+			value, err := literal(typed[key])
+			if err != nil {
+				return "", err
+			}
+			entries = append(entries, quote(key)+": "+value)
+		}
+		return "{" + strings.Join(entries, ", ") + "}", nil
+	default:
+		return "", fmt.Errorf("unsupported value type %T", value)
+	}
+}
+
+// wildcardRegex translates a Kyverno wildcard expression to an anchored regular expression
+func wildcardRegex(pattern string) string {
+	var builder strings.Builder
+	builder.WriteString("^")
+	for _, r := range pattern {
+		switch r {
+		case '*':
+			builder.WriteString(".*")
+		case '?':
+			builder.WriteString(".")
+		default:
+			builder.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	builder.WriteString("$")
+	return builder.String()
+}
+
+// matchString returns the CEL expression comparing a string with a value supporting Kyverno wildcards
+func matchString(value string, pattern string) string {
+	if pattern == "*" {
+		return "true"
+	}
+	if wildcard.ContainsWildcard(pattern) {
+		return value + ".matches(" + quote(wildcardRegex(pattern)) + ")"
+	}
+	return value + " == " + quote(pattern)
+}
+
+// matchStrings returns the CEL expression testing that a string matches one of the values supporting Kyverno wildcards
+func matchStrings(value string, patterns []string) string {
+	var exact, wildcards []string
+	for _, pattern := range patterns {
+		if wildcard.ContainsWildcard(pattern) {
+			wildcards = append(wildcards, pattern)
+		} else {
+			exact = append(exact, pattern)
+		}
+	}
+	var terms []string
+	if len(exact) == 1 {
+		terms = append(terms, matchString(value, exact[0]))
+	} else if len(exact) > 1 {
+		quoted := make([]string, 0, len(exact))
+		for _, item := range exact {
+			quoted = append(quoted, quote(item))
+		}
+		terms = append(terms, value+" in ["+strings.Join(quoted, ", ")+"]")
+	}
+	for _, pattern := range wildcards {
+		terms = append(terms, matchString(value, pattern))
+	}
+	return or(terms...)
+}
+
+// and joins terms with a logical and, terms that are always true are dropped
+func and(terms ...string) string {
+	return join(" && ", "true", []string{"||", " ? "}, terms...)
+}
+
+// or joins terms with a logical or, terms that are always false are dropped
+func or(terms ...string) string {
+	return join(" || ", "false", []string{"&&", " ? "}, terms...)
+}
+
+// join joins terms with a logical operator, terms using operators that could be confused with it are wrapped in parentheses
+func join(operator string, neutral string, confusing []string, terms ...string) string {
+	var filtered []string
+	for _, term := range terms {
+		// an absorbing term decides the result
+		if term == not(neutral) {
+			return term
+		}
+		if term != neutral && term != "" {
+			filtered = append(filtered, term)
+		}
+	}
+	switch len(filtered) {
+	case 0:
+		return neutral
+	case 1:
+		return filtered[0]
+	}
+	for i := range filtered {
+		if !isOperand(filtered[i]) && hasTopLevel(filtered[i], confusing...) {
+			filtered[i] = "(" + filtered[i] + ")"
+		}
+	}
+	return strings.Join(filtered, operator)
+}
+
+// not negates an expression
+func not(term string) string {
+	switch term {
+	case "true":
+		return "false"
+	case "false":
+		return "true"
+	}
+	if strings.HasPrefix(term, "!") && isOperand(term) {
+		return term[1:]
+	}
+	return "!" + group(term)
+}
+
+// group wraps an expression in parentheses unless it is a single operand
+func group(term string) string {
+	if isOperand(term) {
+		return term
+	}
+	return "(" + term + ")"
+}
+
+// isOperand returns true if the expression has no top level binary or ternary operator
+func isOperand(term string) bool {
+	// a leading negation applies to the following operand only
+	return !hasTopLevel(term, " ") && !hasTopLevel(strings.TrimPrefix(term, "!"), "!")
+}
+
+// hasTopLevel returns true if the expression contains one of the tokens outside of string literals, parentheses, brackets and braces
+func hasTopLevel(term string, tokens ...string) bool {
+	depth := 0
+	var quote byte
+	escaped := false
+	for i := 0; i < len(term); i++ {
+		c := term[i]
+		if quote != 0 {
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == quote:
+				quote = 0
+			}
+			continue
+		}
+		switch c {
+		case '\'', '"':
+			quote = c
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			depth--
+		default:
+			if depth == 0 {
+				for _, token := range tokens {
+					if strings.HasPrefix(term[i:], token) {
+						return true
+					}
+				}
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,164 @@
+package convert
+
+import (
+	"strconv"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// conditions translates preconditions or deny conditions, either a legacy list of conditions that must all be true or an any/all block
+func conditions(path *field.Path, t *translator, wrapped any) (string, error) {
+	switch typed := wrapped.(type) {
+	case nil:
+		return "true", nil
+	case []kyvernov1.Condition:
+		return all(path, t, typed)
+	case kyvernov1.AnyAllConditions:
+		return anyAll(path, t, typed)
+	case *kyvernov1.AnyAllConditions:
+		if typed == nil {
+			return "true", nil
+		}
+		return anyAll(path, t, *typed)
+	}
+	return "", unsupported(path, "unexpected conditions type %T", wrapped)
+}
+
+func anyAll(path *field.Path, t *translator, block kyvernov1.AnyAllConditions) (string, error) {
+	var terms []string
+	if len(block.AnyConditions) != 0 {
+		var alternatives []string
+		for i, item := range block.AnyConditions {
+			term, err := condition(path.Child("any").Index(i), t, item)
+			if err != nil {
+				return "", err
+			}
+			alternatives = append(alternatives, term)
+		}
+		terms = append(terms, or(alternatives...))
+	}
+	term, err := all(path.Child("all"), t, block.AllConditions)
+	if err != nil {
+		return "", err
+	}
+	return and(append(terms, term)...), nil
+}
+
+func all(path *field.Path, t *translator, items []kyvernov1.Condition) (string, error) {
+	var terms []string
+	for i, item := range items {
+		term, err := condition(path.Index(i), t, item)
+		if err != nil {
+			return "", err
+		}
+		terms = append(terms, term)
+	}
+	return and(terms...), nil
+}
+
+func condition(path *field.Path, t *translator, item kyvernov1.Condition) (string, error) {
+	rawKey, rawValue := item.GetKey(), item.GetValue()
+	key, err := t.value(rawKey)
+	if err != nil {
+		return "", unsupported(path.Child("key"), "%s", err)
+	}
+	value, err := t.value(rawValue)
+	if err != nil {
+		return "", unsupported(path.Child("value"), "%s", err)
+	}
+	_, keyIsList := rawKey.([]any)
+	keyIsList = keyIsList || key.list
+	// member returns the CEL expression testing that an item belongs to the condition value
+	member := func(item string) string {
+		switch typed := rawValue.(type) {
+		case []any:
+			patterns := make([]string, 0, len(typed))
+			for _, pattern := range typed {
+				if pattern, ok := pattern.(string); ok && !hasVariables(pattern) {
+					patterns = append(patterns, pattern)
+				}
+			}
+			if len(patterns) == len(typed) {
+				return matchStrings(item, patterns)
+			}
+			return item + " in " + value.cel
+		case string:
+			if !hasVariables(typed) {
+				return matchString(item, typed)
+			}
+		}
+		return group(item) + " in " + group(value.cel)
+	}
+	switch item.Operator {
+	case "Equal", "Equals":
+		return equality(key.cel, rawValue, value.cel), nil
+	case "NotEqual", "NotEquals":
+		return not(equality(key.cel, rawValue, value.cel)), nil
+	case "In", "AllIn":
+		if keyIsList {
+			return group(key.cel) + ".all(k, " + member("k") + ")", nil
+		}
+		return member(key.cel), nil
+	case "AnyIn":
+		if keyIsList {
+			return group(key.cel) + ".exists(k, " + member("k") + ")", nil
+		}
+		return member(key.cel), nil
+	case "NotIn":
+		if keyIsList {
+			return not(group(key.cel) + ".all(k, " + member("k") + ")"), nil
+		}
+		return not(member(key.cel)), nil
+	case "AnyNotIn":
+		if keyIsList {
+			return group(key.cel) + ".exists(k, " + not(member("k")) + ")", nil
+		}
+		return not(member(key.cel)), nil
+	case "AllNotIn":
+		if keyIsList {
+			return group(key.cel) + ".all(k, " + not(member("k")) + ")", nil
+		}
+		return not(member(key.cel)), nil
+	case "GreaterThanOrEquals", "GreaterThan", "LessThanOrEquals", "LessThan":
+		operator := comparisons[item.Operator]
+		switch typed := rawValue.(type) {
+		case float64, int, int64:
+			return "double(" + key.cel + ") " + operator + " " + value.cel, nil
+		case string:
+			if !hasVariables(typed) {
+				if number, err := strconv.ParseFloat(typed, 64); err == nil {
+					return "double(" + key.cel + ") " + operator + " " + strconv.FormatFloat(number, 'f', -1, 64), nil
+				}
+				if _, err := time.ParseDuration(typed); err == nil {
+					return "duration(string(" + key.cel + ")) " + operator + " duration(" + value.cel + ")", nil
+				}
+				return "", unsupported(path.Child("value"), "comparisons with %q are not supported, only numbers and durations can be compared", typed)
+			}
+		}
+		return "double(" + key.cel + ") " + operator + " double(" + value.cel + ")", nil
+	case "DurationGreaterThanOrEquals", "DurationGreaterThan", "DurationLessThanOrEquals", "DurationLessThan":
+		return "duration(string(" + key.cel + ")) " + comparisons[item.Operator] + " duration(string(" + value.cel + "))", nil
+	}
+	return "", unsupported(path.Child("operator"), "operator %s is not supported", item.Operator)
+}
+
+var comparisons = map[kyvernov1.ConditionOperator]string{
+	"GreaterThanOrEquals":         ">=",
+	"GreaterThan":                 ">",
+	"LessThanOrEquals":            "<=",
+	"LessThan":                    "<",
+	"DurationGreaterThanOrEquals": ">=",
+	"DurationGreaterThan":         ">",
+	"DurationLessThanOrEquals":    "<=",
+	"DurationLessThan":            "<",
+}
+
+// equality returns the CEL expression comparing a key with a value, string values support wildcards
+func equality(key string, raw any, value string) string {
+	if typed, ok := raw.(string); ok && !hasVariables(typed) {
+		return matchString(key, typed)
+	}
+	return group(key) + " == " + group(value)
+}
@@ -0,0 +1,185 @@
+package convert
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var invalidIdentifierCharacters = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// variables translates context entries to variables, it returns the variables and the names of the translated entries
+func (c *ruleConverter) variables(path *field.Path, entries []kyvernov1.ContextEntry) ([]admissionregistrationv1.Variable, sets.Set[string]) {
+	var variables []admissionregistrationv1.Variable
+	names := sets.New[string]()
+	declared := sets.New[string]()
+	for i, entry := range entries {
+		path := path.Index(i)
+		name := variableName(entry.Name)
+		if declared.Has(name) {
+			c.unsupported(path.Child("name"), "the variable "+name+" is already declared by another context entry")
+			continue
+		}
+		expression, err := c.contextEntry(path, newTranslator(names), entry)
+		if err != nil {
+			c.report(path, err)
+			continue
+		}
+		variables = append(variables, admissionregistrationv1.Variable{Name: name, Expression: expression})
+		names.Insert(entry.Name)
+		declared.Insert(name)
+	}
+	return variables, names
+}
+
+// variableName returns the CEL variable name of a context entry, characters that are not allowed in identifiers are replaced
+func variableName(name string) string {
+	name = invalidIdentifierCharacters.ReplaceAllString(name, "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "_" + name
+	}
+	if reserved.Has(name) {
+		name += "_"
+	}
+	return name
+}
+
+func (c *ruleConverter) contextEntry(path *field.Path, t *translator, entry kyvernov1.ContextEntry) (string, error) {
+	switch {
+	case entry.Variable != nil:
+		path := path.Child("variable")
+		if entry.Variable.Default != nil {
+			c.unsupported(path.Child("default"), "default values are not supported, use optional types in the expressions using the variable")
+		}
+		var value string
+		if entry.Variable.Value != nil {
+			raw := kyverno.FromAny(entry.Variable.Value)
+			if _, ok := raw.(map[string]any); ok && strings.Contains(toString(raw), "{{") {
+				return "", unsupported(path.Child("value"), "variables in object values are not supported")
+			}
+			translated, err := t.value(raw)
+			if err != nil {
+				return "", unsupported(path.Child("value"), "%s", err)
+			}
+			value = translated.cel
+		}
+		if entry.Variable.JMESPath == "" {
+			return value, nil
+		}
+		if value != "" {
+			t = t.withRoot(group(value))
+		}
+		translated, err := t.jmespath(entry.Variable.JMESPath)
+		if err != nil {
+			return "", unsupported(path.Child("jmesPath"), "%s", err)
+		}
+		return translated.cel, nil
+	case entry.ConfigMap != nil:
+		path := path.Child("configMap")
+		name, err := t.template(entry.ConfigMap.Name)
+		if err != nil {
+			return "", unsupported(path.Child("name"), "%s", err)
+		}
+		namespace := expression{cel: "'default'"}
+		if entry.ConfigMap.Namespace != "" {
+			namespace, err = t.template(entry.ConfigMap.Namespace)
+			if err != nil {
+				return "", unsupported(path.Child("namespace"), "%s", err)
+			}
+		}
+		return "dyn(resource.Get('v1', 'configmaps', " + namespace.cel + ", " + name.cel + "))", nil
+	case entry.APICall != nil:
+		path := path.Child("apiCall")
+		if entry.APICall.Service != nil {
+			return "", unsupported(path.Child("service"), "service calls are not supported, use the http library in a variable instead")
+		}
+		if entry.APICall.Method != "" && entry.APICall.Method != "GET" {
+			return "", unsupported(path.Child("method"), "only GET requests are supported")
+		}
+		if entry.APICall.Default != nil {
+			c.unsupported(path.Child("default"), "default values are not supported, use optional types in the expressions using the variable")
+		}
+		call, err := apiCall(path.Child("urlPath"), t, entry.APICall.URLPath)
+		if err != nil {
+			return "", err
+		}
+		if entry.APICall.JMESPath == "" {
+			return call, nil
+		}
+		translated, err := t.withRoot(call).jmespath(entry.APICall.JMESPath)
+		if err != nil {
+			return "", unsupported(path.Child("jmesPath"), "%s", err)
+		}
+		return translated.cel, nil
+	case entry.GlobalReference != nil:
+		path := path.Child("globalReference")
+		call := "globalContext.Get(" + quote(entry.GlobalReference.Name) + ", '')"
+		if entry.GlobalReference.JMESPath == "" {
+			return call, nil
+		}
+		translated, err := t.withRoot(call).jmespath(entry.GlobalReference.JMESPath)
+		if err != nil {
+			return "", unsupported(path.Child("jmesPath"), "%s", err)
+		}
+		return translated.cel, nil
+	case entry.ImageRegistry != nil:
+		return "", unsupported(path.Child("imageRegistry"), "image registry entries are not supported, use the image and imagedata libraries instead")
+	}
+	return "", unsupported(path, "empty context entry")
+}
+
+// apiCall translates the URL path of a Kubernetes API call to a resource.Get or resource.List call,
+// the result is dynamic because the nested fields of the declared map type can't be iterated
+func apiCall(path *field.Path, t *translator, urlPath string) (string, error) {
+	if strings.Contains(variable.ReplaceAllString(urlPath, ""), "?") {
+		return "", unsupported(path, "query parameters are not supported")
+	}
+	segments := strings.Split(strings.Trim(urlPath, "/"), "/")
+	var apiVersion string
+	switch {
+	case len(segments) >= 3 && segments[0] == "api":
+		apiVersion, segments = segments[1], segments[2:]
+	case len(segments) >= 4 && segments[0] == "apis":
+		apiVersion, segments = segments[1]+"/"+segments[2], segments[3:]
+	default:
+		return "", unsupported(path, "only Kubernetes API paths are supported")
+	}
+	if hasVariables(apiVersion) {
+		return "", unsupported(path, "variables in API versions are not supported")
+	}
+	namespace := expression{cel: "''"}
+	if len(segments) >= 3 && segments[0] == "namespaces" {
+		translated, err := t.template(unescape(segments[1]))
+		if err != nil {
+			return "", unsupported(path, "%s", err)
+		}
+		namespace, segments = translated, segments[2:]
+	}
+	if len(segments) > 2 {
+		return "", unsupported(path, "subresources are not supported")
+	}
+	if hasVariables(segments[0]) {
+		return "", unsupported(path, "variables in resource names are not supported")
+	}
+	if len(segments) == 1 {
+		return "dyn(resource.List(" + quote(apiVersion) + ", " + quote(segments[0]) + ", " + namespace.cel + "))", nil
+	}
+	name, err := t.template(unescape(segments[1]))
+	if err != nil {
+		return "", unsupported(path, "%s", err)
+	}
+	return "dyn(resource.Get(" + quote(apiVersion) + ", " + quote(segments[0]) + ", " + namespace.cel + ", " + name.cel + "))", nil
+}
+
+func unescape(segment string) string {
+	if unescaped, err := url.PathUnescape(segment); err == nil {
+		return unescaped
+	}
+	return segment
+}
@@ -0,0 +1,345 @@
+package convert
+
+import (
+	"errors"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	policiesv1alpha1 "github.com/kyverno/kyverno/api/policies.kyverno.io/v1alpha1"
+	"github.com/kyverno/kyverno/pkg/cel/autogen"
+	"github.com/kyverno/kyverno/pkg/cel/policies/vpol/compiler"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+)
+
+var invalidNameCharacters = regexp.MustCompile(`[^a-z0-9.-]+`)
+
+// Issue is a part of a Kyverno policy that could not be translated faithfully
+type Issue struct {
+	// Policy is the name of the converted policy, prefixed with its namespace for namespaced policies
+	Policy string `json:"policy"`
+	// Rule is the name of the rule the issue belongs to
+	Rule string `json:"rule,omitempty"`
+	// Path is the path of the field that could not be translated
+	Path string `json:"path"`
+	// Message describes the issue
+	Message string `json:"message"`
+}
+
+func (i Issue) String() string {
+	return i.Path + ": " + i.Message
+}
+
+// Result is the result of the conversion of a Kyverno policy
+type Result struct {
+	// Policies are the validating policies generated from the validate rules, one per rule
+	Policies []*policiesv1alpha1.ValidatingPolicy
+	// Issues are the parts of the policy that need to be reviewed
+	Issues []Issue
+}
+
+// Convert translates the validate rules of a Kyverno policy to validating policies.
+// Everything that can't be translated is reported as an issue and recorded in the
+// policies.kyverno.io/conversion-todo annotation of the generated policy.
+func Convert(policy kyvernov1.PolicyInterface) Result {
+	spec := policy.GetSpec()
+	key := policy.GetName()
+	if policy.GetNamespace() != "" {
+		key = policy.GetNamespace() + "/" + key
+	}
+	var result Result
+	report := func(rule string, path *field.Path, message string) {
+		result.Issues = append(result.Issues, Issue{Policy: key, Rule: rule, Path: path.String(), Message: message})
+	}
+	if len(spec.ValidationFailureActionOverrides) != 0 {
+		report("", field.NewPath("spec", "validationFailureActionOverrides"), "failure action overrides are not supported, split the policy per namespace instead")
+	}
+	validateRules := 0
+	for _, rule := range spec.Rules {
+		if rule.HasValidate() {
+			validateRules++
+		}
+	}
+	for i, rule := range spec.Rules {
+		path := field.NewPath("spec", "rules").Index(i)
+		if !rule.HasValidate() {
+			report(rule.Name, path, "only validate rules can be converted to validating policies")
+			continue
+		}
+		converter := &ruleConverter{policy: policy, rule: rule, key: key, path: path}
+		result.Policies = append(result.Policies, converter.convert(validateRules == 1))
+		result.Issues = append(result.Issues, converter.issues...)
+	}
+	return result
+}
+
+// ruleConverter converts a validate rule and collects the issues found along the way
+type ruleConverter struct {
+	policy kyvernov1.PolicyInterface
+	rule   kyvernov1.Rule
+	key    string
+	path   *field.Path
+	issues []Issue
+}
+
+func (c *ruleConverter) unsupported(path *field.Path, message string) {
+	c.issues = append(c.issues, Issue{Policy: c.key, Rule: c.rule.Name, Path: path.String(), Message: message})
+}
+
+// report records a translation error, errors without a path are attributed to the fallback path
+func (c *ruleConverter) report(fallback *field.Path, err error) {
+	var unsupported unsupportedError
+	if errors.As(err, &unsupported) {
+		c.unsupported(unsupported.path, unsupported.message)
+		return
+	}
+	c.unsupported(fallback, err.Error())
+}
+
+func (c *ruleConverter) convert(single bool) *policiesv1alpha1.ValidatingPolicy {
+	spec := c.policy.GetSpec()
+	validation := c.rule.Validation
+	validationPath := c.path.Child("validate")
+	name := []string{c.policy.GetName()}
+	if c.policy.GetNamespace() != "" {
+		name = append([]string{c.policy.GetNamespace()}, name...)
+	}
+	if !single {
+		name = append(name, c.rule.Name)
+	}
+	vp := &policiesv1alpha1.ValidatingPolicy{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: policiesv1alpha1.SchemeGroupVersion.String(),
+			Kind:       "ValidatingPolicy",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        resourceName(name...),
+			Labels:      maps.Clone(c.policy.GetLabels()),
+			Annotations: c.annotations(),
+		},
+	}
+	// validation actions
+	action := spec.ValidationFailureAction
+	if validation.FailureAction != nil {
+		action = *validation.FailureAction
+	}
+	if action.Enforce() {
+		vp.Spec.ValidationAction = []admissionregistrationv1.ValidationAction{admissionregistrationv1.Deny}
+	} else {
+		vp.Spec.ValidationAction = []admissionregistrationv1.ValidationAction{admissionregistrationv1.Audit}
+	}
+	if len(validation.FailureActionOverrides) != 0 {
+		c.unsupported(validationPath.Child("failureActionOverrides"), "failure action overrides are not supported, split the policy per namespace instead")
+	}
+	if validation.AllowExistingViolations != nil && !*validation.AllowExistingViolations {
+		c.unsupported(validationPath.Child("allowExistingViolations"), "existing violations are always reported as failures")
+	}
+	// evaluation and webhook settings
+	if !spec.AdmissionProcessingEnabled() || !spec.BackgroundProcessingEnabled() {
+		vp.Spec.EvaluationConfiguration = &policiesv1alpha1.EvaluationConfiguration{}
+		if !spec.AdmissionProcessingEnabled() {
+			vp.Spec.EvaluationConfiguration.Admission = &policiesv1alpha1.AdmissionConfiguration{Enabled: ptr.To(false)}
+		}
+		if !spec.BackgroundProcessingEnabled() {
+			vp.Spec.EvaluationConfiguration.Background = &policiesv1alpha1.BackgroundConfiguration{Enabled: ptr.To(false)}
+		}
+	}
+	failurePolicy := spec.FailurePolicy
+	if spec.WebhookConfiguration != nil && spec.WebhookConfiguration.FailurePolicy != nil {
+		failurePolicy = spec.WebhookConfiguration.FailurePolicy
+	}
+	if failurePolicy != nil {
+		vp.Spec.FailurePolicy = ptr.To(admissionregistrationv1.FailurePolicyType(*failurePolicy))
+	}
+	if timeout := spec.GetWebhookTimeoutSeconds(); timeout != nil {
+		vp.Spec.WebhookConfiguration = &policiesv1alpha1.WebhookConfiguration{TimeoutSeconds: timeout}
+	}
+	c.autogen(vp)
+	// match constraints and conditions
+	constraints, matchConditions := c.convertMatch(c.path, c.rule, c.defaultOperations())
+	if constraints == nil {
+		c.unsupported(c.path.Child("match"), "no resource could be matched")
+	}
+	vp.Spec.MatchConstraints = constraints
+	if namespace := c.policy.GetNamespace(); namespace != "" {
+		matchConditions = append(matchConditions, admissionregistrationv1.MatchCondition{Name: "namespace", Expression: "request.namespace == " + quote(namespace)})
+	}
+	matchConditions = append(matchConditions, spec.GetMatchConditions()...)
+	matchConditions = append(matchConditions, c.rule.CELPreconditions...)
+	// context entries become variables, they are available to the validations but not to the match conditions
+	variables, names := c.variables(c.path.Child("context"), c.rule.Context)
+	vp.Spec.Variables = variables
+	preconditions := "true"
+	if c.rule.RawAnyAllConditions != nil {
+		t := newTranslator(names)
+		translated, err := conditions(c.path.Child("preconditions"), t, c.rule.GetAnyAllConditions())
+		switch {
+		case err != nil:
+			c.report(c.path.Child("preconditions"), err)
+		case *t.usesVariables:
+			preconditions = translated
+		case translated != "true":
+			matchConditions = append(matchConditions, admissionregistrationv1.MatchCondition{Name: "preconditions", Expression: translated})
+		}
+	}
+	vp.Spec.MatchConditions = matchConditions
+	// validations
+	t := newTranslator(names)
+	check := func(expression string) admissionregistrationv1.Validation {
+		validation := admissionregistrationv1.Validation{Expression: or(not(preconditions), expression)}
+		c.message(validationPath.Child("message"), t, &validation, c.rule.Validation.Message)
+		return validation
+	}
+	switch {
+	case validation.GetPattern() != nil || validation.GetAnyPattern() != nil || validation.Deny != nil || len(validation.ForEachValidation) != 0:
+		if expression, err := c.validation(validationPath, t, constraints); err != nil {
+			c.report(validationPath, err)
+		} else {
+			vp.Spec.Validations = append(vp.Spec.Validations, check(expression))
+		}
+	case validation.CEL != nil:
+		celPath := validationPath.Child("cel")
+		if validation.CEL.ParamKind != nil || validation.CEL.ParamRef != nil {
+			c.unsupported(celPath.Child("paramKind"), "parameter resources are not supported, use resource.Get or globalContext.Get in variables instead")
+		}
+		vp.Spec.Variables = append(vp.Spec.Variables, validation.CEL.Variables...)
+		for _, expression := range validation.CEL.Expressions {
+			expression.Expression = or(not(preconditions), expression.Expression)
+			vp.Spec.Validations = append(vp.Spec.Validations, expression)
+		}
+		vp.Spec.AuditAnnotations = validation.CEL.AuditAnnotations
+	case validation.PodSecurity != nil:
+		c.unsupported(validationPath.Child("podSecurity"), "pod security rules are not supported, write the pod security checks as CEL expressions")
+	case validation.Manifests != nil:
+		c.unsupported(validationPath.Child("manifests"), "manifest verification is not supported")
+	case validation.Assert != nil:
+		c.unsupported(validationPath.Child("assert"), "assertion trees are not supported")
+	}
+	if len(vp.Spec.Validations) == 0 {
+		c.unsupported(validationPath, "no validation could be translated")
+	} else if _, errs := compiler.NewCompiler().Compile(vp, nil); len(errs) != 0 {
+		for _, err := range errs {
+			c.unsupported(field.NewPath(err.Field), "the generated expression doesn't compile: "+err.ErrorBody())
+		}
+	}
+	if len(c.issues) != 0 {
+		if vp.Annotations == nil {
+			vp.Annotations = map[string]string{}
+		}
+		var todo []string
+		for _, issue := range c.issues {
+			todo = append(todo, "TODO "+issue.String())
+		}
+		vp.Annotations[kyverno.AnnotationConversionTodo] = strings.Join(todo, "\n")
+	}
+	return vp
+}
+
+// annotations returns the annotations of the source policy that still make sense on a validating policy
+func (c *ruleConverter) annotations() map[string]string {
+	annotations := map[string]string{}
+	for key, value := range c.policy.GetAnnotations() {
+		if key == kyverno.AnnotationAutogenControllers || key == "kubectl.kubernetes.io/last-applied-configuration" {
+			continue
+		}
+		annotations[key] = value
+	}
+	if len(annotations) == 0 {
+		return nil
+	}
+	return annotations
+}
+
+// autogen translates the autogen annotation of the source policy to the autogen configuration
+func (c *ruleConverter) autogen(vp *policiesv1alpha1.ValidatingPolicy) {
+	value, ok := c.policy.GetAnnotations()[kyverno.AnnotationAutogenControllers]
+	if !ok {
+		return
+	}
+	path := field.NewPath("metadata", "annotations").Key(kyverno.AnnotationAutogenControllers)
+	if value == "none" {
+		c.unsupported(path, "autogen can't be disabled, pod controllers are always generated when the policy only matches pods")
+		return
+	}
+	controllers := sets.New[string]()
+	for _, kind := range strings.Split(value, ",") {
+		found := false
+		for name, config := range autogen.ConfigsMap {
+			if strings.EqualFold(config.Target.Kind, strings.TrimSpace(kind)) {
+				controllers.Insert(name)
+				found = true
+			}
+		}
+		if !found {
+			c.unsupported(path, "unknown pod controller "+kind)
+		}
+	}
+	vp.Spec.AutogenConfiguration = &policiesv1alpha1.ValidatingPolicyAutogenConfiguration{
+		PodControllers: &policiesv1alpha1.PodControllersGenerationConfiguration{
+			Controllers: sets.List(controllers),
+		},
+	}
+}
+
+// defaultOperations returns the operations matched when a resource filter doesn't specify any,
+// pattern rules are skipped by Kyverno on deletion and deny rules only need deletions when they test the operation
+func (c *ruleConverter) defaultOperations() []admissionregistrationv1.OperationType {
+	operations := []admissionregistrationv1.OperationType{admissionregistrationv1.Create, admissionregistrationv1.Update}
+	if c.rule.Validation.Deny == nil {
+		return operations
+	}
+	var conditions []any
+	conditions = append(conditions, c.rule.Validation.Deny.GetAnyAllConditions(), c.rule.GetAnyAllConditions())
+	for _, item := range conditions {
+		if strings.Contains(toString(item), "request.operation") {
+			return append(operations, admissionregistrationv1.Delete, admissionregistrationv1.Connect)
+		}
+	}
+	return operations
+}
+
+// message sets the message of a validation, messages containing variables become message expressions
+func (c *ruleConverter) message(path *field.Path, t *translator, validation *admissionregistrationv1.Validation, message string) {
+	if !hasVariables(message) {
+		validation.Message = message
+		return
+	}
+	translated, err := t.template(message)
+	if err != nil {
+		c.report(path, err)
+		validation.Message = message
+		return
+	}
+	if variable.FindString(message) == message {
+		validation.MessageExpression = "string(" + translated.cel + ")"
+	} else {
+		validation.MessageExpression = translated.cel
+	}
+}
+
+// deletes returns true if the match constraints match deletions
+func deletes(constraints *admissionregistrationv1.MatchResources) bool {
+	if constraints == nil {
+		return false
+	}
+	for _, rule := range constraints.ResourceRules {
+		if slices.Contains(rule.Operations, admissionregistrationv1.Delete) || slices.Contains(rule.Operations, admissionregistrationv1.OperationAll) {
+			return true
+		}
+	}
+	return false
+}
+
+// resourceName returns a valid resource name made of the given parts
+func resourceName(parts ...string) string {
+	name := strings.ToLower(strings.Join(parts, "-"))
+	name = invalidNameCharacters.ReplaceAllString(name, "-")
+	return strings.Trim(name, "-.")
+}
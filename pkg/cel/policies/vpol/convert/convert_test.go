@@ -0,0 +1,363 @@
+package convert
+
+import (
+	"testing"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+func load(t *testing.T, document string) *kyvernov1.ClusterPolicy {
+	t.Helper()
+	var policy kyvernov1.ClusterPolicy
+	require.NoError(t, yaml.Unmarshal([]byte(document), &policy))
+	return &policy
+}
+
+func TestConvertPattern(t *testing.T) {
+	policy := load(t, `
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: disallow-latest-tag
+spec:
+  validationFailureAction: Enforce
+  rules:
+  - name: validate-image-tag
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    validate:
+      message: Using a mutable image tag e.g. 'latest' is not allowed.
+      pattern:
+        spec:
+          =(initContainers):
+          - image: "!*:latest"
+          containers:
+          - (name): "!istio-*"
+            image: "!*:latest"
+            X(hostPort): "null"
+            =(resources):
+              =(requests):
+                =(memory): "?*"
+`)
+	result := Convert(policy)
+	assert.Empty(t, result.Issues)
+	require.Len(t, result.Policies, 1)
+	vp := result.Policies[0]
+	assert.Equal(t, "disallow-latest-tag", vp.Name)
+	assert.Equal(t, []admissionregistrationv1.ValidationAction{admissionregistrationv1.Deny}, vp.Spec.ValidationAction)
+	assert.Equal(t, &admissionregistrationv1.MatchResources{
+		ResourceRules: []admissionregistrationv1.NamedRuleWithOperations{{
+			RuleWithOperations: admissionregistrationv1.RuleWithOperations{
+				Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create, admissionregistrationv1.Update},
+				Rule: admissionregistrationv1.Rule{
+					APIGroups:   []string{""},
+					APIVersions: []string{"v1"},
+					Resources:   []string{"pods"},
+				},
+			},
+		}},
+	}, vp.Spec.MatchConstraints)
+	assert.Empty(t, vp.Spec.MatchConditions)
+	assert.Equal(t, []admissionregistrationv1.Validation{{
+		Expression: "has(object.spec) && " +
+			"(!has(object.spec.initContainers) || object.spec.initContainers.all(initContainer, has(initContainer.image) && !initContainer.image.matches('^.*:latest$'))) && " +
+			"has(object.spec.containers) && object.spec.containers.all(container, " +
+			"!(has(container.name) && !container.name.matches('^istio-.*$')) || " +
+			"((!has(container.resources) || !has(container.resources.requests) || !has(container.resources.requests.memory) || string(container.resources.requests.memory) != '') && " +
+			"!has(container.hostPort) && has(container.image) && !container.image.matches('^.*:latest$')))",
+		Message: "Using a mutable image tag e.g. 'latest' is not allowed.",
+	}}, vp.Spec.Validations)
+	assert.NotContains(t, vp.Annotations, kyverno.AnnotationConversionTodo)
+}
+
+func TestConvertDeny(t *testing.T) {
+	policy := load(t, `
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: restrict-roles
+spec:
+  background: false
+  rules:
+  - name: restrict-roles
+    match:
+      any:
+      - resources:
+          kinds:
+          - rbac.authorization.k8s.io/v1/ClusterRoleBinding
+          operations:
+          - CREATE
+          - UPDATE
+    context:
+    - name: roles-dictionary
+      configMap:
+        name: roles
+        namespace: kyverno
+    preconditions:
+      all:
+      - key: "{{ request.object.metadata.labels.team || '' }}"
+        operator: NotEquals
+        value: platform
+    validate:
+      message: "The role {{ request.object.roleRef.name }} is not allowed."
+      deny:
+        conditions:
+          any:
+          - key: "{{ request.object.roleRef.name }}"
+            operator: AnyNotIn
+            value: "{{ \"roles-dictionary\".data.allowed }}"
+          - key: "{{ request.object.subjects[].kind }}"
+            operator: AnyIn
+            value:
+            - Group
+            - system:*
+`)
+	result := Convert(policy)
+	assert.Empty(t, result.Issues)
+	require.Len(t, result.Policies, 1)
+	vp := result.Policies[0]
+	assert.Equal(t, []admissionregistrationv1.ValidationAction{admissionregistrationv1.Audit}, vp.Spec.ValidationAction)
+	assert.False(t, vp.Spec.BackgroundEnabled())
+	assert.Equal(t, []admissionregistrationv1.MatchCondition{{
+		Name:       "preconditions",
+		Expression: "!(object.?metadata.?labels.?team.orValue('') == 'platform')",
+	}}, vp.Spec.MatchConditions)
+	assert.Equal(t, []admissionregistrationv1.Variable{{
+		Name:       "roles_dictionary",
+		Expression: "dyn(resource.Get('v1', 'configmaps', 'kyverno', 'roles'))",
+	}}, vp.Spec.Variables)
+	assert.Equal(t, []admissionregistrationv1.Validation{{
+		Expression:        "!(!(object.roleRef.name in variables.roles_dictionary.data.allowed) || object.subjects.map(x, x.kind).exists(k, k == 'Group' || k.matches('^system:.*$')))",
+		MessageExpression: "'The role ' + string(object.roleRef.name) + ' is not allowed.'",
+	}}, vp.Spec.Validations)
+}
+
+func TestConvertForeach(t *testing.T) {
+	policy := load(t, `
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: restrict-image-registries
+spec:
+  rules:
+  - name: validate-registries
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    validate:
+      message: Unknown image registry.
+      foreach:
+      - list: request.object.spec.containers
+        preconditions:
+          all:
+          - key: "{{ element.name }}"
+            operator: NotEquals
+            value: sidecar
+        pattern:
+          image: "eu.foo.io/* | bar.io/*"
+      - list: request.object.spec.volumes
+        deny:
+          conditions:
+          - key: "{{ element.hostPath.path }}"
+            operator: Equals
+            value: /var/run/*
+`)
+	result := Convert(policy)
+	assert.Empty(t, result.Issues)
+	require.Len(t, result.Policies, 1)
+	assert.Equal(t, []admissionregistrationv1.Validation{{
+		Expression: "object.?spec.?containers.orValue([]).all(element, (element.name == 'sidecar') || (has(element.image) && (element.image.matches(\"^eu\\\\.foo\\\\.io/.*$\") || element.image.matches(\"^bar\\\\.io/.*$\")))) && " +
+			"object.?spec.?volumes.orValue([]).all(element, !element.hostPath.path.matches('^/var/run/.*$'))",
+		Message: "Unknown image registry.",
+	}}, result.Policies[0].Spec.Validations)
+}
+
+func TestConvertMatch(t *testing.T) {
+	policy := load(t, `
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: require-labels
+  annotations:
+    policies.kyverno.io/title: Require Labels
+    pod-policies.kyverno.io/autogen-controllers: Deployment,CronJob
+spec:
+  webhookTimeoutSeconds: 5
+  failurePolicy: Ignore
+  rules:
+  - name: check-team
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+          namespaces:
+          - prod-*
+      - resources:
+          kinds:
+          - apps/v1/Deployment
+          selector:
+            matchLabels:
+              app: web
+    exclude:
+      any:
+      - subjects:
+        - kind: ServiceAccount
+          name: deployer
+          namespace: ci
+      - resources:
+          namespaces:
+          - kube-system
+    validate:
+      pattern:
+        metadata:
+          labels:
+            team: "?*"
+  - name: generate-quota
+    match:
+      any:
+      - resources:
+          kinds:
+          - Namespace
+    generate:
+      apiVersion: v1
+      kind: ResourceQuota
+      name: quota
+      namespace: "{{ request.object.metadata.name }}"
+      data: {}
+`)
+	result := Convert(policy)
+	require.Len(t, result.Policies, 1)
+	vp := result.Policies[0]
+	assert.Equal(t, "require-labels", vp.Name)
+	assert.Equal(t, map[string]string{"policies.kyverno.io/title": "Require Labels"}, vp.Annotations)
+	assert.Equal(t, []string{"cronjobs", "deployments"}, vp.Spec.AutogenConfiguration.PodControllers.Controllers)
+	assert.Equal(t, admissionregistrationv1.Ignore, *vp.Spec.FailurePolicy)
+	assert.Equal(t, int32(5), *vp.Spec.WebhookConfiguration.TimeoutSeconds)
+	operations := []admissionregistrationv1.OperationType{admissionregistrationv1.Create, admissionregistrationv1.Update}
+	assert.Equal(t, &admissionregistrationv1.MatchResources{
+		ResourceRules: []admissionregistrationv1.NamedRuleWithOperations{{
+			RuleWithOperations: admissionregistrationv1.RuleWithOperations{
+				Operations: operations,
+				Rule:       admissionregistrationv1.Rule{APIGroups: []string{""}, APIVersions: []string{"v1"}, Resources: []string{"pods"}},
+			},
+		}, {
+			RuleWithOperations: admissionregistrationv1.RuleWithOperations{
+				Operations: operations,
+				Rule:       admissionregistrationv1.Rule{APIGroups: []string{"apps"}, APIVersions: []string{"v1"}, Resources: []string{"deployments"}},
+			},
+		}},
+	}, vp.Spec.MatchConstraints)
+	assert.Equal(t, []admissionregistrationv1.MatchCondition{{
+		Name: "match",
+		Expression: "(request.resource.group == '' && request.resource.version == 'v1' && request.resource.resource == 'pods' && request.namespace.matches('^prod-.*$')) || " +
+			"(request.resource.group == 'apps' && request.resource.version == 'v1' && request.resource.resource == 'deployments' && 'app' in object.metadata.?labels.orValue({}) && object.metadata.?labels.orValue({})['app'] == 'web')",
+	}, {
+		Name:       "exclude",
+		Expression: "!(request.userInfo.username == 'system:serviceaccount:ci:deployer' || request.namespace == 'kube-system')",
+	}}, vp.Spec.MatchConditions)
+	assert.Equal(t, []Issue{{
+		Policy:  "require-labels",
+		Rule:    "generate-quota",
+		Path:    "spec.rules[1]",
+		Message: "only validate rules can be converted to validating policies",
+	}}, result.Issues)
+}
+
+func TestConvertUnsupported(t *testing.T) {
+	policy := load(t, `
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: unsupported
+spec:
+  rules:
+  - name: global-anchor
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    validate:
+      pattern:
+        spec:
+          containers:
+          - <(image): "*/nginx:*"
+  - name: pod-security
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    validate:
+      podSecurity:
+        level: baseline
+        version: latest
+`)
+	result := Convert(policy)
+	require.Len(t, result.Policies, 2)
+	assert.Equal(t, "unsupported-global-anchor", result.Policies[0].Name)
+	assert.Equal(t, "unsupported-pod-security", result.Policies[1].Name)
+	assert.Equal(t, []Issue{{
+		Policy:  "unsupported",
+		Rule:    "global-anchor",
+		Path:    "spec.rules[0].validate.pattern[spec][containers][0][<(image)]",
+		Message: "< anchors are not supported in validation patterns",
+	}, {
+		Policy:  "unsupported",
+		Rule:    "global-anchor",
+		Path:    "spec.rules[0].validate",
+		Message: "no validation could be translated",
+	}, {
+		Policy:  "unsupported",
+		Rule:    "pod-security",
+		Path:    "spec.rules[1].validate.podSecurity",
+		Message: "pod security rules are not supported, write the pod security checks as CEL expressions",
+	}, {
+		Policy:  "unsupported",
+		Rule:    "pod-security",
+		Path:    "spec.rules[1].validate",
+		Message: "no validation could be translated",
+	}}, result.Issues)
+	assert.Equal(t,
+		"TODO spec.rules[0].validate.pattern[spec][containers][0][<(image)]: < anchors are not supported in validation patterns\n"+
+			"TODO spec.rules[0].validate: no validation could be translated",
+		result.Policies[0].Annotations[kyverno.AnnotationConversionTodo],
+	)
+}
+
+func TestConvertNamespacedPolicy(t *testing.T) {
+	policy := &kyvernov1.Policy{
+		ObjectMeta: metav1.ObjectMeta{Name: "require-owner", Namespace: "team-a"},
+		Spec: kyvernov1.Spec{
+			Rules: []kyvernov1.Rule{{
+				Name: "owner",
+				MatchResources: kyvernov1.MatchResources{
+					ResourceDescription: kyvernov1.ResourceDescription{Kinds: []string{"ConfigMap"}},
+				},
+				Validation: &kyvernov1.Validation{
+					RawPattern: kyvernov1.ToJSON(map[string]any{"metadata": map[string]any{"annotations": map[string]any{"owner": "?*"}}}),
+				},
+			}},
+		},
+	}
+	result := Convert(policy)
+	assert.Empty(t, result.Issues)
+	require.Len(t, result.Policies, 1)
+	assert.Equal(t, "team-a-require-owner", result.Policies[0].Name)
+	assert.Equal(t, []admissionregistrationv1.MatchCondition{{
+		Name:       "namespace",
+		Expression: "request.namespace == 'team-a'",
+	}}, result.Policies[0].Spec.MatchConditions)
+}
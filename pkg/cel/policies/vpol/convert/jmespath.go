@@ -0,0 +1,434 @@
+package convert
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	gojmespath "github.com/kyverno/go-jmespath"
+	"github.com/kyverno/kyverno/pkg/cel/compiler"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+var (
+	variable     = regexp.MustCompile(`\{\{(.*?)\}\}`)
+	elementIndex = regexp.MustCompile(`^element(\d+)$`)
+	// rootIdentifier and chainSegment split selection chains like object.metadata.labels['app']
+	rootIdentifier = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*`)
+	chainSegment   = regexp.MustCompile(`^(?:\.([a-zA-Z_][a-zA-Z0-9_]*)|\[(\d+|'(?:[^'\\]|\\.)*'|"(?:[^"\\]|\\.)*")\])`)
+	// requestFields are the fields of the admission request available in CEL
+	requestFields = sets.New(
+		"dryRun", "kind", "name", "namespace", "operation", "options",
+		"requestKind", "requestResource", "requestSubResource", "resource", "subResource", "uid", "userInfo",
+	)
+)
+
+// expression is a CEL expression translated from a JMESPath expression
+type expression struct {
+	cel string
+	// list is true when the expression is the result of a projection
+	list bool
+	// boolean is true when the expression evaluates to a bool
+	boolean bool
+	// request is true when the expression is the request root variable
+	request bool
+}
+
+// translator translates Kyverno variables to CEL expressions
+type translator struct {
+	// variables are the names of the context entries declared as CEL variables
+	variables sets.Set[string]
+	// elements are the comprehension variables of the enclosing foreach declarations, outermost first
+	elements []string
+	// root is the CEL expression fields are selected from at the root of the expression,
+	// the Kyverno variables are used when empty
+	root string
+	// usesVariables is set when a translated expression references a CEL variable
+	usesVariables *bool
+}
+
+func newTranslator(variables sets.Set[string]) *translator {
+	return &translator{
+		variables:     variables,
+		usesVariables: new(bool),
+	}
+}
+
+// withElement returns a translator for the body of a foreach declaration
+func (t *translator) withElement(name string) *translator {
+	return &translator{
+		variables:     t.variables,
+		elements:      append(append([]string{}, t.elements...), name),
+		usesVariables: t.usesVariables,
+	}
+}
+
+// withRoot returns a translator selecting root fields from an expression
+func (t *translator) withRoot(root string) *translator {
+	return &translator{
+		variables:     t.variables,
+		elements:      t.elements,
+		root:          root,
+		usesVariables: t.usesVariables,
+	}
+}
+
+// hasVariables returns true if a string contains Kyverno variables
+func hasVariables(value string) bool {
+	return variable.MatchString(value)
+}
+
+// template translates a string containing Kyverno variables, strings made of a single variable
+// keep the type of the variable while other strings are translated to a concatenation
+func (t *translator) template(value string) (expression, error) {
+	matches := variable.FindAllStringSubmatchIndex(value, -1)
+	if len(matches) == 0 {
+		return expression{cel: quote(value)}, nil
+	}
+	if len(matches) == 1 && matches[0][0] == 0 && matches[0][1] == len(value) {
+		return t.jmespath(value[matches[0][2]:matches[0][3]])
+	}
+	var parts []string
+	last := 0
+	for _, match := range matches {
+		if match[0] > last {
+			parts = append(parts, quote(value[last:match[0]]))
+		}
+		translated, err := t.jmespath(value[match[2]:match[3]])
+		if err != nil {
+			return expression{}, err
+		}
+		parts = append(parts, "string("+translated.cel+")")
+		last = match[1]
+	}
+	if last < len(value) {
+		parts = append(parts, quote(value[last:]))
+	}
+	return expression{cel: strings.Join(parts, " + ")}, nil
+}
+
+// value translates a JSON value that may contain Kyverno variables
+func (t *translator) value(value any) (expression, error) {
+	if typed, ok := value.(string); ok {
+		return t.template(typed)
+	}
+	if typed, ok := value.([]any); ok {
+		items := make([]string, 0, len(typed))
+		for _, item := range typed {
+			translated, err := t.value(item)
+			if err != nil {
+				return expression{}, err
+			}
+			items = append(items, translated.cel)
+		}
+		return expression{cel: "[" + strings.Join(items, ", ") + "]", list: true}, nil
+	}
+	cel, err := literal(value)
+	if err != nil {
+		return expression{}, err
+	}
+	_, boolean := value.(bool)
+	return expression{cel: cel, boolean: boolean}, nil
+}
+
+// jmespath translates a JMESPath expression
+func (t *translator) jmespath(value string) (expression, error) {
+	value = strings.TrimSpace(value)
+	node, err := gojmespath.NewParser().Parse(value)
+	if err != nil {
+		return expression{}, fmt.Errorf("invalid JMESPath expression %q: %w", value, err)
+	}
+	translated, err := t.node(node, nil)
+	if err != nil {
+		return expression{}, fmt.Errorf("JMESPath expression %q: %w", value, err)
+	}
+	if translated.request {
+		return expression{}, fmt.Errorf("JMESPath expression %q: the admission request can't be referenced as a whole", value)
+	}
+	return translated, nil
+}
+
+func (t *translator) node(node gojmespath.ASTNode, current *expression) (expression, error) {
+	switch node.NodeType {
+	case gojmespath.ASTField:
+		name := node.Value.(string)
+		if current == nil {
+			return t.rootField(name)
+		}
+		return t.field(*current, name)
+	case gojmespath.ASTSubexpression:
+		left, err := t.node(node.Children[0], current)
+		if err != nil {
+			return expression{}, err
+		}
+		if left.list {
+			return expression{}, fmt.Errorf("selecting fields from the result of a projection is not supported")
+		}
+		return t.node(node.Children[1], &left)
+	case gojmespath.ASTIndexExpression:
+		left, err := t.node(node.Children[0], current)
+		if err != nil {
+			return expression{}, err
+		}
+		index := node.Children[1]
+		if index.NodeType != gojmespath.ASTIndex {
+			return expression{}, fmt.Errorf("slices are not supported")
+		}
+		position := index.Value.(int)
+		if position < 0 {
+			return expression{}, fmt.Errorf("negative indexes are not supported")
+		}
+		return expression{cel: left.cel + "[" + strconv.Itoa(position) + "]"}, nil
+	case gojmespath.ASTPipe:
+		left, err := t.node(node.Children[0], current)
+		if err != nil {
+			return expression{}, err
+		}
+		// the right side of a pipe is evaluated against the result of the left side
+		return t.node(node.Children[1], &left)
+	case gojmespath.ASTProjection:
+		left, err := t.node(node.Children[0], current)
+		if err != nil {
+			return expression{}, err
+		}
+		return t.project(left.cel, node.Children[1])
+	case gojmespath.ASTFlatten:
+		inner, err := t.node(node.Children[0], current)
+		if err != nil {
+			return expression{}, err
+		}
+		if inner.list {
+			return expression{cel: inner.cel + ".flatten()", list: true}, nil
+		}
+		return expression{cel: inner.cel, list: true}, nil
+	case gojmespath.ASTFilterProjection:
+		left, err := t.node(node.Children[0], current)
+		if err != nil {
+			return expression{}, err
+		}
+		condition, err := t.node(node.Children[2], &expression{cel: "x"})
+		if err != nil {
+			return expression{}, err
+		}
+		if !condition.boolean {
+			return expression{}, fmt.Errorf("filters must be boolean expressions")
+		}
+		return t.project(left.cel+".filter(x, "+condition.cel+")", node.Children[1])
+	case gojmespath.ASTIdentity, gojmespath.ASTCurrentNode:
+		if current == nil {
+			return expression{}, fmt.Errorf("the current node can only be referenced in projections and filters")
+		}
+		return *current, nil
+	case gojmespath.ASTLiteral:
+		return t.value(node.Value)
+	case gojmespath.ASTComparator:
+		operators := map[string]string{"tEQ": "==", "tNE": "!=", "tLT": "<", "tLTE": "<=", "tGT": ">", "tGTE": ">="}
+		operator, ok := operators[fmt.Sprint(node.Value)]
+		if !ok {
+			return expression{}, fmt.Errorf("comparator %v is not supported", node.Value)
+		}
+		left, right, err := t.binary(node, current)
+		if err != nil {
+			return expression{}, err
+		}
+		return expression{cel: group(left.cel) + " " + operator + " " + group(right.cel), boolean: true}, nil
+	case gojmespath.ASTAndExpression, gojmespath.ASTOrExpression:
+		left, right, err := t.binary(node, current)
+		if err != nil {
+			return expression{}, err
+		}
+		if node.NodeType == gojmespath.ASTOrExpression && !left.boolean {
+			// a || b is commonly used to default missing values
+			if optional, ok := optionalChain(left.cel); ok {
+				return expression{cel: optional + ".orValue(" + right.cel + ")", list: right.list}, nil
+			}
+		}
+		if !left.boolean || !right.boolean {
+			return expression{}, fmt.Errorf("logical operators are only supported between boolean expressions")
+		}
+		if node.NodeType == gojmespath.ASTAndExpression {
+			return expression{cel: and(left.cel, right.cel), boolean: true}, nil
+		}
+		return expression{cel: or(left.cel, right.cel), boolean: true}, nil
+	case gojmespath.ASTNotExpression:
+		operand, err := t.node(node.Children[0], current)
+		if err != nil {
+			return expression{}, err
+		}
+		if !operand.boolean {
+			return expression{}, fmt.Errorf("negations are only supported for boolean expressions")
+		}
+		return expression{cel: not(operand.cel), boolean: true}, nil
+	case gojmespath.ASTFunctionExpression:
+		return t.function(node, current)
+	}
+	return expression{}, fmt.Errorf("%s is not supported", strings.TrimPrefix(node.NodeType.String(), "AST"))
+}
+
+// optionalChain turns a chain of field selections and indexes into optional selections,
+// it returns false if the expression is not such a chain
+func optionalChain(cel string) (string, bool) {
+	root := rootIdentifier.FindString(cel)
+	// the root can also be a function call
+	if root != "" && strings.HasPrefix(cel[len(root):], "(") {
+		depth := 0
+		for i := len(root); i < len(cel); i++ {
+			if cel[i] == '(' {
+				depth++
+			} else if cel[i] == ')' {
+				depth--
+			}
+			if depth == 0 {
+				root = cel[:i+1]
+				break
+			}
+		}
+	}
+	if root == "" || root == cel {
+		return "", false
+	}
+	optional := root
+	for rest := cel[len(root):]; rest != ""; {
+		match := chainSegment.FindStringSubmatch(rest)
+		if match == nil {
+			return "", false
+		}
+		if match[1] != "" {
+			optional += ".?" + match[1]
+		} else {
+			optional += "[?" + match[2] + "]"
+		}
+		rest = rest[len(match[0]):]
+	}
+	return optional, true
+}
+
+func (t *translator) binary(node gojmespath.ASTNode, current *expression) (expression, expression, error) {
+	left, err := t.node(node.Children[0], current)
+	if err != nil {
+		return expression{}, expression{}, err
+	}
+	right, err := t.node(node.Children[1], current)
+	if err != nil {
+		return expression{}, expression{}, err
+	}
+	return left, right, nil
+}
+
+func (t *translator) project(list string, right gojmespath.ASTNode) (expression, error) {
+	if right.NodeType == gojmespath.ASTIdentity {
+		return expression{cel: list, list: true}, nil
+	}
+	projected, err := t.node(right, &expression{cel: "x"})
+	if err != nil {
+		return expression{}, err
+	}
+	return expression{cel: list + ".map(x, " + projected.cel + ")", list: true}, nil
+}
+
+func (t *translator) rootField(name string) (expression, error) {
+	if t.root != "" {
+		return t.field(expression{cel: t.root}, name)
+	}
+	switch name {
+	case compiler.RequestKey:
+		return expression{cel: compiler.RequestKey, request: true}, nil
+	case "element":
+		if len(t.elements) == 0 {
+			return expression{}, fmt.Errorf("element can only be referenced in foreach declarations")
+		}
+		return expression{cel: t.elements[len(t.elements)-1]}, nil
+	}
+	if match := elementIndex.FindStringSubmatch(name); match != nil {
+		index, _ := strconv.Atoi(match[1])
+		if index >= len(t.elements) {
+			return expression{}, fmt.Errorf("%s is not declared", name)
+		}
+		return expression{cel: t.elements[index]}, nil
+	}
+	if t.variables.Has(name) {
+		*t.usesVariables = true
+		return expression{cel: compiler.VariablesKey + "." + variableName(name)}, nil
+	}
+	if name == "" {
+		return expression{}, fmt.Errorf("empty identifiers are not supported, string literals must use single quotes")
+	}
+	return expression{}, fmt.Errorf("variable %s is not supported", name)
+}
+
+func (t *translator) field(base expression, name string) (expression, error) {
+	if base.request {
+		switch {
+		case name == "object":
+			return expression{cel: compiler.ObjectKey}, nil
+		case name == "oldObject":
+			return expression{cel: compiler.OldObjectKey}, nil
+		case requestFields.Has(name):
+			return expression{cel: compiler.RequestKey + "." + name}, nil
+		}
+		return expression{}, fmt.Errorf("request.%s is not supported", name)
+	}
+	if base.list {
+		return expression{}, fmt.Errorf("selecting fields from the result of a projection is not supported")
+	}
+	cel, _ := selectField(base.cel, name)
+	return expression{cel: cel}, nil
+}
+
+func (t *translator) function(node gojmespath.ASTNode, current *expression) (expression, error) {
+	name, _ := node.Value.(string)
+	args := make([]expression, 0, len(node.Children))
+	for _, child := range node.Children {
+		arg, err := t.node(child, current)
+		if err != nil {
+			return expression{}, err
+		}
+		args = append(args, arg)
+	}
+	arity := map[string]int{
+		"contains": 2, "ends_with": 2, "join": 2, "keys": 1, "length": 1, "regex_match": 2, "split": 2,
+		"starts_with": 2, "to_lower": 1, "to_number": 1, "to_string": 1, "to_upper": 1, "values": 1,
+	}
+	if expected, ok := arity[name]; !ok {
+		return expression{}, fmt.Errorf("function %s is not supported", name)
+	} else if len(args) != expected {
+		return expression{}, fmt.Errorf("function %s expects %d arguments", name, expected)
+	}
+	switch name {
+	case "length":
+		return expression{cel: "size(" + args[0].cel + ")"}, nil
+	case "to_string":
+		return expression{cel: "string(" + args[0].cel + ")"}, nil
+	case "to_number":
+		return expression{cel: "double(" + args[0].cel + ")"}, nil
+	case "to_lower":
+		return expression{cel: "string(" + args[0].cel + ").lowerAscii()"}, nil
+	case "to_upper":
+		return expression{cel: "string(" + args[0].cel + ").upperAscii()"}, nil
+	case "starts_with":
+		return expression{cel: "string(" + args[0].cel + ").startsWith(" + args[1].cel + ")", boolean: true}, nil
+	case "ends_with":
+		return expression{cel: "string(" + args[0].cel + ").endsWith(" + args[1].cel + ")", boolean: true}, nil
+	case "regex_match":
+		return expression{cel: "string(" + args[1].cel + ").matches(" + args[0].cel + ")", boolean: true}, nil
+	case "contains":
+		if args[0].list {
+			return expression{cel: group(args[1].cel) + " in " + group(args[0].cel), boolean: true}, nil
+		}
+		// the subject can be a string or a list
+		subject := args[0].cel
+		return expression{
+			cel:     "type(" + subject + ") == list ? " + group(args[1].cel) + " in " + group(subject) + " : string(" + subject + ").contains(" + args[1].cel + ")",
+			boolean: true,
+		}, nil
+	case "join":
+		return expression{cel: args[1].cel + ".join(" + args[0].cel + ")"}, nil
+	case "split":
+		return expression{cel: "string(" + args[0].cel + ").split(" + args[1].cel + ")", list: true}, nil
+	case "keys":
+		return expression{cel: args[0].cel + ".map(k, k)", list: true}, nil
+	default:
+		return expression{cel: args[0].cel + ".map(k, " + args[0].cel + "[k])", list: true}, nil
+	}
+}
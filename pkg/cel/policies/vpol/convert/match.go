@@ -0,0 +1,369 @@
+package convert
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/ext/wildcard"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+const (
+	objectLabels    = "object.metadata.?labels.orValue({})"
+	namespaceLabels = "namespaceObject.metadata.?labels.orValue({})"
+)
+
+var stableVersion = regexp.MustCompile(`^v\d+$`)
+
+// knownKinds indexes the group versions of the kinds registered in the client-go scheme
+var knownKinds = sync.OnceValue(func() map[string][]schema.GroupVersion {
+	kinds := map[string][]schema.GroupVersion{}
+	for gvk := range scheme.Scheme.AllKnownTypes() {
+		if gvk.Version == runtime.APIVersionInternal {
+			continue
+		}
+		kinds[gvk.Kind] = append(kinds[gvk.Kind], gvk.GroupVersion())
+	}
+	return kinds
+})
+
+// apiResource is an API resource matched by a kind selector
+type apiResource struct {
+	group    string
+	version  string
+	resource string
+}
+
+// resolveKind resolves a Kyverno kind selector to API resources using the types registered in the client-go scheme,
+// the resource names of the other kinds are guessed and reported in the returned warning
+func resolveKind(selector string) ([]apiResource, string, error) {
+	group, version, kind, subresource := kubeutils.ParseKindSelector(selector)
+	if kind == "" {
+		return nil, "", fmt.Errorf("invalid kind %s", selector)
+	}
+	withSubresource := func(resource string) string {
+		if subresource == "" {
+			return resource
+		}
+		return resource + "/" + subresource
+	}
+	if kind == "*" {
+		return []apiResource{{group: group, version: version, resource: withSubresource("*")}}, "", nil
+	}
+	if wildcard.ContainsWildcard(kind) {
+		return nil, "", fmt.Errorf("wildcards in kind %s are not supported", selector)
+	}
+	// keep the preferred version of every group serving the kind
+	preferred := map[string]string{}
+	for _, gv := range knownKinds()[kind] {
+		if !wildcard.Match(group, gv.Group) || !wildcard.Match(version, gv.Version) {
+			continue
+		}
+		current, ok := preferred[gv.Group]
+		if !ok || slices.Index(scheme.Scheme.PrioritizedVersionsForGroup(gv.Group), gv) < slices.Index(scheme.Scheme.PrioritizedVersionsForGroup(gv.Group), schema.GroupVersion{Group: gv.Group, Version: current}) {
+			preferred[gv.Group] = gv.Version
+		}
+	}
+	if len(preferred) == 0 {
+		plural, _ := meta.UnsafeGuessKindToResource(schema.GroupVersionKind{Kind: kind})
+		resource := apiResource{group: group, version: version, resource: withSubresource(plural.Resource)}
+		return []apiResource{resource}, fmt.Sprintf("kind %s is not a built-in kind, the resource name %s was guessed", selector, plural.Resource), nil
+	}
+	// legacy groups are ignored when the kind is served by a stable version
+	stable := false
+	for _, version := range preferred {
+		stable = stable || stableVersion.MatchString(version)
+	}
+	var resources []apiResource
+	for _, group := range slices.Sorted(maps.Keys(preferred)) {
+		version := preferred[group]
+		if stable && !stableVersion.MatchString(version) {
+			continue
+		}
+		plural, _ := meta.UnsafeGuessKindToResource(schema.GroupVersionKind{Group: group, Version: version, Kind: kind})
+		resources = append(resources, apiResource{group: group, version: version, resource: withSubresource(plural.Resource)})
+	}
+	return resources, "", nil
+}
+
+// filter is a resource filter of a match or exclude block
+type filter struct {
+	path *field.Path
+	kyvernov1.ResourceDescription
+	kyvernov1.UserInfo
+	resources []apiResource
+}
+
+// filters returns the resource filters of a match or exclude block and how they are combined
+func filters(path *field.Path, match kyvernov1.MatchResources) ([]filter, bool) {
+	if len(match.Any) != 0 {
+		var filters []filter
+		for i, item := range match.Any {
+			filters = append(filters, filter{path: path.Child("any").Index(i), ResourceDescription: item.ResourceDescription, UserInfo: item.UserInfo})
+		}
+		return filters, false
+	}
+	if len(match.All) != 0 {
+		var filters []filter
+		for i, item := range match.All {
+			filters = append(filters, filter{path: path.Child("all").Index(i), ResourceDescription: item.ResourceDescription, UserInfo: item.UserInfo})
+		}
+		return filters, true
+	}
+	if match.ResourceDescription.IsEmpty() && match.UserInfo.IsEmpty() {
+		return nil, false
+	}
+	return []filter{{path: path.Child("resources"), ResourceDescription: match.ResourceDescription, UserInfo: match.UserInfo}}, false
+}
+
+// convertMatch converts the match and exclude blocks of a rule to match constraints and match conditions
+func (c *ruleConverter) convertMatch(path *field.Path, rule kyvernov1.Rule, defaultOperations []admissionregistrationv1.OperationType) (*admissionregistrationv1.MatchResources, []admissionregistrationv1.MatchCondition) {
+	var matchConditions []admissionregistrationv1.MatchCondition
+	matchFilters, all := filters(path.Child("match"), rule.MatchResources)
+	c.resolve(matchFilters)
+	// a single filter is translated to match constraints as much as possible
+	structural := len(matchFilters) == 1
+	constraints := &admissionregistrationv1.MatchResources{}
+	for _, filter := range matchFilters {
+		operations := translateOperations(filter.Operations, defaultOperations)
+		var names []string
+		if structural && !slices.ContainsFunc(filter.allNames(), wildcard.ContainsWildcard) {
+			names = filter.allNames()
+		}
+		for _, resource := range filter.resources {
+			addRule(constraints, resource, operations, names)
+		}
+	}
+	if len(constraints.ResourceRules) == 0 {
+		return nil, nil
+	}
+	var predicates []string
+	for _, filter := range matchFilters {
+		predicates = append(predicates, c.predicate(filter, !structural, structural, constraints))
+	}
+	match := or(predicates...)
+	if all {
+		match = and(predicates...)
+	}
+	if match != "true" {
+		matchConditions = append(matchConditions, admissionregistrationv1.MatchCondition{Name: "match", Expression: match})
+	}
+	if rule.ExcludeResources != nil {
+		excludeFilters, all := filters(path.Child("exclude"), *rule.ExcludeResources)
+		c.resolve(excludeFilters)
+		var predicates []string
+		for _, filter := range excludeFilters {
+			predicates = append(predicates, c.predicate(filter, true, false, nil))
+		}
+		exclude := or(predicates...)
+		if all {
+			exclude = and(predicates...)
+		}
+		if exclude != "false" {
+			matchConditions = append(matchConditions, admissionregistrationv1.MatchCondition{Name: "exclude", Expression: not(exclude)})
+		}
+	}
+	return constraints, matchConditions
+}
+
+func (c *ruleConverter) resolve(filters []filter) {
+	for i := range filters {
+		for j, kind := range filters[i].Kinds {
+			resources, warning, err := resolveKind(kind)
+			if err != nil {
+				c.unsupported(filters[i].path.Child("kinds").Index(j), err.Error())
+				continue
+			}
+			if warning != "" {
+				c.unsupported(filters[i].path.Child("kinds").Index(j), warning)
+			}
+			filters[i].resources = append(filters[i].resources, resources...)
+		}
+	}
+}
+
+func (f filter) allNames() []string {
+	names := slices.Clone(f.Names)
+	if f.Name != "" {
+		names = append(names, f.Name)
+	}
+	return names
+}
+
+// predicate returns the CEL expression of the constraints of a filter that are not expressed by the match constraints
+func (c *ruleConverter) predicate(filter filter, withResources bool, structural bool, constraints *admissionregistrationv1.MatchResources) string {
+	var terms []string
+	// filters without kinds match all resources
+	if withResources && len(filter.Kinds) != 0 {
+		var resources []string
+		for _, resource := range filter.resources {
+			resources = append(resources, resourcePredicate(resource))
+		}
+		terms = append(terms, or(resources...))
+		if len(filter.Operations) != 0 {
+			var operations []string
+			for _, operation := range filter.Operations {
+				operations = append(operations, string(operation))
+			}
+			terms = append(terms, matchStrings("request.operation", operations))
+		}
+	}
+	if names := filter.allNames(); len(names) != 0 && (!structural || slices.ContainsFunc(names, wildcard.ContainsWildcard)) {
+		terms = append(terms, matchStrings("request.name", names))
+	}
+	if len(filter.Namespaces) != 0 {
+		if structural && filter.NamespaceSelector == nil && !slices.ContainsFunc(filter.Namespaces, wildcard.ContainsWildcard) {
+			constraints.NamespaceSelector = &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{{
+					Key:      "kubernetes.io/metadata.name",
+					Operator: metav1.LabelSelectorOpIn,
+					Values:   filter.Namespaces,
+				}},
+			}
+		} else {
+			terms = append(terms, matchStrings("request.namespace", filter.Namespaces))
+		}
+	}
+	for _, key := range slices.Sorted(maps.Keys(filter.Annotations)) {
+		if wildcard.ContainsWildcard(key) {
+			c.unsupported(filter.path.Child("annotations").Key(key), "wildcards in annotation keys are not supported")
+			continue
+		}
+		terms = append(terms, matchString("object.metadata.?annotations[?"+quote(key)+"].orValue('')", filter.Annotations[key]))
+	}
+	if filter.Selector != nil {
+		if structural && !selectorHasWildcards(filter.Selector) {
+			constraints.ObjectSelector = filter.Selector
+		} else {
+			terms = append(terms, selectorPredicate(objectLabels, filter.Selector))
+		}
+	}
+	if filter.NamespaceSelector != nil {
+		if structural && !selectorHasWildcards(filter.NamespaceSelector) {
+			constraints.NamespaceSelector = filter.NamespaceSelector
+		} else {
+			terms = append(terms, selectorPredicate(namespaceLabels, filter.NamespaceSelector))
+		}
+	}
+	if len(filter.Roles) != 0 {
+		c.unsupported(filter.path.Child("roles"), "roles are not available in CEL expressions")
+	}
+	if len(filter.ClusterRoles) != 0 {
+		c.unsupported(filter.path.Child("clusterRoles"), "cluster roles are not available in CEL expressions")
+	}
+	var subjects []string
+	for _, subject := range filter.Subjects {
+		switch subject.Kind {
+		case rbacv1.UserKind:
+			subjects = append(subjects, matchString("request.userInfo.username", subject.Name))
+		case rbacv1.GroupKind:
+			subjects = append(subjects, "request.userInfo.groups.exists(group, "+matchString("group", subject.Name)+")")
+		case rbacv1.ServiceAccountKind:
+			subjects = append(subjects, matchString("request.userInfo.username", "system:serviceaccount:"+subject.Namespace+":"+subject.Name))
+		}
+	}
+	if len(subjects) != 0 {
+		terms = append(terms, or(subjects...))
+	}
+	return and(terms...)
+}
+
+func resourcePredicate(resource apiResource) string {
+	name, subresource, _ := strings.Cut(resource.resource, "/")
+	var terms []string
+	if resource.group != "*" {
+		terms = append(terms, matchString("request.resource.group", resource.group))
+	}
+	if resource.version != "*" {
+		terms = append(terms, matchString("request.resource.version", resource.version))
+	}
+	if name != "*" {
+		terms = append(terms, matchString("request.resource.resource", name))
+	}
+	if subresource != "" && subresource != "*" {
+		terms = append(terms, matchString("request.subResource", subresource))
+	}
+	return and(terms...)
+}
+
+func selectorHasWildcards(selector *metav1.LabelSelector) bool {
+	for key, value := range selector.MatchLabels {
+		if wildcard.ContainsWildcard(key) || wildcard.ContainsWildcard(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// selectorPredicate returns the CEL expression of a label selector
+func selectorPredicate(labels string, selector *metav1.LabelSelector) string {
+	var terms []string
+	for _, key := range slices.Sorted(maps.Keys(selector.MatchLabels)) {
+		terms = append(terms, and(quote(key)+" in "+labels, matchString(labels+"["+quote(key)+"]", selector.MatchLabels[key])))
+	}
+	for _, requirement := range selector.MatchExpressions {
+		exists := quote(requirement.Key) + " in " + labels
+		value := labels + "[" + quote(requirement.Key) + "]"
+		switch requirement.Operator {
+		case metav1.LabelSelectorOpIn:
+			terms = append(terms, and(exists, matchStrings(value, requirement.Values)))
+		case metav1.LabelSelectorOpNotIn:
+			terms = append(terms, or(not(exists), not(matchStrings(value, requirement.Values))))
+		case metav1.LabelSelectorOpExists:
+			terms = append(terms, exists)
+		case metav1.LabelSelectorOpDoesNotExist:
+			terms = append(terms, not(exists))
+		}
+	}
+	return and(terms...)
+}
+
+func addRule(constraints *admissionregistrationv1.MatchResources, resource apiResource, operations []admissionregistrationv1.OperationType, names []string) {
+	for i := range constraints.ResourceRules {
+		rule := &constraints.ResourceRules[i]
+		if slices.Equal(rule.APIGroups, []string{resource.group}) &&
+			slices.Equal(rule.APIVersions, []string{resource.version}) &&
+			slices.Equal(rule.Operations, operations) &&
+			slices.Equal(rule.ResourceNames, names) {
+			if !slices.Contains(rule.Resources, resource.resource) {
+				rule.Resources = append(rule.Resources, resource.resource)
+			}
+			return
+		}
+	}
+	constraints.ResourceRules = append(constraints.ResourceRules, admissionregistrationv1.NamedRuleWithOperations{
+		ResourceNames: names,
+		RuleWithOperations: admissionregistrationv1.RuleWithOperations{
+			Operations: operations,
+			Rule: admissionregistrationv1.Rule{
+				APIGroups:   []string{resource.group},
+				APIVersions: []string{resource.version},
+				Resources:   []string{resource.resource},
+			},
+		},
+	})
+}
+
+func translateOperations(operations []kyvernov1.AdmissionOperation, defaults []admissionregistrationv1.OperationType) []admissionregistrationv1.OperationType {
+	if len(operations) == 0 {
+		return defaults
+	}
+	translated := make([]admissionregistrationv1.OperationType, 0, len(operations))
+	for _, operation := range operations {
+		translated = append(translated, admissionregistrationv1.OperationType(operation))
+	}
+	return translated
+}
@@ -0,0 +1,294 @@
+package convert
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kyverno/kyverno/ext/wildcard"
+	"github.com/kyverno/kyverno/pkg/engine/anchor"
+	"github.com/kyverno/kyverno/pkg/engine/operator"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// builtins are the CEL variables declared by the validating policy environment
+var builtins = sets.New(
+	"object", "oldObject", "request", "namespaceObject", "variables", "params", "authorizer",
+	"globalContext", "http", "image", "imagedata", "resource", "exceptions",
+)
+
+// unsupportedError is returned when a construct can't be translated to CEL
+type unsupportedError struct {
+	path    *field.Path
+	message string
+}
+
+func (e unsupportedError) Error() string {
+	return e.path.String() + ": " + e.message
+}
+
+func unsupported(path *field.Path, format string, args ...any) error {
+	return unsupportedError{path: path, message: fmt.Sprintf(format, args...)}
+}
+
+// patternTranslator translates validation patterns to CEL expressions
+type patternTranslator struct {
+	translator *translator
+	// names are the comprehension variables already declared
+	names sets.Set[string]
+}
+
+func newPatternTranslator(translator *translator) *patternTranslator {
+	names := builtins.Clone()
+	names.Insert(translator.elements...)
+	return &patternTranslator{
+		translator: translator,
+		names:      names,
+	}
+}
+
+// translate returns the CEL expression testing that a value matches a pattern
+func (p *patternTranslator) translate(path *field.Path, value string, pattern any) (string, error) {
+	return p.element(path, "", value, pattern, false)
+}
+
+// element translates the pattern of an element, name is the name of the field holding the element
+// and condition is true when the element is part of a conditional anchor
+func (p *patternTranslator) element(path *field.Path, name string, value string, pattern any, condition bool) (string, error) {
+	switch typed := pattern.(type) {
+	case map[string]any:
+		return p.mapPattern(path, value, typed, condition)
+	case []any:
+		return p.listPattern(path, name, value, typed, condition)
+	default:
+		return p.scalar(path, value, pattern)
+	}
+}
+
+func (p *patternTranslator) mapPattern(path *field.Path, value string, pattern map[string]any, condition bool) (string, error) {
+	var conditions, requirements []string
+	for _, key := range slices.Sorted(maps.Keys(pattern)) {
+		child := path.Key(key)
+		a := anchor.Parse(key)
+		if a == nil {
+			if wildcard.ContainsWildcard(key) {
+				return "", unsupported(child, "wildcards in keys are not supported")
+			}
+			access, presence := selectField(value, key)
+			switch pattern[key] {
+			case "*":
+				requirements = append(requirements, presence)
+			case nil:
+				requirements = append(requirements, or(not(presence), access+" == null"))
+			default:
+				term, err := p.element(child, key, access, pattern[key], condition)
+				if err != nil {
+					return "", err
+				}
+				requirements = append(requirements, and(presence, term))
+			}
+			continue
+		}
+		access, presence := selectField(value, a.Key())
+		switch {
+		case anchor.IsCondition(a):
+			term, err := p.element(child, a.Key(), access, pattern[key], true)
+			if err != nil {
+				return "", err
+			}
+			// conditions nested in a conditional anchor are requirements of the enclosing condition
+			if condition {
+				requirements = append(requirements, and(presence, term))
+			} else {
+				conditions = append(conditions, and(presence, term))
+			}
+		case anchor.IsEquality(a):
+			term, err := p.element(child, a.Key(), access, pattern[key], condition)
+			if err != nil {
+				return "", err
+			}
+			requirements = append(requirements, or(not(presence), term))
+		case anchor.IsNegation(a):
+			requirements = append(requirements, not(presence))
+		case anchor.IsExistence(a):
+			items, ok := pattern[key].([]any)
+			if !ok {
+				return "", unsupported(child, "existence anchors must hold a list of patterns")
+			}
+			var terms []string
+			for i, item := range items {
+				name := p.declare(a.Key())
+				term, err := p.element(child.Index(i), a.Key(), name, item, condition)
+				if err != nil {
+					return "", err
+				}
+				terms = append(terms, access+".exists("+name+", "+term+")")
+			}
+			requirements = append(requirements, or(not(presence), and(terms...)))
+		default:
+			return "", unsupported(child, "%s anchors are not supported in validation patterns", a.Type())
+		}
+	}
+	if len(conditions) == 0 {
+		return and(requirements...), nil
+	}
+	return or(not(and(conditions...)), and(requirements...)), nil
+}
+
+func (p *patternTranslator) listPattern(path *field.Path, name string, value string, pattern []any, condition bool) (string, error) {
+	if len(pattern) == 0 {
+		return "", unsupported(path, "empty list patterns are not supported")
+	}
+	// the first element of the pattern applies to all the elements of the list
+	switch pattern[0].(type) {
+	case []any:
+		return "", unsupported(path.Index(0), "nested list patterns are not supported")
+	}
+	item := p.declare(name)
+	term, err := p.element(path.Index(0), "", item, pattern[0], condition)
+	if err != nil {
+		return "", err
+	}
+	return value + ".all(" + item + ", " + term + ")", nil
+}
+
+// declare returns a unique comprehension variable name for the elements of a list field
+func (p *patternTranslator) declare(field string) string {
+	name := singular(field)
+	candidate := name
+	for i := 2; p.names.Has(candidate); i++ {
+		candidate = name + strconv.Itoa(i)
+	}
+	p.names.Insert(candidate)
+	return candidate
+}
+
+func singular(name string) string {
+	switch {
+	case strings.HasSuffix(name, "ies") && len(name) > 3:
+		name = strings.TrimSuffix(name, "ies") + "y"
+	case strings.HasSuffix(name, "s") && !strings.HasSuffix(name, "ss") && len(name) > 1:
+		name = strings.TrimSuffix(name, "s")
+	}
+	if !isIdentifier(name) || strings.Contains(name, "_") {
+		return "item"
+	}
+	return name
+}
+
+func (p *patternTranslator) scalar(path *field.Path, value string, pattern any) (string, error) {
+	switch typed := pattern.(type) {
+	case string:
+		return p.stringPattern(path, value, typed)
+	case nil:
+		return value + " == null", nil
+	default:
+		cel, err := literal(pattern)
+		if err != nil {
+			return "", unsupported(path, "%s", err)
+		}
+		return value + " == " + cel, nil
+	}
+}
+
+// stringPattern translates a string pattern made of conditions joined with | and & operators
+func (p *patternTranslator) stringPattern(path *field.Path, value string, pattern string) (string, error) {
+	if hasVariables(pattern) {
+		static := variable.ReplaceAllString(pattern, "")
+		if strings.ContainsAny(static, "|&<>!*?") || operator.GetOperatorFromStringPattern(pattern) != operator.Equal {
+			return "", unsupported(path, "patterns combining variables and operators are not supported")
+		}
+		expected, err := p.translator.template(pattern)
+		if err != nil {
+			return "", unsupported(path, "%s", err)
+		}
+		return "string(" + value + ") == string(" + expected.cel + ")", nil
+	}
+	if strings.Contains(pattern, "$(") {
+		return "", unsupported(path, "references to other fields of the resource are not supported")
+	}
+	var alternatives []string
+	for _, alternative := range strings.Split(pattern, "|") {
+		var terms []string
+		for _, condition := range strings.Split(alternative, "&") {
+			term, err := p.stringCondition(path, value, strings.TrimSpace(condition))
+			if err != nil {
+				return "", err
+			}
+			terms = append(terms, term)
+		}
+		alternatives = append(alternatives, and(terms...))
+	}
+	return or(alternatives...), nil
+}
+
+func (p *patternTranslator) stringCondition(path *field.Path, value string, condition string) (string, error) {
+	op := operator.GetOperatorFromStringPattern(condition)
+	switch op {
+	case operator.InRange:
+		match := operator.InRangeRegex.FindStringSubmatch(condition)
+		left, err := p.stringCondition(path, value, ">= "+match[1])
+		if err != nil {
+			return "", err
+		}
+		right, err := p.stringCondition(path, value, "<= "+match[2])
+		if err != nil {
+			return "", err
+		}
+		return and(left, right), nil
+	case operator.NotInRange:
+		match := operator.NotInRangeRegex.FindStringSubmatch(condition)
+		left, err := p.stringCondition(path, value, "< "+match[1])
+		if err != nil {
+			return "", err
+		}
+		right, err := p.stringCondition(path, value, "> "+match[2])
+		if err != nil {
+			return "", err
+		}
+		return or(left, right), nil
+	}
+	operand := strings.TrimSpace(condition[len(op):])
+	switch op {
+	case operator.Equal:
+		return equals(value, operand), nil
+	case operator.NotEqual:
+		equal := equals(value, operand)
+		if !wildcard.ContainsWildcard(operand) && strings.Contains(equal, " == ") {
+			return strings.Replace(equal, " == ", " != ", 1), nil
+		}
+		return not(equal), nil
+	}
+	comparison := string(op)
+	if number, err := strconv.ParseFloat(operand, 64); err == nil {
+		return "double(" + value + ") " + comparison + " " + strconv.FormatFloat(number, 'f', -1, 64), nil
+	}
+	// Kyverno compares quantities before strings, operands like 4m are ambiguous
+	if _, err := resource.ParseQuantity(operand); err == nil {
+		return "", unsupported(path, "quantity comparisons are not supported")
+	}
+	if _, err := time.ParseDuration(operand); err == nil {
+		return "duration(string(" + value + ")) " + comparison + " duration(" + quote(operand) + ")", nil
+	}
+	return "", unsupported(path, "operator %s can't be applied to %s", op, operand)
+}
+
+// equals returns the CEL expression testing that a value matches a string supporting Kyverno wildcards,
+// values that don't look like strings are converted because Kyverno compares their string representation
+func equals(value string, pattern string) string {
+	switch pattern {
+	case "*":
+		return "true"
+	case "?*":
+		return "string(" + value + ") != ''"
+	}
+	if _, err := strconv.ParseFloat(pattern, 64); err == nil || pattern == "true" || pattern == "false" {
+		value = "string(" + value + ")"
+	}
+	return matchString(value, pattern)
+}
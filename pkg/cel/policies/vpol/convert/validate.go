@@ -0,0 +1,152 @@
+package convert
+
+import (
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/cel/compiler"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var selection = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*(\.[a-zA-Z_][a-zA-Z0-9_]*)+$`)
+
+// validation translates the pattern, anyPattern, deny and foreach declarations of a validate rule
+func (c *ruleConverter) validation(path *field.Path, t *translator, constraints *admissionregistrationv1.MatchResources) (string, error) {
+	validation := c.rule.Validation
+	var expression string
+	switch {
+	case validation.GetPattern() != nil:
+		translated, err := newPatternTranslator(t).translate(path.Child("pattern"), compiler.ObjectKey, validation.GetPattern())
+		if err != nil {
+			return "", err
+		}
+		expression = translated
+	case validation.GetAnyPattern() != nil:
+		patterns, err := validation.DeserializeAnyPattern()
+		if err != nil {
+			return "", unsupported(path.Child("anyPattern"), "%s", err)
+		}
+		translated, err := anyPattern(path.Child("anyPattern"), t, compiler.ObjectKey, patterns)
+		if err != nil {
+			return "", err
+		}
+		expression = translated
+	case validation.Deny != nil:
+		translated, err := conditions(path.Child("deny", "conditions"), t, validation.Deny.GetAnyAllConditions())
+		if err != nil {
+			return "", err
+		}
+		return not(translated), nil
+	default:
+		translated, err := foreach(path.Child("foreach"), t, validation.ForEachValidation)
+		if err != nil {
+			return "", err
+		}
+		expression = translated
+	}
+	// patterns are not evaluated by Kyverno on deletion
+	if deletes(constraints) {
+		return or("request.operation == 'DELETE'", expression), nil
+	}
+	return expression, nil
+}
+
+func anyPattern(path *field.Path, t *translator, value string, patterns []any) (string, error) {
+	var alternatives []string
+	for i, pattern := range patterns {
+		translated, err := newPatternTranslator(t).translate(path.Index(i), value, pattern)
+		if err != nil {
+			return "", err
+		}
+		alternatives = append(alternatives, translated)
+	}
+	return or(alternatives...), nil
+}
+
+// foreach translates foreach declarations to comprehensions over the lists,
+// the elements are named element when there is no nested declaration and element0, element1... otherwise
+func foreach(path *field.Path, t *translator, declarations []kyvernov1.ForEachValidation) (string, error) {
+	nested := false
+	for _, declaration := range declarations {
+		nested = nested || len(declaration.GetForEachValidation()) != 0
+	}
+	var terms []string
+	for i, declaration := range declarations {
+		path := path.Index(i)
+		if len(declaration.Context) != 0 {
+			return "", unsupported(path.Child("context"), "context entries in foreach declarations are not supported")
+		}
+		list, err := t.jmespath(declaration.List)
+		if err != nil {
+			return "", unsupported(path.Child("list"), "%s", err)
+		}
+		name := "element"
+		if nested || len(t.elements) != 0 {
+			name = "element" + strconv.Itoa(len(t.elements))
+		}
+		element := t.withElement(name)
+		preconditions, err := conditions(path.Child("preconditions"), element, declaration.AnyAllConditions)
+		if err != nil {
+			return "", err
+		}
+		scope := name
+		if declaration.ElementScope != nil && !*declaration.ElementScope {
+			scope = compiler.ObjectKey
+		}
+		var body string
+		switch {
+		case declaration.GetPattern() != nil:
+			body, err = newPatternTranslator(element).translate(path.Child("pattern"), scope, declaration.GetPattern())
+		case declaration.GetAnyPattern() != nil:
+			var patterns []any
+			if err := json.Unmarshal(declaration.RawAnyPattern.Raw, &patterns); err != nil {
+				return "", unsupported(path.Child("anyPattern"), "%s", err)
+			}
+			body, err = anyPattern(path.Child("anyPattern"), element, scope, patterns)
+		case declaration.Deny != nil:
+			body, err = conditions(path.Child("deny", "conditions"), element, declaration.Deny.GetAnyAllConditions())
+			body = not(body)
+		case len(declaration.GetForEachValidation()) != 0:
+			body, err = foreach(path.Child("foreach"), element, declaration.GetForEachValidation())
+		default:
+			return "", unsupported(path, "foreach declarations must have a pattern, an anyPattern, a deny or a nested foreach")
+		}
+		if err != nil {
+			return "", err
+		}
+		terms = append(terms, group(optionalList(list.cel, t.elements))+".all("+name+", "+or(not(preconditions), body)+")")
+	}
+	return and(terms...), nil
+}
+
+// optionalList makes the selection of a list optional, Kyverno skips foreach declarations when the list doesn't exist
+func optionalList(list string, elements []string) string {
+	if !selection.MatchString(list) {
+		return list
+	}
+	fields := strings.Split(list, ".")
+	switch fields[0] {
+	case compiler.ObjectKey, compiler.OldObjectKey:
+	default:
+		found := false
+		for _, element := range elements {
+			found = found || element == fields[0]
+		}
+		if !found {
+			return list
+		}
+	}
+	return fields[0] + ".?" + strings.Join(fields[1:], ".?") + ".orValue([])"
+}
+
+func toString(value any) string {
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+	return string(bytes)
+}
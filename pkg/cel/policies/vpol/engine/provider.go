@@ -128,6 +128,8 @@ func NewKubeProvider(
 		}
 		builder = builder.Watches(&policiesv1alpha1.PolicyException{}, exceptionHandlerFuncs)
 	}
+	// policies are compiled again when the cluster wide cost limits changed
+	builder = builder.WatchesRawSource(engine.CostLimitsSource(mgr.GetClient(), &policiesv1alpha1.ValidatingPolicyList{}))
 	if err := builder.Complete(reconciler); err != nil {
 		return nil, fmt.Errorf("failed to construct validatingpolicies manager: %w", err)
 	}
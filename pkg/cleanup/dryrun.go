@@ -0,0 +1,27 @@
+package cleanup
+
+import (
+	policiesv1alpha1 "github.com/kyverno/kyverno/api/policies.kyverno.io/v1alpha1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// MaxDryRunCandidates is the maximum number of resources reported in the status of a policy running in preview mode
+const MaxDryRunCandidates = 20
+
+// DryRunCandidate returns the candidate identifying a resource selected in preview mode
+func DryRunCandidate(resource unstructured.Unstructured) policiesv1alpha1.DryRunCandidate {
+	return policiesv1alpha1.DryRunCandidate{
+		APIVersion: resource.GetAPIVersion(),
+		Kind:       resource.GetKind(),
+		Namespace:  resource.GetNamespace(),
+		Name:       resource.GetName(),
+	}
+}
+
+// AddDryRunCandidate counts a resource selected in preview mode, only the first resources are kept as a sample
+func AddDryRunCandidate(status *policiesv1alpha1.DryRunStatus, resource unstructured.Unstructured) {
+	status.Count++
+	if len(status.Candidates) < MaxDryRunCandidates {
+		status.Candidates = append(status.Candidates, DryRunCandidate(resource))
+	}
+}
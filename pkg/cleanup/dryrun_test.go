@@ -0,0 +1,30 @@
+package cleanup
+
+import (
+	"fmt"
+	"testing"
+
+	policiesv1alpha1 "github.com/kyverno/kyverno/api/policies.kyverno.io/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestAddDryRunCandidate(t *testing.T) {
+	var status policiesv1alpha1.DryRunStatus
+	for i := 0; i < MaxDryRunCandidates+5; i++ {
+		resource := unstructured.Unstructured{}
+		resource.SetAPIVersion("v1")
+		resource.SetKind("ConfigMap")
+		resource.SetNamespace("default")
+		resource.SetName(fmt.Sprintf("cm-%d", i))
+		AddDryRunCandidate(&status, resource)
+	}
+	assert.Equal(t, MaxDryRunCandidates+5, status.Count)
+	assert.Len(t, status.Candidates, MaxDryRunCandidates)
+	assert.Equal(t, policiesv1alpha1.DryRunCandidate{
+		APIVersion: "v1",
+		Kind:       "ConfigMap",
+		Namespace:  "default",
+		Name:       "cm-0",
+	}, status.Candidates[0])
+}
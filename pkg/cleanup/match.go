@@ -0,0 +1,86 @@
+package cleanup
+
+import (
+	"fmt"
+
+	"github.com/go-logr/logr"
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	"github.com/kyverno/kyverno/pkg/config"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/utils/conditions"
+	"github.com/kyverno/kyverno/pkg/utils/match"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Matches checks if a resource is selected by a cleanup policy.
+// The resource must match the match clause, not match the exclude clause and pass the policy conditions,
+// callers are expected to only list resources in the policy namespace. The conditions are evaluated in
+// the given context, it is expected to contain the policy context entries.
+func Matches(
+	logger logr.Logger,
+	policy kyvernov2.CleanupPolicyInterface,
+	resource unstructured.Unstructured,
+	nsLabels map[string]string,
+	enginectx enginecontext.Interface,
+	configuration config.Configuration,
+) (bool, error) {
+	spec := policy.GetSpec()
+	// match namespaces
+	if err := match.CheckNamespace(policy.GetNamespace(), resource); err != nil {
+		logger.Info("resource namespace didn't match policy namespace", "result", err)
+	}
+	// match resource with match/exclude clause
+	matched := match.CheckMatchesResources(
+		resource,
+		spec.MatchResources,
+		nsLabels,
+		// TODO(eddycharly): we don't have user info here, we should check that
+		// we don't have user conditions in the policy rule
+		kyvernov2.RequestInfo{},
+		resource.GroupVersionKind(),
+		"",
+	)
+	if matched != nil {
+		logger.Info("resource/match didn't match", "result", matched)
+		return false, nil
+	}
+	if spec.ExcludeResources != nil {
+		excluded := match.CheckMatchesResources(
+			resource,
+			*spec.ExcludeResources,
+			nsLabels,
+			// TODO(eddycharly): we don't have user info here, we should check that
+			// we don't have user conditions in the policy rule
+			kyvernov2.RequestInfo{},
+			resource.GroupVersionKind(),
+			"",
+		)
+		if excluded == nil {
+			logger.Info("resource/exclude matched")
+			return false, nil
+		}
+		logger.Info("resource/exclude didn't match", "result", excluded)
+	}
+	// check conditions
+	if spec.Conditions != nil {
+		enginectx.Reset()
+		if err := enginectx.SetTargetResource(resource.Object); err != nil {
+			return false, fmt.Errorf("failed to add resource in context: %w", err)
+		}
+		if err := enginectx.AddNamespace(resource.GetNamespace()); err != nil {
+			return false, fmt.Errorf("failed to add namespace in context: %w", err)
+		}
+		if err := enginectx.AddImageInfos(&resource, configuration); err != nil {
+			return false, fmt.Errorf("failed to add image infos in context: %w", err)
+		}
+		passed, err := conditions.CheckAnyAllConditions(logger, enginectx, *spec.Conditions)
+		if err != nil {
+			return false, fmt.Errorf("failed to check condition: %w", err)
+		}
+		if !passed {
+			logger.Info("conditions did not pass")
+			return false, nil
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,98 @@
+package cleanup
+
+import (
+	"testing"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	"github.com/kyverno/kyverno/pkg/config"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/logging"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestMatches(t *testing.T) {
+	configMap := func(namespace string, labels map[string]string) unstructured.Unstructured {
+		resource := unstructured.Unstructured{}
+		resource.SetAPIVersion("v1")
+		resource.SetKind("ConfigMap")
+		resource.SetNamespace(namespace)
+		resource.SetName("config")
+		resource.SetLabels(labels)
+		return resource
+	}
+	spec := kyvernov2.CleanupPolicySpec{
+		MatchResources: kyvernov2.MatchResources{
+			Any: kyvernov1.ResourceFilters{{
+				ResourceDescription: kyvernov1.ResourceDescription{
+					Kinds: []string{"ConfigMap"},
+				},
+			}},
+		},
+		ExcludeResources: &kyvernov2.MatchResources{
+			Any: kyvernov1.ResourceFilters{{
+				ResourceDescription: kyvernov1.ResourceDescription{
+					Namespaces: []string{"kube-system"},
+				},
+			}},
+		},
+		Conditions: &kyvernov2.AnyAllConditions{
+			AllConditions: []kyvernov2.Condition{{
+				RawKey:   kyverno.ToAny("{{ target.metadata.labels.temporary || '' }}"),
+				Operator: kyvernov2.ConditionOperators["Equals"],
+				RawValue: kyverno.ToAny("true"),
+			}},
+		},
+		Schedule: "* * * * *",
+	}
+	clusterPolicy := &kyvernov2.ClusterCleanupPolicy{Spec: spec}
+	policy := &kyvernov2.CleanupPolicy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "dev"},
+		Spec:       spec,
+	}
+	tests := []struct {
+		name     string
+		policy   kyvernov2.CleanupPolicyInterface
+		resource unstructured.Unstructured
+		want     bool
+	}{{
+		name:     "matched",
+		policy:   clusterPolicy,
+		resource: configMap("dev", map[string]string{"temporary": "true"}),
+		want:     true,
+	}, {
+		name:     "conditions not passed",
+		policy:   clusterPolicy,
+		resource: configMap("dev", nil),
+		want:     false,
+	}, {
+		name:     "excluded",
+		policy:   clusterPolicy,
+		resource: configMap("kube-system", map[string]string{"temporary": "true"}),
+		want:     false,
+	}, {
+		name:     "policy namespace",
+		policy:   policy,
+		resource: configMap("dev", map[string]string{"temporary": "true"}),
+		want:     true,
+	}, {
+		// the namespace mismatch is only logged, resources are listed in the policy namespace
+		name:     "other namespace",
+		policy:   policy,
+		resource: configMap("prod", map[string]string{"temporary": "true"}),
+		want:     true,
+	}}
+	configuration := config.NewDefaultConfiguration(false)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enginectx := enginecontext.NewContext(jmespath.New(configuration))
+			got, err := Matches(logging.GlobalLogger(), tt.policy, tt.resource, nil, enginectx, configuration)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -39,6 +39,8 @@ type PolicyExceptionsGetter interface {
 type PolicyExceptionInterface interface {
 	Create(ctx context.Context, policyException *kyvernov2.PolicyException, opts v1.CreateOptions) (*kyvernov2.PolicyException, error)
 	Update(ctx context.Context, policyException *kyvernov2.PolicyException, opts v1.UpdateOptions) (*kyvernov2.PolicyException, error)
+	// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+	UpdateStatus(ctx context.Context, policyException *kyvernov2.PolicyException, opts v1.UpdateOptions) (*kyvernov2.PolicyException, error)
 	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
 	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
 	Get(ctx context.Context, name string, opts v1.GetOptions) (*kyvernov2.PolicyException, error)
@@ -0,0 +1,52 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1 "github.com/kyverno/kyverno/api/reports/v1"
+	reportsv1 "github.com/kyverno/kyverno/pkg/client/clientset/versioned/typed/reports/v1"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// fakeComplianceSummaries implements ComplianceSummaryInterface
+type fakeComplianceSummaries struct {
+	*gentype.FakeClientWithList[*v1.ComplianceSummary, *v1.ComplianceSummaryList]
+	Fake *FakeReportsV1
+}
+
+func newFakeComplianceSummaries(fake *FakeReportsV1, namespace string) reportsv1.ComplianceSummaryInterface {
+	return &fakeComplianceSummaries{
+		gentype.NewFakeClientWithList[*v1.ComplianceSummary, *v1.ComplianceSummaryList](
+			fake.Fake,
+			namespace,
+			v1.SchemeGroupVersion.WithResource("compliancesummaries"),
+			v1.SchemeGroupVersion.WithKind("ComplianceSummary"),
+			func() *v1.ComplianceSummary { return &v1.ComplianceSummary{} },
+			func() *v1.ComplianceSummaryList { return &v1.ComplianceSummaryList{} },
+			func(dst, src *v1.ComplianceSummaryList) { dst.ListMeta = src.ListMeta },
+			func(list *v1.ComplianceSummaryList) []*v1.ComplianceSummary {
+				return gentype.ToPointerSlice(list.Items)
+			},
+			func(list *v1.ComplianceSummaryList, items []*v1.ComplianceSummary) {
+				list.Items = gentype.FromPointerSlice(items)
+			},
+		),
+		fake,
+	}
+}
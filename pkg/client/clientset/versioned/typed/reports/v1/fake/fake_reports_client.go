@@ -32,6 +32,10 @@ func (c *FakeReportsV1) ClusterEphemeralReports() v1.ClusterEphemeralReportInter
 	return newFakeClusterEphemeralReports(c)
 }
 
+func (c *FakeReportsV1) ComplianceSummaries(namespace string) v1.ComplianceSummaryInterface {
+	return newFakeComplianceSummaries(c, namespace)
+}
+
 func (c *FakeReportsV1) EphemeralReports(namespace string) v1.EphemeralReportInterface {
 	return newFakeEphemeralReports(c, namespace)
 }
@@ -20,4 +20,6 @@ package v1
 
 type ClusterEphemeralReportExpansion interface{}
 
+type ComplianceSummaryExpansion interface{}
+
 type EphemeralReportExpansion interface{}
@@ -29,6 +29,7 @@ import (
 type ReportsV1Interface interface {
 	RESTClient() rest.Interface
 	ClusterEphemeralReportsGetter
+	ComplianceSummariesGetter
 	EphemeralReportsGetter
 }
 
@@ -41,6 +42,10 @@ func (c *ReportsV1Client) ClusterEphemeralReports() ClusterEphemeralReportInterf
 	return newClusterEphemeralReports(c)
 }
 
+func (c *ReportsV1Client) ComplianceSummaries(namespace string) ComplianceSummaryInterface {
+	return newComplianceSummaries(c, namespace)
+}
+
 func (c *ReportsV1Client) EphemeralReports(namespace string) EphemeralReportInterface {
 	return newEphemeralReports(c, namespace)
 }
@@ -94,6 +94,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		// Group=reports.kyverno.io, Version=v1
 	case reportsv1.SchemeGroupVersion.WithResource("clusterephemeralreports"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Reports().V1().ClusterEphemeralReports().Informer()}, nil
+	case reportsv1.SchemeGroupVersion.WithResource("compliancesummaries"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Reports().V1().ComplianceSummaries().Informer()}, nil
 	case reportsv1.SchemeGroupVersion.WithResource("ephemeralreports"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Reports().V1().EphemeralReports().Informer()}, nil
 
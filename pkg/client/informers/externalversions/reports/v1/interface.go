@@ -26,6 +26,8 @@ import (
 type Interface interface {
 	// ClusterEphemeralReports returns a ClusterEphemeralReportInformer.
 	ClusterEphemeralReports() ClusterEphemeralReportInformer
+	// ComplianceSummaries returns a ComplianceSummaryInformer.
+	ComplianceSummaries() ComplianceSummaryInformer
 	// EphemeralReports returns a EphemeralReportInformer.
 	EphemeralReports() EphemeralReportInformer
 }
@@ -46,6 +48,11 @@ func (v *version) ClusterEphemeralReports() ClusterEphemeralReportInformer {
 	return &clusterEphemeralReportInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// ComplianceSummaries returns a ComplianceSummaryInformer.
+func (v *version) ComplianceSummaries() ComplianceSummaryInformer {
+	return &complianceSummaryInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // EphemeralReports returns a EphemeralReportInformer.
 func (v *version) EphemeralReports() EphemeralReportInformer {
 	return &ephemeralReportInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
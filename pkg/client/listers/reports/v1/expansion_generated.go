@@ -22,6 +22,14 @@ package v1
 // ClusterEphemeralReportLister.
 type ClusterEphemeralReportListerExpansion interface{}
 
+// ComplianceSummaryListerExpansion allows custom methods to be added to
+// ComplianceSummaryLister.
+type ComplianceSummaryListerExpansion interface{}
+
+// ComplianceSummaryNamespaceListerExpansion allows custom methods to be added to
+// ComplianceSummaryNamespaceLister.
+type ComplianceSummaryNamespaceListerExpansion interface{}
+
 // EphemeralReportListerExpansion allows custom methods to be added to
 // EphemeralReportLister.
 type EphemeralReportListerExpansion interface{}
@@ -4,6 +4,7 @@ import (
 	"github.com/go-logr/logr"
 	github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_reports_v1 "github.com/kyverno/kyverno/pkg/client/clientset/versioned/typed/reports/v1"
 	clusterephemeralreports "github.com/kyverno/kyverno/pkg/clients/kyverno/reportsv1/clusterephemeralreports"
+	compliancesummaries "github.com/kyverno/kyverno/pkg/clients/kyverno/reportsv1/compliancesummaries"
 	ephemeralreports "github.com/kyverno/kyverno/pkg/clients/kyverno/reportsv1/ephemeralreports"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	"k8s.io/client-go/rest"
@@ -34,6 +35,10 @@ func (c *withMetrics) ClusterEphemeralReports() github_com_kyverno_kyverno_pkg_c
 	recorder := metrics.ClusteredClientQueryRecorder(c.metrics, "ClusterEphemeralReport", c.clientType)
 	return clusterephemeralreports.WithMetrics(c.inner.ClusterEphemeralReports(), recorder)
 }
+func (c *withMetrics) ComplianceSummaries(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_reports_v1.ComplianceSummaryInterface {
+	recorder := metrics.NamespacedClientQueryRecorder(c.metrics, namespace, "ComplianceSummary", c.clientType)
+	return compliancesummaries.WithMetrics(c.inner.ComplianceSummaries(namespace), recorder)
+}
 func (c *withMetrics) EphemeralReports(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_reports_v1.EphemeralReportInterface {
 	recorder := metrics.NamespacedClientQueryRecorder(c.metrics, namespace, "EphemeralReport", c.clientType)
 	return ephemeralreports.WithMetrics(c.inner.EphemeralReports(namespace), recorder)
@@ -50,6 +55,9 @@ func (c *withTracing) RESTClient() rest.Interface {
 func (c *withTracing) ClusterEphemeralReports() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_reports_v1.ClusterEphemeralReportInterface {
 	return clusterephemeralreports.WithTracing(c.inner.ClusterEphemeralReports(), c.client, "ClusterEphemeralReport")
 }
+func (c *withTracing) ComplianceSummaries(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_reports_v1.ComplianceSummaryInterface {
+	return compliancesummaries.WithTracing(c.inner.ComplianceSummaries(namespace), c.client, "ComplianceSummary")
+}
 func (c *withTracing) EphemeralReports(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_reports_v1.EphemeralReportInterface {
 	return ephemeralreports.WithTracing(c.inner.EphemeralReports(namespace), c.client, "EphemeralReport")
 }
@@ -65,6 +73,9 @@ func (c *withLogging) RESTClient() rest.Interface {
 func (c *withLogging) ClusterEphemeralReports() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_reports_v1.ClusterEphemeralReportInterface {
 	return clusterephemeralreports.WithLogging(c.inner.ClusterEphemeralReports(), c.logger.WithValues("resource", "ClusterEphemeralReports"))
 }
+func (c *withLogging) ComplianceSummaries(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_reports_v1.ComplianceSummaryInterface {
+	return compliancesummaries.WithLogging(c.inner.ComplianceSummaries(namespace), c.logger.WithValues("resource", "ComplianceSummaries").WithValues("namespace", namespace))
+}
 func (c *withLogging) EphemeralReports(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_reports_v1.EphemeralReportInterface {
 	return ephemeralreports.WithLogging(c.inner.EphemeralReports(namespace), c.logger.WithValues("resource", "EphemeralReports").WithValues("namespace", namespace))
 }
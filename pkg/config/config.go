@@ -109,12 +109,22 @@ const (
 	webhooks                      = "webhooks"
 	webhookAnnotations            = "webhookAnnotations"
 	webhookLabels                 = "webhookLabels"
+	webhookTiers                  = "webhookTiers"
 	matchConditions               = "matchConditions"
 	updateRequestThreshold        = "updateRequestThreshold"
+	celExpressionCostLimit        = "celExpressionCostLimit"
+	celPolicyCostLimit            = "celPolicyCostLimit"
 )
 
 const UpdateRequestThreshold = 1000
 
+const (
+	// CELExpressionCostLimit is the default runtime cost limit of a CEL expression, it matches the Kubernetes per call limit
+	CELExpressionCostLimit = 1000000
+	// CELPolicyCostLimit is the default runtime cost limit of the CEL expressions of a policy, it matches the Kubernetes runtime budget
+	CELPolicyCostLimit = 10000000
+)
+
 var (
 	// kyvernoNamespace is the Kyverno namespace
 	kyvernoNamespace = osutils.GetEnvWithFallback("KYVERNO_NAMESPACE", "kyverno")
@@ -194,6 +204,8 @@ type Configuration interface {
 	GetWebhookAnnotations() map[string]string
 	// GetWebhookLabels returns labels to set on webhook configs
 	GetWebhookLabels() map[string]string
+	// GetWebhookTiers returns the namespace tiers used to split resource webhooks
+	GetWebhookTiers() []WebhookTier
 	// GetMatchConditions returns match conditions to set on webhook configs
 	GetMatchConditions() []admissionregistrationv1.MatchCondition
 	// Load loads configuration from a configmap
@@ -202,6 +214,10 @@ type Configuration interface {
 	OnChanged(func())
 	// GetUpdateRequestThreshold gets the threshold limit for the total number of updaterequests
 	GetUpdateRequestThreshold() int64
+	// GetCELExpressionCostLimit gets the runtime cost limit of a CEL expression, zero disables the limit
+	GetCELExpressionCostLimit() int64
+	// GetCELPolicyCostLimit gets the runtime cost limit of the CEL expressions of a policy, zero disables the limit
+	GetCELPolicyCostLimit() int64
 }
 
 // configuration stores the configuration
@@ -216,10 +232,13 @@ type configuration struct {
 	webhook                       WebhookConfig
 	webhookAnnotations            map[string]string
 	webhookLabels                 map[string]string
+	webhookTiers                  []WebhookTier
 	matchConditions               []admissionregistrationv1.MatchCondition
 	mux                           sync.RWMutex
 	callbacks                     []func()
 	updateRequestThreshold        int64
+	celExpressionCostLimit        int64
+	celPolicyCostLimit            int64
 }
 
 type match struct {
@@ -269,6 +288,8 @@ func NewDefaultConfiguration(skipResourceFilters bool) *configuration {
 		skipResourceFilters:           skipResourceFilters,
 		defaultRegistry:               "docker.io",
 		enableDefaultRegistryMutation: true,
+		celExpressionCostLimit:        CELExpressionCostLimit,
+		celPolicyCostLimit:            CELPolicyCostLimit,
 	}
 }
 
@@ -342,6 +363,12 @@ func (cd *configuration) GetWebhookLabels() map[string]string {
 	return cd.webhookLabels
 }
 
+func (cd *configuration) GetWebhookTiers() []WebhookTier {
+	cd.mux.RLock()
+	defer cd.mux.RUnlock()
+	return cd.webhookTiers
+}
+
 func (cd *configuration) GetMatchConditions() []admissionregistrationv1.MatchCondition {
 	cd.mux.RLock()
 	defer cd.mux.RUnlock()
@@ -354,6 +381,18 @@ func (cd *configuration) GetUpdateRequestThreshold() int64 {
 	return cd.updateRequestThreshold
 }
 
+func (cd *configuration) GetCELExpressionCostLimit() int64 {
+	cd.mux.RLock()
+	defer cd.mux.RUnlock()
+	return cd.celExpressionCostLimit
+}
+
+func (cd *configuration) GetCELPolicyCostLimit() int64 {
+	cd.mux.RLock()
+	defer cd.mux.RUnlock()
+	return cd.celPolicyCostLimit
+}
+
 func (cd *configuration) Load(cm *corev1.ConfigMap) {
 	if cm != nil {
 		cd.load(cm)
@@ -381,10 +420,13 @@ func (cd *configuration) load(cm *corev1.ConfigMap) {
 	cd.webhook = WebhookConfig{}
 	cd.webhookAnnotations = nil
 	cd.webhookLabels = nil
+	cd.webhookTiers = nil
 	cd.matchConditions = nil
 	// load filters
 	cd.filters = parseKinds(data[resourceFilters])
 	cd.updateRequestThreshold = UpdateRequestThreshold
+	cd.celExpressionCostLimit = CELExpressionCostLimit
+	cd.celPolicyCostLimit = CELPolicyCostLimit
 	logger.V(4).Info("filters configured", "filters", cd.filters)
 	// load defaultRegistry
 	defaultRegistry, ok := data[defaultRegistry]
@@ -501,6 +543,20 @@ func (cd *configuration) load(cm *corev1.ConfigMap) {
 			logger.V(2).Info("webhookLabels configured")
 		}
 	}
+	// load webhook tiers
+	webhookTiers, ok := data[webhookTiers]
+	if !ok {
+		logger.V(2).Info("webhookTiers not set")
+	} else {
+		logger := logger.WithValues("webhookTiers", webhookTiers)
+		webhookTiers, err := parseWebhookTiers(webhookTiers)
+		if err != nil {
+			logger.Error(err, "failed to parse webhook tiers")
+		} else {
+			cd.webhookTiers = webhookTiers
+			logger.V(2).Info("webhookTiers configured")
+		}
+	}
 	// load match conditions
 	matchConditions, ok := data[matchConditions]
 	if !ok {
@@ -528,6 +584,40 @@ func (cd *configuration) load(cm *corev1.ConfigMap) {
 			logger.V(2).Info("enableDefaultRegistryMutation configured")
 		}
 	}
+	// load celExpressionCostLimit
+	expressionCostLimit, ok := data[celExpressionCostLimit]
+	if !ok {
+		logger.V(2).Info("celExpressionCostLimit not set")
+	} else {
+		logger := logger.WithValues("celExpressionCostLimit", expressionCostLimit)
+		limit, err := strconv.ParseInt(expressionCostLimit, 10, 64)
+		if err == nil && limit < 0 {
+			err = fmt.Errorf("negative limit %d", limit)
+		}
+		if err != nil {
+			logger.Error(err, "celExpressionCostLimit is not a positive integer")
+		} else {
+			cd.celExpressionCostLimit = limit
+			logger.V(2).Info("celExpressionCostLimit configured")
+		}
+	}
+	// load celPolicyCostLimit
+	policyCostLimit, ok := data[celPolicyCostLimit]
+	if !ok {
+		logger.V(2).Info("celPolicyCostLimit not set")
+	} else {
+		logger := logger.WithValues("celPolicyCostLimit", policyCostLimit)
+		limit, err := strconv.ParseInt(policyCostLimit, 10, 64)
+		if err == nil && limit < 0 {
+			err = fmt.Errorf("negative limit %d", limit)
+		}
+		if err != nil {
+			logger.Error(err, "celPolicyCostLimit is not a positive integer")
+		} else {
+			cd.celPolicyCostLimit = limit
+			logger.V(2).Info("celPolicyCostLimit configured")
+		}
+	}
 }
 
 func (cd *configuration) unload() {
@@ -543,6 +633,9 @@ func (cd *configuration) unload() {
 	cd.webhook = WebhookConfig{}
 	cd.webhookAnnotations = nil
 	cd.webhookLabels = nil
+	cd.webhookTiers = nil
+	cd.celExpressionCostLimit = CELExpressionCostLimit
+	cd.celPolicyCostLimit = CELPolicyCostLimit
 	logger.V(2).Info("configuration unloaded")
 }
 
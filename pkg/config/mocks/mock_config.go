@@ -37,6 +37,34 @@ func (m *MockConfiguration) EXPECT() *MockConfigurationMockRecorder {
 	return m.recorder
 }
 
+// GetCELExpressionCostLimit mocks base method.
+func (m *MockConfiguration) GetCELExpressionCostLimit() int64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCELExpressionCostLimit")
+	ret0, _ := ret[0].(int64)
+	return ret0
+}
+
+// GetCELExpressionCostLimit indicates an expected call of GetCELExpressionCostLimit.
+func (mr *MockConfigurationMockRecorder) GetCELExpressionCostLimit() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCELExpressionCostLimit", reflect.TypeOf((*MockConfiguration)(nil).GetCELExpressionCostLimit))
+}
+
+// GetCELPolicyCostLimit mocks base method.
+func (m *MockConfiguration) GetCELPolicyCostLimit() int64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCELPolicyCostLimit")
+	ret0, _ := ret[0].(int64)
+	return ret0
+}
+
+// GetCELPolicyCostLimit indicates an expected call of GetCELPolicyCostLimit.
+func (mr *MockConfigurationMockRecorder) GetCELPolicyCostLimit() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCELPolicyCostLimit", reflect.TypeOf((*MockConfiguration)(nil).GetCELPolicyCostLimit))
+}
+
 // GetDefaultRegistry mocks base method.
 func (m *MockConfiguration) GetDefaultRegistry() string {
 	m.ctrl.T.Helper()
@@ -149,6 +177,20 @@ func (mr *MockConfigurationMockRecorder) GetWebhookLabels() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookLabels", reflect.TypeOf((*MockConfiguration)(nil).GetWebhookLabels))
 }
 
+// GetWebhookTiers mocks base method.
+func (m *MockConfiguration) GetWebhookTiers() []config.WebhookTier {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookTiers")
+	ret0, _ := ret[0].([]config.WebhookTier)
+	return ret0
+}
+
+// GetWebhookTiers indicates an expected call of GetWebhookTiers.
+func (mr *MockConfigurationMockRecorder) GetWebhookTiers() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookTiers", reflect.TypeOf((*MockConfiguration)(nil).GetWebhookTiers))
+}
+
 // IsExcluded mocks base method.
 func (m *MockConfiguration) IsExcluded(username string, groups, roles, clusterroles []string) bool {
 	m.ctrl.T.Helper()
@@ -10,6 +10,8 @@ import (
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 type WebhookConfig struct {
@@ -25,6 +27,58 @@ func parseWebhooks(in string) (*WebhookConfig, error) {
 	return &webhookCfg, nil
 }
 
+// DefaultWebhookTier is the name of the tier serving the namespaces not assigned to a configured tier
+const DefaultWebhookTier = "default"
+
+// WebhookTier assigns namespaces to dedicated resource webhooks
+type WebhookTier struct {
+	// Name identifies the tier, it is used in the name of the webhooks serving the tier
+	Name string `json:"name"`
+	// Namespaces served by the tier
+	Namespaces []string `json:"namespaces"`
+	// FailurePolicy overrides the failure policy of the webhooks serving the tier
+	FailurePolicy *admissionregistrationv1.FailurePolicyType `json:"failurePolicy,omitempty"`
+	// TimeoutSeconds overrides the timeout of the webhooks serving the tier
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+}
+
+func parseWebhookTiers(in string) ([]WebhookTier, error) {
+	var tiers []WebhookTier
+	if err := json.Unmarshal([]byte(in), &tiers); err != nil {
+		return nil, err
+	}
+	names := sets.New[string]()
+	namespaces := sets.New[string]()
+	for _, tier := range tiers {
+		if errs := validation.IsDNS1123Label(tier.Name); len(errs) != 0 {
+			return nil, fmt.Errorf("invalid tier name %q: %s", tier.Name, strings.Join(errs, ", "))
+		}
+		if tier.Name == DefaultWebhookTier {
+			return nil, fmt.Errorf("tier name %q is reserved", tier.Name)
+		}
+		if names.Has(tier.Name) {
+			return nil, fmt.Errorf("duplicate tier name %q", tier.Name)
+		}
+		names.Insert(tier.Name)
+		if len(tier.Namespaces) == 0 {
+			return nil, fmt.Errorf("tier %q has no namespaces", tier.Name)
+		}
+		for _, namespace := range tier.Namespaces {
+			if namespaces.Has(namespace) {
+				return nil, fmt.Errorf("namespace %q is assigned to more than one tier", namespace)
+			}
+			namespaces.Insert(namespace)
+		}
+		if tier.FailurePolicy != nil && *tier.FailurePolicy != admissionregistrationv1.Ignore && *tier.FailurePolicy != admissionregistrationv1.Fail {
+			return nil, fmt.Errorf("tier %q has an invalid failure policy %q", tier.Name, *tier.FailurePolicy)
+		}
+		if tier.TimeoutSeconds != nil && (*tier.TimeoutSeconds < 1 || *tier.TimeoutSeconds > 30) {
+			return nil, fmt.Errorf("tier %q has an invalid timeout %d, it must be between 1 and 30 seconds", tier.Name, *tier.TimeoutSeconds)
+		}
+	}
+	return tiers, nil
+}
+
 func parseExclusions(in string) (exclusions, inclusions []string) {
 	for _, in := range strings.Split(in, ",") {
 		in := strings.TrimSpace(in)
@@ -5,6 +5,7 @@ import (
 	"reflect"
 	"testing"
 
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	"k8s.io/utils/ptr"
 )
 
@@ -297,6 +298,75 @@ func Test_parseWebhookLabels(t *testing.T) {
 	}
 }
 
+func Test_parseWebhookTiers(t *testing.T) {
+	ignore := admissionregistrationv1.Ignore
+	tests := []struct {
+		name    string
+		in      string
+		want    []WebhookTier
+		wantErr bool
+	}{{
+		name:    "invalid json",
+		in:      "hello",
+		wantErr: true,
+	}, {
+		name: "null",
+		in:   "null",
+	}, {
+		name: "valid",
+		in:   `[{"name":"critical","namespaces":["kube-system","kyverno"],"failurePolicy":"Ignore","timeoutSeconds":5},{"name":"tenants","namespaces":["team-a"]}]`,
+		want: []WebhookTier{{
+			Name:           "critical",
+			Namespaces:     []string{"kube-system", "kyverno"},
+			FailurePolicy:  &ignore,
+			TimeoutSeconds: ptr.To[int32](5),
+		}, {
+			Name:       "tenants",
+			Namespaces: []string{"team-a"},
+		}},
+	}, {
+		name:    "invalid name",
+		in:      `[{"name":"Critical","namespaces":["kube-system"]}]`,
+		wantErr: true,
+	}, {
+		name:    "reserved name",
+		in:      `[{"name":"default","namespaces":["kube-system"]}]`,
+		wantErr: true,
+	}, {
+		name:    "duplicate name",
+		in:      `[{"name":"critical","namespaces":["kube-system"]},{"name":"critical","namespaces":["kyverno"]}]`,
+		wantErr: true,
+	}, {
+		name:    "no namespaces",
+		in:      `[{"name":"critical"}]`,
+		wantErr: true,
+	}, {
+		name:    "namespace in two tiers",
+		in:      `[{"name":"critical","namespaces":["kube-system"]},{"name":"system","namespaces":["kube-system"]}]`,
+		wantErr: true,
+	}, {
+		name:    "invalid failure policy",
+		in:      `[{"name":"critical","namespaces":["kube-system"],"failurePolicy":"Skip"}]`,
+		wantErr: true,
+	}, {
+		name:    "invalid timeout",
+		in:      `[{"name":"critical","namespaces":["kube-system"],"timeoutSeconds":31}]`,
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseWebhookTiers(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseWebhookTiers() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseWebhookTiers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_parseBucketBoundariesConfig(t *testing.T) {
 	var emptyBoundaries []float64
 
@@ -7,6 +7,8 @@ import (
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	policiesv1alpha1 "github.com/kyverno/kyverno/api/policies.kyverno.io/v1alpha1"
+	"github.com/kyverno/kyverno/pkg/cleanup"
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
 	kyvernov2informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v2"
 	kyvernov2listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2"
@@ -22,9 +24,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/logging"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	"github.com/kyverno/kyverno/pkg/toggle"
-	"github.com/kyverno/kyverno/pkg/utils/conditions"
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
-	"github.com/kyverno/kyverno/pkg/utils/match"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
@@ -179,11 +179,16 @@ func (c *controller) getPolicy(namespace, name string) (kyvernov2.CleanupPolicyI
 	}
 }
 
-func (c *controller) cleanup(ctx context.Context, logger logr.Logger, policy kyvernov2.CleanupPolicyInterface) error {
+// cleanup deletes the resources selected by a policy, in preview mode nothing is deleted and the selected resources are returned
+func (c *controller) cleanup(ctx context.Context, logger logr.Logger, policy kyvernov2.CleanupPolicyInterface) (*policiesv1alpha1.DryRunStatus, error) {
 	spec := policy.GetSpec()
 	kinds := sets.New(spec.MatchResources.GetKinds()...)
 	debug := logger.V(4)
 	var errs []error
+	var dryRun *policiesv1alpha1.DryRunStatus
+	if spec.DryRunEnabled() {
+		dryRun = &policiesv1alpha1.DryRunStatus{}
+	}
 	deleteOptions := metav1.DeleteOptions{
 		PropagationPolicy: spec.DeletionPropagationPolicy,
 	}
@@ -198,7 +203,7 @@ func (c *controller) cleanup(ctx context.Context, logger logr.Logger, policy kyv
 		spec.Context,
 		enginectx,
 	); err != nil {
-		return err
+		return nil, err
 	}
 	for kind := range kinds {
 		commonLabels := []attribute.KeyValue{
@@ -251,71 +256,19 @@ func (c *controller) cleanup(ctx context.Context, logger logr.Logger, policy kyv
 				}
 				nsLabels = ns.GetLabels()
 			}
-			// match namespaces
-			if err := match.CheckNamespace(policy.GetNamespace(), resource); err != nil {
-				debug.Info("resource namespace didn't match policy namespace", "result", err)
-			}
-			// match resource with match/exclude clause
-			matched := match.CheckMatchesResources(
-				resource,
-				spec.MatchResources,
-				nsLabels,
-				// TODO(eddycharly): we don't have user info here, we should check that
-				// we don't have user conditions in the policy rule
-				kyvernov2.RequestInfo{},
-				resource.GroupVersionKind(),
-				"",
-			)
-			if matched != nil {
-				debug.Info("resource/match didn't match", "result", matched)
+			matched, err := cleanup.Matches(debug, policy, resource, nsLabels, enginectx, c.configuration)
+			if err != nil {
+				debug.Error(err, "failed to match resource")
+				errs = append(errs, err)
 				continue
 			}
-			if spec.ExcludeResources != nil {
-				excluded := match.CheckMatchesResources(
-					resource,
-					*spec.ExcludeResources,
-					nsLabels,
-					// TODO(eddycharly): we don't have user info here, we should check that
-					// we don't have user conditions in the policy rule
-					kyvernov2.RequestInfo{},
-					resource.GroupVersionKind(),
-					"",
-				)
-				if excluded == nil {
-					debug.Info("resource/exclude matched")
-					continue
-				} else {
-					debug.Info("resource/exclude didn't match", "result", excluded)
-				}
+			if !matched {
+				continue
 			}
-			// check conditions
-			if spec.Conditions != nil {
-				enginectx.Reset()
-				if err := enginectx.SetTargetResource(resource.Object); err != nil {
-					debug.Error(err, "failed to add resource in context")
-					errs = append(errs, err)
-					continue
-				}
-				if err := enginectx.AddNamespace(resource.GetNamespace()); err != nil {
-					debug.Error(err, "failed to add namespace in context")
-					errs = append(errs, err)
-					continue
-				}
-				if err := enginectx.AddImageInfos(&resource, c.configuration); err != nil {
-					debug.Error(err, "failed to add image infos in context")
-					errs = append(errs, err)
-					continue
-				}
-				passed, err := conditions.CheckAnyAllConditions(logger, enginectx, *spec.Conditions)
-				if err != nil {
-					debug.Error(err, "failed to check condition")
-					errs = append(errs, err)
-					continue
-				}
-				if !passed {
-					debug.Info("conditions did not pass")
-					continue
-				}
+			if dryRun != nil {
+				debug.Info("resource matched, it would be deleted (dry run)")
+				cleanup.AddDryRunCandidate(dryRun, resource)
+				continue
 			}
 			var labels []attribute.KeyValue
 			labels = append(labels, commonLabels...)
@@ -342,7 +295,10 @@ func (c *controller) cleanup(ctx context.Context, logger logr.Logger, policy kyv
 			}
 		}
 	}
-	return multierr.Combine(errs...)
+	if dryRun != nil {
+		c.eventGen.Add(event.NewCleanupPolicyDryRunEvent(policy, *dryRun))
+	}
+	return dryRun, multierr.Combine(errs...)
 }
 
 func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, namespace, name string) error {
@@ -363,11 +319,11 @@ func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, nam
 	}
 	// In case it is the time to do the cleanup process
 	if time.Now().After(*executionTime) {
-		err := c.cleanup(ctx, logger, policy)
+		dryRun, err := c.cleanup(ctx, logger, policy)
 		if err != nil {
 			return err
 		}
-		if err := c.updateCleanupPolicyStatus(ctx, policy, namespace, *executionTime); err != nil {
+		if err := c.updateCleanupPolicyStatus(ctx, policy, namespace, *executionTime, dryRun); err != nil {
 			logger.Error(err, "failed to update the cleanup policy status")
 			return err
 		}
@@ -387,11 +343,12 @@ func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, nam
 	return nil
 }
 
-func (c *controller) updateCleanupPolicyStatus(ctx context.Context, policy kyvernov2.CleanupPolicyInterface, namespace string, time time.Time) error {
+func (c *controller) updateCleanupPolicyStatus(ctx context.Context, policy kyvernov2.CleanupPolicyInterface, namespace string, time time.Time, dryRun *policiesv1alpha1.DryRunStatus) error {
 	switch obj := policy.(type) {
 	case *kyvernov2.ClusterCleanupPolicy:
 		latest := obj.DeepCopy()
 		latest.Status.LastExecutionTime = metav1.NewTime(time)
+		latest.Status.DryRun = dryRun
 
 		new, err := c.kyvernoClient.KyvernoV2().ClusterCleanupPolicies().UpdateStatus(ctx, latest, metav1.UpdateOptions{})
 		if err != nil {
@@ -401,6 +358,7 @@ func (c *controller) updateCleanupPolicyStatus(ctx context.Context, policy kyver
 	case *kyvernov2.CleanupPolicy:
 		latest := obj.DeepCopy()
 		latest.Status.LastExecutionTime = metav1.NewTime(time)
+		latest.Status.DryRun = dryRun
 
 		new, err := c.kyvernoClient.KyvernoV2().CleanupPolicies(namespace).UpdateStatus(ctx, latest, metav1.UpdateOptions{})
 		if err != nil {
@@ -9,6 +9,7 @@ import (
 	"github.com/kyverno/kyverno/api/policies.kyverno.io/v1alpha1"
 	"github.com/kyverno/kyverno/pkg/admissionpolicy"
 	"github.com/kyverno/kyverno/pkg/cel/policies/dpol/engine"
+	"github.com/kyverno/kyverno/pkg/cleanup"
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
 	kyvernov1alpha1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/policies.kyverno.io/v1alpha1"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
@@ -146,23 +147,28 @@ func (c *controller) Run(ctx context.Context, workers int) {
 	controllerutils.Run(ctx, logger.V(3), ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile)
 }
 
-func (c *controller) deleting(ctx context.Context, logger logr.Logger, ePolicy engine.Policy) error {
+// deleting deletes the resources selected by a policy, in preview mode nothing is deleted and the selected resources are returned
+func (c *controller) deleting(ctx context.Context, logger logr.Logger, ePolicy engine.Policy) (*v1alpha1.DryRunStatus, error) {
 	spec := ePolicy.Policy.Spec
 	policy := ePolicy.Policy
 
 	debug := logger.V(4)
 	var errs []error
+	var dryRun *v1alpha1.DryRunStatus
+	if spec.DryRunEnabled() {
+		dryRun = &v1alpha1.DryRunStatus{}
+	}
 	deleteOptions := metav1.DeleteOptions{
 		PropagationPolicy: spec.DeletionPropagationPolicy,
 	}
 
 	if spec.MatchConstraints == nil {
-		return errors.New("matchConstraints is required")
+		return nil, errors.New("matchConstraints is required")
 	}
 
 	restMapper, err := restmapper.GetRESTMapper(c.client, false)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	kinds := admissionpolicy.GetKinds(spec.MatchConstraints, restMapper)
@@ -224,6 +230,12 @@ func (c *controller) deleting(ctx context.Context, logger logr.Logger, ePolicy e
 				continue
 			}
 
+			if dryRun != nil {
+				debug.Info("resource matched, it would be deleted (dry run)")
+				cleanup.AddDryRunCandidate(dryRun, resource)
+				continue
+			}
+
 			var labels []attribute.KeyValue
 			labels = append(labels, commonLabels...)
 			labels = append(labels, attribute.String("resource_namespace", namespace))
@@ -249,7 +261,10 @@ func (c *controller) deleting(ctx context.Context, logger logr.Logger, ePolicy e
 			}
 		}
 	}
-	return multierr.Combine(errs...)
+	if dryRun != nil {
+		c.eventGen.Add(event.NewDeletingPolicyDryRunEvent(policy, *dryRun))
+	}
+	return dryRun, multierr.Combine(errs...)
 }
 
 func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, namespace, name string) error {
@@ -271,11 +286,11 @@ func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, nam
 
 	// In case it is the time to do the cleanup process
 	if time.Now().After(*executionTime) {
-		err := c.deleting(ctx, logger, policy)
+		dryRun, err := c.deleting(ctx, logger, policy)
 		if err != nil {
 			return err
 		}
-		if err := c.updateDeletingPolicyStatus(ctx, policy.Policy, *executionTime); err != nil {
+		if err := c.updateDeletingPolicyStatus(ctx, policy.Policy, *executionTime, dryRun); err != nil {
 			logger.Error(err, "failed to update the cleanup policy status")
 			return err
 		}
@@ -294,10 +309,11 @@ func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, nam
 	return nil
 }
 
-func (c *controller) updateDeletingPolicyStatus(ctx context.Context, policy v1alpha1.DeletingPolicy, time time.Time) error {
+func (c *controller) updateDeletingPolicyStatus(ctx context.Context, policy v1alpha1.DeletingPolicy, time time.Time, dryRun *v1alpha1.DryRunStatus) error {
 	err := controllerutils.UpdateStatus(ctx, &policy, c.kyvernoClient.PoliciesV1alpha1().DeletingPolicies(), func(p *v1alpha1.DeletingPolicy) error {
 		p.Status = v1alpha1.DeletingPolicyStatus{
 			LastExecutionTime: metav1.NewTime(time),
+			DryRun:            dryRun,
 		}
 
 		return nil
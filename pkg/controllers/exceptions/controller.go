@@ -15,6 +15,7 @@ import (
 	kyvernov2informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v2"
 	kyvernov1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
 	kyvernov2listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2"
+	"github.com/kyverno/kyverno/pkg/toggle"
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/labels"
@@ -84,7 +85,16 @@ func (c *controller) Run(ctx context.Context, workers int) {
 func (c *controller) Find(policyName string, ruleName string) ([]*kyvernov2.PolicyException, error) {
 	c.lock.RLock()
 	defer c.lock.RUnlock()
-	return c.index[policyName][ruleName], nil
+	now := time.Now()
+	approvalRequired := toggle.FromContext(context.TODO()).RequireExceptionApproval()
+	var results []*kyvernov2.PolicyException
+	for _, polex := range c.index[policyName][ruleName] {
+		// expired, not yet valid or unapproved exceptions are not applied
+		if polex.IsActive(now, approvalRequired) {
+			results = append(results, polex)
+		}
+	}
+	return results, nil
 }
 
 func (c *controller) addPolex(polex *kyvernov2.PolicyException) {
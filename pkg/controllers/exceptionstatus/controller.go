@@ -0,0 +1,211 @@
+package exceptionstatus
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	policiesv1alpha1 "github.com/kyverno/kyverno/api/policies.kyverno.io/v1alpha1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	kyvernov2informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v2"
+	policiesv1alpha1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/policies.kyverno.io/v1alpha1"
+	kyvernov2listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2"
+	policiesv1alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/policies.kyverno.io/v1alpha1"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	"github.com/kyverno/kyverno/pkg/event"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	Workers        = 1
+	ControllerName = "exception-status-controller"
+	maxRetries     = 10
+)
+
+type Controller interface {
+	controllers.Controller
+}
+
+type controller struct {
+	// clients
+	client versioned.Interface
+
+	// listers
+	polexLister    kyvernov2listers.PolicyExceptionLister
+	celpolexLister policiesv1alpha1listers.PolicyExceptionLister
+
+	// queue
+	queue workqueue.TypedRateLimitingInterface[any]
+
+	eventGen event.Interface
+}
+
+func NewController(
+	client versioned.Interface,
+	polexInformer kyvernov2informers.PolicyExceptionInformer,
+	celpolexInformer policiesv1alpha1informers.PolicyExceptionInformer,
+	eventGen event.Interface,
+	expiringWindow time.Duration,
+) Controller {
+	c := &controller{
+		client:         client,
+		polexLister:    polexInformer.Lister(),
+		celpolexLister: celpolexInformer.Lister(),
+		queue: workqueue.NewTypedRateLimitingQueueWithConfig(
+			workqueue.DefaultTypedControllerRateLimiter[any](),
+			workqueue.TypedRateLimitingQueueConfig[any]{Name: ControllerName},
+		),
+		eventGen: eventGen,
+	}
+	if _, _, err := controllerutils.AddExplicitEventHandlers(
+		logger,
+		polexInformer.Informer(),
+		c.queue,
+		func(obj *kyvernov2.PolicyException) cache.ExplicitKey {
+			return buildKey(kyvernov2.SchemeGroupVersion.Group, obj)
+		},
+	); err != nil {
+		logger.Error(err, "failed to register event handlers for PolicyException")
+	}
+	if _, _, err := controllerutils.AddExplicitEventHandlers(
+		logger,
+		celpolexInformer.Informer(),
+		c.queue,
+		func(obj *policiesv1alpha1.PolicyException) cache.ExplicitKey {
+			return buildKey(policiesv1alpha1.SchemeGroupVersion.Group, obj)
+		},
+	); err != nil {
+		logger.Error(err, "failed to register event handlers for CEL PolicyException")
+	}
+	newMetrics(c.polexLister, c.celpolexLister, expiringWindow)
+	return c
+}
+
+func (c *controller) Run(ctx context.Context, workers int) {
+	controllerutils.Run(ctx, logger, ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile)
+}
+
+func buildKey(group string, obj metav1.Object) cache.ExplicitKey {
+	return cache.ExplicitKey(strings.Join([]string{group, obj.GetNamespace(), obj.GetName()}, "/"))
+}
+
+func parseKey(key string) (string, string, string, error) {
+	parts := strings.Split(key, "/")
+	if len(parts) != 3 {
+		return "", "", "", fmt.Errorf("unexpected key format: %s", key)
+	}
+	return parts[0], parts[1], parts[2], nil
+}
+
+func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, _, _ string) error {
+	group, namespace, name, err := parseKey(key)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	var next time.Duration
+	switch group {
+	case kyvernov2.SchemeGroupVersion.Group:
+		next, err = c.reconcilePolicyException(ctx, namespace, name, now)
+	case policiesv1alpha1.SchemeGroupVersion.Group:
+		next, err = c.reconcileCELPolicyException(ctx, namespace, name, now)
+	default:
+		return fmt.Errorf("unsupported policy exception group: %s", group)
+	}
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.V(4).Info("policy exception not found")
+			return nil
+		}
+		return err
+	}
+	// requeue the exception when its validity window starts or ends
+	if next > 0 {
+		c.queue.AddAfter(cache.ExplicitKey(key), next)
+	}
+	return nil
+}
+
+func (c *controller) reconcilePolicyException(ctx context.Context, namespace, name string, now time.Time) (time.Duration, error) {
+	polex, err := c.polexLister.PolicyExceptions(namespace).Get(name)
+	if err != nil {
+		return 0, err
+	}
+	validity := polex.Spec.Validity(now)
+	if polex.Status.Validity() != validity {
+		err := controllerutils.UpdateStatus(
+			ctx,
+			polex,
+			c.client.KyvernoV2().PolicyExceptions(namespace),
+			func(polex *kyvernov2.PolicyException) error {
+				polex.Status.SetValidity(validity, validityMessage(string(validity), polex.Spec.NotBefore, polex.Spec.NotAfter))
+				return nil
+			},
+			nil,
+		)
+		if err != nil {
+			return 0, err
+		}
+		if validity == kyvernov2.PolicyExceptionExpired {
+			c.eventGen.Add(event.NewPolicyExceptionExpiredEvent(kyvernov2.SchemeGroupVersion.String(), polex, polex.Spec.NotAfter.Time))
+		}
+	}
+	return nextTransition(now, polex.Spec.NotBefore, polex.Spec.NotAfter), nil
+}
+
+func (c *controller) reconcileCELPolicyException(ctx context.Context, namespace, name string, now time.Time) (time.Duration, error) {
+	polex, err := c.celpolexLister.PolicyExceptions(namespace).Get(name)
+	if err != nil {
+		return 0, err
+	}
+	validity := polex.Spec.Validity(now)
+	if polex.Status.Validity() != validity {
+		err := controllerutils.UpdateStatus(
+			ctx,
+			polex,
+			c.client.PoliciesV1alpha1().PolicyExceptions(namespace),
+			func(polex *policiesv1alpha1.PolicyException) error {
+				polex.Status.SetValidity(validity, validityMessage(string(validity), polex.Spec.NotBefore, polex.Spec.NotAfter))
+				return nil
+			},
+			nil,
+		)
+		if err != nil {
+			return 0, err
+		}
+		if validity == policiesv1alpha1.PolicyExceptionExpired {
+			c.eventGen.Add(event.NewPolicyExceptionExpiredEvent(policiesv1alpha1.SchemeGroupVersion.String(), polex, polex.Spec.NotAfter.Time))
+		}
+	}
+	return nextTransition(now, polex.Spec.NotBefore, polex.Spec.NotAfter), nil
+}
+
+// nextTransition returns the duration until the next change of the validity window state,
+// or zero if the state will not change anymore
+func nextTransition(now time.Time, notBefore, notAfter *metav1.Time) time.Duration {
+	if notBefore != nil && now.Before(notBefore.Time) {
+		return notBefore.Sub(now)
+	}
+	if notAfter != nil && now.Before(notAfter.Time) {
+		return notAfter.Sub(now)
+	}
+	return 0
+}
+
+func validityMessage(validity string, notBefore, notAfter *metav1.Time) string {
+	switch validity {
+	case string(kyvernov2.PolicyExceptionNotYetValid):
+		return fmt.Sprintf("exception is not applied before %s", notBefore.UTC().Format(time.RFC3339))
+	case string(kyvernov2.PolicyExceptionExpired):
+		return fmt.Sprintf("exception expired at %s", notAfter.UTC().Format(time.RFC3339))
+	default:
+		return "exception is within its validity window"
+	}
+}
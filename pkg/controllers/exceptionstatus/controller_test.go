@@ -0,0 +1,108 @@
+package exceptionstatus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	policiesv1alpha1 "github.com/kyverno/kyverno/api/policies.kyverno.io/v1alpha1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	kyvernoinformer "github.com/kyverno/kyverno/pkg/client/informers/externalversions"
+	"github.com/kyverno/kyverno/pkg/event"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newTestController(t *testing.T, polexs []*kyvernov2.PolicyException, celpolexs []*policiesv1alpha1.PolicyException) (*controller, *fake.Clientset) {
+	t.Helper()
+	client := fake.NewSimpleClientset()
+	factory := kyvernoinformer.NewSharedInformerFactory(client, 0)
+	polexInformer := factory.Kyverno().V2().PolicyExceptions()
+	celpolexInformer := factory.Policies().V1alpha1().PolicyExceptions()
+	for _, polex := range polexs {
+		_, err := client.KyvernoV2().PolicyExceptions(polex.Namespace).Create(context.TODO(), polex, metav1.CreateOptions{})
+		assert.NoError(t, err)
+		assert.NoError(t, polexInformer.Informer().GetIndexer().Add(polex))
+	}
+	for _, polex := range celpolexs {
+		_, err := client.PoliciesV1alpha1().PolicyExceptions(polex.Namespace).Create(context.TODO(), polex, metav1.CreateOptions{})
+		assert.NoError(t, err)
+		assert.NoError(t, celpolexInformer.Informer().GetIndexer().Add(polex))
+	}
+	c := NewController(client, polexInformer, celpolexInformer, event.NewFake(), time.Hour).(*controller)
+	return c, client
+}
+
+func TestReconcile_MarksExpiredException(t *testing.T) {
+	expired := metav1.NewTime(time.Now().Add(-time.Minute))
+	polex := &kyvernov2.PolicyException{
+		ObjectMeta: metav1.ObjectMeta{Name: "expired", Namespace: "default"},
+		Spec:       kyvernov2.PolicyExceptionSpec{NotAfter: &expired},
+	}
+	c, client := newTestController(t, []*kyvernov2.PolicyException{polex}, nil)
+	err := c.reconcile(context.TODO(), logr.Discard(), string(buildKey(kyvernov2.SchemeGroupVersion.Group, polex)), "", "")
+	assert.NoError(t, err)
+	updated, err := client.KyvernoV2().PolicyExceptions("default").Get(context.TODO(), "expired", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.True(t, updated.Status.IsExpired())
+	assert.Equal(t, kyvernov2.PolicyExceptionExpired, updated.Status.Validity())
+	assert.Equal(t, 0, c.queue.Len())
+}
+
+func TestReconcile_CELExceptionNotYetValid(t *testing.T) {
+	notBefore := metav1.NewTime(time.Now().Add(time.Hour))
+	polex := &policiesv1alpha1.PolicyException{
+		ObjectMeta: metav1.ObjectMeta{Name: "scheduled", Namespace: "default"},
+		Spec:       policiesv1alpha1.PolicyExceptionSpec{NotBefore: &notBefore},
+	}
+	c, client := newTestController(t, nil, []*policiesv1alpha1.PolicyException{polex})
+	err := c.reconcile(context.TODO(), logr.Discard(), string(buildKey(policiesv1alpha1.SchemeGroupVersion.Group, polex)), "", "")
+	assert.NoError(t, err)
+	updated, err := client.PoliciesV1alpha1().PolicyExceptions("default").Get(context.TODO(), "scheduled", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.False(t, updated.Status.IsExpired())
+	assert.Equal(t, policiesv1alpha1.PolicyExceptionNotYetValid, updated.Status.Validity())
+}
+
+func TestReconcile_NotFound(t *testing.T) {
+	c, _ := newTestController(t, nil, nil)
+	err := c.reconcile(context.TODO(), logr.Discard(), "kyverno.io/default/missing", "", "")
+	assert.NoError(t, err)
+	err = c.reconcile(context.TODO(), logr.Discard(), "invalid", "", "")
+	assert.Error(t, err)
+}
+
+func TestNextTransition(t *testing.T) {
+	now := time.Now()
+	before := metav1.NewTime(now.Add(-time.Hour))
+	after := metav1.NewTime(now.Add(time.Hour))
+	later := metav1.NewTime(now.Add(2 * time.Hour))
+	assert.Equal(t, time.Duration(0), nextTransition(now, nil, nil))
+	assert.Equal(t, time.Hour, nextTransition(now, &after, &later))
+	assert.Equal(t, time.Hour, nextTransition(now, &before, &after))
+	assert.Equal(t, time.Duration(0), nextTransition(now, nil, &before))
+}
+
+func TestListExpiring(t *testing.T) {
+	now := time.Now()
+	soon := metav1.NewTime(now.Add(30 * time.Minute))
+	later := metav1.NewTime(now.Add(48 * time.Hour))
+	expired := metav1.NewTime(now.Add(-time.Minute))
+	c, _ := newTestController(t,
+		[]*kyvernov2.PolicyException{
+			{ObjectMeta: metav1.ObjectMeta{Name: "soon", Namespace: "default"}, Spec: kyvernov2.PolicyExceptionSpec{NotAfter: &soon}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "later", Namespace: "default"}, Spec: kyvernov2.PolicyExceptionSpec{NotAfter: &later}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "forever", Namespace: "default"}},
+		},
+		[]*policiesv1alpha1.PolicyException{
+			{ObjectMeta: metav1.ObjectMeta{Name: "expired", Namespace: "default"}, Spec: policiesv1alpha1.PolicyExceptionSpec{NotAfter: &expired}},
+		},
+	)
+	results := listExpiring(c.polexLister, c.celpolexLister, now, time.Hour)
+	assert.Len(t, results, 1)
+	assert.Equal(t, "soon", results[0].name)
+	assert.Equal(t, kyvernov2.SchemeGroupVersion.Group, results[0].group)
+	assert.Equal(t, 30*time.Minute, results[0].remaining)
+}
@@ -0,0 +1,87 @@
+package exceptionstatus
+
+import (
+	"context"
+	"time"
+
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	policiesv1alpha1 "github.com/kyverno/kyverno/api/policies.kyverno.io/v1alpha1"
+	kyvernov2listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2"
+	policiesv1alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/policies.kyverno.io/v1alpha1"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+type expiringException struct {
+	group     string
+	namespace string
+	name      string
+	remaining time.Duration
+}
+
+// newMetrics reports the remaining time of the exceptions that expire within the given window
+func newMetrics(polexLister kyvernov2listers.PolicyExceptionLister, celpolexLister policiesv1alpha1listers.PolicyExceptionLister, window time.Duration) {
+	meter := otel.GetMeterProvider().Meter(metrics.MeterName)
+	expiringMetric, err := meter.Float64ObservableGauge(
+		"kyverno_policy_exception_expiring_seconds",
+		metric.WithDescription("can be used to track policy exceptions expiring soon, the value is the number of seconds before the exception expires"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_policy_exception_expiring_seconds")
+		return
+	}
+	_, err = meter.RegisterCallback(func(_ context.Context, observer metric.Observer) error {
+		for _, exception := range listExpiring(polexLister, celpolexLister, time.Now(), window) {
+			observer.ObserveFloat64(
+				expiringMetric,
+				exception.remaining.Seconds(),
+				metric.WithAttributes(
+					attribute.String("exception_group", exception.group),
+					attribute.String("exception_namespace", exception.namespace),
+					attribute.String("exception_name", exception.name),
+				),
+			)
+		}
+		return nil
+	}, expiringMetric)
+	if err != nil {
+		logger.Error(err, "failed to register callback")
+	}
+}
+
+func listExpiring(polexLister kyvernov2listers.PolicyExceptionLister, celpolexLister policiesv1alpha1listers.PolicyExceptionLister, now time.Time, window time.Duration) []expiringException {
+	var results []expiringException
+	add := func(group string, obj metav1.Object, notAfter *metav1.Time) {
+		if notAfter == nil || !now.Before(notAfter.Time) {
+			return
+		}
+		if remaining := notAfter.Sub(now); remaining <= window {
+			results = append(results, expiringException{
+				group:     group,
+				namespace: obj.GetNamespace(),
+				name:      obj.GetName(),
+				remaining: remaining,
+			})
+		}
+	}
+	polexs, err := polexLister.List(labels.Everything())
+	if err != nil {
+		logger.Error(err, "failed to list policy exceptions")
+	}
+	for _, polex := range polexs {
+		add(kyvernov2.SchemeGroupVersion.Group, polex, polex.Spec.NotAfter)
+	}
+	celpolexs, err := celpolexLister.List(labels.Everything())
+	if err != nil {
+		logger.Error(err, "failed to list CEL policy exceptions")
+	}
+	for _, polex := range celpolexs {
+		add(policiesv1alpha1.SchemeGroupVersion.Group, polex, polex.Spec.NotAfter)
+	}
+	return results
+}
@@ -154,9 +154,11 @@ func (c *controller) makeStoreEntry(ctx context.Context, gce *kyvernov2alpha1.Gl
 			c.eventGen,
 			c.kubeClient,
 			c.dclient.GetDynamicInterface(),
+			c.kyvernoClient,
 			logger,
 			gvr,
-			gce.Spec.KubernetesResource.Namespace,
+			gce.Spec.KubernetesResource,
+			c.shouldUpdateStatus,
 			c.jp,
 		)
 	}
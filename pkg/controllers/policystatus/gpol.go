@@ -28,6 +28,8 @@ func (c controller) updateGpolStatus(ctx context.Context, gpol *policiesv1alpha1
 		// assign
 		gpol.Status = policiesv1alpha1.GeneratingPolicyStatus{
 			ConditionStatus: *conditionStatus,
+			Sources:         gpol.Status.Sources,
+			WebhookTiers:    gpol.Status.WebhookTiers,
 		}
 		return nil
 	}
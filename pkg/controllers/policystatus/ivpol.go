@@ -39,6 +39,7 @@ func (c controller) updateIvpolStatus(ctx context.Context, ivpol *policiesv1alph
 		ivpol.Status = policiesv1alpha1.ImageValidatingPolicyStatus{
 			ConditionStatus: *conditionStatus,
 			Autogen:         autogenStatus,
+			WebhookTiers:    ivpol.Status.WebhookTiers,
 		}
 		return nil
 	}
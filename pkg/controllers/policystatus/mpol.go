@@ -38,6 +38,7 @@ func (c controller) updateMpolStatus(ctx context.Context, mpol *policiesv1alpha1
 			ConditionStatus: *conditionStatus,
 			Autogen:         autogenStatus,
 			Generated:       status.Generated,
+			WebhookTiers:    status.WebhookTiers,
 		}
 		return nil
 	}
@@ -40,6 +40,7 @@ func (c controller) updateVpolStatus(ctx context.Context, vpol *policiesv1alpha1
 			ConditionStatus: *conditionStatus,
 			Autogen:         autogenStatus,
 			Generated:       status.Generated,
+			WebhookTiers:    status.WebhookTiers,
 		}
 		return nil
 	}
@@ -28,6 +28,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 	"github.com/kyverno/kyverno/pkg/event"
 	gctxstore "github.com/kyverno/kyverno/pkg/globalcontext/store"
+	"github.com/kyverno/kyverno/pkg/resultsink"
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
 	datautils "github.com/kyverno/kyverno/pkg/utils/data"
 	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
@@ -95,6 +96,7 @@ type controller struct {
 	eventGen      event.Interface
 	policyReports bool
 	reportsConfig reportutils.ReportingConfiguration
+	resultSink    resultsink.Sink
 	gctxStore     gctxstore.Store
 
 	typeConverter patch.TypeConverterManager
@@ -124,6 +126,7 @@ func NewController(
 	eventGen event.Interface,
 	policyReports bool,
 	reportsConfig reportutils.ReportingConfiguration,
+	resultSink resultsink.Sink,
 	gctxStore gctxstore.Store,
 	typeConverter patch.TypeConverterManager,
 ) controllers.Controller {
@@ -151,6 +154,7 @@ func NewController(
 		eventGen:       eventGen,
 		policyReports:  policyReports,
 		reportsConfig:  reportsConfig,
+		resultSink:     resultSink,
 		gctxStore:      gctxStore,
 		typeConverter:  typeConverter,
 	}
@@ -576,6 +580,7 @@ func (c *controller) reconcileReport(
 		}
 	}
 	// calculate necessary results
+	var scanned []engineapi.EngineResponse
 	for _, policy := range policies {
 		if vpol := policy.AsValidatingPolicy(); vpol != nil && vpol.Status.Generated {
 			continue
@@ -614,6 +619,7 @@ func (c *controller) reconcileReport(
 					return result.Error
 				} else if result.EngineResponse != nil {
 					ruleResults = append(ruleResults, reportutils.EngineResponseToReportResults(*result.EngineResponse)...)
+					scanned = append(scanned, *result.EngineResponse)
 					utils.GenerateEvents(logger, c.eventGen, c.config, *result.EngineResponse)
 				}
 			}
@@ -642,6 +648,14 @@ func (c *controller) reconcileReport(
 	if full || !controllerutils.HasAnnotation(desired, annotationLastScanTime) {
 		controllerutils.SetAnnotation(desired, annotationLastScanTime, time.Now().Format(time.RFC3339))
 	}
+	// only the results computed by this scan are streamed, kept results were already sent
+	if c.resultSink != nil {
+		if records := resultsink.FromEngineResponses(resultsink.SourceBackground, "", scanned...); len(records) != 0 {
+			if err := c.resultSink.Send(ctx, records...); err != nil {
+				logger.Error(err, "failed to send results to the result sink")
+			}
+		}
+	}
 	if c.policyReports {
 		return c.storeReport(ctx, observed, desired)
 	}
@@ -0,0 +1,285 @@
+package summary
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/api/kyverno"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	reportsv1 "github.com/kyverno/kyverno/api/reports/v1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	policyreportv1alpha2informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/policyreport/v1alpha2"
+	reportsv1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/reports/v1"
+	reportsv1listers "github.com/kyverno/kyverno/pkg/client/listers/reports/v1"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	"github.com/kyverno/kyverno/pkg/openreports"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	openreportsv1alpha1 "github.com/openreports/reports-api/apis/openreports.io/v1alpha1"
+	openreportsclient "github.com/openreports/reports-api/pkg/client/clientset/versioned/typed/openreports.io/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	// Workers is the number of workers for this controller
+	Workers        = 2
+	ControllerName = "compliance-summary-controller"
+	maxRetries     = 10
+	enqueueDelay   = 30 * time.Second
+	// resyncPeriod makes sure a daily snapshot is recorded even when no report changes
+	resyncPeriod = time.Hour
+	dateFormat   = "2006-01-02"
+)
+
+type controller struct {
+	// clients
+	client   versioned.Interface
+	orClient openreportsclient.OpenreportsV1alpha1Interface
+
+	// informers
+	reportInformer cache.SharedIndexInformer
+
+	// listers
+	csumLister reportsv1listers.ComplianceSummaryLister
+
+	// queue
+	queue workqueue.TypedRateLimitingInterface[any]
+
+	// config
+	maxHistory int
+}
+
+func NewController(
+	client versioned.Interface,
+	orClient openreportsclient.OpenreportsV1alpha1Interface,
+	csumInformer reportsv1informers.ComplianceSummaryInformer,
+	maxHistory int,
+) controllers.Controller {
+	c := &controller{
+		client:         client,
+		orClient:       orClient,
+		reportInformer: newReportInformer(client, orClient),
+		csumLister:     csumInformer.Lister(),
+		queue: workqueue.NewTypedRateLimitingQueueWithConfig(
+			workqueue.DefaultTypedControllerRateLimiter[any](),
+			workqueue.TypedRateLimitingQueueConfig[any]{Name: ControllerName},
+		),
+		maxHistory: maxHistory,
+	}
+	// reports are updated in bursts, delay the reconciliation to summarize a namespace only once per burst
+	if _, _, err := controllerutils.AddDelayedExplicitEventHandlers(
+		logger,
+		c.reportInformer,
+		c.queue,
+		enqueueDelay,
+		func(obj metav1.Object) cache.ExplicitKey {
+			return cache.ExplicitKey(obj.GetNamespace())
+		},
+	); err != nil {
+		logger.Error(err, "failed to register event handlers")
+	}
+	// recreate the summary if it is deleted or modified
+	if _, _, err := controllerutils.AddDelayedExplicitEventHandlers(
+		logger,
+		csumInformer.Informer(),
+		c.queue,
+		enqueueDelay,
+		func(obj *reportsv1.ComplianceSummary) cache.ExplicitKey {
+			return cache.ExplicitKey(obj.GetNamespace())
+		},
+	); err != nil {
+		logger.Error(err, "failed to register event handlers")
+	}
+	return c
+}
+
+// newReportInformer returns an informer caching the namespaced reports managed by kyverno,
+// the summaries are computed from its cache instead of listing the reports on every reconciliation
+func newReportInformer(client versioned.Interface, orClient openreportsclient.OpenreportsV1alpha1Interface) cache.SharedIndexInformer {
+	selector := labels.SelectorFromSet(labels.Set{
+		kyverno.LabelAppManagedBy: kyverno.ValueKyvernoApp,
+	}).String()
+	tweakListOptions := func(options *metav1.ListOptions) {
+		options.LabelSelector = selector
+	}
+	indexers := cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}
+	if orClient == nil {
+		return policyreportv1alpha2informers.NewFilteredPolicyReportInformer(client, metav1.NamespaceAll, 0, indexers, tweakListOptions)
+	}
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				tweakListOptions(&options)
+				return orClient.Reports(metav1.NamespaceAll).List(context.TODO(), options)
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				tweakListOptions(&options)
+				return orClient.Reports(metav1.NamespaceAll).Watch(context.TODO(), options)
+			},
+		},
+		&openreportsv1alpha1.Report{},
+		0,
+		indexers,
+	)
+}
+
+func (c *controller) Run(ctx context.Context, workers int) {
+	go c.reportInformer.Run(ctx.Done())
+	if !cache.WaitForCacheSync(ctx.Done(), c.reportInformer.HasSynced) {
+		logger.Error(errors.New("failed to wait for cache sync"), "failed to sync the reports cache")
+		return
+	}
+	controllerutils.Run(ctx, logger, ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile, c.resync)
+}
+
+// resync periodically enqueues all the namespaces having reports or a summary
+func (c *controller) resync(ctx context.Context, logger logr.Logger) {
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		namespaces := sets.New[string]()
+		for _, report := range c.reportInformer.GetStore().List() {
+			if obj, ok := report.(metav1.Object); ok && obj.GetNamespace() != "" {
+				namespaces.Insert(obj.GetNamespace())
+			}
+		}
+		summaries, err := c.csumLister.List(labels.Everything())
+		if err != nil {
+			logger.Error(err, "failed to list compliance summaries")
+			return
+		}
+		for _, summary := range summaries {
+			namespaces.Insert(summary.GetNamespace())
+		}
+		for namespace := range namespaces {
+			c.queue.Add(cache.ExplicitKey(namespace))
+		}
+	}, resyncPeriod)
+}
+
+func (c *controller) listResults(namespace string) (bool, []openreportsv1alpha1.ReportResult, error) {
+	reports, err := c.reportInformer.GetIndexer().ByIndex(cache.NamespaceIndex, namespace)
+	if err != nil {
+		return false, nil, err
+	}
+	var results []openreportsv1alpha1.ReportResult
+	for _, report := range reports {
+		switch report := report.(type) {
+		case *openreportsv1alpha1.Report:
+			results = append(results, report.Results...)
+		case *policyreportv1alpha2.PolicyReport:
+			results = append(results, openreports.NewWGPolAdapter(report).GetResults()...)
+		}
+	}
+	return len(reports) != 0, results, nil
+}
+
+func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, _, _ string) error {
+	namespace := key
+	found, results, err := c.listResults(namespace)
+	if err != nil {
+		return err
+	}
+	current, err := c.csumLister.ComplianceSummaries(namespace).Get(reportsv1.ComplianceSummaryName)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		// nothing to summarize yet
+		if !found {
+			return nil
+		}
+		spec := summarize(results)
+		spec.History = updateHistory(nil, today(), spec.Summary, c.maxHistory)
+		summary := &reportsv1.ComplianceSummary{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      reportsv1.ComplianceSummaryName,
+				Namespace: namespace,
+				Labels: map[string]string{
+					kyverno.LabelAppManagedBy: kyverno.ValueKyvernoApp,
+				},
+			},
+			Spec: spec,
+		}
+		logger.V(3).Info("creating compliance summary", "namespace", namespace)
+		_, err := c.client.ReportsV1().ComplianceSummaries(namespace).Create(ctx, summary, metav1.CreateOptions{})
+		return err
+	}
+	spec := summarize(results)
+	spec.History = updateHistory(current.Spec.History, today(), spec.Summary, c.maxHistory)
+	if reflect.DeepEqual(spec, current.Spec) {
+		return nil
+	}
+	summary := current.DeepCopy()
+	summary.Spec = spec
+	logger.V(3).Info("updating compliance summary", "namespace", namespace)
+	_, err = c.client.ReportsV1().ComplianceSummaries(namespace).Update(ctx, summary, metav1.UpdateOptions{})
+	return err
+}
+
+func today() string {
+	return time.Now().UTC().Format(dateFormat)
+}
+
+// summarize counts the results globally and by policy, severity and category
+func summarize(results []openreportsv1alpha1.ReportResult) reportsv1.ComplianceSummarySpec {
+	return reportsv1.ComplianceSummarySpec{
+		Summary: reportutils.CalculateSummary(results),
+		Policies: countBy(results, func(result openreportsv1alpha1.ReportResult) string {
+			return result.Policy
+		}),
+		Severities: countBy(results, func(result openreportsv1alpha1.ReportResult) string {
+			return string(result.Severity)
+		}),
+		Categories: countBy(results, func(result openreportsv1alpha1.ReportResult) string {
+			return result.Category
+		}),
+	}
+}
+
+func countBy(results []openreportsv1alpha1.ReportResult, name func(openreportsv1alpha1.ReportResult) string) []reportsv1.ComplianceCount {
+	groups := map[string][]openreportsv1alpha1.ReportResult{}
+	for _, result := range results {
+		groups[name(result)] = append(groups[name(result)], result)
+	}
+	var counts []reportsv1.ComplianceCount
+	for name, results := range groups {
+		counts = append(counts, reportsv1.ComplianceCount{
+			Name:    name,
+			Summary: reportutils.CalculateSummary(results),
+		})
+	}
+	slices.SortFunc(counts, func(a, b reportsv1.ComplianceCount) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return counts
+}
+
+// updateHistory records the summary of the given date, replacing the snapshot of that date if it exists,
+// and drops the oldest snapshots to keep at most limit entries
+func updateHistory(history []reportsv1.ComplianceSnapshot, date string, summary openreportsv1alpha1.ReportSummary, limit int) []reportsv1.ComplianceSnapshot {
+	if limit <= 0 {
+		return nil
+	}
+	history = slices.Clone(history)
+	if len(history) != 0 && history[len(history)-1].Date == date {
+		history[len(history)-1].Summary = summary
+	} else {
+		history = append(history, reportsv1.ComplianceSnapshot{Date: date, Summary: summary})
+	}
+	if len(history) > limit {
+		history = history[len(history)-limit:]
+	}
+	return history
+}
@@ -0,0 +1,110 @@
+package summary
+
+import (
+	"testing"
+
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	reportsv1 "github.com/kyverno/kyverno/api/reports/v1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	openreportsv1alpha1 "github.com/openreports/reports-api/apis/openreports.io/v1alpha1"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_listResults(t *testing.T) {
+	c := &controller{reportInformer: newReportInformer(fake.NewSimpleClientset(), nil)}
+	store := c.reportInformer.GetStore()
+	assert.NilError(t, store.Add(&policyreportv1alpha2.PolicyReport{
+		ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "prod"},
+		Results:    []policyreportv1alpha2.PolicyReportResult{{Policy: "p1", Result: "pass"}, {Policy: "p2", Result: "fail"}},
+	}))
+	assert.NilError(t, store.Add(&policyreportv1alpha2.PolicyReport{
+		ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "dev"},
+		Results:    []policyreportv1alpha2.PolicyReportResult{{Policy: "p1", Result: "pass"}},
+	}))
+	found, results, err := c.listResults("prod")
+	assert.NilError(t, err)
+	assert.Assert(t, found)
+	assert.Equal(t, len(results), 2)
+	found, results, err = c.listResults("staging")
+	assert.NilError(t, err)
+	assert.Assert(t, !found)
+	assert.Equal(t, len(results), 0)
+}
+
+func Test_summarize(t *testing.T) {
+	results := []openreportsv1alpha1.ReportResult{
+		{Policy: "require-labels", Result: "pass", Severity: "medium", Category: "Best Practices"},
+		{Policy: "require-labels", Result: "fail", Severity: "medium", Category: "Best Practices"},
+		{Policy: "disallow-latest", Result: "fail", Severity: "high"},
+		{Policy: "disallow-latest", Result: "skip", Severity: "high"},
+	}
+	spec := summarize(results)
+	assert.DeepEqual(t, spec.Summary, openreportsv1alpha1.ReportSummary{Pass: 1, Fail: 2, Skip: 1})
+	assert.DeepEqual(t, spec.Policies, []reportsv1.ComplianceCount{
+		{Name: "disallow-latest", Summary: openreportsv1alpha1.ReportSummary{Fail: 1, Skip: 1}},
+		{Name: "require-labels", Summary: openreportsv1alpha1.ReportSummary{Pass: 1, Fail: 1}},
+	})
+	assert.DeepEqual(t, spec.Severities, []reportsv1.ComplianceCount{
+		{Name: "high", Summary: openreportsv1alpha1.ReportSummary{Fail: 1, Skip: 1}},
+		{Name: "medium", Summary: openreportsv1alpha1.ReportSummary{Pass: 1, Fail: 1}},
+	})
+	assert.DeepEqual(t, spec.Categories, []reportsv1.ComplianceCount{
+		{Name: "", Summary: openreportsv1alpha1.ReportSummary{Fail: 1, Skip: 1}},
+		{Name: "Best Practices", Summary: openreportsv1alpha1.ReportSummary{Pass: 1, Fail: 1}},
+	})
+	assert.Assert(t, summarize(nil).Policies == nil)
+}
+
+func Test_updateHistory(t *testing.T) {
+	snapshot := func(date string, fail int) reportsv1.ComplianceSnapshot {
+		return reportsv1.ComplianceSnapshot{Date: date, Summary: openreportsv1alpha1.ReportSummary{Fail: fail}}
+	}
+	tests := []struct {
+		name    string
+		history []reportsv1.ComplianceSnapshot
+		date    string
+		fail    int
+		limit   int
+		want    []reportsv1.ComplianceSnapshot
+	}{{
+		name:  "empty",
+		date:  "2025-01-01",
+		fail:  1,
+		limit: 3,
+		want:  []reportsv1.ComplianceSnapshot{snapshot("2025-01-01", 1)},
+	}, {
+		name:    "same day",
+		history: []reportsv1.ComplianceSnapshot{snapshot("2025-01-01", 1)},
+		date:    "2025-01-01",
+		fail:    2,
+		limit:   3,
+		want:    []reportsv1.ComplianceSnapshot{snapshot("2025-01-01", 2)},
+	}, {
+		name:    "next day",
+		history: []reportsv1.ComplianceSnapshot{snapshot("2025-01-01", 1)},
+		date:    "2025-01-02",
+		fail:    2,
+		limit:   3,
+		want:    []reportsv1.ComplianceSnapshot{snapshot("2025-01-01", 1), snapshot("2025-01-02", 2)},
+	}, {
+		name:    "bounded",
+		history: []reportsv1.ComplianceSnapshot{snapshot("2025-01-01", 1), snapshot("2025-01-02", 2), snapshot("2025-01-03", 3)},
+		date:    "2025-01-04",
+		fail:    4,
+		limit:   2,
+		want:    []reportsv1.ComplianceSnapshot{snapshot("2025-01-03", 3), snapshot("2025-01-04", 4)},
+	}, {
+		name:    "disabled",
+		history: []reportsv1.ComplianceSnapshot{snapshot("2025-01-01", 1)},
+		date:    "2025-01-02",
+		fail:    2,
+		limit:   0,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			history := updateHistory(tt.history, tt.date, openreportsv1alpha1.ReportSummary{Fail: tt.fail}, tt.limit)
+			assert.DeepEqual(t, history, tt.want)
+		})
+	}
+}
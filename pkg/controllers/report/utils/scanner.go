@@ -179,6 +179,7 @@ func (s *scanner) ScanResource(
 				nil,
 				// TODO
 				// []imagedataloader.Option{imagedataloader.WithLocalCredentials(c.RegistryAccess)},
+				nil,
 				s.gctxStore,
 				false,
 			)
@@ -231,6 +232,7 @@ func (s *scanner) ScanResource(
 				nil,
 				// TODO
 				// []imagedataloader.Option{imagedataloader.WithLocalCredentials(c.RegistryAccess)},
+				nil,
 				s.gctxStore,
 				false,
 			)
@@ -296,8 +298,9 @@ func (s *scanner) ScanResource(
 				matching.NewMatcher(),
 				s.client.GetKubeClient().CoreV1().Secrets(""),
 				nil,
+				nil,
 			)
-			context, err := libs.NewContextProvider(s.client, nil, gctxstore.New(), false)
+			context, err := libs.NewContextProvider(s.client, nil, nil, gctxstore.New(), false)
 			if err != nil {
 				logger.Error(err, "failed to create cel context provider")
 				results[&ivpols[i]] = ScanResult{nil, err}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"strings"
 	"sync"
 	"time"
@@ -11,6 +12,7 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/kyverno/kyverno/api/kyverno"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	policiesv1alpha1 "github.com/kyverno/kyverno/api/policies.kyverno.io/v1alpha1"
 	"github.com/kyverno/kyverno/ext/wildcard"
 	"github.com/kyverno/kyverno/pkg/autogen"
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
@@ -149,6 +151,8 @@ type controller struct {
 	// state
 	lock        sync.Mutex
 	policyState map[string]sets.Set[string]
+	// policyTiers records the namespace tiers of the webhooks serving the policies
+	policyTiers map[string]map[string][]kyvernov1.WebhookTierStatus
 
 	// stateRecorder records policies that are configured successfully in webhook object
 	stateRecorder StateRecorder
@@ -224,6 +228,7 @@ func NewController(
 			config.MutatingWebhookConfigurationName:   sets.New[string](),
 			config.ValidatingWebhookConfigurationName: sets.New[string](),
 		},
+		policyTiers:   map[string]map[string][]kyvernov1.WebhookTierStatus{},
 		stateRecorder: stateRecorder,
 	}
 	// Set up the CRD change callback
@@ -462,6 +467,37 @@ func (c *controller) recordKyvernoPolicyState(webhookConfigurationName string, p
 	}
 }
 
+func (c *controller) recordKyvernoPolicyTiers(webhookConfigurationName string, tiers map[string][]kyvernov1.WebhookTierStatus) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.policyTiers[webhookConfigurationName] = tiers
+}
+
+// recordCELPolicyTiers replaces the tiers of the CEL policies served by the webhook configuration,
+// they are keyed by kind and name next to the tiers of the kyverno policies
+func (c *controller) recordCELPolicyTiers(webhookConfigurationName string, tiers map[string][]kyvernov1.WebhookTierStatus) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	recorded := map[string][]kyvernov1.WebhookTierStatus{}
+	for key, policyTiers := range c.policyTiers[webhookConfigurationName] {
+		if policyType, name := ParseRecorderKey(key); BuildRecorderKey(policyType, name) != key {
+			recorded[key] = policyTiers
+		}
+	}
+	maps.Copy(recorded, tiers)
+	c.policyTiers[webhookConfigurationName] = recorded
+}
+
+// webhookTiers returns the tiers of the resource webhooks generated for the policy rules
+func (c *controller) webhookTiers(tiers []config.WebhookTier, policy kyvernov1.PolicyInterface, failurePolicy admissionregistrationv1.FailurePolicyType, updateValidate bool) []kyvernov1.WebhookTierStatus {
+	if len(tiers) == 0 {
+		return nil
+	}
+	w := newWebhook(c.defaultTimeout, failurePolicy, nil)
+	c.mergeWebhook(w, policy, updateValidate)
+	return policyWebhookTiers(tiers, policy.GetNamespace(), w.buildRulesWithOperations(), failurePolicy)
+}
+
 func (c *controller) recordPolicyState(policies ...engineapi.GenericPolicy) {
 	for _, policy := range policies {
 		if key := BuildRecorderKey(policy.GetKind(), policy.GetName()); key != "" {
@@ -633,6 +669,13 @@ func (c *controller) updatePolicyStatuses(ctx context.Context, webhookType strin
 		}
 		status := policy.GetStatus()
 		status.SetReady(ready, message)
+		status.WebhookTiers = nil
+		if c.autoUpdateWebhooks && policy.AdmissionProcessingEnabled() {
+			status.WebhookTiers = mergeWebhookTiers(
+				c.policyTiers[config.MutatingWebhookConfigurationName][policyKey],
+				c.policyTiers[config.ValidatingWebhookConfigurationName][policyKey],
+			)
+		}
 		status.Autogen.Rules = nil
 		rules := autogen.Default.ComputeRules(policy, "")
 		setRuleCount(rules, status)
@@ -716,9 +759,86 @@ func (c *controller) updatePolicyStatuses(ctx context.Context, webhookType strin
 			}
 		}
 	}
+	return c.updateCELPolicyStatuses(ctx)
+}
+
+// updateCELPolicyStatuses updates the webhook tiers in the status of the CEL policies,
+// the other fields of their status are managed by the policy status controller
+func (c *controller) updateCELPolicyStatuses(ctx context.Context) error {
+	tiers := func(policyType, name string) []policiesv1alpha1.WebhookTierStatus {
+		if !c.autoUpdateWebhooks {
+			return nil
+		}
+		key := BuildRecorderKey(policyType, name)
+		return celWebhookTierStatuses(mergeWebhookTiers(
+			c.policyTiers[config.MutatingWebhookConfigurationName][key],
+			c.policyTiers[config.ValidatingWebhookConfigurationName][key],
+		))
+	}
+	vpols, err := c.vpolLister.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	for _, vpol := range vpols {
+		updateWebhookTiers(ctx, vpol, c.kyvernoClient.PoliciesV1alpha1().ValidatingPolicies(), func(vpol *policiesv1alpha1.ValidatingPolicy) {
+			vpol.Status.WebhookTiers = tiers(ValidatingPolicyType, vpol.Name)
+		})
+	}
+	mpols, err := c.mpolLister.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	for _, mpol := range mpols {
+		updateWebhookTiers(ctx, mpol, c.kyvernoClient.PoliciesV1alpha1().MutatingPolicies(), func(mpol *policiesv1alpha1.MutatingPolicy) {
+			mpol.Status.WebhookTiers = tiers(MutatingPolicyType, mpol.Name)
+		})
+	}
+	ivpols, err := c.ivpolLister.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	for _, ivpol := range ivpols {
+		updateWebhookTiers(ctx, ivpol, c.kyvernoClient.PoliciesV1alpha1().ImageValidatingPolicies(), func(ivpol *policiesv1alpha1.ImageValidatingPolicy) {
+			ivpol.Status.WebhookTiers = tiers(ImageValidatingPolicyType, ivpol.Name)
+		})
+	}
+	gpols, err := c.gpolLister.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	for _, gpol := range gpols {
+		updateWebhookTiers(ctx, gpol, c.kyvernoClient.PoliciesV1alpha1().GeneratingPolicies(), func(gpol *policiesv1alpha1.GeneratingPolicy) {
+			gpol.Status.WebhookTiers = tiers(GeneratingPolicyType, gpol.Name)
+		})
+	}
 	return nil
 }
 
+func updateWebhookTiers[T interface {
+	metav1.Object
+	controllerutils.DeepCopy[T]
+}](ctx context.Context, policy T, client controllerutils.ObjectStatusClient[T], setTiers func(T)) {
+	update := func(policy T) error {
+		return controllerutils.UpdateStatus(ctx, policy, client, func(policy T) error {
+			setTiers(policy)
+			return nil
+		}, nil)
+	}
+	if err := update(policy); err == nil {
+		return
+	}
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest, err := client.Get(ctx, policy.GetName(), metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		return update(latest)
+	})
+	if err != nil {
+		logger.Error(err, "failed to update policy webhook tiers", "policy", policy.GetName())
+	}
+}
+
 func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, namespace, name string) error {
 	if c.autoDeleteWebhooks && c.runtime.IsGoingDown() {
 		return c.reconcileWebhookDeletion(ctx)
@@ -931,6 +1051,7 @@ func (c *controller) buildResourceMutatingWebhookConfiguration(ctx context.Conte
 
 func (c *controller) buildForJSONPoliciesMutation(cfg config.Configuration, caBundle []byte, result *admissionregistrationv1.MutatingWebhookConfiguration) error {
 	if !c.watchdogCheck() {
+		c.recordCELPolicyTiers(config.MutatingWebhookConfigurationName, nil)
 		return nil
 	}
 
@@ -960,6 +1081,8 @@ func (c *controller) buildForJSONPoliciesMutation(cfg config.Configuration, caBu
 		caBundle,
 		ivpols)...)
 
+	validate = splitValidatingWebhookTiers(cfg.GetWebhookTiers(), validate)
+
 	mutate := make([]admissionregistrationv1.MutatingWebhook, 0, len(validate))
 	for _, w := range validate {
 		mutate = append(mutate, admissionregistrationv1.MutatingWebhook{
@@ -977,6 +1100,9 @@ func (c *controller) buildForJSONPoliciesMutation(cfg config.Configuration, caBu
 	}
 	result.Webhooks = append(result.Webhooks, mutate...)
 	c.recordPolicyState(mpols...)
+	policyTiers := celPolicyWebhookTiers(cfg, config.MutatingPolicyWebhookName, mpols)
+	maps.Copy(policyTiers, celPolicyWebhookTiers(cfg, config.ImageValidatingPolicyMutateWebhookName, ivpols))
+	c.recordCELPolicyTiers(config.MutatingWebhookConfigurationName, policyTiers)
 	return nil
 }
 
@@ -991,6 +1117,7 @@ func (c *controller) buildForPoliciesMutation(ctx context.Context, cfg config.Co
 		}
 		var fineGrainedIgnoreList, fineGrainedFailList []*webhook
 		var readyPolicies []kyvernov1.PolicyInterface
+		policyTiers := map[string][]kyvernov1.WebhookTierStatus{}
 		// reset policy state set
 		c.recordKyvernoPolicyState(config.MutatingWebhookConfigurationName)
 		for _, p := range policies {
@@ -1002,17 +1129,21 @@ func (c *controller) buildForPoliciesMutation(ctx context.Context, cfg config.Co
 						if spec.GetFailurePolicy(ctx) == kyvernov1.Ignore {
 							fineGrainedIgnore := newWebhookPerPolicy(c.defaultTimeout, ignore, cfg.GetMatchConditions(), p)
 							ready = c.mergeWebhook(fineGrainedIgnore, p, false)
+							policyTiers[cache.MetaObjectToName(p).String()] = c.webhookTiers(cfg.GetWebhookTiers(), p, ignore, false)
 							fineGrainedIgnoreList = append(fineGrainedIgnoreList, fineGrainedIgnore)
 						} else {
 							fineGrainedFail := newWebhookPerPolicy(c.defaultTimeout, fail, cfg.GetMatchConditions(), p)
 							ready = c.mergeWebhook(fineGrainedFail, p, false)
+							policyTiers[cache.MetaObjectToName(p).String()] = c.webhookTiers(cfg.GetWebhookTiers(), p, fail, false)
 							fineGrainedFailList = append(fineGrainedFailList, fineGrainedFail)
 						}
 					} else {
 						if spec.GetFailurePolicy(ctx) == kyvernov1.Ignore {
 							ready = c.mergeWebhook(ignoreWebhook, p, false)
+							policyTiers[cache.MetaObjectToName(p).String()] = c.webhookTiers(cfg.GetWebhookTiers(), p, ignore, false)
 						} else {
 							ready = c.mergeWebhook(failWebhook, p, false)
+							policyTiers[cache.MetaObjectToName(p).String()] = c.webhookTiers(cfg.GetWebhookTiers(), p, fail, false)
 						}
 					}
 				}
@@ -1026,15 +1157,17 @@ func (c *controller) buildForPoliciesMutation(ctx context.Context, cfg config.Co
 		webhooks := []*webhook{ignoreWebhook, failWebhook}
 		webhooks = append(webhooks, fineGrainedIgnoreList...)
 		webhooks = append(webhooks, fineGrainedFailList...)
-		result.Webhooks = c.buildResourceMutatingWebhookRules(caBundle, webhookCfg, &noneOnDryRun, webhooks)
+		result.Webhooks = c.buildResourceMutatingWebhookRules(caBundle, webhookCfg, cfg.GetWebhookTiers(), &noneOnDryRun, webhooks)
 		c.recordKyvernoPolicyState(config.MutatingWebhookConfigurationName, readyPolicies...)
+		c.recordKyvernoPolicyTiers(config.MutatingWebhookConfigurationName, policyTiers)
 	} else {
 		c.recordKyvernoPolicyState(config.MutatingWebhookConfigurationName)
+		c.recordKyvernoPolicyTiers(config.MutatingWebhookConfigurationName, nil)
 	}
 	return nil
 }
 
-func (c *controller) buildResourceMutatingWebhookRules(caBundle []byte, webhookCfg config.WebhookConfig, sideEffects *admissionregistrationv1.SideEffectClass, webhooks []*webhook) []admissionregistrationv1.MutatingWebhook {
+func (c *controller) buildResourceMutatingWebhookRules(caBundle []byte, webhookCfg config.WebhookConfig, tiers []config.WebhookTier, sideEffects *admissionregistrationv1.SideEffectClass, webhooks []*webhook) []admissionregistrationv1.MutatingWebhook {
 	var mutatingWebhooks []admissionregistrationv1.MutatingWebhook //nolint:prealloc
 	objectSelector := webhookCfg.ObjectSelector
 	if objectSelector == nil {
@@ -1044,26 +1177,27 @@ func (c *controller) buildResourceMutatingWebhookRules(caBundle []byte, webhookC
 		if webhook.isEmpty() {
 			continue
 		}
-		failurePolicy := webhook.failurePolicy
 		timeout := capTimeout(webhook.maxWebhookTimeout)
 		name, path := webhookNameAndPath(*webhook, config.MutatingWebhookName, config.MutatingWebhookServicePath)
-		mutatingWebhooks = append(
-			mutatingWebhooks,
-			admissionregistrationv1.MutatingWebhook{
-				Name:                    name,
-				ClientConfig:            newClientConfig(c.server, c.servicePort, caBundle, path),
-				Rules:                   webhook.buildRulesWithOperations(),
-				FailurePolicy:           &failurePolicy,
-				SideEffects:             sideEffects,
-				AdmissionReviewVersions: []string{"v1"},
-				NamespaceSelector:       webhookCfg.NamespaceSelector,
-				ObjectSelector:          objectSelector,
-				TimeoutSeconds:          &timeout,
-				ReinvocationPolicy:      &ifNeeded,
-				MatchConditions:         webhook.matchConditions,
-				MatchPolicy:             ptr.To(admissionregistrationv1.Equivalent),
-			},
-		)
+		for _, tiered := range splitWebhookTiers(tiers, webhookCfg.NamespaceSelector, webhook.buildRulesWithOperations(), webhook.failurePolicy, timeout) {
+			mutatingWebhooks = append(
+				mutatingWebhooks,
+				admissionregistrationv1.MutatingWebhook{
+					Name:                    name + tiered.nameSuffix,
+					ClientConfig:            newClientConfig(c.server, c.servicePort, caBundle, path),
+					Rules:                   tiered.rules,
+					FailurePolicy:           &tiered.failurePolicy,
+					SideEffects:             sideEffects,
+					AdmissionReviewVersions: []string{"v1"},
+					NamespaceSelector:       tiered.namespaceSelector,
+					ObjectSelector:          objectSelector,
+					TimeoutSeconds:          &tiered.timeout,
+					ReinvocationPolicy:      &ifNeeded,
+					MatchConditions:         webhook.matchConditions,
+					MatchPolicy:             ptr.To(admissionregistrationv1.Equivalent),
+				},
+			)
+		}
 	}
 	return mutatingWebhooks
 }
@@ -1142,6 +1276,7 @@ func (c *controller) buildResourceValidatingWebhookConfiguration(ctx context.Con
 
 func (c *controller) buildForJSONPoliciesValidation(cfg config.Configuration, caBundle []byte, result *admissionregistrationv1.ValidatingWebhookConfiguration) error {
 	if !c.watchdogCheck() {
+		c.recordCELPolicyTiers(config.ValidatingWebhookConfigurationName, nil)
 		return nil
 	}
 
@@ -1149,19 +1284,19 @@ func (c *controller) buildForJSONPoliciesValidation(cfg config.Configuration, ca
 	if err != nil {
 		return err
 	}
-	result.Webhooks = append(result.Webhooks, buildWebhookRules(cfg,
+	webhooks := buildWebhookRules(cfg,
 		c.server,
 		config.ValidatingPolicyWebhookName,
 		"/vpol",
 		c.servicePort,
 		caBundle,
-		pols)...)
+		pols)
 
 	gpols, err := c.getGeneratingPolicies()
 	if err != nil {
 		return err
 	}
-	result.Webhooks = append(result.Webhooks, buildWebhookRules(cfg,
+	webhooks = append(webhooks, buildWebhookRules(cfg,
 		c.server,
 		config.GeneratingPolicyWebhookName,
 		"/gpol",
@@ -1173,7 +1308,7 @@ func (c *controller) buildForJSONPoliciesValidation(cfg config.Configuration, ca
 	if err != nil {
 		return err
 	}
-	result.Webhooks = append(result.Webhooks, buildWebhookRules(cfg,
+	webhooks = append(webhooks, buildWebhookRules(cfg,
 		c.server,
 		config.ImageValidatingPolicyValidateWebhookName,
 		"/ivpol/validate",
@@ -1181,9 +1316,15 @@ func (c *controller) buildForJSONPoliciesValidation(cfg config.Configuration, ca
 		caBundle,
 		ivpols)...)
 
+	result.Webhooks = append(result.Webhooks, splitValidatingWebhookTiers(cfg.GetWebhookTiers(), webhooks)...)
+
 	policies := append(pols, gpols...)
 	policies = append(policies, ivpols...)
 	c.recordPolicyState(policies...)
+	policyTiers := celPolicyWebhookTiers(cfg, config.ValidatingPolicyWebhookName, pols)
+	maps.Copy(policyTiers, celPolicyWebhookTiers(cfg, config.GeneratingPolicyWebhookName, gpols))
+	maps.Copy(policyTiers, celPolicyWebhookTiers(cfg, config.ImageValidatingPolicyValidateWebhookName, ivpols))
+	c.recordCELPolicyTiers(config.ValidatingWebhookConfigurationName, policyTiers)
 	return nil
 }
 
@@ -1199,6 +1340,7 @@ func (c *controller) buildForPoliciesValidation(ctx context.Context, cfg config.
 
 		var fineGrainedIgnoreList, fineGrainedFailList []*webhook
 		var readyPolicies []kyvernov1.PolicyInterface
+		policyTiers := map[string][]kyvernov1.WebhookTierStatus{}
 		// reset policy state set
 		c.recordKyvernoPolicyState(config.ValidatingWebhookConfigurationName)
 		for _, p := range policies {
@@ -1210,17 +1352,21 @@ func (c *controller) buildForPoliciesValidation(ctx context.Context, cfg config.
 						if spec.GetFailurePolicy(ctx) == kyvernov1.Ignore {
 							fineGrainedIgnore := newWebhookPerPolicy(c.defaultTimeout, ignore, cfg.GetMatchConditions(), p)
 							ready = c.mergeWebhook(fineGrainedIgnore, p, true)
+							policyTiers[cache.MetaObjectToName(p).String()] = c.webhookTiers(cfg.GetWebhookTiers(), p, ignore, true)
 							fineGrainedIgnoreList = append(fineGrainedIgnoreList, fineGrainedIgnore)
 						} else {
 							fineGrainedFail := newWebhookPerPolicy(c.defaultTimeout, fail, cfg.GetMatchConditions(), p)
 							ready = c.mergeWebhook(fineGrainedFail, p, true)
+							policyTiers[cache.MetaObjectToName(p).String()] = c.webhookTiers(cfg.GetWebhookTiers(), p, fail, true)
 							fineGrainedFailList = append(fineGrainedFailList, fineGrainedFail)
 						}
 					} else {
 						if spec.GetFailurePolicy(ctx) == kyvernov1.Ignore {
 							ready = c.mergeWebhook(ignoreWebhook, p, true)
+							policyTiers[cache.MetaObjectToName(p).String()] = c.webhookTiers(cfg.GetWebhookTiers(), p, ignore, true)
 						} else {
 							ready = c.mergeWebhook(failWebhook, p, true)
+							policyTiers[cache.MetaObjectToName(p).String()] = c.webhookTiers(cfg.GetWebhookTiers(), p, fail, true)
 						}
 					}
 				}
@@ -1238,15 +1384,17 @@ func (c *controller) buildForPoliciesValidation(ctx context.Context, cfg config.
 		webhooks := []*webhook{ignoreWebhook, failWebhook}
 		webhooks = append(webhooks, fineGrainedIgnoreList...)
 		webhooks = append(webhooks, fineGrainedFailList...)
-		result.Webhooks = c.buildResourceValidatingWebhookRules(caBundle, webhookCfg, sideEffects, webhooks)
+		result.Webhooks = c.buildResourceValidatingWebhookRules(caBundle, webhookCfg, cfg.GetWebhookTiers(), sideEffects, webhooks)
 		c.recordKyvernoPolicyState(config.ValidatingWebhookConfigurationName, readyPolicies...)
+		c.recordKyvernoPolicyTiers(config.ValidatingWebhookConfigurationName, policyTiers)
 	} else {
 		c.recordKyvernoPolicyState(config.ValidatingWebhookConfigurationName)
+		c.recordKyvernoPolicyTiers(config.ValidatingWebhookConfigurationName, nil)
 	}
 	return nil
 }
 
-func (c *controller) buildResourceValidatingWebhookRules(caBundle []byte, webhookCfg config.WebhookConfig, sideEffects *admissionregistrationv1.SideEffectClass, webhooks []*webhook) []admissionregistrationv1.ValidatingWebhook {
+func (c *controller) buildResourceValidatingWebhookRules(caBundle []byte, webhookCfg config.WebhookConfig, tiers []config.WebhookTier, sideEffects *admissionregistrationv1.SideEffectClass, webhooks []*webhook) []admissionregistrationv1.ValidatingWebhook {
 	var validatingWebhooks []admissionregistrationv1.ValidatingWebhook //nolint:prealloc
 	objectSelector := webhookCfg.ObjectSelector
 	if objectSelector == nil {
@@ -1258,23 +1406,24 @@ func (c *controller) buildResourceValidatingWebhookRules(caBundle []byte, webhoo
 		}
 		timeout := capTimeout(webhook.maxWebhookTimeout)
 		name, path := webhookNameAndPath(*webhook, config.ValidatingWebhookName, config.ValidatingWebhookServicePath)
-		failurePolicy := webhook.failurePolicy
-		validatingWebhooks = append(
-			validatingWebhooks,
-			admissionregistrationv1.ValidatingWebhook{
-				Name:                    name,
-				ClientConfig:            newClientConfig(c.server, c.servicePort, caBundle, path),
-				Rules:                   webhook.buildRulesWithOperations(),
-				FailurePolicy:           &failurePolicy,
-				SideEffects:             sideEffects,
-				AdmissionReviewVersions: []string{"v1"},
-				NamespaceSelector:       webhookCfg.NamespaceSelector,
-				ObjectSelector:          objectSelector,
-				TimeoutSeconds:          &timeout,
-				MatchConditions:         webhook.matchConditions,
-				MatchPolicy:             ptr.To(admissionregistrationv1.Equivalent),
-			},
-		)
+		for _, tiered := range splitWebhookTiers(tiers, webhookCfg.NamespaceSelector, webhook.buildRulesWithOperations(), webhook.failurePolicy, timeout) {
+			validatingWebhooks = append(
+				validatingWebhooks,
+				admissionregistrationv1.ValidatingWebhook{
+					Name:                    name + tiered.nameSuffix,
+					ClientConfig:            newClientConfig(c.server, c.servicePort, caBundle, path),
+					Rules:                   tiered.rules,
+					FailurePolicy:           &tiered.failurePolicy,
+					SideEffects:             sideEffects,
+					AdmissionReviewVersions: []string{"v1"},
+					NamespaceSelector:       tiered.namespaceSelector,
+					ObjectSelector:          objectSelector,
+					TimeoutSeconds:          &tiered.timeout,
+					MatchConditions:         webhook.matchConditions,
+					MatchPolicy:             ptr.To(admissionregistrationv1.Equivalent),
+				},
+			)
+		}
 	}
 	return validatingWebhooks
 }
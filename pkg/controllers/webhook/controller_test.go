@@ -0,0 +1,63 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	policiesv1alpha1 "github.com/kyverno/kyverno/api/policies.kyverno.io/v1alpha1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	policiesv1alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/policies.kyverno.io/v1alpha1"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/ptr"
+)
+
+func Test_updateCELPolicyStatuses(t *testing.T) {
+	vpol := &policiesv1alpha1.ValidatingPolicy{ObjectMeta: metav1.ObjectMeta{Name: "check-pods"}}
+	client := fake.NewSimpleClientset(vpol)
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	require.NoError(t, indexer.Add(vpol))
+	empty := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	c := &controller{
+		kyvernoClient:      client,
+		vpolLister:         policiesv1alpha1listers.NewValidatingPolicyLister(indexer),
+		mpolLister:         policiesv1alpha1listers.NewMutatingPolicyLister(empty),
+		ivpolLister:        policiesv1alpha1listers.NewImageValidatingPolicyLister(empty),
+		gpolLister:         policiesv1alpha1listers.NewGeneratingPolicyLister(empty),
+		autoUpdateWebhooks: true,
+		policyTiers:        map[string]map[string][]kyvernov1.WebhookTierStatus{},
+	}
+	critical := kyvernov1.WebhookTierStatus{Name: "critical", FailurePolicy: kyvernov1.Ignore, TimeoutSeconds: ptr.To[int32](3)}
+	c.recordKyvernoPolicyTiers(config.ValidatingWebhookConfigurationName, map[string][]kyvernov1.WebhookTierStatus{
+		"default/check-pods": {critical},
+	})
+	c.recordCELPolicyTiers(config.ValidatingWebhookConfigurationName, map[string][]kyvernov1.WebhookTierStatus{
+		"ValidatingPolicy/check-pods": {critical},
+	})
+	c.recordCELPolicyTiers(config.MutatingWebhookConfigurationName, nil)
+	// the tiers of the kyverno policies are kept
+	assert.Len(t, c.policyTiers[config.ValidatingWebhookConfigurationName], 2)
+
+	require.NoError(t, c.updateCELPolicyStatuses(context.TODO()))
+	updated, err := client.PoliciesV1alpha1().ValidatingPolicies().Get(context.TODO(), "check-pods", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []policiesv1alpha1.WebhookTierStatus{{
+		Name:           "critical",
+		FailurePolicy:  admissionregistrationv1.Ignore,
+		TimeoutSeconds: ptr.To[int32](3),
+	}}, updated.Status.WebhookTiers)
+
+	// the tiers are removed once the policy is not served by tiered webhooks anymore
+	c.recordCELPolicyTiers(config.ValidatingWebhookConfigurationName, nil)
+	assert.Len(t, c.policyTiers[config.ValidatingWebhookConfigurationName], 1)
+	require.NoError(t, indexer.Update(updated))
+	require.NoError(t, c.updateCELPolicyStatuses(context.TODO()))
+	updated, err = client.PoliciesV1alpha1().ValidatingPolicies().Get(context.TODO(), "check-pods", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Nil(t, updated.Status.WebhookTiers)
+}
@@ -3,6 +3,7 @@ package webhook
 import (
 	"cmp"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/kyverno/kyverno/api/kyverno"
@@ -14,6 +15,7 @@ import (
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
 )
 
 func extractGenericPolicy(policy engineapi.GenericPolicy) policiesv1alpha1.GenericPolicy {
@@ -181,6 +183,246 @@ func webhookNameAndPath(wh webhook, baseName, basePath string) (name string, pat
 	return name, path
 }
 
+// namespaceNameLabel is the label set by the API server on every namespace with the namespace name
+const namespaceNameLabel = "kubernetes.io/metadata.name"
+
+// tierNameSuffix is appended to the name of a webhook, followed by the name of the tier it serves
+const tierNameSuffix = "-tier-"
+
+// tieredWebhook holds the settings of a resource webhook serving a namespace tier
+type tieredWebhook struct {
+	nameSuffix        string
+	namespaceSelector *metav1.LabelSelector
+	rules             []admissionregistrationv1.RuleWithOperations
+	failurePolicy     admissionregistrationv1.FailurePolicyType
+	timeout           int32
+}
+
+// splitWebhookTiers splits a resource webhook into one webhook per namespace tier.
+// The original webhook keeps serving the cluster scoped resources and the namespaces not assigned to a tier,
+// the webhook of a tier only serves the namespaced resources and the namespaces of the tier.
+func splitWebhookTiers(
+	tiers []config.WebhookTier,
+	namespaceSelector *metav1.LabelSelector,
+	rules []admissionregistrationv1.RuleWithOperations,
+	failurePolicy admissionregistrationv1.FailurePolicyType,
+	timeout int32,
+) []tieredWebhook {
+	if len(tiers) == 0 {
+		return []tieredWebhook{{
+			namespaceSelector: namespaceSelector,
+			rules:             rules,
+			failurePolicy:     failurePolicy,
+			timeout:           timeout,
+		}}
+	}
+	var namespaces []string
+	for _, tier := range tiers {
+		namespaces = append(namespaces, tier.Namespaces...)
+	}
+	out := []tieredWebhook{{
+		namespaceSelector: withNamespaceNames(namespaceSelector, metav1.LabelSelectorOpNotIn, namespaces),
+		rules:             rules,
+		failurePolicy:     failurePolicy,
+		timeout:           timeout,
+	}}
+	tierRules := namespacedRules(rules)
+	if len(tierRules) == 0 {
+		return out
+	}
+	for _, tier := range tiers {
+		tiered := tieredWebhook{
+			nameSuffix:        tierNameSuffix + tier.Name,
+			namespaceSelector: withNamespaceNames(namespaceSelector, metav1.LabelSelectorOpIn, tier.Namespaces),
+			rules:             tierRules,
+			failurePolicy:     failurePolicy,
+			timeout:           timeout,
+		}
+		if tier.FailurePolicy != nil {
+			tiered.failurePolicy = *tier.FailurePolicy
+		}
+		if tier.TimeoutSeconds != nil {
+			tiered.timeout = *tier.TimeoutSeconds
+		}
+		out = append(out, tiered)
+	}
+	return out
+}
+
+// withNamespaceNames returns a copy of the selector with an additional requirement on the namespace name
+func withNamespaceNames(selector *metav1.LabelSelector, operator metav1.LabelSelectorOperator, namespaces []string) *metav1.LabelSelector {
+	out := selector.DeepCopy()
+	if out == nil {
+		out = &metav1.LabelSelector{}
+	}
+	values := sets.List(sets.New(namespaces...))
+	out.MatchExpressions = append(out.MatchExpressions, metav1.LabelSelectorRequirement{
+		Key:      namespaceNameLabel,
+		Operator: operator,
+		Values:   values,
+	})
+	return out
+}
+
+// namespacedRules restricts the rules to the namespaced resources and to the namespaces themselves,
+// the namespace selector of a webhook is evaluated against the labels of the namespace for both of them
+func namespacedRules(rules []admissionregistrationv1.RuleWithOperations) []admissionregistrationv1.RuleWithOperations {
+	var out []admissionregistrationv1.RuleWithOperations
+	for _, rule := range rules {
+		scope := admissionregistrationv1.AllScopes
+		if rule.Scope != nil {
+			scope = *rule.Scope
+		}
+		if scope != admissionregistrationv1.ClusterScope {
+			namespaced := *rule.DeepCopy()
+			namespaced.Scope = ptr.To(admissionregistrationv1.NamespacedScope)
+			out = append(out, namespaced)
+		}
+		if scope == admissionregistrationv1.NamespacedScope {
+			continue
+		}
+		if !slices.ContainsFunc(rule.APIGroups, func(group string) bool { return group == "" || group == "*" }) {
+			continue
+		}
+		if !slices.ContainsFunc(rule.APIVersions, func(version string) bool { return version == "v1" || version == "*" }) {
+			continue
+		}
+		resources := sets.New[string]()
+		for _, resource := range rule.Resources {
+			resource, subresource, _ := strings.Cut(resource, "/")
+			if resource != "namespaces" && resource != "*" {
+				continue
+			}
+			if subresource == "" {
+				resources.Insert("namespaces")
+			} else {
+				resources.Insert("namespaces/" + subresource)
+			}
+		}
+		if len(resources) != 0 {
+			out = append(out, admissionregistrationv1.RuleWithOperations{
+				Operations: slices.Clone(rule.Operations),
+				Rule: admissionregistrationv1.Rule{
+					APIGroups:   []string{""},
+					APIVersions: []string{"v1"},
+					Resources:   sets.List(resources),
+					Scope:       ptr.To(admissionregistrationv1.ClusterScope),
+				},
+			})
+		}
+	}
+	return out
+}
+
+// policyWebhookTiers returns the tiers of the webhooks generated for the rules of a policy,
+// a namespaced policy is only served by the tier of its namespace
+func policyWebhookTiers(
+	tiers []config.WebhookTier,
+	namespace string,
+	rules []admissionregistrationv1.RuleWithOperations,
+	failurePolicy admissionregistrationv1.FailurePolicyType,
+) []kyvernov1.WebhookTierStatus {
+	if len(tiers) == 0 || len(rules) == 0 {
+		return nil
+	}
+	namespaceTier := config.DefaultWebhookTier
+	for _, tier := range tiers {
+		if slices.Contains(tier.Namespaces, namespace) {
+			namespaceTier = tier.Name
+		}
+	}
+	var out []kyvernov1.WebhookTierStatus
+	for _, tiered := range splitWebhookTiers(tiers, nil, rules, failurePolicy, 0) {
+		name := config.DefaultWebhookTier
+		if tiered.nameSuffix != "" {
+			name = strings.TrimPrefix(tiered.nameSuffix, tierNameSuffix)
+		}
+		if namespace != "" && name != namespaceTier {
+			continue
+		}
+		status := kyvernov1.WebhookTierStatus{
+			Name:          name,
+			FailurePolicy: kyvernov1.FailurePolicyType(tiered.failurePolicy),
+		}
+		for _, tier := range tiers {
+			if tier.Name == name && tier.TimeoutSeconds != nil {
+				status.TimeoutSeconds = ptr.To(*tier.TimeoutSeconds)
+			}
+		}
+		out = append(out, status)
+	}
+	// the default tier is listed last
+	if len(out) != 0 && out[0].Name == config.DefaultWebhookTier {
+		out = append(out[1:], out[0])
+	}
+	return out
+}
+
+// mergeWebhookTiers merges the tiers serving a policy in the mutating and validating webhooks
+func mergeWebhookTiers(in ...[]kyvernov1.WebhookTierStatus) []kyvernov1.WebhookTierStatus {
+	var out []kyvernov1.WebhookTierStatus
+	for _, tiers := range in {
+		for _, tier := range tiers {
+			if !slices.ContainsFunc(out, func(t kyvernov1.WebhookTierStatus) bool { return t.Name == tier.Name }) {
+				out = append(out, tier)
+			}
+		}
+	}
+	return out
+}
+
+// celPolicyWebhookTiers returns the tiers of the webhooks generated for the CEL policies, keyed by policy kind and name
+func celPolicyWebhookTiers(cfg config.Configuration, name string, policies []engineapi.GenericPolicy) map[string][]kyvernov1.WebhookTierStatus {
+	out := map[string][]kyvernov1.WebhookTierStatus{}
+	tiers := cfg.GetWebhookTiers()
+	if len(tiers) == 0 {
+		return out
+	}
+	for _, policy := range policies {
+		key := BuildRecorderKey(policy.GetKind(), policy.GetName())
+		for _, webhook := range buildWebhookRules(cfg, "", name, "", 0, nil, []engineapi.GenericPolicy{policy}) {
+			failurePolicy := ptr.Deref(webhook.FailurePolicy, admissionregistrationv1.Fail)
+			out[key] = mergeWebhookTiers(out[key], policyWebhookTiers(tiers, "", webhook.Rules, failurePolicy))
+		}
+	}
+	return out
+}
+
+// celWebhookTierStatuses converts the tiers serving a CEL policy to the status of the policies.kyverno.io policies
+func celWebhookTierStatuses(tiers []kyvernov1.WebhookTierStatus) []policiesv1alpha1.WebhookTierStatus {
+	var out []policiesv1alpha1.WebhookTierStatus
+	for _, tier := range tiers {
+		out = append(out, policiesv1alpha1.WebhookTierStatus{
+			Name:           tier.Name,
+			FailurePolicy:  admissionregistrationv1.FailurePolicyType(tier.FailurePolicy),
+			TimeoutSeconds: tier.TimeoutSeconds,
+		})
+	}
+	return out
+}
+
+// splitValidatingWebhookTiers splits the webhooks of the CEL policies into one webhook per namespace tier
+func splitValidatingWebhookTiers(tiers []config.WebhookTier, webhooks []admissionregistrationv1.ValidatingWebhook) []admissionregistrationv1.ValidatingWebhook {
+	if len(tiers) == 0 {
+		return webhooks
+	}
+	var out []admissionregistrationv1.ValidatingWebhook //nolint:prealloc
+	for _, webhook := range webhooks {
+		failurePolicy := ptr.Deref(webhook.FailurePolicy, admissionregistrationv1.Fail)
+		timeout := ptr.Deref(webhook.TimeoutSeconds, DefaultWebhookTimeout)
+		for _, tiered := range splitWebhookTiers(tiers, webhook.NamespaceSelector, webhook.Rules, failurePolicy, timeout) {
+			tieredWebhook := *webhook.DeepCopy()
+			tieredWebhook.Name = webhook.Name + tiered.nameSuffix
+			tieredWebhook.NamespaceSelector = tiered.namespaceSelector
+			tieredWebhook.Rules = tiered.rules
+			tieredWebhook.FailurePolicy = ptr.To(tiered.failurePolicy)
+			tieredWebhook.TimeoutSeconds = ptr.To(tiered.timeout)
+			out = append(out, tieredWebhook)
+		}
+	}
+	return out
+}
+
 func less[T cmp.Ordered](a []T, b []T) int {
 	if x := cmp.Compare(len(a), len(b)); x != 0 {
 		return x
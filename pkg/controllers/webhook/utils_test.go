@@ -5,9 +5,13 @@ import (
 	"testing"
 
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	policiesv1alpha1 "github.com/kyverno/kyverno/api/policies.kyverno.io/v1alpha1"
 	autogenv1 "github.com/kyverno/kyverno/pkg/autogen/v1"
+	"github.com/kyverno/kyverno/pkg/config"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/stretchr/testify/assert"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/utils/ptr"
@@ -213,6 +217,281 @@ func TestBuildRulesWithOperations(t *testing.T) {
 	}
 }
 
+func Test_namespacedRules(t *testing.T) {
+	testCases := []struct {
+		name           string
+		rules          []admissionregistrationv1.RuleWithOperations
+		expectedResult []admissionregistrationv1.RuleWithOperations
+	}{{
+		name: "namespaced",
+		rules: []admissionregistrationv1.RuleWithOperations{{
+			Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+			Rule: admissionregistrationv1.Rule{
+				APIGroups:   []string{""},
+				APIVersions: []string{"v1"},
+				Resources:   []string{"configmaps"},
+				Scope:       ptr.To(admissionregistrationv1.NamespacedScope),
+			},
+		}},
+		expectedResult: []admissionregistrationv1.RuleWithOperations{{
+			Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+			Rule: admissionregistrationv1.Rule{
+				APIGroups:   []string{""},
+				APIVersions: []string{"v1"},
+				Resources:   []string{"configmaps"},
+				Scope:       ptr.To(admissionregistrationv1.NamespacedScope),
+			},
+		}},
+	}, {
+		name: "cluster scoped",
+		rules: []admissionregistrationv1.RuleWithOperations{{
+			Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+			Rule: admissionregistrationv1.Rule{
+				APIGroups:   []string{"rbac.authorization.k8s.io"},
+				APIVersions: []string{"v1"},
+				Resources:   []string{"clusterroles"},
+				Scope:       ptr.To(admissionregistrationv1.ClusterScope),
+			},
+		}, {
+			Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Update},
+			Rule: admissionregistrationv1.Rule{
+				APIGroups:   []string{""},
+				APIVersions: []string{"v1"},
+				Resources:   []string{"namespaces", "nodes"},
+				Scope:       ptr.To(admissionregistrationv1.ClusterScope),
+			},
+		}},
+		expectedResult: []admissionregistrationv1.RuleWithOperations{{
+			Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Update},
+			Rule: admissionregistrationv1.Rule{
+				APIGroups:   []string{""},
+				APIVersions: []string{"v1"},
+				Resources:   []string{"namespaces"},
+				Scope:       ptr.To(admissionregistrationv1.ClusterScope),
+			},
+		}},
+	}, {
+		name: "all scopes",
+		rules: []admissionregistrationv1.RuleWithOperations{{
+			Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+			Rule: admissionregistrationv1.Rule{
+				APIGroups:   []string{"*"},
+				APIVersions: []string{"*"},
+				Resources:   []string{"*", "*/status"},
+				Scope:       ptr.To(admissionregistrationv1.AllScopes),
+			},
+		}},
+		expectedResult: []admissionregistrationv1.RuleWithOperations{{
+			Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+			Rule: admissionregistrationv1.Rule{
+				APIGroups:   []string{"*"},
+				APIVersions: []string{"*"},
+				Resources:   []string{"*", "*/status"},
+				Scope:       ptr.To(admissionregistrationv1.NamespacedScope),
+			},
+		}, {
+			Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+			Rule: admissionregistrationv1.Rule{
+				APIGroups:   []string{""},
+				APIVersions: []string{"v1"},
+				Resources:   []string{"namespaces", "namespaces/status"},
+				Scope:       ptr.To(admissionregistrationv1.ClusterScope),
+			},
+		}},
+	}}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			result := namespacedRules(testCase.rules)
+			assert.Equal(t, testCase.expectedResult, result)
+		})
+	}
+}
+
+func Test_splitWebhookTiers(t *testing.T) {
+	rules := []admissionregistrationv1.RuleWithOperations{{
+		Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+		Rule: admissionregistrationv1.Rule{
+			APIGroups:   []string{""},
+			APIVersions: []string{"v1"},
+			Resources:   []string{"pods"},
+			Scope:       ptr.To(admissionregistrationv1.NamespacedScope),
+		},
+	}}
+	selector := &metav1.LabelSelector{
+		MatchLabels: map[string]string{"env": "prod"},
+	}
+	// no tiers
+	result := splitWebhookTiers(nil, selector, rules, admissionregistrationv1.Fail, 10)
+	assert.Equal(t, []tieredWebhook{{
+		namespaceSelector: selector,
+		rules:             rules,
+		failurePolicy:     admissionregistrationv1.Fail,
+		timeout:           10,
+	}}, result)
+	// tiers
+	tiers := []config.WebhookTier{{
+		Name:           "critical",
+		Namespaces:     []string{"kyverno", "kube-system"},
+		FailurePolicy:  ptr.To(admissionregistrationv1.Ignore),
+		TimeoutSeconds: ptr.To[int32](3),
+	}, {
+		Name:       "tenants",
+		Namespaces: []string{"team-a"},
+	}}
+	result = splitWebhookTiers(tiers, selector, rules, admissionregistrationv1.Fail, 10)
+	assert.Equal(t, []tieredWebhook{{
+		namespaceSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{"env": "prod"},
+			MatchExpressions: []metav1.LabelSelectorRequirement{{
+				Key:      namespaceNameLabel,
+				Operator: metav1.LabelSelectorOpNotIn,
+				Values:   []string{"kube-system", "kyverno", "team-a"},
+			}},
+		},
+		rules:         rules,
+		failurePolicy: admissionregistrationv1.Fail,
+		timeout:       10,
+	}, {
+		nameSuffix: "-tier-critical",
+		namespaceSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{"env": "prod"},
+			MatchExpressions: []metav1.LabelSelectorRequirement{{
+				Key:      namespaceNameLabel,
+				Operator: metav1.LabelSelectorOpIn,
+				Values:   []string{"kube-system", "kyverno"},
+			}},
+		},
+		rules:         rules,
+		failurePolicy: admissionregistrationv1.Ignore,
+		timeout:       3,
+	}, {
+		nameSuffix: "-tier-tenants",
+		namespaceSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{"env": "prod"},
+			MatchExpressions: []metav1.LabelSelectorRequirement{{
+				Key:      namespaceNameLabel,
+				Operator: metav1.LabelSelectorOpIn,
+				Values:   []string{"team-a"},
+			}},
+		},
+		rules:         rules,
+		failurePolicy: admissionregistrationv1.Fail,
+		timeout:       10,
+	}}, result)
+	// the original selector is left untouched
+	assert.Empty(t, selector.MatchExpressions)
+}
+
+func Test_policyWebhookTiers(t *testing.T) {
+	tiers := []config.WebhookTier{{
+		Name:           "critical",
+		Namespaces:     []string{"kube-system"},
+		FailurePolicy:  ptr.To(admissionregistrationv1.Ignore),
+		TimeoutSeconds: ptr.To[int32](3),
+	}, {
+		Name:       "tenants",
+		Namespaces: []string{"team-a"},
+	}}
+	namespaced := []admissionregistrationv1.RuleWithOperations{{
+		Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+		Rule: admissionregistrationv1.Rule{
+			APIGroups:   []string{""},
+			APIVersions: []string{"v1"},
+			Resources:   []string{"pods"},
+			Scope:       ptr.To(admissionregistrationv1.NamespacedScope),
+		},
+	}}
+	clusterScoped := []admissionregistrationv1.RuleWithOperations{{
+		Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+		Rule: admissionregistrationv1.Rule{
+			APIGroups:   []string{"rbac.authorization.k8s.io"},
+			APIVersions: []string{"v1"},
+			Resources:   []string{"clusterroles"},
+			Scope:       ptr.To(admissionregistrationv1.ClusterScope),
+		},
+	}}
+	assert.Nil(t, policyWebhookTiers(nil, "", namespaced, admissionregistrationv1.Fail))
+	assert.Nil(t, policyWebhookTiers(tiers, "", nil, admissionregistrationv1.Fail))
+	assert.Equal(t, []kyvernov1.WebhookTierStatus{{
+		Name:           "critical",
+		FailurePolicy:  kyvernov1.Ignore,
+		TimeoutSeconds: ptr.To[int32](3),
+	}, {
+		Name:          "tenants",
+		FailurePolicy: kyvernov1.Fail,
+	}, {
+		Name:          config.DefaultWebhookTier,
+		FailurePolicy: kyvernov1.Fail,
+	}}, policyWebhookTiers(tiers, "", namespaced, admissionregistrationv1.Fail))
+	// cluster scoped rules are only served by the default tier
+	assert.Equal(t, []kyvernov1.WebhookTierStatus{{
+		Name:          config.DefaultWebhookTier,
+		FailurePolicy: kyvernov1.Fail,
+	}}, policyWebhookTiers(tiers, "", clusterScoped, admissionregistrationv1.Fail))
+	assert.Equal(t, []kyvernov1.WebhookTierStatus{{
+		Name:          "tenants",
+		FailurePolicy: kyvernov1.Ignore,
+	}}, policyWebhookTiers(tiers, "team-a", namespaced, admissionregistrationv1.Ignore))
+	assert.Equal(t, []kyvernov1.WebhookTierStatus{{
+		Name:          config.DefaultWebhookTier,
+		FailurePolicy: kyvernov1.Fail,
+	}}, policyWebhookTiers(tiers, "team-b", namespaced, admissionregistrationv1.Fail))
+}
+
+func Test_mergeWebhookTiers(t *testing.T) {
+	critical := kyvernov1.WebhookTierStatus{Name: "critical", FailurePolicy: kyvernov1.Ignore}
+	defaultTier := kyvernov1.WebhookTierStatus{Name: config.DefaultWebhookTier, FailurePolicy: kyvernov1.Fail}
+	assert.Nil(t, mergeWebhookTiers(nil, nil))
+	assert.Equal(t, []kyvernov1.WebhookTierStatus{defaultTier, critical}, mergeWebhookTiers(
+		[]kyvernov1.WebhookTierStatus{defaultTier},
+		[]kyvernov1.WebhookTierStatus{critical, defaultTier},
+	))
+}
+
+func Test_splitValidatingWebhookTiers(t *testing.T) {
+	webhooks := []admissionregistrationv1.ValidatingWebhook{{
+		Name:          "vpol.validate.kyverno.svc-fail",
+		FailurePolicy: ptr.To(admissionregistrationv1.Fail),
+		Rules: []admissionregistrationv1.RuleWithOperations{{
+			Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+			Rule: admissionregistrationv1.Rule{
+				APIGroups:   []string{""},
+				APIVersions: []string{"v1"},
+				Resources:   []string{"pods"},
+				Scope:       ptr.To(admissionregistrationv1.NamespacedScope),
+			},
+		}},
+	}}
+	assert.Equal(t, webhooks, splitValidatingWebhookTiers(nil, webhooks))
+	tiers := []config.WebhookTier{{
+		Name:           "critical",
+		Namespaces:     []string{"kube-system"},
+		FailurePolicy:  ptr.To(admissionregistrationv1.Ignore),
+		TimeoutSeconds: ptr.To[int32](3),
+	}}
+	result := splitValidatingWebhookTiers(tiers, webhooks)
+	assert.Len(t, result, 2)
+	assert.Equal(t, "vpol.validate.kyverno.svc-fail", result[0].Name)
+	assert.Equal(t, admissionregistrationv1.Fail, *result[0].FailurePolicy)
+	assert.Equal(t, int32(DefaultWebhookTimeout), *result[0].TimeoutSeconds)
+	assert.Equal(t, []metav1.LabelSelectorRequirement{{
+		Key:      namespaceNameLabel,
+		Operator: metav1.LabelSelectorOpNotIn,
+		Values:   []string{"kube-system"},
+	}}, result[0].NamespaceSelector.MatchExpressions)
+	assert.Equal(t, "vpol.validate.kyverno.svc-fail-tier-critical", result[1].Name)
+	assert.Equal(t, admissionregistrationv1.Ignore, *result[1].FailurePolicy)
+	assert.Equal(t, int32(3), *result[1].TimeoutSeconds)
+	assert.Equal(t, []metav1.LabelSelectorRequirement{{
+		Key:      namespaceNameLabel,
+		Operator: metav1.LabelSelectorOpIn,
+		Values:   []string{"kube-system"},
+	}}, result[1].NamespaceSelector.MatchExpressions)
+	assert.Equal(t, webhooks[0].Rules, result[1].Rules)
+	// the original webhooks are left untouched
+	assert.Nil(t, webhooks[0].NamespaceSelector)
+}
+
 func Test_less(t *testing.T) {
 	tests := []struct {
 		name string
@@ -635,3 +914,42 @@ func Test_collectResourceDescriptions(t *testing.T) {
 		})
 	}
 }
+
+func Test_celPolicyWebhookTiers(t *testing.T) {
+	cfg := config.NewDefaultConfiguration(false)
+	vpol := &policiesv1alpha1.ValidatingPolicy{
+		TypeMeta:   metav1.TypeMeta{Kind: ValidatingPolicyType},
+		ObjectMeta: metav1.ObjectMeta{Name: "check-pods"},
+		Spec: policiesv1alpha1.ValidatingPolicySpec{
+			FailurePolicy: ptr.To(admissionregistrationv1.Fail),
+			MatchConstraints: &admissionregistrationv1.MatchResources{
+				ResourceRules: []admissionregistrationv1.NamedRuleWithOperations{{
+					RuleWithOperations: admissionregistrationv1.RuleWithOperations{
+						Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+						Rule: admissionregistrationv1.Rule{
+							APIGroups:   []string{""},
+							APIVersions: []string{"v1"},
+							Resources:   []string{"pods"},
+							Scope:       ptr.To(admissionregistrationv1.NamespacedScope),
+						},
+					},
+				}},
+			},
+		},
+	}
+	policies := []engineapi.GenericPolicy{engineapi.NewValidatingPolicy(vpol)}
+	assert.Empty(t, celPolicyWebhookTiers(cfg, config.ValidatingPolicyWebhookName, policies))
+	cfg.Load(&corev1.ConfigMap{Data: map[string]string{
+		"webhookTiers": `[{"name":"critical","namespaces":["kube-system"],"failurePolicy":"Ignore","timeoutSeconds":3}]`,
+	}})
+	assert.Equal(t, map[string][]kyvernov1.WebhookTierStatus{
+		"ValidatingPolicy/check-pods": {{
+			Name:           "critical",
+			FailurePolicy:  kyvernov1.Ignore,
+			TimeoutSeconds: ptr.To[int32](3),
+		}, {
+			Name:          config.DefaultWebhookTier,
+			FailurePolicy: kyvernov1.Fail,
+		}},
+	}, celPolicyWebhookTiers(cfg, config.ValidatingPolicyWebhookName, policies))
+}
@@ -0,0 +1,96 @@
+package cosign
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	gcrremote "github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/kyverno/kyverno/pkg/images"
+	"github.com/sigstore/cosign/v2/pkg/cosign"
+	"github.com/sigstore/cosign/v2/pkg/oci/mutate"
+	"github.com/sigstore/cosign/v2/pkg/oci/remote"
+	"github.com/sigstore/cosign/v2/pkg/oci/static"
+	sigs "github.com/sigstore/cosign/v2/pkg/signature"
+	"github.com/sigstore/sigstore/pkg/signature/options"
+	"github.com/sigstore/sigstore/pkg/signature/payload"
+)
+
+// SignOptions configures the signature of an image with a private key.
+type SignOptions struct {
+	// ImageRef is the image to sign, tags are resolved to the digest of the image
+	ImageRef string
+	Client   images.Client
+	// Key is a path to a cosign private key, or a KMS or Kubernetes secret reference
+	Key string
+	// Password returns the password of the private key
+	Password cosign.PassFunc
+	// Annotations are added to the optional section of the signature payload
+	Annotations map[string]string
+	// Repository is the repository the signature is pushed to, it defaults to the repository of the image
+	Repository string
+}
+
+// SignImage signs the digest of an image with a private key and pushes the signature to the registry
+// using the cosign tag layout. The signature is not uploaded to a transparency log.
+// It returns the signed digest.
+func SignImage(ctx context.Context, opts SignOptions) (string, error) {
+	ref, err := name.ParseReference(opts.ImageRef, opts.Client.NameOptions()...)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse image %s", opts.ImageRef)
+	}
+	remoteOpts, err := opts.Client.Options(ctx)
+	if err != nil {
+		return "", fmt.Errorf("constructing remote options: %w", err)
+	}
+	desc, err := gcrremote.Head(ref, remoteOpts...)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve image %s: %w", opts.ImageRef, err)
+	}
+	digest := ref.Context().Digest(desc.Digest.String())
+	signer, err := sigs.SignerFromKeyRef(ctx, opts.Key, opts.Password)
+	if err != nil {
+		return "", fmt.Errorf("failed to load private key from %s: %w", opts.Key, err)
+	}
+	var annotations map[string]interface{}
+	if len(opts.Annotations) != 0 {
+		annotations = map[string]interface{}{}
+		for key, value := range opts.Annotations {
+			annotations[key] = value
+		}
+	}
+	pld, err := (&payload.Cosign{Image: digest, Annotations: annotations}).MarshalJSON()
+	if err != nil {
+		return "", fmt.Errorf("failed to build signature payload: %w", err)
+	}
+	signature, err := signer.SignMessage(bytes.NewReader(pld), options.WithContext(ctx))
+	if err != nil {
+		return "", fmt.Errorf("failed to sign payload: %w", err)
+	}
+	sig, err := static.NewSignature(pld, base64.StdEncoding.EncodeToString(signature))
+	if err != nil {
+		return "", fmt.Errorf("failed to create signature: %w", err)
+	}
+	cosignOpts := []remote.Option{remote.WithRemoteOptions(remoteOpts...)}
+	if opts.Repository != "" {
+		signatureRepo, err := name.NewRepository(opts.Repository, opts.Client.NameOptions()...)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse signature repository %s: %w", opts.Repository, err)
+		}
+		cosignOpts = append(cosignOpts, remote.WithTargetRepository(signatureRepo))
+	}
+	entity, err := remote.SignedEntity(digest, cosignOpts...)
+	if err != nil {
+		return "", fmt.Errorf("failed to access image %s: %w", digest, err)
+	}
+	entity, err = mutate.AttachSignatureToEntity(entity, sig)
+	if err != nil {
+		return "", fmt.Errorf("failed to attach signature: %w", err)
+	}
+	if err := remote.WriteSignatures(digest.Repository, entity, cosignOpts...); err != nil {
+		return "", fmt.Errorf("failed to push signature: %w", err)
+	}
+	return desc.Digest.String(), nil
+}
@@ -0,0 +1,75 @@
+package cosign
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	gcrremote "github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/kyverno/kyverno/pkg/images"
+	"github.com/kyverno/kyverno/pkg/registryclient"
+	"github.com/sigstore/cosign/v2/pkg/cosign"
+	"gotest.tools/assert"
+)
+
+func TestSignImage(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	defer server.Close()
+	imageRef := strings.TrimPrefix(server.URL, "http://") + "/policies:v1"
+	ref, err := name.ParseReference(imageRef)
+	assert.NilError(t, err)
+	img, err := random.Image(64, 1)
+	assert.NilError(t, err)
+	assert.NilError(t, gcrremote.Write(ref, img))
+	expected, err := img.Digest()
+	assert.NilError(t, err)
+
+	password := func(bool) ([]byte, error) { return []byte("secret"), nil }
+	keys, err := cosign.GenerateKeyPair(password)
+	assert.NilError(t, err)
+	key := filepath.Join(t.TempDir(), "cosign.key")
+	assert.NilError(t, os.WriteFile(key, keys.PrivateBytes, 0o600))
+	client := registryclient.NewOrDie()
+
+	digest, err := SignImage(ctx, SignOptions{
+		ImageRef:    imageRef,
+		Client:      client,
+		Key:         key,
+		Password:    password,
+		Annotations: map[string]string{"foo": "bar"},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, digest, expected.String())
+
+	verifier := NewVerifier()
+	response, err := verifier.VerifySignature(ctx, images.Options{
+		ImageRef:    imageRef,
+		Client:      client,
+		Key:         string(keys.PublicBytes),
+		Annotations: map[string]string{"foo": "bar"},
+		IgnoreTlog:  true,
+		IgnoreSCT:   true,
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, response.Digest, expected.String())
+
+	other, err := cosign.GenerateKeyPair(password)
+	assert.NilError(t, err)
+	_, err = verifier.VerifySignature(ctx, images.Options{
+		ImageRef:   imageRef,
+		Client:     client,
+		Key:        string(other.PublicBytes),
+		IgnoreTlog: true,
+		IgnoreSCT:  true,
+	})
+	assert.ErrorContains(t, err, "no matching signatures")
+}
@@ -53,6 +53,8 @@ type RuleResponse struct {
 	emitWarning bool
 	// properties are the additional properties from the rule that will be added to the policy report result
 	properties map[string]string
+	// validationIndex is the index of the failing validation (only for CEL policies)
+	validationIndex *int
 }
 
 func NewRuleResponse(name string, ruleType RuleType, msg string, status RuleStatus, properties map[string]string) *RuleResponse {
@@ -140,6 +142,11 @@ func (r RuleResponse) WithProperties(m map[string]string) *RuleResponse {
 	return &r
 }
 
+func (r RuleResponse) WithValidationIndex(index int) *RuleResponse {
+	r.validationIndex = &index
+	return &r
+}
+
 func (r *RuleResponse) Stats() ExecutionStats {
 	return r.stats
 }
@@ -196,6 +203,14 @@ func (r *RuleResponse) Properties() map[string]string {
 	return r.properties
 }
 
+// ValidationIndex returns the index of the failing validation, if known
+func (r *RuleResponse) ValidationIndex() (int, bool) {
+	if r.validationIndex == nil {
+		return 0, false
+	}
+	return *r.validationIndex, true
+}
+
 // HasStatus checks if rule status is in a given list
 func (r *RuleResponse) HasStatus(status ...RuleStatus) bool {
 	for _, s := range status {
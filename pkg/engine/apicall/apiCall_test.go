@@ -7,12 +7,14 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/config"
 	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/httpguard"
 	"gotest.tools/assert"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 )
@@ -305,3 +307,37 @@ func Test_serviceHeaders(t *testing.T) {
 	assert.Equal(t, "application/json", responseHeaders["Content-Type"][0])
 	assert.Equal(t, "CustomVal", responseHeaders["Custom-Key"][0])
 }
+
+func Test_serviceCallGuard(t *testing.T) {
+	var calls int
+	mux := http.NewServeMux()
+	mux.HandleFunc("/resource", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte(`{ "day": "Tuesday" }`))
+	})
+	s := httptest.NewServer(mux)
+	defer s.Close()
+
+	entry := kyvernov1.ContextEntry{
+		Name: "test",
+		APICall: &kyvernov1.ContextAPICall{
+			APICall: kyvernov1.APICall{
+				Method: "GET",
+				Service: &kyvernov1.ServiceCall{
+					URL: s.URL + "/resource",
+				},
+			},
+		},
+	}
+	guard := httpguard.New(httpguard.Options{CacheTTL: time.Minute, CacheSize: 10})
+	config := apiConfig.WithGuard(guard)
+
+	for i := 0; i < 3; i++ {
+		call, err := New(logr.Discard(), jp, entry, enginecontext.NewContext(jp), nil, config)
+		assert.NilError(t, err)
+		data, err := call.FetchAndLoad(context.TODO())
+		assert.NilError(t, err)
+		assert.Equal(t, `{ "day": "Tuesday" }`, string(data))
+	}
+	assert.Equal(t, 1, calls)
+}
@@ -1,7 +1,15 @@
 package apicall
 
+import (
+	"time"
+
+	"github.com/kyverno/kyverno/pkg/httpguard"
+)
+
 type APICallConfiguration struct {
 	maxAPICallResponseLength int64
+	guard                    httpguard.Guard
+	timeout                  time.Duration
 }
 
 func NewAPICallConfiguration(maxLen int64) APICallConfiguration {
@@ -9,3 +17,15 @@ func NewAPICallConfiguration(maxLen int64) APICallConfiguration {
 		maxAPICallResponseLength: maxLen,
 	}
 }
+
+// WithGuard returns a copy of the configuration bounding service calls with the given guard.
+func (c APICallConfiguration) WithGuard(guard httpguard.Guard) APICallConfiguration {
+	c.guard = guard
+	return c
+}
+
+// WithTimeout returns a copy of the configuration overriding the default timeout of the guard.
+func (c APICallConfiguration) WithTimeout(timeout time.Duration) APICallConfiguration {
+	c.timeout = timeout
+	return c
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/httpguard"
 	"github.com/kyverno/kyverno/pkg/tracing"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
@@ -67,6 +68,40 @@ func (a *executor) executeServiceCall(ctx context.Context, apiCall *kyvernov1.AP
 		return nil, fmt.Errorf("missing service for APICall %s", a.name)
 	}
 
+	if a.config.guard == nil {
+		return a.doServiceCall(ctx, apiCall)
+	}
+
+	var body []byte
+	if apiCall.Method == "POST" {
+		data, err := a.buildRequestData(apiCall.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build request data for APICall %s: %w", a.name, err)
+		}
+		if body, err = io.ReadAll(data); err != nil {
+			return nil, fmt.Errorf("failed to build request data for APICall %s: %w", a.name, err)
+		}
+	}
+
+	headers := make(map[string]string, len(apiCall.Service.Headers))
+	for _, header := range apiCall.Service.Headers {
+		headers[header.Key] = header.Value
+	}
+	request := httpguard.Request{
+		Engine:   httpguard.EngineJMESPath,
+		Method:   string(apiCall.Method),
+		URL:      apiCall.Service.URL,
+		Body:     body,
+		Headers:  headers,
+		CABundle: apiCall.Service.CABundle,
+		Timeout:  a.config.timeout,
+	}
+	return a.config.guard.Do(ctx, request, func(ctx context.Context) ([]byte, error) {
+		return a.doServiceCall(ctx, apiCall)
+	})
+}
+
+func (a *executor) doServiceCall(ctx context.Context, apiCall *kyvernov1.APICall) ([]byte, error) {
 	client, err := a.buildHTTPClient(apiCall.Service)
 	if err != nil {
 		return nil, err
@@ -110,6 +145,42 @@ func (a *executor) executeServiceCall(ctx context.Context, apiCall *kyvernov1.AP
 	return body, nil
 }
 
+// Stream executes a service call and returns the response body as soon as the response headers are received,
+// the caller is responsible for reading and closing it.
+func (a *executor) Stream(ctx context.Context, apiCall *kyvernov1.APICall) (io.ReadCloser, error) {
+	if apiCall.Service == nil {
+		return nil, fmt.Errorf("missing service for APICall %s", a.name)
+	}
+
+	client, err := a.buildHTTPClient(apiCall.Service)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := a.buildHTTPRequest(ctx, apiCall)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build HTTP request for APICall %s: %w", a.name, err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute HTTP request for APICall %s: %w", a.name, err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		b, err := io.ReadAll(resp.Body)
+		if err == nil {
+			return nil, fmt.Errorf("HTTP %s: %s", resp.Status, string(b))
+		}
+
+		return nil, fmt.Errorf("HTTP %s", resp.Status)
+	}
+
+	a.logger.V(4).Info("opened service APICall stream", "name", a.name)
+	return resp.Body, nil
+}
+
 func (a *executor) buildHTTPRequest(ctx context.Context, apiCall *kyvernov1.APICall) (*http.Request, error) {
 	if apiCall.Service == nil {
 		return nil, fmt.Errorf("missing service")
@@ -11,6 +11,7 @@ import (
 	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
 	"github.com/kyverno/kyverno/pkg/engine/context/loaders"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/httpguard"
 	"github.com/kyverno/kyverno/pkg/logging"
 	"github.com/kyverno/kyverno/pkg/toggle"
 )
@@ -18,7 +19,7 @@ import (
 type ContextLoaderFactoryOptions func(*contextLoader)
 
 func DefaultContextLoaderFactory(cmResolver engineapi.ConfigmapResolver, opts ...ContextLoaderFactoryOptions) engineapi.ContextLoaderFactory {
-	return func(_ kyvernov1.PolicyInterface, _ kyvernov1.Rule) engineapi.ContextLoader {
+	return func(policy kyvernov1.PolicyInterface, _ kyvernov1.Rule) engineapi.ContextLoader {
 		cl := &contextLoader{
 			logger:     logging.WithName("DefaultContextLoaderFactory"),
 			cmResolver: cmResolver,
@@ -26,6 +27,13 @@ func DefaultContextLoaderFactory(cmResolver engineapi.ConfigmapResolver, opts ..
 		for _, o := range opts {
 			o(cl)
 		}
+		if policy != nil {
+			if timeout, err := httpguard.PolicyTimeout(policy); err != nil {
+				cl.logger.Error(err, "ignoring policy http timeout", "policy", policy.GetName())
+			} else if timeout > 0 {
+				cl.apiCallConfig = cl.apiCallConfig.WithTimeout(timeout)
+			}
+		}
 		return cl
 	}
 }
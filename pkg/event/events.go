@@ -3,12 +3,14 @@ package event
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
 	"github.com/kyverno/kyverno/api/policies.kyverno.io/v1alpha1"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 )
@@ -338,6 +340,27 @@ func NewCleanupPolicyEvent(policy kyvernov2.CleanupPolicyInterface, resource uns
 	}
 }
 
+func NewCleanupPolicyDryRunEvent(policy kyvernov2.CleanupPolicyInterface, status v1alpha1.DryRunStatus) Info {
+	candidates := make([]string, 0, len(status.Candidates))
+	for _, candidate := range status.Candidates {
+		candidates = append(candidates, candidateKey(candidate.Kind, candidate.Namespace, candidate.Name))
+	}
+	return Info{
+		Regarding: corev1.ObjectReference{
+			// TODO: iirc it's not safe to assume api version is set
+			APIVersion: "kyverno.io/v2",
+			Kind:       policy.GetKind(),
+			Name:       policy.GetName(),
+			Namespace:  policy.GetNamespace(),
+			UID:        policy.GetUID(),
+		},
+		Source:  CleanupController,
+		Action:  None,
+		Reason:  PolicyDryRun,
+		Message: dryRunMessage(status.Count, candidates),
+	}
+}
+
 func NewValidatingAdmissionPolicyEvent(policy engineapi.GenericPolicy, vapName, vapBindingName string) []Info {
 	regarding := corev1.ObjectReference{
 		// TODO: iirc it's not safe to assume api version is set
@@ -431,6 +454,90 @@ func NewDeletingPolicyEvent(policy v1alpha1.DeletingPolicy, resource unstructure
 	}
 }
 
+func NewDeletingPolicyDryRunEvent(policy v1alpha1.DeletingPolicy, status v1alpha1.DryRunStatus) Info {
+	candidates := make([]string, 0, len(status.Candidates))
+	for _, candidate := range status.Candidates {
+		candidates = append(candidates, candidateKey(candidate.Kind, candidate.Namespace, candidate.Name))
+	}
+	return Info{
+		Regarding: corev1.ObjectReference{
+			APIVersion: v1alpha1.SchemeGroupVersion.String(),
+			Kind:       policy.GetKind(),
+			Name:       policy.GetName(),
+			Namespace:  policy.GetNamespace(),
+			UID:        policy.GetUID(),
+		},
+		Source:  CleanupController,
+		Action:  None,
+		Reason:  PolicyDryRun,
+		Message: dryRunMessage(status.Count, candidates),
+	}
+}
+
+// maxDryRunEventCandidates is the maximum number of resources listed in dry run event messages
+const maxDryRunEventCandidates = 5
+
+func dryRunMessage(count int, candidates []string) string {
+	if count == 0 {
+		return "dry run: no resource would be deleted"
+	}
+	if len(candidates) > maxDryRunEventCandidates {
+		candidates = candidates[:maxDryRunEventCandidates]
+	}
+	message := fmt.Sprintf("dry run: %d resource(s) would be deleted: %s", count, strings.Join(candidates, ", "))
+	if count > len(candidates) {
+		message += fmt.Sprintf(" and %d more", count-len(candidates))
+	}
+	return message
+}
+
+func candidateKey(kind, namespace, name string) string {
+	if namespace != "" {
+		return strings.Join([]string{kind, namespace, name}, "/")
+	}
+	return strings.Join([]string{kind, name}, "/")
+}
+
+func NewPolicyExceptionExpiredEvent(apiVersion string, exception metav1.Object, notAfter time.Time) Info {
+	return Info{
+		Regarding: corev1.ObjectReference{
+			APIVersion: apiVersion,
+			Kind:       "PolicyException",
+			Name:       exception.GetName(),
+			Namespace:  exception.GetNamespace(),
+			UID:        exception.GetUID(),
+		},
+		Source:  ExceptionController,
+		Reason:  PolicyExceptionExpired,
+		Action:  None,
+		Message: fmt.Sprintf("policy exception expired at %s and is no longer applied", notAfter.UTC().Format(time.RFC3339)),
+	}
+}
+
+func NewUpdateRequestAbandonedEvent(ur kyvernov2.UpdateRequest) Info {
+	resource := ur.Spec.GetResource()
+	return Info{
+		Regarding: corev1.ObjectReference{
+			APIVersion: kyvernov2.SchemeGroupVersion.String(),
+			Kind:       "UpdateRequest",
+			Name:       ur.GetName(),
+			Namespace:  ur.GetNamespace(),
+			UID:        ur.GetUID(),
+		},
+		Related: &corev1.ObjectReference{
+			APIVersion: resource.APIVersion,
+			Kind:       resource.Kind,
+			Name:       resource.Name,
+			Namespace:  resource.Namespace,
+			UID:        resource.UID,
+		},
+		Source:  BackgroundController,
+		Reason:  UpdateRequestAbandoned,
+		Action:  None,
+		Message: fmt.Sprintf("%s request for policy %s abandoned after %d attempts: %s", ur.Spec.GetRequestType(), ur.Spec.GetPolicyKey(), ur.Status.RetryCount, ur.Status.Message),
+	}
+}
+
 func resourceKey(resource unstructured.Unstructured) string {
 	if resource.GetNamespace() != "" {
 		return strings.Join([]string{resource.GetKind(), resource.GetNamespace(), resource.GetName()}, "/")
@@ -438,3 +545,59 @@ func resourceKey(resource unstructured.Unstructured) string {
 
 	return strings.Join([]string{resource.GetKind(), resource.GetName()}, "/")
 }
+
+// maxDriftEventPaths is the maximum number of drifted fields listed in drift event messages
+const maxDriftEventPaths = 5
+
+func NewResourceDriftEvent(source Source, policy engineapi.GenericPolicy, rule string, resource unstructured.Unstructured, paths []string) Info {
+	count := len(paths)
+	if count > maxDriftEventPaths {
+		paths = paths[:maxDriftEventPaths]
+	}
+	message := fmt.Sprintf("%s drifted from policy %s/%s: %s", candidateKey(resource.GetKind(), resource.GetNamespace(), resource.GetName()), policy.GetName(), rule, strings.Join(paths, ", "))
+	if count > len(paths) {
+		message += fmt.Sprintf(" and %d more", count-len(paths))
+	}
+	return Info{
+		Regarding: corev1.ObjectReference{
+			APIVersion: resource.GetAPIVersion(),
+			Kind:       resource.GetKind(),
+			Name:       resource.GetName(),
+			Namespace:  resource.GetNamespace(),
+			UID:        resource.GetUID(),
+		},
+		Related: &corev1.ObjectReference{
+			APIVersion: policy.GetAPIVersion(),
+			Kind:       policy.GetKind(),
+			Name:       policy.GetName(),
+			Namespace:  policy.GetNamespace(),
+			UID:        policy.GetUID(),
+		},
+		Source:  source,
+		Reason:  ResourceDrifted,
+		Action:  None,
+		Message: message,
+	}
+}
+
+func NewResourceMissingEvent(source Source, policy engineapi.GenericPolicy, rule string, resource unstructured.Unstructured) Info {
+	return Info{
+		Regarding: corev1.ObjectReference{
+			APIVersion: resource.GetAPIVersion(),
+			Kind:       resource.GetKind(),
+			Name:       resource.GetName(),
+			Namespace:  resource.GetNamespace(),
+		},
+		Related: &corev1.ObjectReference{
+			APIVersion: policy.GetAPIVersion(),
+			Kind:       policy.GetKind(),
+			Name:       policy.GetName(),
+			Namespace:  policy.GetNamespace(),
+			UID:        policy.GetUID(),
+		},
+		Source:  source,
+		Reason:  ResourceDrifted,
+		Action:  None,
+		Message: fmt.Sprintf("%s generated by policy %s/%s is missing", candidateKey(resource.GetKind(), resource.GetNamespace(), resource.GetName()), policy.GetName(), rule),
+	}
+}
@@ -4,8 +4,12 @@ package event
 type Reason string
 
 const (
-	PolicyViolation Reason = "PolicyViolation"
-	PolicyApplied   Reason = "PolicyApplied"
-	PolicyError     Reason = "PolicyError"
-	PolicySkipped   Reason = "PolicySkipped"
+	PolicyViolation        Reason = "PolicyViolation"
+	PolicyApplied          Reason = "PolicyApplied"
+	PolicyError            Reason = "PolicyError"
+	PolicySkipped          Reason = "PolicySkipped"
+	PolicyExceptionExpired Reason = "PolicyExceptionExpired"
+	PolicyDryRun           Reason = "PolicyDryRun"
+	UpdateRequestAbandoned Reason = "UpdateRequestAbandoned"
+	ResourceDrifted        Reason = "ResourceDrifted"
 )
@@ -14,4 +14,8 @@ const (
 	MutateExistingController Source = "kyverno-mutate"
 	// CleanupController : event generated for cleanup policies
 	CleanupController Source = "kyverno-cleanup"
+	// ExceptionController : event generated for policy exceptions
+	ExceptionController Source = "kyverno-exception"
+	// BackgroundController : event generated by the update request controller
+	BackgroundController Source = "kyverno-background"
 )
@@ -1,7 +1,11 @@
 package exceptions
 
 import (
+	"context"
+	"time"
+
 	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	"github.com/kyverno/kyverno/pkg/toggle"
 	"k8s.io/apimachinery/pkg/labels"
 )
 
@@ -24,8 +28,14 @@ func (s selector) Find(policyName string, ruleName string) ([]*kyvernov2.PolicyE
 	if err != nil {
 		return nil, err
 	}
+	now := time.Now()
+	approvalRequired := toggle.FromContext(context.TODO()).RequireExceptionApproval()
 	var results []*kyvernov2.PolicyException
 	for _, polex := range polexs {
+		// expired, not yet valid or unapproved exceptions are not applied
+		if !polex.IsActive(now, approvalRequired) {
+			continue
+		}
 		if polex.Contains(policyName, ruleName) {
 			results = append(results, polex)
 		}
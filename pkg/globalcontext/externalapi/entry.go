@@ -27,6 +27,7 @@ import (
 type entry struct {
 	sync.Mutex
 	dataMap     map[string]any
+	raw         []byte
 	err         error
 	stop        func()
 	projections []store.Projection
@@ -55,16 +56,9 @@ func New(
 		group.Wait()
 	}
 
-	projections := make([]store.Projection, 0)
-	for _, p := range gce.Spec.Projections {
-		jpQuery, err := jp.Query(p.JMESPath)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse jmespath query: %s", err)
-		}
-		projections = append(projections, store.Projection{
-			Name: p.Name,
-			JP:   jpQuery,
-		})
+	projections, err := store.CompileProjections(jp, gce.Spec.Projections...)
+	if err != nil {
+		return nil, err
 	}
 
 	e := &entry{
@@ -73,6 +67,57 @@ func New(
 		projections: projections,
 	}
 
+	if gce.Spec.APICall.IsStream() {
+		group.StartWithContext(ctx, func(ctx context.Context) {
+			config := apicall.NewAPICallConfiguration(maxResponseLength)
+			caller := apicall.NewExecutor(logger, "globalcontext", client, config)
+
+			e.streamUntil(ctx, caller, call, gce.Spec.APICall.Stream, maxResponseLength, func() {
+				logger.V(4).Info("api call stream connected")
+
+				if shouldUpdateStatus {
+					if updateErr := updateStreamStatus(ctx, gce, kyvernoClient, func(status *kyvernov2alpha1.GlobalContextEntryStatus) {
+						status.SetConnected(true, "")
+					}); updateErr != nil {
+						logger.Error(updateErr, "failed to update status")
+					}
+				}
+			}, func() {
+				logger.V(4).Info("api call stream ready")
+
+				if shouldUpdateStatus {
+					if updateErr := updateStreamStatus(ctx, gce, kyvernoClient, func(status *kyvernov2alpha1.GlobalContextEntryStatus) {
+						status.SetReady(true, "")
+						status.UpdateRefreshTime()
+					}); updateErr != nil {
+						logger.Error(updateErr, "failed to update status")
+					}
+				}
+			}, func(err error) {
+				logger.Error(err, "api call stream disconnected")
+
+				eventGen.Add(entryevent.NewErrorEvent(corev1.ObjectReference{
+					APIVersion: gce.APIVersion,
+					Kind:       gce.Kind,
+					Name:       gce.Name,
+					Namespace:  gce.Namespace,
+					UID:        gce.UID,
+				}, err))
+
+				if shouldUpdateStatus {
+					if updateErr := updateStreamStatus(ctx, gce, kyvernoClient, func(status *kyvernov2alpha1.GlobalContextEntryStatus) {
+						status.SetConnected(false, err.Error())
+						status.SetReady(false, err.Error())
+					}); updateErr != nil {
+						logger.Error(updateErr, "failed to update status")
+					}
+				}
+			})
+		})
+
+		return e, nil
+	}
+
 	group.StartWithContext(ctx, func(ctx context.Context) {
 		config := apicall.NewAPICallConfiguration(maxResponseLength)
 		caller := apicall.NewExecutor(logger, "globalcontext", client, config)
@@ -137,20 +182,21 @@ func (e *entry) setData(data any, err error) {
 		e.err = err
 	} else {
 		var jsonData any
-		if bytes, ok := data.([]byte); ok {
-			err = json.Unmarshal(bytes, &jsonData)
-			if err != nil {
-				e.err = err
-				return
-			}
-		} else {
+		bytes, ok := data.([]byte)
+		if !ok {
 			e.err = fmt.Errorf("data is not a byte array")
 			return
 		}
+		if err := json.Unmarshal(bytes, &jsonData); err != nil {
+			e.err = err
+			return
+		}
 		e.dataMap[""] = jsonData
+		e.raw = bytes
+		e.err = nil
 		if len(e.projections) > 0 {
 			for _, projection := range e.projections {
-				result, err := projection.JP.Search(jsonData)
+				result, err := projection.Apply(jsonData)
 				if err != nil {
 					e.err = err
 					return
@@ -182,6 +228,26 @@ func doCall(ctx context.Context, caller apicall.Executor, call kyvernov1.APICall
 	return result, retryError
 }
 
+func updateStreamStatus(ctx context.Context, gce *kyvernov2alpha1.GlobalContextEntry, kyvernoClient versioned.Interface, update func(*kyvernov2alpha1.GlobalContextEntryStatus)) error {
+	retryErr := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		// Fetch the latest version of the GlobalContextEntry
+		latest, err := kyvernoClient.KyvernoV2alpha1().GlobalContextEntries().Get(ctx, gce.GetName(), metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		return controllerutils.UpdateStatus(ctx, latest, kyvernoClient.KyvernoV2alpha1().GlobalContextEntries(), func(latest *kyvernov2alpha1.GlobalContextEntry) error {
+			if latest == nil {
+				return fmt.Errorf("failed to update status: %s", gce.GetName())
+			}
+			update(&latest.Status)
+			return nil
+		}, nil)
+	})
+
+	return retryErr
+}
+
 func updateStatus(ctx context.Context, gce *kyvernov2alpha1.GlobalContextEntry, kyvernoClient versioned.Interface) error {
 	retryErr := retry.RetryOnConflict(retry.DefaultRetry, func() error {
 		// Fetch the latest version of the GlobalContextEntry
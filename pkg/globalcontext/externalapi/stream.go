@@ -0,0 +1,215 @@
+package externalapi
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sync/atomic"
+	"time"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	// defaultMaxStreamMessageLength is the maximum length of a single update when no max response length is configured
+	defaultMaxStreamMessageLength = 10 * 1024 * 1024
+	initialReconnectDelay         = time.Second
+)
+
+var (
+	errStreamClosed = errors.New("stream closed by the server")
+	errStreamIdle   = errors.New("no data received on the stream")
+)
+
+type streamer interface {
+	Stream(context.Context, *kyvernov1.APICall) (io.ReadCloser, error)
+}
+
+// streamUntil keeps a connection to the service open until the context is cancelled,
+// reconnecting with an exponential backoff when the connection is lost.
+// onReady is called once the first update of a connection was applied, when the connection is lost
+// the entry fails with the stream error until the next update.
+func (e *entry) streamUntil(
+	ctx context.Context,
+	caller streamer,
+	call kyvernov1.APICall,
+	stream *kyvernov2alpha1.ExternalAPIStream,
+	maxResponseLength int64,
+	onConnect func(),
+	onReady func(),
+	onDisconnect func(error),
+) {
+	maxDelay := stream.GetMaxReconnectDelay()
+	newBackoff := func() wait.Backoff {
+		return wait.Backoff{
+			Duration: min(initialReconnectDelay, maxDelay),
+			Factor:   2,
+			Jitter:   0.1,
+			Steps:    math.MaxInt32,
+			Cap:      maxDelay,
+		}
+	}
+	backoff := newBackoff()
+	for {
+		connected := false
+		err := e.readStream(ctx, caller, call, stream, maxResponseLength, func() {
+			connected = true
+			onConnect()
+		}, onReady)
+		if ctx.Err() != nil {
+			return
+		}
+		e.disconnect(err)
+		onDisconnect(err)
+		// reset the delay once a connection was successfully established
+		if connected {
+			backoff = newBackoff()
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff.Step()):
+		}
+	}
+}
+
+// readStream opens a connection to the service and applies updates until the stream ends,
+// the connection is closed when no data is received for the idle timeout of the stream.
+func (e *entry) readStream(
+	ctx context.Context,
+	caller streamer,
+	call kyvernov1.APICall,
+	stream *kyvernov2alpha1.ExternalAPIStream,
+	maxResponseLength int64,
+	onConnect func(),
+	onReady func(),
+) error {
+	body, err := caller.Stream(ctx, &call)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+	onConnect()
+	if maxResponseLength <= 0 {
+		maxResponseLength = defaultMaxStreamMessageLength
+	}
+	idleTimeout := stream.GetIdleTimeout()
+	var idle atomic.Bool
+	timer := time.AfterFunc(idleTimeout, func() {
+		idle.Store(true)
+		body.Close()
+	})
+	defer timer.Stop()
+	scanner := bufio.NewScanner(&idleReader{reader: body, timer: timer, timeout: idleTimeout})
+	scanner.Buffer(make([]byte, 0, 64*1024), int(maxResponseLength))
+	ready := false
+	update := func(data []byte) error {
+		if err := e.applyUpdate(data, stream.GetUpdateStrategy()); err != nil {
+			return err
+		}
+		if !ready {
+			ready = true
+			onReady()
+		}
+		return nil
+	}
+	switch stream.GetFormat() {
+	case kyvernov2alpha1.StreamFormatJSONLines:
+		err = readJSONLines(scanner, update)
+	default:
+		err = readServerSentEvents(scanner, update)
+	}
+	if idle.Load() {
+		return fmt.Errorf("%w for %s", errStreamIdle, idleTimeout)
+	}
+	if err != nil {
+		return err
+	}
+	return errStreamClosed
+}
+
+// idleReader postpones the idle timeout of a stream every time data is received.
+type idleReader struct {
+	reader  io.Reader
+	timer   *time.Timer
+	timeout time.Duration
+}
+
+func (r *idleReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if n > 0 {
+		r.timer.Reset(r.timeout)
+	}
+	return n, err
+}
+
+func readJSONLines(scanner *bufio.Scanner, update func([]byte) error) error {
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		if err := update(line); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// readServerSentEvents dispatches the data of each event, other fields are ignored.
+// See https://html.spec.whatwg.org/multipage/server-sent-events.html#event-stream-interpretation
+func readServerSentEvents(scanner *bufio.Scanner, update func([]byte) error) error {
+	var data [][]byte
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			if len(data) > 0 {
+				if err := update(bytes.Join(data, []byte("\n"))); err != nil {
+					return err
+				}
+				data = nil
+			}
+			continue
+		}
+		if value, ok := bytes.CutPrefix(line, []byte("data:")); ok {
+			value, _ = bytes.CutPrefix(value, []byte(" "))
+			data = append(data, bytes.Clone(value))
+		}
+	}
+	return scanner.Err()
+}
+
+// disconnect fails the entry with the stream error, the data received before can't be trusted to be up to date
+// and merge patches of the next connection apply to the first update it sends.
+func (e *entry) disconnect(err error) {
+	e.setData(nil, err)
+	e.Lock()
+	defer e.Unlock()
+	e.raw = nil
+}
+
+func (e *entry) applyUpdate(data []byte, strategy kyvernov2alpha1.StreamUpdateStrategy) error {
+	if strategy == kyvernov2alpha1.StreamUpdateStrategyMergePatch {
+		e.Lock()
+		current := e.raw
+		e.Unlock()
+		if current != nil {
+			merged, err := jsonpatch.MergePatch(current, data)
+			if err != nil {
+				return fmt.Errorf("failed to apply merge patch: %w", err)
+			}
+			data = merged
+		}
+	}
+	e.setData(data, nil)
+	e.Lock()
+	defer e.Unlock()
+	return e.err
+}
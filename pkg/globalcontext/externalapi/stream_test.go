@@ -0,0 +1,225 @@
+package externalapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/event"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newStreamEntry(t *testing.T, url string, stream *kyvernov2alpha1.ExternalAPIStream) *entry {
+	return newStreamEntryWithStatus(t, url, stream, nil)
+}
+
+func newStreamEntryWithStatus(t *testing.T, url string, stream *kyvernov2alpha1.ExternalAPIStream, kyvernoClient versioned.Interface) *entry {
+	gce := &kyvernov2alpha1.GlobalContextEntry{
+		ObjectMeta: metav1.ObjectMeta{Name: "stream"},
+		Spec: kyvernov2alpha1.GlobalContextEntrySpec{
+			APICall: &kyvernov2alpha1.ExternalAPICall{
+				APICall: kyvernov1.APICall{
+					Method:  "GET",
+					Service: &kyvernov1.ServiceCall{URL: url},
+				},
+				RefreshInterval: &metav1.Duration{Duration: time.Minute},
+				Stream:          stream,
+			},
+			Projections: []kyvernov2alpha1.GlobalContextEntryProjection{{
+				Name:     "count",
+				JMESPath: "length(items)",
+			}},
+		},
+	}
+	if kyvernoClient != nil {
+		_, err := kyvernoClient.KyvernoV2alpha1().GlobalContextEntries().Create(context.TODO(), gce, metav1.CreateOptions{})
+		assert.NoError(t, err)
+	}
+	e, err := New(
+		context.TODO(),
+		gce,
+		event.NewFake(),
+		kyvernoClient,
+		nil,
+		logr.Discard(),
+		nil,
+		gce.Spec.APICall.APICall,
+		gce.Spec.APICall.RefreshInterval.Duration,
+		0,
+		kyvernoClient != nil,
+		jmespath.New(config.NewDefaultConfiguration(false)),
+	)
+	assert.NoError(t, err)
+	t.Cleanup(e.Stop)
+	return e.(*entry)
+}
+
+func TestStream(t *testing.T) {
+	tests := []struct {
+		name     string
+		stream   kyvernov2alpha1.ExternalAPIStream
+		messages []string
+		want     any
+		count    any
+	}{{
+		name:   "server-sent events",
+		stream: kyvernov2alpha1.ExternalAPIStream{},
+		messages: []string{
+			": comment\nevent: update\ndata: {\"items\": [\"a\"]}\n\n",
+			"id: 2\ndata: {\"items\":\ndata: [\"a\", \"b\"]}\n\n",
+		},
+		want:  map[string]any{"items": []any{"a", "b"}},
+		count: float64(2),
+	}, {
+		name:   "json lines",
+		stream: kyvernov2alpha1.ExternalAPIStream{Format: kyvernov2alpha1.StreamFormatJSONLines},
+		messages: []string{
+			"{\"items\": [\"a\"]}\n",
+			"\n{\"items\": [\"a\", \"b\", \"c\"]}\n",
+		},
+		want:  map[string]any{"items": []any{"a", "b", "c"}},
+		count: float64(3),
+	}, {
+		name: "merge patch",
+		stream: kyvernov2alpha1.ExternalAPIStream{
+			Format:         kyvernov2alpha1.StreamFormatJSONLines,
+			UpdateStrategy: kyvernov2alpha1.StreamUpdateStrategyMergePatch,
+		},
+		messages: []string{
+			"{\"items\": [\"a\"], \"version\": 1}\n",
+			"{\"version\": 2}\n",
+		},
+		want:  map[string]any{"items": []any{"a"}, "version": float64(2)},
+		count: float64(1),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			done := make(chan struct{})
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				flusher := w.(http.Flusher)
+				for _, message := range tt.messages {
+					fmt.Fprint(w, message)
+					flusher.Flush()
+				}
+				select {
+				case <-done:
+				case <-r.Context().Done():
+				}
+			}))
+			defer server.Close()
+			defer close(done)
+			e := newStreamEntry(t, server.URL, &tt.stream)
+			assert.EventuallyWithT(t, func(c *assert.CollectT) {
+				data, err := e.Get("")
+				assert.NoError(c, err)
+				assert.Equal(c, tt.want, data)
+			}, 5*time.Second, 10*time.Millisecond)
+			count, err := e.Get("count")
+			assert.NoError(t, err)
+			assert.Equal(t, tt.count, count)
+		})
+	}
+}
+
+func TestStreamReconnect(t *testing.T) {
+	var connections atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		connection := connections.Add(1)
+		if connection == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprintf(w, "{\"items\": [], \"connection\": %d}\n", connection)
+		// the second connection is closed by the server, the third one is kept open
+		if connection > 2 {
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+		}
+	}))
+	// the server is closed once the entry stopped
+	t.Cleanup(server.Close)
+	e := newStreamEntry(t, server.URL, &kyvernov2alpha1.ExternalAPIStream{
+		Format:            kyvernov2alpha1.StreamFormatJSONLines,
+		MaxReconnectDelay: &metav1.Duration{Duration: 100 * time.Millisecond},
+	})
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		data, err := e.Get("")
+		assert.NoError(c, err)
+		assert.Equal(c, map[string]any{"items": []any{}, "connection": float64(3)}, data)
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestStreamStatus(t *testing.T) {
+	var connections atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if connections.Add(1) == 1 {
+			fmt.Fprint(w, "{\"items\": []}\n")
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	kyvernoClient := fake.NewSimpleClientset()
+	e := newStreamEntryWithStatus(t, server.URL, &kyvernov2alpha1.ExternalAPIStream{
+		Format:            kyvernov2alpha1.StreamFormatJSONLines,
+		MaxReconnectDelay: &metav1.Duration{Duration: time.Minute},
+	}, kyvernoClient)
+	// once the stream is closed the entry fails until the next update, stale data isn't served
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		_, err := e.Get("")
+		assert.ErrorIs(c, err, errStreamClosed)
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		gce, err := kyvernoClient.KyvernoV2alpha1().GlobalContextEntries().Get(context.TODO(), "stream", metav1.GetOptions{})
+		assert.NoError(c, err)
+		assert.False(c, gce.Status.IsConnected())
+		assert.False(c, gce.Status.IsReady())
+		ready := meta.FindStatusCondition(gce.Status.Conditions, kyvernov2alpha1.GlobalContextEntryConditionReady)
+		if assert.NotNil(c, ready) {
+			assert.Equal(c, errStreamClosed.Error(), ready.Message)
+		}
+		assert.False(c, gce.Status.LastRefreshTime.IsZero())
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestStreamIdleTimeout(t *testing.T) {
+	var connections atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// only the first connection sends an update, the next ones stay silent
+		if connections.Add(1) == 1 {
+			fmt.Fprint(w, "{\"items\": []}\n")
+		}
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	t.Cleanup(server.Close)
+	e := newStreamEntry(t, server.URL, &kyvernov2alpha1.ExternalAPIStream{
+		Format:            kyvernov2alpha1.StreamFormatJSONLines,
+		MaxReconnectDelay: &metav1.Duration{Duration: 10 * time.Millisecond},
+		IdleTimeout:       &metav1.Duration{Duration: 100 * time.Millisecond},
+	})
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		data, err := e.Get("")
+		assert.NoError(c, err)
+		assert.Equal(c, map[string]any{"items": []any{}}, data)
+	}, 5*time.Second, 10*time.Millisecond)
+	// the silent connection times out, the entry isn't ready and the connection is established again
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		_, err := e.Get("")
+		assert.ErrorIs(c, err, errStreamIdle)
+		assert.GreaterOrEqual(c, connections.Load(), int32(3))
+	}, 5*time.Second, 10*time.Millisecond)
+}
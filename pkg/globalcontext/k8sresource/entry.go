@@ -8,12 +8,15 @@ import (
 
 	"github.com/go-logr/logr"
 	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 	"github.com/kyverno/kyverno/pkg/event"
 	entryevent "github.com/kyverno/kyverno/pkg/globalcontext/event"
 	"github.com/kyverno/kyverno/pkg/globalcontext/store"
+	"go.opentelemetry.io/otel/metric"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/dynamic"
@@ -24,15 +27,18 @@ import (
 )
 
 type entry struct {
-	lister      cache.GenericLister
 	stop        func()
 	gce         *kyvernov2alpha1.GlobalContextEntry
 	eventGen    event.Interface
 	projections []store.Projection
 	jp          jmespath.Interface
+	metrics     metric.Registration
+	logger      logr.Logger
 
 	objectsMu sync.RWMutex
 	objects   map[string]interface{}
+	sizes     map[string]int64
+	size      int64
 	projected map[string]interface{}
 }
 
@@ -42,20 +48,16 @@ func New(
 	eventGen event.Interface,
 	kubeClient kubernetes.Interface,
 	dClient dynamic.Interface,
+	kyvernoClient versioned.Interface,
 	logger logr.Logger,
 	gvr schema.GroupVersionResource,
-	namespace string,
+	resource *kyvernov2alpha1.KubernetesResource,
+	shouldUpdateStatus bool,
 	jp jmespath.Interface,
 ) (store.Entry, error) {
-	if namespace == "" {
-		namespace = metav1.NamespaceAll
-	}
-
-	factory := informers.NewSharedInformerFactoryWithOptions(kubeClient, 0, informers.WithNamespace(namespace))
-	informer, err := factory.ForResource(gvr)
+	tweakListOptions, err := listOptionsFor(resource)
 	if err != nil {
-		logger.Info("no built-in informer found, use dynamic informer", "gvr", gvr)
-		informer = dynamicinformer.NewFilteredDynamicInformer(dClient, gvr, namespace, 0, nil, nil)
+		return nil, err
 	}
 
 	var group wait.Group
@@ -66,62 +68,69 @@ func New(
 		group.Wait()
 	}
 
-	err = informer.Informer().SetWatchErrorHandler(func(r *cache.Reflector, err error) {
-		eventErr := fmt.Errorf("failed to run informer for %s", gvr)
-		eventGen.Add(entryevent.NewErrorEvent(corev1.ObjectReference{
-			APIVersion: gce.APIVersion,
-			Kind:       gce.Kind,
-			Name:       gce.Name,
-			Namespace:  gce.Namespace,
-			UID:        gce.UID,
-		}, eventErr))
-
-		stop()
-	})
+	projections, err := store.CompileProjections(jp, gce.Spec.Projections...)
 	if err != nil {
-		logger.Error(err, "failed to set watch error handler")
 		return nil, err
 	}
 
-	var projections []store.Projection
-	if len(gce.Spec.Projections) > 0 {
-		for _, p := range gce.Spec.Projections {
-			jpQuery, err := jp.Query(p.JMESPath)
-			if err != nil {
-				return nil, fmt.Errorf("failed to parse jmespath query for projection %q: %w", p.Name, err)
-			}
-			projections = append(projections, store.Projection{
-				Name: p.Name,
-				JP:   jpQuery,
-			})
-		}
-	}
-
 	e := &entry{
-		lister:      informer.Lister(),
 		stop:        stop,
 		gce:         gce,
 		eventGen:    eventGen,
 		projections: projections,
 		jp:          jp,
+		logger:      logger,
 		objects:     make(map[string]interface{}),
+		sizes:       make(map[string]int64),
 		projected:   make(map[string]interface{}),
 	}
 
-	_, err = informer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
-		AddFunc:    e.handleAdd,
-		UpdateFunc: func(oldObj, newObj interface{}) { e.handleUpdate(newObj) },
-		DeleteFunc: e.handleDelete,
-	})
-	if err != nil {
-		return nil, err
+	var informers []cache.SharedIndexInformer
+	for _, namespace := range resource.GetNamespaces() {
+		if namespace == "" {
+			namespace = metav1.NamespaceAll
+		}
+
+		informer := newInformer(logger, kubeClient, dClient, gvr, namespace, tweakListOptions)
+
+		err = informer.SetWatchErrorHandler(func(r *cache.Reflector, err error) {
+			eventErr := fmt.Errorf("failed to run informer for %s", gvr)
+			eventGen.Add(entryevent.NewErrorEvent(corev1.ObjectReference{
+				APIVersion: gce.APIVersion,
+				Kind:       gce.Kind,
+				Name:       gce.Name,
+				Namespace:  gce.Namespace,
+				UID:        gce.UID,
+			}, eventErr))
+
+			stop()
+		})
+		if err != nil {
+			logger.Error(err, "failed to set watch error handler")
+			return nil, err
+		}
+
+		_, err = informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    e.handleAdd,
+			UpdateFunc: func(oldObj, newObj interface{}) { e.handleUpdate(newObj) },
+			DeleteFunc: e.handleDelete,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		informers = append(informers, informer)
 	}
 
-	group.StartWithContext(ctx, func(ctx context.Context) {
-		informer.Informer().Run(ctx.Done())
-	})
+	hasSynced := make([]cache.InformerSynced, 0, len(informers))
+	for _, informer := range informers {
+		group.StartWithContext(ctx, func(ctx context.Context) {
+			informer.Run(ctx.Done())
+		})
+		hasSynced = append(hasSynced, informer.HasSynced)
+	}
 
-	if !cache.WaitForCacheSync(ctx.Done(), informer.Informer().HasSynced) {
+	if !cache.WaitForCacheSync(ctx.Done(), hasSynced...) {
 		stop()
 		err := fmt.Errorf("failed to sync cache for %s", gvr)
 		eventGen.Add(entryevent.NewErrorEvent(corev1.ObjectReference{
@@ -134,9 +143,74 @@ func New(
 		return nil, err
 	}
 
+	e.metrics = registerMetrics(logger, gce.Name, e.stats)
+
+	if shouldUpdateStatus {
+		group.StartWithContext(ctx, func(ctx context.Context) {
+			var reported bool
+			var lastObjects, lastSize int64
+			wait.UntilWithContext(ctx, func(ctx context.Context) {
+				objects, size := e.stats()
+				if reported && objects == lastObjects && size == lastSize {
+					return
+				}
+				if err := updateStatus(ctx, gce, kyvernoClient, objects, size); err != nil {
+					logger.Error(err, "failed to update status")
+					return
+				}
+				reported, lastObjects, lastSize = true, objects, size
+			}, statusInterval)
+		})
+	}
+
 	return e, nil
 }
 
+func newInformer(
+	logger logr.Logger,
+	kubeClient kubernetes.Interface,
+	dClient dynamic.Interface,
+	gvr schema.GroupVersionResource,
+	namespace string,
+	tweakListOptions func(*metav1.ListOptions),
+) cache.SharedIndexInformer {
+	factory := informers.NewSharedInformerFactoryWithOptions(
+		kubeClient,
+		0,
+		informers.WithNamespace(namespace),
+		informers.WithTweakListOptions(tweakListOptions),
+	)
+	informer, err := factory.ForResource(gvr)
+	if err != nil {
+		logger.Info("no built-in informer found, use dynamic informer", "gvr", gvr)
+		return dynamicinformer.NewFilteredDynamicInformer(dClient, gvr, namespace, 0, nil, tweakListOptions).Informer()
+	}
+	return informer.Informer()
+}
+
+// listOptionsFor returns a function restricting the informer list and watch calls to the selected resources
+func listOptionsFor(resource *kyvernov2alpha1.KubernetesResource) (func(*metav1.ListOptions), error) {
+	var labelSelector, fieldSelector string
+	if resource.LabelSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(resource.LabelSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid label selector: %w", err)
+		}
+		labelSelector = selector.String()
+	}
+	if resource.FieldSelector != "" {
+		selector, err := fields.ParseSelector(resource.FieldSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid field selector: %w", err)
+		}
+		fieldSelector = selector.String()
+	}
+	return func(options *metav1.ListOptions) {
+		options.LabelSelector = labelSelector
+		options.FieldSelector = fieldSelector
+	}, nil
+}
+
 func (e *entry) handleAdd(obj interface{}) {
 	key, err := cache.MetaNamespaceKeyFunc(obj)
 	if err != nil {
@@ -176,6 +250,7 @@ func (e *entry) handleAdd(obj interface{}) {
 
 	e.objectsMu.Lock()
 	e.objects[key] = data
+	e.setSize(key, int64(len(jsonData)))
 	e.objectsMu.Unlock()
 
 	e.recomputeProjections()
@@ -220,6 +295,7 @@ func (e *entry) handleUpdate(obj interface{}) {
 
 	e.objectsMu.Lock()
 	e.objects[key] = data
+	e.setSize(key, int64(len(jsonData)))
 	e.objectsMu.Unlock()
 
 	e.recomputeProjections()
@@ -245,11 +321,30 @@ func (e *entry) handleDelete(obj interface{}) {
 
 	e.objectsMu.Lock()
 	delete(e.objects, key)
+	e.setSize(key, 0)
 	e.objectsMu.Unlock()
 
 	e.recomputeProjections()
 }
 
+// setSize records the approximate size of an object, it must be called with the objects lock held
+func (e *entry) setSize(key string, size int64) {
+	e.size -= e.sizes[key]
+	if size == 0 {
+		delete(e.sizes, key)
+	} else {
+		e.sizes[key] = size
+		e.size += size
+	}
+}
+
+// stats returns the number of cached objects and their approximate size in bytes
+func (e *entry) stats() (int64, int64) {
+	e.objectsMu.RLock()
+	defer e.objectsMu.RUnlock()
+	return int64(len(e.objects)), e.size
+}
+
 func (e *entry) recomputeProjections() {
 	e.objectsMu.RLock()
 	list := make([]interface{}, 0, len(e.objects))
@@ -259,7 +354,7 @@ func (e *entry) recomputeProjections() {
 	e.objectsMu.RUnlock()
 
 	for _, proj := range e.projections {
-		result, err := proj.JP.Search(list)
+		result, err := proj.Apply(list)
 		if err != nil {
 			e.eventGen.Add(entryevent.NewErrorEvent(corev1.ObjectReference{
 				APIVersion: e.gce.APIVersion,
@@ -295,5 +390,10 @@ func (e *entry) Get(projection string) (any, error) {
 }
 
 func (e *entry) Stop() {
+	if e.metrics != nil {
+		if err := e.metrics.Unregister(); err != nil {
+			e.logger.Error(err, "failed to unregister metrics callback")
+		}
+	}
 	e.stop()
 }
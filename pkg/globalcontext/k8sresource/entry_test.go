@@ -0,0 +1,95 @@
+package k8sresource
+
+import (
+	"testing"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestListOptionsFor(t *testing.T) {
+	tests := []struct {
+		name          string
+		resource      kyvernov2alpha1.KubernetesResource
+		labelSelector string
+		fieldSelector string
+		wantErr       bool
+	}{{
+		name:     "no selectors",
+		resource: kyvernov2alpha1.KubernetesResource{Version: "v1", Resource: "configmaps"},
+	}, {
+		name: "label selector",
+		resource: kyvernov2alpha1.KubernetesResource{
+			Version:  "v1",
+			Resource: "configmaps",
+			LabelSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app.kubernetes.io/part-of": "platform"},
+			},
+		},
+		labelSelector: "app.kubernetes.io/part-of=platform",
+	}, {
+		name: "field selector",
+		resource: kyvernov2alpha1.KubernetesResource{
+			Version:       "v1",
+			Resource:      "pods",
+			FieldSelector: "status.phase=Running",
+		},
+		fieldSelector: "status.phase=Running",
+	}, {
+		name: "invalid field selector",
+		resource: kyvernov2alpha1.KubernetesResource{
+			Version:       "v1",
+			Resource:      "pods",
+			FieldSelector: "status.phase",
+		},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tweak, err := listOptionsFor(&tt.resource)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			var options metav1.ListOptions
+			tweak(&options)
+			assert.Equal(t, tt.labelSelector, options.LabelSelector)
+			assert.Equal(t, tt.fieldSelector, options.FieldSelector)
+		})
+	}
+}
+
+func TestStats(t *testing.T) {
+	e := &entry{
+		objects: make(map[string]interface{}),
+		sizes:   make(map[string]int64),
+	}
+	set := func(key string, size int64) {
+		e.objectsMu.Lock()
+		defer e.objectsMu.Unlock()
+		if size == 0 {
+			delete(e.objects, key)
+		} else {
+			e.objects[key] = struct{}{}
+		}
+		e.setSize(key, size)
+	}
+
+	set("default/a", 100)
+	set("default/b", 50)
+	objects, size := e.stats()
+	assert.Equal(t, int64(2), objects)
+	assert.Equal(t, int64(150), size)
+
+	set("default/a", 80)
+	objects, size = e.stats()
+	assert.Equal(t, int64(2), objects)
+	assert.Equal(t, int64(130), size)
+
+	set("default/b", 0)
+	objects, size = e.stats()
+	assert.Equal(t, int64(1), objects)
+	assert.Equal(t, int64(80), size)
+}
@@ -0,0 +1,46 @@
+package k8sresource
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// registerMetrics reports the number of cached objects and their approximate size for the entry,
+// the returned registration must be unregistered when the entry stops
+func registerMetrics(logger logr.Logger, name string, stats func() (int64, int64)) metric.Registration {
+	meter := otel.GetMeterProvider().Meter(metrics.MeterName)
+	objectsMetric, err := meter.Int64ObservableGauge(
+		"kyverno_global_context_entry_objects",
+		metric.WithDescription("can be used to track the number of objects cached by a global context entry"),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_global_context_entry_objects")
+		return nil
+	}
+	sizeMetric, err := meter.Int64ObservableGauge(
+		"kyverno_global_context_entry_size_bytes",
+		metric.WithDescription("can be used to track the approximate memory footprint of the objects cached by a global context entry"),
+		metric.WithUnit("By"),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_global_context_entry_size_bytes")
+		return nil
+	}
+	attributes := metric.WithAttributes(attribute.String("entry_name", name))
+	registration, err := meter.RegisterCallback(func(_ context.Context, observer metric.Observer) error {
+		objects, size := stats()
+		observer.ObserveInt64(objectsMetric, objects, attributes)
+		observer.ObserveInt64(sizeMetric, size, attributes)
+		return nil
+	}, objectsMetric, sizeMetric)
+	if err != nil {
+		logger.Error(err, "failed to register callback")
+		return nil
+	}
+	return registration
+}
@@ -0,0 +1,37 @@
+package k8sresource
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+// statusInterval is the interval at which the cache stats are reported in the entry status
+const statusInterval = time.Minute
+
+func updateStatus(ctx context.Context, gce *kyvernov2alpha1.GlobalContextEntry, kyvernoClient versioned.Interface, objects, size int64) error {
+	retryErr := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		// Fetch the latest version of the GlobalContextEntry
+		latest, err := kyvernoClient.KyvernoV2alpha1().GlobalContextEntries().Get(ctx, gce.GetName(), metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		return controllerutils.UpdateStatus(ctx, latest, kyvernoClient.KyvernoV2alpha1().GlobalContextEntries(), func(latest *kyvernov2alpha1.GlobalContextEntry) error {
+			if latest == nil {
+				return fmt.Errorf("failed to update status: %s", gce.GetName())
+			}
+			latest.Status.SetCacheStats(objects, size)
+			latest.Status.UpdateRefreshTime()
+			return nil
+		}, nil)
+	})
+
+	return retryErr
+}
@@ -1,10 +1,14 @@
 package store
 
-import "github.com/kyverno/kyverno/pkg/engine/jmespath"
+import (
+	"github.com/google/cel-go/cel"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+)
 
 type Projection struct {
 	Name string
 	JP   jmespath.Query
+	CEL  cel.Program
 }
 
 type Entry interface {
@@ -0,0 +1,58 @@
+package store
+
+import (
+	"fmt"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/cel/compiler"
+	"github.com/kyverno/kyverno/pkg/cel/utils"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"google.golang.org/protobuf/types/known/structpb"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// CompileProjections compiles the JMESPath and CEL projections of a global context entry.
+func CompileProjections(jp jmespath.Interface, projections ...kyvernov2alpha1.GlobalContextEntryProjection) ([]Projection, error) {
+	env, err := compiler.NewGlobalContextProjectionEnv()
+	if err != nil {
+		return nil, err
+	}
+	programs, errs := compiler.CompileProjections(field.NewPath("spec", "projections"), env, projections...)
+	if errs != nil {
+		return nil, errs.ToAggregate()
+	}
+	result := make([]Projection, 0, len(projections))
+	for _, p := range projections {
+		projection := Projection{
+			Name: p.Name,
+			CEL:  programs[p.Name],
+		}
+		if p.IsJMESPath() {
+			query, err := jp.Query(p.JMESPath)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse jmespath query for projection %q: %w", p.Name, err)
+			}
+			projection.JP = query
+		}
+		result = append(result, projection)
+	}
+	return result, nil
+}
+
+// Apply evaluates the projection against the cached data.
+func (p Projection) Apply(data any) (any, error) {
+	if p.CEL == nil {
+		return p.JP.Search(data)
+	}
+	out, _, err := p.CEL.Eval(map[string]any{
+		compiler.DataKey: data,
+	})
+	if err != nil {
+		return nil, err
+	}
+	value, err := utils.ConvertToNative[*structpb.Value](out)
+	if err != nil {
+		return nil, err
+	}
+	return value.AsInterface(), nil
+}
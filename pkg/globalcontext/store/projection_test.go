@@ -0,0 +1,65 @@
+package store
+
+import (
+	"testing"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompileProjections(t *testing.T) {
+	jp := jmespath.New(config.NewDefaultConfiguration(false))
+	data := []any{
+		map[string]any{"metadata": map[string]any{"name": "foo"}},
+		map[string]any{"metadata": map[string]any{"name": "bar"}},
+	}
+	tests := []struct {
+		name        string
+		projections []kyvernov2alpha1.GlobalContextEntryProjection
+		want        map[string]any
+		wantErr     bool
+	}{{
+		name: "jmespath",
+		projections: []kyvernov2alpha1.GlobalContextEntryProjection{{
+			Name:     "names",
+			JMESPath: "[].metadata.name",
+		}},
+		want: map[string]any{"names": []any{"foo", "bar"}},
+	}, {
+		name: "cel",
+		projections: []kyvernov2alpha1.GlobalContextEntryProjection{{
+			Name:       "names",
+			Expression: "data.map(o, o.metadata.name)",
+		}, {
+			Name:       "count",
+			Expression: "size(data)",
+		}},
+		want: map[string]any{"names": []any{"foo", "bar"}, "count": float64(2)},
+	}, {
+		name: "invalid cel",
+		projections: []kyvernov2alpha1.GlobalContextEntryProjection{{
+			Name:       "names",
+			Expression: "data.map(o,",
+		}},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projections, err := CompileProjections(jp, tt.projections...)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			got := map[string]any{}
+			for _, projection := range projections {
+				result, err := projection.Apply(data)
+				assert.NoError(t, err)
+				got[projection.Name] = result
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
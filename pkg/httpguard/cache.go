@@ -0,0 +1,74 @@
+package httpguard
+
+import (
+	"sync"
+	"time"
+)
+
+type cacheEntry struct {
+	data    []byte
+	expires time.Time
+}
+
+// cache is a TTL cache of response bodies, the entry closest to expiry is evicted when it is full
+type cache struct {
+	sync.Mutex
+	ttl      time.Duration
+	size     int
+	entries  map[string]cacheEntry
+	timeFunc func() time.Time
+}
+
+func newCache(ttl time.Duration, size int) *cache {
+	return &cache{
+		ttl:      ttl,
+		size:     size,
+		entries:  make(map[string]cacheEntry),
+		timeFunc: time.Now,
+	}
+}
+
+func (c *cache) get(key string) ([]byte, bool) {
+	c.Lock()
+	defer c.Unlock()
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !c.timeFunc().Before(entry.expires) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return entry.data, true
+}
+
+func (c *cache) set(key string, data []byte) {
+	c.Lock()
+	defer c.Unlock()
+	now := c.timeFunc()
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= c.size {
+		c.evict(now)
+	}
+	c.entries[key] = cacheEntry{
+		data:    data,
+		expires: now.Add(c.ttl),
+	}
+}
+
+// evict removes the expired entries, or the entry closest to expiry if none expired
+func (c *cache) evict(now time.Time) {
+	var oldestKey string
+	var oldest time.Time
+	for key, entry := range c.entries {
+		if !now.Before(entry.expires) {
+			delete(c.entries, key)
+			continue
+		}
+		if oldestKey == "" || entry.expires.Before(oldest) {
+			oldestKey, oldest = key, entry.expires
+		}
+	}
+	if len(c.entries) >= c.size && oldestKey != "" {
+		delete(c.entries, oldestKey)
+	}
+}
@@ -0,0 +1,152 @@
+package httpguard
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+)
+
+const (
+	EngineCEL      = "cel"
+	EngineJMESPath = "jmespath"
+)
+
+var defaultGuard Guard = New(Options{})
+
+// Default returns the guard shared by the policy engines.
+func Default() Guard { return defaultGuard }
+
+// SetDefault replaces the guard shared by the policy engines, it is meant to be called once at startup.
+func SetDefault(guard Guard) { defaultGuard = guard }
+
+// Guard bounds the HTTP calls made while evaluating policies.
+type Guard interface {
+	// Do returns the cached response for the request if any, otherwise it waits for the rate limiter
+	// of the destination host and runs the call with the request timeout.
+	Do(ctx context.Context, request Request, call func(context.Context) ([]byte, error)) ([]byte, error)
+}
+
+// Request describes an HTTP call made by a policy engine.
+type Request struct {
+	// Engine is the policy engine making the call, used in metrics.
+	Engine string
+	Method string
+	URL    string
+	Body   []byte
+	// Headers are the request headers, they are part of the cache key as they can carry credentials.
+	Headers map[string]string
+	// CABundle is the CA bundle used to validate the server certificate, it is part of the cache key.
+	CABundle string
+	// Timeout overrides the default timeout of the guard when not zero.
+	Timeout time.Duration
+}
+
+// Options configures a guard, zero values disable the corresponding control.
+type Options struct {
+	// Timeout is the default timeout of a call, including the time spent waiting for the rate limiter.
+	Timeout time.Duration
+	// CacheTTL is the duration a successful response is cached for.
+	CacheTTL time.Duration
+	// CacheSize is the maximum number of cached responses.
+	CacheSize int
+	// QPS is the number of calls per second allowed per destination host.
+	QPS float64
+	// Burst is the maximum burst of calls allowed per destination host.
+	Burst int
+}
+
+type guard struct {
+	timeout  time.Duration
+	cache    *cache
+	limiters *limiters
+	metrics  guardMetrics
+}
+
+func New(options Options) Guard {
+	g := &guard{
+		timeout: options.Timeout,
+		metrics: newMetrics(),
+	}
+	if options.CacheTTL > 0 && options.CacheSize > 0 {
+		g.cache = newCache(options.CacheTTL, options.CacheSize)
+	}
+	if options.QPS > 0 {
+		burst := options.Burst
+		if burst <= 0 {
+			burst = 1
+		}
+		g.limiters = newLimiters(options.QPS, burst)
+	}
+	return g
+}
+
+func (g *guard) Do(ctx context.Context, request Request, call func(context.Context) ([]byte, error)) ([]byte, error) {
+	key := cacheKey(request)
+	if g.cache != nil {
+		data, ok := g.cache.get(key)
+		g.metrics.recordCacheLookup(ctx, request.Engine, ok)
+		if ok {
+			return data, nil
+		}
+	}
+	timeout := g.timeout
+	if request.Timeout > 0 {
+		timeout = request.Timeout
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	host := hostOf(request.URL)
+	if g.limiters != nil {
+		if err := g.limiters.wait(ctx, host); err != nil {
+			return nil, fmt.Errorf("rate limit exceeded for host %s: %w", host, err)
+		}
+	}
+	start := time.Now()
+	data, err := call(ctx)
+	g.metrics.recordRequest(ctx, request.Engine, host, time.Since(start), err)
+	if err != nil {
+		return nil, err
+	}
+	if g.cache != nil {
+		g.cache.set(key, data)
+	}
+	return data, nil
+}
+
+func cacheKey(request Request) string {
+	hash := sha256.New()
+	hash.Write([]byte(request.Method))
+	hash.Write([]byte{0})
+	hash.Write([]byte(request.URL))
+	hash.Write([]byte{0})
+	hash.Write(request.Body)
+	hash.Write([]byte{0})
+	names := make([]string, 0, len(request.Headers))
+	for name := range request.Headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		hash.Write([]byte(name))
+		hash.Write([]byte{0})
+		hash.Write([]byte(request.Headers[name]))
+		hash.Write([]byte{0})
+	}
+	hash.Write([]byte(request.CABundle))
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func hostOf(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return rawURL
+	}
+	return u.Host
+}
@@ -0,0 +1,162 @@
+package httpguard
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func counter(calls *int, err error) func(context.Context) ([]byte, error) {
+	return func(context.Context) ([]byte, error) {
+		*calls++
+		if err != nil {
+			return nil, err
+		}
+		return []byte(`{"ok":true}`), nil
+	}
+}
+
+func TestGuardCache(t *testing.T) {
+	g := New(Options{CacheTTL: time.Minute, CacheSize: 10})
+	request := Request{Engine: EngineCEL, Method: "GET", URL: "https://svc.kyverno/data"}
+	var calls int
+	for i := 0; i < 3; i++ {
+		data, err := g.Do(context.TODO(), request, counter(&calls, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, `{"ok":true}`, string(data))
+	}
+	assert.Equal(t, 1, calls)
+	// a different body is a different key
+	request.Method = "POST"
+	request.Body = []byte(`{"a":1}`)
+	_, err := g.Do(context.TODO(), request, counter(&calls, nil))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+}
+
+func TestGuardCacheKeyCredentials(t *testing.T) {
+	g := New(Options{CacheTTL: time.Minute, CacheSize: 10})
+	request := Request{Engine: EngineCEL, Method: "GET", URL: "https://svc.kyverno/data", Headers: map[string]string{"Authorization": "Bearer a"}}
+	var calls int
+	_, err := g.Do(context.TODO(), request, counter(&calls, nil))
+	assert.NoError(t, err)
+	// other credentials are a different key
+	request.Headers = map[string]string{"Authorization": "Bearer b"}
+	_, err = g.Do(context.TODO(), request, counter(&calls, nil))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+	// other trust is a different key
+	request.CABundle = "bundle"
+	_, err = g.Do(context.TODO(), request, counter(&calls, nil))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+	// same headers and trust hit the cache
+	_, err = g.Do(context.TODO(), request, counter(&calls, nil))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+}
+
+func TestGuardCacheSkipsErrors(t *testing.T) {
+	g := New(Options{CacheTTL: time.Minute, CacheSize: 10})
+	request := Request{Engine: EngineJMESPath, Method: "GET", URL: "https://svc.kyverno/data"}
+	var calls int
+	for i := 0; i < 2; i++ {
+		_, err := g.Do(context.TODO(), request, counter(&calls, errors.New("HTTP 500")))
+		assert.Error(t, err)
+	}
+	assert.Equal(t, 2, calls)
+}
+
+func TestGuardWithoutCache(t *testing.T) {
+	g := New(Options{})
+	request := Request{Engine: EngineCEL, Method: "GET", URL: "https://svc.kyverno/data"}
+	var calls int
+	for i := 0; i < 2; i++ {
+		_, err := g.Do(context.TODO(), request, counter(&calls, nil))
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 2, calls)
+}
+
+func TestGuardTimeout(t *testing.T) {
+	g := New(Options{Timeout: time.Hour})
+	request := Request{Engine: EngineCEL, Method: "GET", URL: "https://svc.kyverno/data", Timeout: 10 * time.Millisecond}
+	_, err := g.Do(context.TODO(), request, func(ctx context.Context) ([]byte, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestGuardRateLimit(t *testing.T) {
+	g := New(Options{QPS: 0.001, Burst: 1, Timeout: 50 * time.Millisecond})
+	request := Request{Engine: EngineCEL, Method: "GET", URL: "https://svc.kyverno/data"}
+	var calls int
+	_, err := g.Do(context.TODO(), request, counter(&calls, nil))
+	assert.NoError(t, err)
+	// the bucket of the host is empty
+	_, err = g.Do(context.TODO(), request, counter(&calls, nil))
+	assert.ErrorContains(t, err, "rate limit exceeded for host svc.kyverno")
+	// other hosts have their own bucket
+	request.URL = "https://other.kyverno/data"
+	_, err = g.Do(context.TODO(), request, counter(&calls, nil))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+}
+
+func TestCacheExpiryAndEviction(t *testing.T) {
+	now := time.Now()
+	c := newCache(time.Minute, 2)
+	c.timeFunc = func() time.Time { return now }
+	c.set("a", []byte("a"))
+	now = now.Add(time.Second)
+	c.set("b", []byte("b"))
+	now = now.Add(time.Second)
+	c.set("c", []byte("c"))
+	_, ok := c.get("a")
+	assert.False(t, ok, "oldest entry should be evicted")
+	_, ok = c.get("b")
+	assert.True(t, ok)
+	now = now.Add(time.Minute)
+	_, ok = c.get("c")
+	assert.False(t, ok, "entry should be expired")
+}
+
+func TestPolicyTimeout(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        time.Duration
+		wantErr     bool
+	}{{
+		name: "not set",
+	}, {
+		name:        "valid",
+		annotations: map[string]string{kyverno.AnnotationPolicyHTTPTimeout: "3s"},
+		want:        3 * time.Second,
+	}, {
+		name:        "invalid",
+		annotations: map[string]string{kyverno.AnnotationPolicyHTTPTimeout: "soon"},
+		wantErr:     true,
+	}, {
+		name:        "negative",
+		annotations: map[string]string{kyverno.AnnotationPolicyHTTPTimeout: "-1s"},
+		wantErr:     true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PolicyTimeout(&metav1.ObjectMeta{Annotations: tt.annotations})
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
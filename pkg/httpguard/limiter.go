@@ -0,0 +1,39 @@
+package httpguard
+
+import (
+	"context"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// limiters holds a token bucket per destination host
+type limiters struct {
+	sync.Mutex
+	qps      rate.Limit
+	burst    int
+	limiters map[string]*rate.Limiter
+}
+
+func newLimiters(qps float64, burst int) *limiters {
+	return &limiters{
+		qps:      rate.Limit(qps),
+		burst:    burst,
+		limiters: make(map[string]*rate.Limiter),
+	}
+}
+
+func (l *limiters) wait(ctx context.Context, host string) error {
+	return l.get(host).Wait(ctx)
+}
+
+func (l *limiters) get(host string) *rate.Limiter {
+	l.Lock()
+	defer l.Unlock()
+	limiter, ok := l.limiters[host]
+	if !ok {
+		limiter = rate.NewLimiter(l.qps, l.burst)
+		l.limiters[host] = limiter
+	}
+	return limiter
+}
@@ -0,0 +1,67 @@
+package httpguard
+
+import (
+	"context"
+	"time"
+
+	"github.com/kyverno/kyverno/pkg/logging"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+type guardMetrics struct {
+	cacheLookups    metric.Int64Counter
+	requestDuration metric.Float64Histogram
+}
+
+func newMetrics() guardMetrics {
+	logger := logging.WithName("http-guard")
+	meter := otel.GetMeterProvider().Meter(metrics.MeterName)
+	cacheLookups, err := meter.Int64Counter(
+		"kyverno_http_cache_lookups",
+		metric.WithDescription("can be used to track the hit ratio of the response cache of HTTP calls made by policies"),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_http_cache_lookups")
+	}
+	requestDuration, err := meter.Float64Histogram(
+		"kyverno_http_request_duration_seconds",
+		metric.WithDescription("can be used to track the latency of HTTP calls made by policies"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_http_request_duration_seconds")
+	}
+	return guardMetrics{
+		cacheLookups:    cacheLookups,
+		requestDuration: requestDuration,
+	}
+}
+
+func (m guardMetrics) recordCacheLookup(ctx context.Context, engine string, hit bool) {
+	if m.cacheLookups == nil {
+		return
+	}
+	m.cacheLookups.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("engine", engine),
+		attribute.Bool("cache_hit", hit),
+	))
+}
+
+func (m guardMetrics) recordRequest(ctx context.Context, engine string, host string, duration time.Duration, err error) {
+	if m.requestDuration == nil {
+		return
+	}
+	status := "success"
+	if err != nil {
+		status = "error"
+	}
+	// the request context may be expired, metrics must still be recorded
+	m.requestDuration.Record(context.WithoutCancel(ctx), duration.Seconds(), metric.WithAttributes(
+		attribute.String("engine", engine),
+		attribute.String("host", host),
+		attribute.String("status", status),
+	))
+}
@@ -0,0 +1,23 @@
+package httpguard
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PolicyTimeout returns the timeout of the HTTP calls made by a policy, set with the
+// policies.kyverno.io/http-timeout annotation. It returns zero when the annotation is not set.
+func PolicyTimeout(policy metav1.Object) (time.Duration, error) {
+	value, ok := policy.GetAnnotations()[kyverno.AnnotationPolicyHTTPTimeout]
+	if !ok {
+		return 0, nil
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout <= 0 {
+		return 0, fmt.Errorf("invalid %s annotation %q, it must be a positive duration", kyverno.AnnotationPolicyHTTPTimeout, value)
+	}
+	return timeout, nil
+}
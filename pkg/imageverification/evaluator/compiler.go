@@ -2,6 +2,7 @@ package eval
 
 import (
 	"github.com/google/cel-go/cel"
+	"github.com/kyverno/kyverno/api/kyverno"
 	"github.com/kyverno/kyverno/api/policies.kyverno.io/v1alpha1"
 	policiesv1alpha1 "github.com/kyverno/kyverno/api/policies.kyverno.io/v1alpha1"
 	engine "github.com/kyverno/kyverno/pkg/cel/compiler"
@@ -12,8 +13,10 @@ import (
 	"github.com/kyverno/kyverno/pkg/cel/libs/imageverify"
 	"github.com/kyverno/kyverno/pkg/cel/libs/resource"
 	"github.com/kyverno/kyverno/pkg/cel/libs/user"
+	"github.com/kyverno/kyverno/pkg/httpguard"
 	"github.com/kyverno/kyverno/pkg/imageverification/imagedataloader"
 	ivpolvar "github.com/kyverno/kyverno/pkg/imageverification/variables"
+	"github.com/kyverno/kyverno/pkg/imageverifycache"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	apiservercel "k8s.io/apiserver/pkg/cel"
@@ -24,22 +27,29 @@ type Compiler interface {
 	Compile(*policiesv1alpha1.ImageValidatingPolicy, []*policiesv1alpha1.PolicyException) (CompiledPolicy, field.ErrorList)
 }
 
-func NewCompiler(ictx imagedataloader.ImageContext, lister k8scorev1.SecretInterface, reqGVR *metav1.GroupVersionResource) Compiler {
+func NewCompiler(ictx imagedataloader.ImageContext, lister k8scorev1.SecretInterface, reqGVR *metav1.GroupVersionResource, ivCache imageverifycache.Client) Compiler {
 	return &compiler{
-		ictx:   ictx,
-		lister: lister,
-		reqGVR: reqGVR,
+		ictx:    ictx,
+		lister:  lister,
+		reqGVR:  reqGVR,
+		ivCache: ivCache,
 	}
 }
 
 type compiler struct {
-	ictx   imagedataloader.ImageContext
-	lister k8scorev1.SecretInterface
-	reqGVR *metav1.GroupVersionResource
+	ictx    imagedataloader.ImageContext
+	lister  k8scorev1.SecretInterface
+	reqGVR  *metav1.GroupVersionResource
+	ivCache imageverifycache.Client
 }
 
 func (c *compiler) Compile(ivpolicy *policiesv1alpha1.ImageValidatingPolicy, exceptions []*policiesv1alpha1.PolicyException) (CompiledPolicy, field.ErrorList) {
 	var allErrs field.ErrorList
+	httpTimeout, err := httpguard.PolicyTimeout(ivpolicy)
+	if err != nil {
+		path := field.NewPath("metadata", "annotations").Key(kyverno.AnnotationPolicyHTTPTimeout)
+		return nil, field.ErrorList{field.Invalid(path, ivpolicy.GetAnnotations()[kyverno.AnnotationPolicyHTTPTimeout], err.Error())}
+	}
 	base, err := engine.NewBaseEnv()
 	if err != nil {
 		return nil, append(allErrs, field.InternalError(nil, err))
@@ -76,7 +86,7 @@ func (c *compiler) Compile(ivpolicy *policiesv1alpha1.ImageValidatingPolicy, exc
 		return nil, append(allErrs, field.InternalError(nil, err))
 	}
 	options = append(options, declOptions...)
-	options = append(options, globalcontext.Lib(), http.Lib(), image.Lib(), imagedata.Lib(), imageverify.Lib(c.ictx, ivpolicy, c.lister), resource.Lib(), user.Lib())
+	options = append(options, globalcontext.Lib(), http.Lib(), image.Lib(), imagedata.Lib(), imageverify.Lib(c.ictx, ivpolicy, c.lister, c.ivCache), resource.Lib(), user.Lib())
 	env, err := base.Extend(options...)
 	if err != nil {
 		return nil, append(allErrs, field.InternalError(nil, err))
@@ -173,6 +183,7 @@ func (c *compiler) Compile(ivpolicy *policiesv1alpha1.ImageValidatingPolicy, exc
 		creds:                ivpolicy.Spec.Credentials,
 		exceptions:           compiledExceptions,
 		variables:            variables,
+		httpTimeout:          httpTimeout,
 	}, nil
 }
 
@@ -37,7 +37,7 @@ func Evaluate(ctx context.Context, ivpols []*CompiledImageValidatingPolicy, requ
 
 	policies := filterPolicies(ivpols, isAdmissionRequest)
 
-	c := NewCompiler(ictx, lister, gvr)
+	c := NewCompiler(ictx, lister, gvr, nil)
 	results := make(map[string]*EvaluationResult, len(policies))
 	for _, ivpol := range policies {
 		p, errList := c.Compile(ivpol.Policy, ivpol.Exceptions)
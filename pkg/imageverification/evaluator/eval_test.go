@@ -23,7 +23,7 @@ var (
 
 	ivpol = &policiesv1alpha1.ImageValidatingPolicy{
 		Spec: policiesv1alpha1.ImageValidatingPolicySpec{
-			EvaluationConfiguration: &policiesv1alpha1.EvaluationConfiguration{
+			EvaluationConfiguration: &policiesv1alpha1.ImageValidatingPolicyEvaluationConfiguration{
 				Mode: policiesv1alpha1.EvaluationModeJSON,
 			},
 			MatchImageReferences: []policiesv1alpha1.MatchImageReference{
@@ -93,7 +93,7 @@ func Validate(ivpol *policiesv1alpha1.ImageValidatingPolicy, lister k8scorev1.Se
 		return nil, nil
 	}
 
-	compiler := NewCompiler(ictx, lister, nil)
+	compiler := NewCompiler(ictx, lister, nil, nil)
 	_, err := compiler.Compile(ivpol, nil)
 	if err == nil {
 		return nil, nil
@@ -2,11 +2,14 @@ package imageverifycache
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"time"
 
 	"github.com/dgraph-io/ristretto"
 	"github.com/go-logr/logr"
-	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const (
@@ -19,6 +22,7 @@ type cache struct {
 	isCacheEnabled bool
 	maxSize        int64
 	ttl            time.Duration
+	storePath      string
 	cache          *ristretto.Cache
 }
 
@@ -31,6 +35,9 @@ func New(options ...Option) (Client, error) {
 			return nil, err
 		}
 	}
+	if cache.storePath != "" {
+		return newPersistentCache(cache)
+	}
 	config := ristretto.Config{
 		MaxCost:     cache.maxSize,
 		NumCounters: 10 * cache.maxSize,
@@ -87,11 +94,37 @@ func WithTTLDuration(t time.Duration) Option {
 	}
 }
 
-func generateKey(policy kyvernov1.PolicyInterface, ruleName string, imageRef string) string {
-	return string(policy.GetUID()) + ";" + policy.GetResourceVersion() + ";" + ruleName + ";" + imageRef
+// WithPersistentStore makes the cache persist verified images in a file at the given path,
+// entries survive restarts and can be shared by replicas mounting the same volume.
+// An empty path keeps the in-memory cache.
+func WithPersistentStore(path string) Option {
+	return func(c *cache) error {
+		c.storePath = path
+		return nil
+	}
+}
+
+func hashAttestors(attestors any) (string, error) {
+	if attestors == nil {
+		return "", nil
+	}
+	data, err := json.Marshal(attestors)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
 }
 
-func (c *cache) Set(ctx context.Context, policy kyvernov1.PolicyInterface, ruleName string, imageRef string, useCache bool) (bool, error) {
+func generateKey(policy metav1.Object, ruleName string, attestors any, imageRef string) (string, error) {
+	attestorsHash, err := hashAttestors(attestors)
+	if err != nil {
+		return "", err
+	}
+	return string(policy.GetUID()) + ";" + policy.GetResourceVersion() + ";" + ruleName + ";" + attestorsHash + ";" + imageRef, nil
+}
+
+func (c *cache) Set(ctx context.Context, policy metav1.Object, ruleName string, attestors any, imageRef string, useCache bool) (bool, error) {
 	if !c.isCacheEnabled {
 		// If cache is globally disabled just return
 		return false, nil
@@ -99,7 +132,10 @@ func (c *cache) Set(ctx context.Context, policy kyvernov1.PolicyInterface, ruleN
 		// Else If enabled globally then return if locally disabled
 		return false, nil
 	}
-	key := generateKey(policy, ruleName, imageRef)
+	key, err := generateKey(policy, ruleName, attestors, imageRef)
+	if err != nil {
+		return false, err
+	}
 
 	stored := c.cache.SetWithTTL(key, nil, 1, c.ttl)
 	c.cache.Wait()
@@ -109,7 +145,7 @@ func (c *cache) Set(ctx context.Context, policy kyvernov1.PolicyInterface, ruleN
 	return false, nil
 }
 
-func (c *cache) Get(ctx context.Context, policy kyvernov1.PolicyInterface, ruleName string, imageRef string, useCache bool) (bool, error) {
+func (c *cache) Get(ctx context.Context, policy metav1.Object, ruleName string, attestors any, imageRef string, useCache bool) (bool, error) {
 	if !c.isCacheEnabled {
 		// If cache is globally disabled just return
 		return false, nil
@@ -117,7 +153,10 @@ func (c *cache) Get(ctx context.Context, policy kyvernov1.PolicyInterface, ruleN
 		// Else If enabled globally then return if locally disabled
 		return false, nil
 	}
-	key := generateKey(policy, ruleName, imageRef)
+	key, err := generateKey(policy, ruleName, attestors, imageRef)
+	if err != nil {
+		return false, err
+	}
 	_, found := c.cache.Get(key)
 	if found {
 		return true, nil
@@ -0,0 +1,292 @@
+package imageverifycache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// defaultFlushInterval is the delay after which the changes made to the cache are written to the store
+	defaultFlushInterval = time.Second
+	// defaultRefreshInterval is the interval at which the entries written to the store by other processes are read
+	defaultRefreshInterval = 10 * time.Second
+)
+
+// persistentCache stores verified images in a file so that entries survive restarts.
+// Entries are indexed by policy, rule and image, the policy resource version and the attestors hash
+// are recorded with the entry and a mismatch invalidates it.
+// Changes are batched and written periodically, the store is locked and read again before every write
+// so that processes sharing it don't overwrite each other entries. The entries written by other processes
+// are picked up when the store is refreshed periodically.
+type persistentCache struct {
+	logger          logr.Logger
+	isCacheEnabled  bool
+	maxSize         int64
+	ttl             time.Duration
+	path            string
+	flushInterval   time.Duration
+	refreshInterval time.Duration
+
+	lock    sync.Mutex
+	modTime time.Time
+	entries map[string]persistentEntry
+	// pending are the changes not written to the store yet, a nil entry is a deletion
+	pending   map[string]*persistentEntry
+	scheduled bool
+	timeFunc  func() time.Time
+}
+
+type persistentEntry struct {
+	ResourceVersion string    `json:"resourceVersion"`
+	AttestorsHash   string    `json:"attestorsHash,omitempty"`
+	Expires         time.Time `json:"expires"`
+}
+
+type persistentStore struct {
+	Entries map[string]persistentEntry `json:"entries"`
+}
+
+func newPersistentCache(c *cache) (Client, error) {
+	pc := &persistentCache{
+		logger:          c.logger,
+		isCacheEnabled:  c.isCacheEnabled,
+		maxSize:         c.maxSize,
+		ttl:             c.ttl,
+		path:            c.storePath,
+		flushInterval:   defaultFlushInterval,
+		refreshInterval: defaultRefreshInterval,
+		entries:         map[string]persistentEntry{},
+		pending:         map[string]*persistentEntry{},
+		timeFunc:        time.Now,
+	}
+	if pc.maxSize <= 0 {
+		pc.maxSize = defaultMaxSize
+	}
+	if pc.ttl <= 0 {
+		pc.ttl = defaultTTL
+	}
+	if !pc.isCacheEnabled {
+		return pc, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(pc.path), 0o755); err != nil {
+		return nil, err
+	}
+	if err := pc.load(false); err != nil {
+		return nil, err
+	}
+	go pc.refresh()
+	return pc, nil
+}
+
+func generateEntryKey(policy metav1.Object, ruleName string, imageRef string) string {
+	return string(policy.GetUID()) + ";" + ruleName + ";" + imageRef
+}
+
+func (c *persistentCache) Set(ctx context.Context, policy metav1.Object, ruleName string, attestors any, imageRef string, useCache bool) (bool, error) {
+	if !c.isCacheEnabled {
+		// If cache is globally disabled just return
+		return false, nil
+	} else if !useCache {
+		// Else If enabled globally then return if locally disabled
+		return false, nil
+	}
+	attestorsHash, err := hashAttestors(attestors)
+	if err != nil {
+		return false, err
+	}
+	key := generateEntryKey(policy, ruleName, imageRef)
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if _, ok := c.entries[key]; !ok && int64(len(c.entries)) >= c.maxSize {
+		c.evict()
+	}
+	entry := persistentEntry{
+		ResourceVersion: policy.GetResourceVersion(),
+		AttestorsHash:   attestorsHash,
+		Expires:         c.timeFunc().Add(c.ttl),
+	}
+	c.entries[key] = entry
+	c.pending[key] = &entry
+	c.schedule()
+	return true, nil
+}
+
+func (c *persistentCache) Get(ctx context.Context, policy metav1.Object, ruleName string, attestors any, imageRef string, useCache bool) (bool, error) {
+	if !c.isCacheEnabled {
+		// If cache is globally disabled just return
+		return false, nil
+	} else if !useCache {
+		// Else If enabled globally then return if locally disabled
+		return false, nil
+	}
+	attestorsHash, err := hashAttestors(attestors)
+	if err != nil {
+		return false, err
+	}
+	key := generateEntryKey(policy, ruleName, imageRef)
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	entry, found := c.entries[key]
+	if !found {
+		return false, nil
+	}
+	if c.timeFunc().After(entry.Expires) || entry.ResourceVersion != policy.GetResourceVersion() || entry.AttestorsHash != attestorsHash {
+		c.logger.V(4).Info("invalidating cache entry", "policy", policy.GetName(), "ruleName", ruleName, "imageRef", imageRef)
+		delete(c.entries, key)
+		c.pending[key] = nil
+		c.schedule()
+		return false, nil
+	}
+	return true, nil
+}
+
+// evict removes expired entries, and the entry closest to expiration when none expired.
+func (c *persistentCache) evict() {
+	now := c.timeFunc()
+	var oldest string
+	for key, entry := range c.entries {
+		if now.After(entry.Expires) {
+			delete(c.entries, key)
+		} else if oldest == "" || entry.Expires.Before(c.entries[oldest].Expires) {
+			oldest = key
+		}
+	}
+	if int64(len(c.entries)) >= c.maxSize && oldest != "" {
+		delete(c.entries, oldest)
+	}
+}
+
+// schedule writes the pending changes to the store once the flush interval elapsed.
+func (c *persistentCache) schedule() {
+	if c.scheduled {
+		return
+	}
+	c.scheduled = true
+	time.AfterFunc(c.flushInterval, func() {
+		if err := c.flush(); err != nil {
+			c.logger.Error(err, "failed to write image verify cache store", "path", c.path)
+		}
+	})
+}
+
+// refresh periodically reads the entries written to the store by other processes sharing it.
+func (c *persistentCache) refresh() {
+	ticker := time.NewTicker(c.refreshInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		if err := c.reload(); err != nil {
+			c.logger.Error(err, "failed to read image verify cache store", "path", c.path)
+		}
+	}
+}
+
+// reload merges the entries of the store if it changed since it was last read.
+func (c *persistentCache) reload() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.load(false)
+}
+
+// flush writes the pending changes to the store, the store is locked and the entries written
+// by other processes are merged before it is replaced.
+func (c *persistentCache) flush() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.scheduled = false
+	if len(c.pending) == 0 {
+		return nil
+	}
+	unlock, err := lockFile(c.path + ".lock")
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	if err := c.load(true); err != nil {
+		return err
+	}
+	for key, entry := range c.pending {
+		if entry == nil {
+			delete(c.entries, key)
+		} else {
+			c.entries[key] = *entry
+		}
+	}
+	for int64(len(c.entries)) > c.maxSize {
+		c.evict()
+	}
+	if err := c.persist(); err != nil {
+		return err
+	}
+	c.pending = map[string]*persistentEntry{}
+	return nil
+}
+
+// load merges the entries found in the store file, if it changed since it was last read or force is set.
+func (c *persistentCache) load(force bool) error {
+	info, err := os.Stat(c.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if !force && !info.ModTime().After(c.modTime) {
+		return nil
+	}
+	data, err := os.ReadFile(c.path)
+	if err != nil {
+		return err
+	}
+	var store persistentStore
+	if err := json.Unmarshal(data, &store); err != nil {
+		// a corrupted store only costs a new verification, don't fail on it
+		c.logger.Error(err, "failed to decode image verify cache store, ignoring it", "path", c.path)
+		c.modTime = info.ModTime()
+		return nil
+	}
+	now := c.timeFunc()
+	for key, entry := range store.Entries {
+		if now.After(entry.Expires) {
+			continue
+		}
+		if existing, ok := c.entries[key]; !ok || existing.Expires.Before(entry.Expires) {
+			c.entries[key] = entry
+		}
+	}
+	c.modTime = info.ModTime()
+	return nil
+}
+
+// persist atomically replaces the store file with the current entries.
+func (c *persistentCache) persist() error {
+	data, err := json.Marshal(persistentStore{Entries: c.entries})
+	if err != nil {
+		return err
+	}
+	file, err := os.CreateTemp(filepath.Dir(c.path), filepath.Base(c.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(file.Name(), c.path); err != nil {
+		return err
+	}
+	if info, err := os.Stat(c.path); err == nil {
+		c.modTime = info.ModTime()
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package imageverifycache
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newTestPolicy(resourceVersion string) *kyvernov1.ClusterPolicy {
+	return &kyvernov1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "check-images",
+			UID:             "8d3b6b2a-0f0b-4a7e-9c4e-1f2a3b4c5d6e",
+			ResourceVersion: resourceVersion,
+		},
+	}
+}
+
+func newTestPersistentCache(t *testing.T, path string, ttl time.Duration) Client {
+	client, err := New(
+		WithCacheEnableFlag(true),
+		WithMaxSize(2),
+		WithTTLDuration(ttl),
+		WithPersistentStore(path),
+	)
+	assert.NoError(t, err)
+	// changes are flushed explicitly
+	client.(*persistentCache).flushInterval = time.Hour
+	return client
+}
+
+func flush(t *testing.T, client Client) {
+	assert.NoError(t, client.(*persistentCache).flush())
+}
+
+func TestPersistentCache_SurvivesRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache", "imageverify.json")
+	policy := newTestPolicy("1")
+	attestors := []string{"key-1"}
+	client := newTestPersistentCache(t, path, time.Hour)
+	set, err := client.Set(context.TODO(), policy, "verify", attestors, "ghcr.io/kyverno/test:v1", true)
+	assert.NoError(t, err)
+	assert.True(t, set)
+	// writes are batched until the cache is flushed
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+	flush(t, client)
+	_, err = os.Stat(path)
+	assert.NoError(t, err)
+	// a new client reads the entries written by the previous one
+	client = newTestPersistentCache(t, path, time.Hour)
+	found, err := client.Get(context.TODO(), policy, "verify", attestors, "ghcr.io/kyverno/test:v1", true)
+	assert.NoError(t, err)
+	assert.True(t, found)
+	found, err = client.Get(context.TODO(), policy, "verify", attestors, "ghcr.io/kyverno/test:v2", true)
+	assert.NoError(t, err)
+	assert.False(t, found)
+	found, err = client.Get(context.TODO(), policy, "verify", attestors, "ghcr.io/kyverno/test:v1", false)
+	assert.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestPersistentCache_Invalidation(t *testing.T) {
+	tests := []struct {
+		name            string
+		resourceVersion string
+		attestors       any
+	}{{
+		name:            "policy changed",
+		resourceVersion: "2",
+		attestors:       []string{"key-1"},
+	}, {
+		name:            "attestors changed",
+		resourceVersion: "1",
+		attestors:       []string{"key-2"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "imageverify.json")
+			client := newTestPersistentCache(t, path, time.Hour)
+			_, err := client.Set(context.TODO(), newTestPolicy("1"), "verify", []string{"key-1"}, "ghcr.io/kyverno/test:v1", true)
+			assert.NoError(t, err)
+			found, err := client.Get(context.TODO(), newTestPolicy(tt.resourceVersion), "verify", tt.attestors, "ghcr.io/kyverno/test:v1", true)
+			assert.NoError(t, err)
+			assert.False(t, found)
+			// the stale entry is removed from the store
+			flush(t, client)
+			client = newTestPersistentCache(t, path, time.Hour)
+			found, err = client.Get(context.TODO(), newTestPolicy("1"), "verify", []string{"key-1"}, "ghcr.io/kyverno/test:v1", true)
+			assert.NoError(t, err)
+			assert.False(t, found)
+		})
+	}
+}
+
+func TestPersistentCache_Expiration(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "imageverify.json")
+	client := newTestPersistentCache(t, path, time.Hour)
+	pc := client.(*persistentCache)
+	now := time.Now()
+	pc.timeFunc = func() time.Time { return now }
+	policy := newTestPolicy("1")
+	_, err := client.Set(context.TODO(), policy, "verify", nil, "ghcr.io/kyverno/test:v1", true)
+	assert.NoError(t, err)
+	now = now.Add(2 * time.Hour)
+	found, err := client.Get(context.TODO(), policy, "verify", nil, "ghcr.io/kyverno/test:v1", true)
+	assert.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestPersistentCache_MaxSize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "imageverify.json")
+	client := newTestPersistentCache(t, path, time.Hour)
+	policy := newTestPolicy("1")
+	for _, image := range []string{"ghcr.io/kyverno/test:v1", "ghcr.io/kyverno/test:v2", "ghcr.io/kyverno/test:v3"} {
+		_, err := client.Set(context.TODO(), policy, "verify", nil, image, true)
+		assert.NoError(t, err)
+	}
+	assert.Len(t, client.(*persistentCache).entries, 2)
+	found, err := client.Get(context.TODO(), policy, "verify", nil, "ghcr.io/kyverno/test:v3", true)
+	assert.NoError(t, err)
+	assert.True(t, found)
+}
+
+func TestPersistentCache_SharedStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "imageverify.json")
+	policy := newTestPolicy("1")
+	first := newTestPersistentCache(t, path, time.Hour)
+	second := newTestPersistentCache(t, path, time.Hour)
+	_, err := first.Set(context.TODO(), policy, "verify", nil, "ghcr.io/kyverno/test:v1", true)
+	assert.NoError(t, err)
+	_, err = second.Set(context.TODO(), policy, "verify", nil, "ghcr.io/kyverno/test:v2", true)
+	assert.NoError(t, err)
+	flush(t, first)
+	// the store is read again before it is written, entries written by the first cache are kept
+	flush(t, second)
+	client := newTestPersistentCache(t, path, time.Hour)
+	for _, image := range []string{"ghcr.io/kyverno/test:v1", "ghcr.io/kyverno/test:v2"} {
+		found, err := client.Get(context.TODO(), policy, "verify", nil, image, true)
+		assert.NoError(t, err)
+		assert.True(t, found, image)
+	}
+}
+
+func TestPersistentCache_Flush(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "imageverify.json")
+	client := newTestPersistentCache(t, path, time.Hour)
+	client.(*persistentCache).flushInterval = 10 * time.Millisecond
+	_, err := client.Set(context.TODO(), newTestPolicy("1"), "verify", nil, "ghcr.io/kyverno/test:v1", true)
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		_, err := os.Stat(path)
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestPersistentCache_Refresh(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "imageverify.json")
+	policy := newTestPolicy("1")
+	first := newTestPersistentCache(t, path, time.Hour)
+	second := newTestPersistentCache(t, path, time.Hour)
+	_, err := first.Set(context.TODO(), policy, "verify", nil, "ghcr.io/kyverno/test:v1", true)
+	assert.NoError(t, err)
+	flush(t, first)
+	// a miss doesn't read the store
+	found, err := second.Get(context.TODO(), policy, "verify", nil, "ghcr.io/kyverno/test:v1", true)
+	assert.NoError(t, err)
+	assert.False(t, found)
+	// entries written by other processes are picked up when the store is refreshed
+	assert.NoError(t, second.(*persistentCache).reload())
+	found, err = second.Get(context.TODO(), policy, "verify", nil, "ghcr.io/kyverno/test:v1", true)
+	assert.NoError(t, err)
+	assert.True(t, found)
+}
@@ -248,8 +248,9 @@ func (pc *policyController) updatePolicy(old, new interface{}) {
 		if datautils.DeepEqual(oldgpol.Spec, newgpol.Spec) {
 			return
 		}
-		// If the policy is updated to disable synchronization, we need to remove the watchers.
-		if oldgpol.Spec.SynchronizationEnabled() && !newgpol.Spec.SynchronizationEnabled() {
+		// If the policy is updated to disable synchronization or drift detection, we need to remove the watchers.
+		if (oldgpol.Spec.SynchronizationEnabled() && !newgpol.Spec.SynchronizationEnabled()) ||
+			(oldgpol.Spec.DriftDetectionEnabled() && !newgpol.Spec.DriftDetectionEnabled()) {
 			logger.V(2).Info("removing watchers for generating policy", "name", oldgpol.GetName())
 			pc.watchManager.RemoveWatchersForPolicy(oldgpol.GetName(), false)
 		}
@@ -157,6 +157,11 @@ func extractBadValues(excludeFieldErr *field.Error) []string {
 	return excludeBadValues
 }
 
+// BadValues returns the bad values of a field error as strings
+func BadValues(fieldErr *field.Error) []string {
+	return extractBadValues(fieldErr)
+}
+
 func remove(s *field.ErrorList, i int) {
 	(*s)[i] = (*s)[len(*s)-1]
 	*s = (*s)[:len(*s)-1]
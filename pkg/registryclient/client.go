@@ -123,6 +123,14 @@ func WithAllowInsecureRegistry() Option {
 	}
 }
 
+// WithKeychain provides initialize registry client option that allows to use the given keychain.
+func WithKeychain(keychain authn.Keychain) Option {
+	return func(c *config) error {
+		c.keychain = append(c.keychain, keychain)
+		return nil
+	}
+}
+
 // WithLocalKeychain provides initialize keychain with the default local keychain.
 func WithLocalKeychain() Option {
 	return func(c *config) error {
@@ -0,0 +1,178 @@
+package resultsink
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+// BatchOptions configures how records are batched and delivered by the sinks
+type BatchOptions struct {
+	// BatchSize is the maximum number of records delivered at once
+	BatchSize int
+	// FlushInterval is the maximum duration a record waits before being delivered
+	FlushInterval time.Duration
+	// QueueSize is the maximum number of pending records, records are dropped when the queue is full
+	QueueSize int
+	// MaxRetries is the number of times a failed delivery is retried before the batch is dropped
+	MaxRetries int
+	// RetryBackoff is the initial wait between retries, it doubles after every attempt
+	RetryBackoff time.Duration
+}
+
+func (o BatchOptions) validate() error {
+	if o.BatchSize <= 0 {
+		return fmt.Errorf("invalid batch size %d", o.BatchSize)
+	}
+	if o.FlushInterval <= 0 {
+		return fmt.Errorf("invalid flush interval %s", o.FlushInterval)
+	}
+	if o.QueueSize <= 0 {
+		return fmt.Errorf("invalid queue size %d", o.QueueSize)
+	}
+	if o.MaxRetries < 0 {
+		return fmt.Errorf("invalid max retries %d", o.MaxRetries)
+	}
+	return nil
+}
+
+// permanentError is returned by exporters when retrying a delivery can't succeed
+type permanentError struct {
+	error
+}
+
+func (e permanentError) Unwrap() error {
+	return e.error
+}
+
+type exportFunc func(context.Context, []Record) error
+
+type batchSink struct {
+	logger  logr.Logger
+	options BatchOptions
+	export  exportFunc
+	queue   chan Record
+	stop    chan struct{}
+	done    chan struct{}
+	ctx     context.Context
+	cancel  context.CancelFunc
+	once    sync.Once
+}
+
+func newBatchSink(logger logr.Logger, options BatchOptions, export exportFunc) (*batchSink, error) {
+	if err := options.validate(); err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &batchSink{
+		logger:  logger,
+		options: options,
+		export:  export,
+		queue:   make(chan Record, options.QueueSize),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+	go s.run()
+	return s, nil
+}
+
+func (s *batchSink) Send(_ context.Context, records ...Record) error {
+	dropped := 0
+	for _, record := range records {
+		select {
+		case <-s.stop:
+			return errors.New("sink is closed")
+		default:
+		}
+		select {
+		case s.queue <- record:
+		default:
+			dropped++
+		}
+	}
+	if dropped != 0 {
+		return fmt.Errorf("queue is full, %d records dropped", dropped)
+	}
+	return nil
+}
+
+// Close stops accepting records and waits until the pending ones are delivered,
+// in-flight deliveries are cancelled when ctx is done
+func (s *batchSink) Close(ctx context.Context) error {
+	s.once.Do(func() {
+		close(s.stop)
+	})
+	defer s.cancel()
+	select {
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		s.cancel()
+		<-s.done
+		return ctx.Err()
+	}
+}
+
+func (s *batchSink) run() {
+	defer close(s.done)
+	ticker := time.NewTicker(s.options.FlushInterval)
+	defer ticker.Stop()
+	batch := make([]Record, 0, s.options.BatchSize)
+	flush := func() {
+		if len(batch) != 0 {
+			s.deliver(batch)
+			batch = make([]Record, 0, s.options.BatchSize)
+		}
+	}
+	for {
+		select {
+		case record := <-s.queue:
+			batch = append(batch, record)
+			if len(batch) >= s.options.BatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-s.stop:
+			for {
+				select {
+				case record := <-s.queue:
+					batch = append(batch, record)
+					if len(batch) >= s.options.BatchSize {
+						flush()
+					}
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+func (s *batchSink) deliver(batch []Record) {
+	backoff := s.options.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		err := s.export(s.ctx, batch)
+		if err == nil {
+			return
+		}
+		var permanent permanentError
+		if attempt >= s.options.MaxRetries || errors.As(err, &permanent) || s.ctx.Err() != nil {
+			s.logger.Error(err, "failed to deliver results, dropping batch", "records", len(batch), "attempts", attempt+1)
+			return
+		}
+		s.logger.V(3).Info("failed to deliver results, retrying", "records", len(batch), "attempt", attempt+1, "reason", err.Error())
+		select {
+		case <-time.After(backoff):
+		case <-s.ctx.Done():
+		}
+		backoff *= 2
+	}
+}
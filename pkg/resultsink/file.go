@@ -0,0 +1,52 @@
+package resultsink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"github.com/go-logr/logr"
+	osutils "github.com/kyverno/kyverno/pkg/utils/os"
+	"go.uber.org/multierr"
+)
+
+type fileSink struct {
+	*batchSink
+	file *osutils.RotatingFile
+}
+
+// NewFileSink returns a Sink appending records as JSON lines to a file,
+// the file is rotated when it exceeds maxSize bytes and at most maxBackups rotated files are kept.
+// Records are written in batches by a background routine so that senders never wait on the disk.
+func NewFileSink(logger logr.Logger, path string, maxSize int64, maxBackups int, options BatchOptions) (Sink, error) {
+	file, err := osutils.NewRotatingFile(path, maxSize, maxBackups)
+	if err != nil {
+		return nil, err
+	}
+	batch, err := newBatchSink(logger, options, func(_ context.Context, records []Record) error {
+		return writeRecords(file, records)
+	})
+	if err != nil {
+		return nil, multierr.Combine(err, file.Close())
+	}
+	return &fileSink{batchSink: batch, file: file}, nil
+}
+
+func writeRecords(file *osutils.RotatingFile, records []Record) error {
+	for _, record := range records {
+		var buffer bytes.Buffer
+		// the encoder terminates every record with a new line
+		if err := json.NewEncoder(&buffer).Encode(record); err != nil {
+			return permanentError{err}
+		}
+		if _, err := file.Write(buffer.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close writes the pending records and closes the file
+func (s *fileSink) Close(ctx context.Context) error {
+	return multierr.Combine(s.batchSink.Close(ctx), s.file.Close())
+}
@@ -0,0 +1,43 @@
+package resultsink
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"gotest.tools/assert"
+)
+
+func readRecords(t *testing.T, path string) []Record {
+	file, err := os.Open(path)
+	assert.NilError(t, err)
+	defer file.Close()
+	var records []Record
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var record Record
+		assert.NilError(t, json.Unmarshal(scanner.Bytes(), &record))
+		records = append(records, record)
+	}
+	assert.NilError(t, scanner.Err())
+	return records
+}
+
+func Test_FileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results", "results.jsonl")
+	line, err := json.Marshal(newRecord("a"))
+	assert.NilError(t, err)
+	// two records fit in a file
+	sink, err := NewFileSink(logr.Discard(), path, int64(2*(len(line)+1)), 1, testBatchOptions())
+	assert.NilError(t, err)
+	assert.NilError(t, sink.Send(context.TODO(), newRecord("a"), newRecord("b"), newRecord("c")))
+	assert.NilError(t, sink.Close(context.TODO()))
+	assert.DeepEqual(t, readRecords(t, path+".1"), []Record{newRecord("a"), newRecord("b")})
+	assert.DeepEqual(t, readRecords(t, path), []Record{newRecord("c")})
+	_, err = os.Stat(path + ".2")
+	assert.Assert(t, os.IsNotExist(err))
+}
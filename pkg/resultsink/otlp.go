@@ -0,0 +1,107 @@
+package resultsink
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/go-logr/logr"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	otlpScopeName = "github.com/kyverno/kyverno/pkg/resultsink"
+	otlpEventName = "kyverno.policy.result"
+)
+
+// NewOTLPSink returns a Sink exporting batches of records as OTLP log records to an OTLP/HTTP logs endpoint,
+// typically http://<collector>:4318/v1/logs
+func NewOTLPSink(logger logr.Logger, endpoint string, serviceName string, headers map[string]string, client *http.Client, options BatchOptions) (Sink, error) {
+	if endpoint == "" {
+		return nil, fmt.Errorf("otlp endpoint must not be empty")
+	}
+	resource := &resourcepb.Resource{
+		Attributes: []*commonpb.KeyValue{stringAttribute("service.name", serviceName)},
+	}
+	return newBatchSink(logger, options, func(ctx context.Context, records []Record) error {
+		body, err := proto.Marshal(toExportLogsServiceRequest(resource, records))
+		if err != nil {
+			return permanentError{err}
+		}
+		return post(ctx, client, endpoint, "application/x-protobuf", headers, body)
+	})
+}
+
+func toExportLogsServiceRequest(resource *resourcepb.Resource, records []Record) *collogspb.ExportLogsServiceRequest {
+	observed := uint64(time.Now().UnixNano()) // #nosec G115
+	logRecords := make([]*logspb.LogRecord, 0, len(records))
+	for _, record := range records {
+		severityNumber, severityText := otlpSeverity(record.Result)
+		attributes := []*commonpb.KeyValue{
+			stringAttribute("kyverno.source", string(record.Source)),
+			stringAttribute("kyverno.policy", record.Policy),
+			stringAttribute("kyverno.rule", record.Rule),
+			stringAttribute("kyverno.result", record.Result),
+			stringAttribute("k8s.resource.api_version", record.Resource.APIVersion),
+			stringAttribute("k8s.resource.kind", record.Resource.Kind),
+			stringAttribute("k8s.resource.name", record.Resource.Name),
+		}
+		for _, attribute := range [][2]string{
+			{"kyverno.operation", record.Operation},
+			{"kyverno.severity", record.Severity},
+			{"kyverno.category", record.Category},
+			{"k8s.namespace.name", record.Resource.Namespace},
+			{"k8s.resource.uid", record.Resource.UID},
+		} {
+			if attribute[1] != "" {
+				attributes = append(attributes, stringAttribute(attribute[0], attribute[1]))
+			}
+		}
+		for _, key := range slices.Sorted(maps.Keys(record.Properties)) {
+			attributes = append(attributes, stringAttribute("kyverno.property."+key, record.Properties[key]))
+		}
+		logRecords = append(logRecords, &logspb.LogRecord{
+			TimeUnixNano:         uint64(record.Timestamp.UnixNano()), // #nosec G115
+			ObservedTimeUnixNano: observed,
+			SeverityNumber:       severityNumber,
+			SeverityText:         severityText,
+			EventName:            otlpEventName,
+			Body:                 &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: record.Message}},
+			Attributes:           attributes,
+		})
+	}
+	return &collogspb.ExportLogsServiceRequest{
+		ResourceLogs: []*logspb.ResourceLogs{{
+			Resource: resource,
+			ScopeLogs: []*logspb.ScopeLogs{{
+				Scope:      &commonpb.InstrumentationScope{Name: otlpScopeName},
+				LogRecords: logRecords,
+			}},
+		}},
+	}
+}
+
+func otlpSeverity(result string) (logspb.SeverityNumber, string) {
+	switch result {
+	case "error":
+		return logspb.SeverityNumber_SEVERITY_NUMBER_ERROR, "ERROR"
+	case "fail", "warn":
+		return logspb.SeverityNumber_SEVERITY_NUMBER_WARN, "WARN"
+	default:
+		return logspb.SeverityNumber_SEVERITY_NUMBER_INFO, "INFO"
+	}
+}
+
+func stringAttribute(key, value string) *commonpb.KeyValue {
+	return &commonpb.KeyValue{
+		Key:   key,
+		Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: value}},
+	}
+}
@@ -0,0 +1,58 @@
+package resultsink
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	"google.golang.org/protobuf/proto"
+	"gotest.tools/assert"
+)
+
+func Test_OTLPSink(t *testing.T) {
+	var request collogspb.ExportLogsServiceRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, r.URL.Path, "/v1/logs")
+		assert.Equal(t, r.Header.Get("Content-Type"), "application/x-protobuf")
+		body, err := io.ReadAll(r.Body)
+		assert.NilError(t, err)
+		assert.NilError(t, proto.Unmarshal(body, &request))
+	}))
+	defer server.Close()
+	sink, err := NewOTLPSink(logr.Discard(), server.URL+"/v1/logs", "kyverno", nil, server.Client(), testBatchOptions())
+	assert.NilError(t, err)
+	record := newRecord("a")
+	record.Properties = map[string]string{"process": "admission review"}
+	assert.NilError(t, sink.Send(context.TODO(), record))
+	assert.NilError(t, sink.Close(context.TODO()))
+	assert.Equal(t, len(request.ResourceLogs), 1)
+	resourceLogs := request.ResourceLogs[0]
+	assert.Equal(t, resourceLogs.Resource.Attributes[0].Key, "service.name")
+	assert.Equal(t, resourceLogs.Resource.Attributes[0].Value.GetStringValue(), "kyverno")
+	assert.Equal(t, len(resourceLogs.ScopeLogs), 1)
+	assert.Equal(t, len(resourceLogs.ScopeLogs[0].LogRecords), 1)
+	logRecord := resourceLogs.ScopeLogs[0].LogRecords[0]
+	assert.Equal(t, logRecord.SeverityNumber, logspb.SeverityNumber_SEVERITY_NUMBER_WARN)
+	assert.Equal(t, logRecord.Body.GetStringValue(), "label team is required")
+	attributes := map[string]string{}
+	for _, attribute := range logRecord.Attributes {
+		attributes[attribute.Key] = attribute.Value.GetStringValue()
+	}
+	assert.DeepEqual(t, attributes, map[string]string{
+		"kyverno.source":           "admission",
+		"kyverno.policy":           "require-labels",
+		"kyverno.rule":             "check-team",
+		"kyverno.result":           "fail",
+		"kyverno.operation":        "CREATE",
+		"kyverno.property.process": "admission review",
+		"k8s.resource.api_version": "v1",
+		"k8s.resource.kind":        "Pod",
+		"k8s.resource.name":        "a",
+		"k8s.namespace.name":       "default",
+	})
+}
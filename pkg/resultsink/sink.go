@@ -0,0 +1,119 @@
+package resultsink
+
+import (
+	"context"
+	"time"
+
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	"go.uber.org/multierr"
+)
+
+// Source identifies the component that produced a result
+type Source string
+
+const (
+	// SourceAdmission is used for results produced by the admission webhooks
+	SourceAdmission Source = "admission"
+	// SourceBackground is used for results produced by the background scan
+	SourceBackground Source = "background"
+)
+
+// Resource identifies the resource a result applies to
+type Resource struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+	UID        string `json:"uid,omitempty"`
+}
+
+// Record is a single policy rule result streamed to the sinks
+type Record struct {
+	Timestamp  time.Time         `json:"timestamp"`
+	Source     Source            `json:"source"`
+	Operation  string            `json:"operation,omitempty"`
+	Resource   Resource          `json:"resource"`
+	Policy     string            `json:"policy"`
+	Rule       string            `json:"rule,omitempty"`
+	Result     string            `json:"result"`
+	Severity   string            `json:"severity,omitempty"`
+	Category   string            `json:"category,omitempty"`
+	Message    string            `json:"message,omitempty"`
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+// Sink receives the policy results produced by admission and background scanning
+type Sink interface {
+	// Send hands records over to the sink, it must not block on the destination
+	Send(context.Context, ...Record) error
+	// Close flushes the pending records and releases the resources held by the sink
+	Close(context.Context) error
+}
+
+// FromEngineResponses converts engine responses to records, one record per rule result
+func FromEngineResponses(source Source, operation string, responses ...engineapi.EngineResponse) []Record {
+	var records []Record
+	now := time.Now().UTC()
+	for _, response := range responses {
+		resource := Resource{
+			APIVersion: response.Resource.GetAPIVersion(),
+			Kind:       response.Resource.GetKind(),
+			Namespace:  response.Resource.GetNamespace(),
+			Name:       response.Resource.GetName(),
+			UID:        string(response.Resource.GetUID()),
+		}
+		for _, result := range reportutils.EngineResponseToReportResults(response) {
+			records = append(records, Record{
+				Timestamp:  now,
+				Source:     source,
+				Operation:  operation,
+				Resource:   resource,
+				Policy:     result.Policy,
+				Rule:       result.Rule,
+				Result:     string(result.Result),
+				Severity:   string(result.Severity),
+				Category:   result.Category,
+				Message:    result.Description,
+				Properties: result.Properties,
+			})
+		}
+	}
+	return records
+}
+
+type multiSink []Sink
+
+// NewMultiSink returns a Sink forwarding records to all the given sinks, it returns nil when no sink is given
+func NewMultiSink(sinks ...Sink) Sink {
+	var s multiSink
+	for _, sink := range sinks {
+		if sink != nil {
+			s = append(s, sink)
+		}
+	}
+	switch len(s) {
+	case 0:
+		return nil
+	case 1:
+		return s[0]
+	default:
+		return s
+	}
+}
+
+func (s multiSink) Send(ctx context.Context, records ...Record) error {
+	var errs []error
+	for _, sink := range s {
+		errs = append(errs, sink.Send(ctx, records...))
+	}
+	return multierr.Combine(errs...)
+}
+
+func (s multiSink) Close(ctx context.Context) error {
+	var errs []error
+	for _, sink := range s {
+		errs = append(errs, sink.Close(ctx))
+	}
+	return multierr.Combine(errs...)
+}
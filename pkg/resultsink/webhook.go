@@ -0,0 +1,53 @@
+package resultsink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/go-logr/logr"
+)
+
+// NewWebhookSink returns a Sink posting batches of records as a JSON array to an HTTP endpoint,
+// failed deliveries are retried unless the endpoint rejects the batch with a client error
+func NewWebhookSink(logger logr.Logger, url string, headers map[string]string, client *http.Client, options BatchOptions) (Sink, error) {
+	if url == "" {
+		return nil, fmt.Errorf("webhook url must not be empty")
+	}
+	return newBatchSink(logger, options, func(ctx context.Context, records []Record) error {
+		body, err := json.Marshal(records)
+		if err != nil {
+			return permanentError{err}
+		}
+		return post(ctx, client, url, "application/json", headers, body)
+	})
+}
+
+func post(ctx context.Context, client *http.Client, url string, contentType string, headers map[string]string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return permanentError{err}
+	}
+	req.Header.Set("Content-Type", contentType)
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	err = fmt.Errorf("unexpected status code %d from %s", resp.StatusCode, url)
+	// client errors won't go away by retrying, except for throttling
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != http.StatusTooManyRequests {
+		return permanentError{err}
+	}
+	return err
+}
@@ -0,0 +1,167 @@
+package resultsink
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"gotest.tools/assert"
+)
+
+func newRecord(name string) Record {
+	return Record{
+		Timestamp: time.Unix(0, 0).UTC(),
+		Source:    SourceAdmission,
+		Operation: "CREATE",
+		Resource:  Resource{APIVersion: "v1", Kind: "Pod", Namespace: "default", Name: name},
+		Policy:    "require-labels",
+		Rule:      "check-team",
+		Result:    "fail",
+		Message:   "label team is required",
+	}
+}
+
+func testBatchOptions() BatchOptions {
+	return BatchOptions{
+		BatchSize:     2,
+		FlushInterval: time.Hour,
+		QueueSize:     10,
+		MaxRetries:    2,
+		RetryBackoff:  time.Millisecond,
+	}
+}
+
+func Test_WebhookSink(t *testing.T) {
+	var lock sync.Mutex
+	var batches [][]Record
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, r.Header.Get("Content-Type"), "application/json")
+		assert.Equal(t, r.Header.Get("Authorization"), "Bearer token")
+		var batch []Record
+		assert.NilError(t, json.NewDecoder(r.Body).Decode(&batch))
+		lock.Lock()
+		defer lock.Unlock()
+		batches = append(batches, batch)
+	}))
+	defer server.Close()
+	sink, err := NewWebhookSink(logr.Discard(), server.URL, map[string]string{"Authorization": "Bearer token"}, server.Client(), testBatchOptions())
+	assert.NilError(t, err)
+	assert.NilError(t, sink.Send(context.TODO(), newRecord("a"), newRecord("b"), newRecord("c")))
+	// closing flushes the incomplete batch
+	assert.NilError(t, sink.Close(context.TODO()))
+	assert.Equal(t, len(batches), 2)
+	assert.DeepEqual(t, batches[0], []Record{newRecord("a"), newRecord("b")})
+	assert.DeepEqual(t, batches[1], []Record{newRecord("c")})
+	assert.ErrorContains(t, sink.Send(context.TODO(), newRecord("d")), "closed")
+}
+
+func Test_WebhookSink_FlushInterval(t *testing.T) {
+	delivered := make(chan []Record, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var batch []Record
+		assert.NilError(t, json.NewDecoder(r.Body).Decode(&batch))
+		delivered <- batch
+	}))
+	defer server.Close()
+	options := testBatchOptions()
+	options.FlushInterval = 10 * time.Millisecond
+	sink, err := NewWebhookSink(logr.Discard(), server.URL, nil, server.Client(), options)
+	assert.NilError(t, err)
+	defer sink.Close(context.TODO())
+	assert.NilError(t, sink.Send(context.TODO(), newRecord("a")))
+	select {
+	case batch := <-delivered:
+		assert.DeepEqual(t, batch, []Record{newRecord("a")})
+	case <-time.After(5 * time.Second):
+		t.Fatal("batch was not flushed")
+	}
+}
+
+func Test_WebhookSink_Retry(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		attempts int32
+	}{{
+		name:     "server error is retried",
+		status:   http.StatusServiceUnavailable,
+		attempts: 3,
+	}, {
+		name:     "throttling is retried",
+		status:   http.StatusTooManyRequests,
+		attempts: 3,
+	}, {
+		name:     "client error is not retried",
+		status:   http.StatusBadRequest,
+		attempts: 1,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempts.Add(1)
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+			sink, err := NewWebhookSink(logr.Discard(), server.URL, nil, server.Client(), testBatchOptions())
+			assert.NilError(t, err)
+			assert.NilError(t, sink.Send(context.TODO(), newRecord("a")))
+			assert.NilError(t, sink.Close(context.TODO()))
+			assert.Equal(t, attempts.Load(), tt.attempts)
+		})
+	}
+}
+
+func Test_WebhookSink_RecoversAfterFailure(t *testing.T) {
+	var attempts atomic.Int32
+	var received []Record
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		assert.NilError(t, json.NewDecoder(r.Body).Decode(&received))
+	}))
+	defer server.Close()
+	sink, err := NewWebhookSink(logr.Discard(), server.URL, nil, server.Client(), testBatchOptions())
+	assert.NilError(t, err)
+	assert.NilError(t, sink.Send(context.TODO(), newRecord("a"), newRecord("b")))
+	assert.NilError(t, sink.Close(context.TODO()))
+	assert.Equal(t, attempts.Load(), int32(2))
+	assert.DeepEqual(t, received, []Record{newRecord("a"), newRecord("b")})
+}
+
+func Test_WebhookSink_QueueFull(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	options := testBatchOptions()
+	options.BatchSize = 1
+	options.QueueSize = 1
+	sink, err := NewWebhookSink(logr.Discard(), server.URL, nil, server.Client(), options)
+	assert.NilError(t, err)
+	records := make([]Record, 10)
+	for i := range records {
+		records[i] = newRecord("a")
+	}
+	assert.ErrorContains(t, sink.Send(context.TODO(), records...), "records dropped")
+	close(release)
+	assert.NilError(t, sink.Close(context.TODO()))
+}
+
+func Test_NewMultiSink(t *testing.T) {
+	assert.Assert(t, NewMultiSink() == nil)
+	assert.Assert(t, NewMultiSink(nil, nil) == nil)
+	file, err := NewFileSink(logr.Discard(), t.TempDir()+"/results.jsonl", 1024, 1, testBatchOptions())
+	assert.NilError(t, err)
+	assert.Equal(t, NewMultiSink(nil, file), file)
+	assert.NilError(t, file.Close(context.TODO()))
+}
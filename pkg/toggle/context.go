@@ -14,6 +14,7 @@ type Toggles interface {
 	GenerateMutatingAdmissionPolicy() bool
 	DumpMutatePatches() bool
 	AutogenV2() bool
+	RequireExceptionApproval() bool
 }
 
 type defaultToggles struct{}
@@ -46,6 +47,10 @@ func (defaultToggles) AutogenV2() bool {
 	return AutogenV2.enabled()
 }
 
+func (defaultToggles) RequireExceptionApproval() bool {
+	return RequireExceptionApproval.enabled()
+}
+
 type contextKey struct{}
 
 func NewContext(ctx context.Context, toggles Toggles) context.Context {
@@ -41,6 +41,11 @@ const (
 	AutogenV2Description = "Set the flag to 'true', to enable autogen v2."
 	autogenV2EnvVar      = "FLAG_AUTOGEN_V2"
 	defaultAutogenV2     = false
+	// require exception approval
+	RequireExceptionApprovalFlagName    = "requireExceptionApproval"
+	RequireExceptionApprovalDescription = "Set the flag to 'true', to only apply policy exceptions that have been approved."
+	requireExceptionApprovalEnvVar      = "FLAG_REQUIRE_EXCEPTION_APPROVAL"
+	defaultRequireExceptionApproval     = false
 )
 
 var (
@@ -51,6 +56,7 @@ var (
 	GenerateMutatingAdmissionPolicy   = newToggle(defaultGenerateMutatingAdmissionPolicy, generateMutatingAdmissionPolicyEnvVar)
 	DumpMutatePatches                 = newToggle(defaultDumpMutatePatches, dumpMutatePatchesEnvVar)
 	AutogenV2                         = newToggle(defaultAutogenV2, autogenV2EnvVar)
+	RequireExceptionApproval          = newToggle(defaultRequireExceptionApproval, requireExceptionApprovalEnvVar)
 )
 
 type ToggleFlag interface {
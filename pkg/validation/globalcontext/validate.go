@@ -5,11 +5,23 @@ import (
 
 	"github.com/go-logr/logr"
 	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/cel/compiler"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 // Validate checks global context entry is valid
 func Validate(ctx context.Context, logger logr.Logger, gctx *kyvernov2alpha1.GlobalContextEntry) ([]string, error) {
 	var warnings []string
 	errs := gctx.Validate()
+	errs = append(errs, validateProjections(field.NewPath("spec", "projections"), gctx.Spec.Projections)...)
 	return warnings, errs.ToAggregate()
 }
+
+func validateProjections(path *field.Path, projections []kyvernov2alpha1.GlobalContextEntryProjection) field.ErrorList {
+	env, err := compiler.NewGlobalContextProjectionEnv()
+	if err != nil {
+		return field.ErrorList{field.InternalError(path, err)}
+	}
+	_, errs := compiler.CompileProjections(path, env, projections...)
+	return errs
+}
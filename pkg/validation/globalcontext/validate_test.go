@@ -59,6 +59,22 @@ func Test_Validate(t *testing.T) {
 			want:    0,
 			wantErr: false,
 		},
+		{
+			name: "GlobalContextEntry with a CEL projection",
+			args: args{
+				resource: []byte(`{"apiVersion":"kyverno.io/v2alpha1","kind":"GlobalContextEntry","metadata":{"name":"gce-kubernetesresource"},"spec":{"kubernetesResource":{"version":"v1","resource":"namespaces"},"projections":[{"name":"names","expression":"data.map(ns, ns.metadata.name)"}]}}`),
+			},
+			want:    0,
+			wantErr: false,
+		},
+		{
+			name: "GlobalContextEntry with an invalid CEL projection",
+			args: args{
+				resource: []byte(`{"apiVersion":"kyverno.io/v2alpha1","kind":"GlobalContextEntry","metadata":{"name":"gce-kubernetesresource"},"spec":{"kubernetesResource":{"version":"v1","resource":"namespaces"},"projections":[{"name":"names","expression":"object.map(ns, ns.metadata.name)"}]}}`),
+			},
+			want:    0,
+			wantErr: true,
+		},
 	}
 	for _, c := range tc {
 		t.Run(c.name, func(t *testing.T) {
@@ -25,6 +25,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/engine/variables"
 	"github.com/kyverno/kyverno/pkg/engine/variables/operator"
 	"github.com/kyverno/kyverno/pkg/engine/variables/regex"
+	"github.com/kyverno/kyverno/pkg/httpguard"
 	"github.com/kyverno/kyverno/pkg/logging"
 	datautils "github.com/kyverno/kyverno/pkg/utils/data"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
@@ -159,6 +160,10 @@ func Validate(policy, oldPolicy kyvernov1.PolicyInterface, client dclient.Interf
 		return warnings, err
 	}
 
+	if _, err := httpguard.PolicyTimeout(policy); err != nil {
+		return warnings, err
+	}
+
 	getClusteredResources := func(invalidate bool) (sets.Set[string], error) {
 		clusterResources := sets.New[string]()
 		// Get all the cluster type kind supported by cluster
@@ -2,31 +2,49 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
 	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
 	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	admissionv1 "k8s.io/api/admission/v1"
 	authenticationv1 "k8s.io/api/authentication/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 )
 
+// DumpRecord is a redacted admission request recorded together with the webhook response
+type DumpRecord struct {
+	Time     metav1.Time              `json:"time"`
+	Request  *AdmissionRequestPayload `json:"request"`
+	Response AdmissionResponse        `json:"response"`
+}
+
+// DumpWriter records admission requests, see NewDumpFileWriter and NewDumpDirWriter
+type DumpWriter interface {
+	Write(DumpRecord) error
+}
+
+// WithDump logs the admission payloads when enabled and records them using the writer when not nil
 func (inner AdmissionHandler) WithDump(
 	enabled bool,
+	writer DumpWriter,
 ) AdmissionHandler {
-	if !enabled {
+	if !enabled && writer == nil {
 		return inner
 	}
-	return inner.withDump().WithTrace("DUMP")
+	return inner.withDump(enabled, writer).WithTrace("DUMP")
 }
 
-func (inner AdmissionHandler) withDump() AdmissionHandler {
+func (inner AdmissionHandler) withDump(enabled bool, writer DumpWriter) AdmissionHandler {
 	return func(ctx context.Context, logger logr.Logger, request AdmissionRequest, startTime time.Time) AdmissionResponse {
 		response := inner(ctx, logger, request, startTime)
-		dumpPayload(logger, request, response)
+		dumpPayload(logger, request, response, enabled, writer)
 		return response
 	}
 }
@@ -35,18 +53,31 @@ func dumpPayload(
 	logger logr.Logger,
 	request AdmissionRequest,
 	response AdmissionResponse,
+	enabled bool,
+	writer DumpWriter,
 ) {
 	reqPayload, err := newAdmissionRequestPayload(request)
 	if err != nil {
 		logger.Error(err, "Failed to extract resources")
-	} else {
-		logger = logger.WithValues("admission.response", response, "admission.request", reqPayload)
-		logger.V(4).Info("admission request dump")
+		return
+	}
+	if enabled {
+		logger.WithValues("admission.response", response, "admission.request", reqPayload).V(4).Info("admission request dump")
+	}
+	if writer != nil {
+		record := DumpRecord{
+			Time:     metav1.Now(),
+			Request:  reqPayload,
+			Response: redactResponse(reqPayload, response),
+		}
+		if err := writer.Write(record); err != nil {
+			logger.Error(err, "Failed to record admission request")
+		}
 	}
 }
 
-// admissionRequestPayload holds a copy of the AdmissionRequest payload
-type admissionRequestPayload struct {
+// AdmissionRequestPayload holds a copy of the AdmissionRequest payload
+type AdmissionRequestPayload struct {
 	UID                types.UID                    `json:"uid"`
 	Kind               metav1.GroupVersionKind      `json:"kind"`
 	Resource           metav1.GroupVersionResource  `json:"resource"`
@@ -68,7 +99,7 @@ type admissionRequestPayload struct {
 
 func newAdmissionRequestPayload(
 	request AdmissionRequest,
-) (*admissionRequestPayload, error) {
+) (*AdmissionRequestPayload, error) {
 	newResource, oldResource, err := admissionutils.ExtractResources(nil, request.AdmissionRequest)
 	if err != nil {
 		return nil, err
@@ -80,7 +111,7 @@ func newAdmissionRequestPayload(
 			return nil, err
 		}
 	}
-	return redactPayload(&admissionRequestPayload{
+	return redactPayload(&AdmissionRequestPayload{
 		UID:                request.UID,
 		Kind:               request.Kind,
 		Resource:           request.Resource,
@@ -101,7 +132,7 @@ func newAdmissionRequestPayload(
 	})
 }
 
-func redactPayload(payload *admissionRequestPayload) (*admissionRequestPayload, error) {
+func redactPayload(payload *AdmissionRequestPayload) (*AdmissionRequestPayload, error) {
 	if strings.EqualFold(payload.Kind.Kind, "Secret") {
 		if payload.Object.Object != nil {
 			obj, err := kubeutils.RedactSecret(&payload.Object)
@@ -120,3 +151,86 @@ func redactPayload(payload *admissionRequestPayload) (*admissionRequestPayload,
 	}
 	return payload, nil
 }
+
+// redactResponse drops the patch of secrets because it can contain secret data
+func redactResponse(payload *AdmissionRequestPayload, response AdmissionResponse) AdmissionResponse {
+	if strings.EqualFold(payload.Kind.Kind, "Secret") && len(response.Patch) != 0 {
+		response = *response.DeepCopy()
+		response.Patch = nil
+		response.PatchType = nil
+	}
+	return response
+}
+
+// UnmarshalJSON decodes a recorded payload, missing objects are recorded as null
+func (p *AdmissionRequestPayload) UnmarshalJSON(data []byte) error {
+	type payload AdmissionRequestPayload
+	raw := struct {
+		*payload
+		Object    json.RawMessage `json:"object,omitempty"`
+		OldObject json.RawMessage `json:"oldObject,omitempty"`
+		Options   json.RawMessage `json:"options,omitempty"`
+	}{
+		payload: (*payload)(p),
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	for _, object := range []struct {
+		raw    json.RawMessage
+		object *unstructured.Unstructured
+	}{
+		{raw.Object, &p.Object},
+		{raw.OldObject, &p.OldObject},
+		{raw.Options, &p.Options},
+	} {
+		if len(object.raw) == 0 || string(object.raw) == "null" {
+			continue
+		}
+		if err := object.object.UnmarshalJSON(object.raw); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AdmissionRequest rebuilds the admission request from the recorded payload
+func (p *AdmissionRequestPayload) AdmissionRequest() (AdmissionRequest, error) {
+	request := admissionv1.AdmissionRequest{
+		UID:                p.UID,
+		Kind:               p.Kind,
+		Resource:           p.Resource,
+		SubResource:        p.SubResource,
+		RequestKind:        p.RequestKind,
+		RequestResource:    p.RequestResource,
+		RequestSubResource: p.RequestSubResource,
+		Name:               p.Name,
+		Namespace:          p.Namespace,
+		Operation:          admissionv1.Operation(p.Operation),
+		UserInfo:           p.UserInfo,
+		DryRun:             p.DryRun,
+	}
+	for _, raw := range []struct {
+		object *unstructured.Unstructured
+		ext    *runtime.RawExtension
+	}{
+		{&p.Object, &request.Object},
+		{&p.OldObject, &request.OldObject},
+		{&p.Options, &request.Options},
+	} {
+		if len(raw.object.Object) == 0 {
+			continue
+		}
+		bytes, err := raw.object.MarshalJSON()
+		if err != nil {
+			return AdmissionRequest{}, err
+		}
+		raw.ext.Raw = bytes
+	}
+	return AdmissionRequest{
+		AdmissionRequest: request,
+		Roles:            p.Roles,
+		ClusterRoles:     p.ClusterRoles,
+		GroupVersionKind: schema.GroupVersionKind(p.Kind),
+	}, nil
+}
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	osutils "github.com/kyverno/kyverno/pkg/utils/os"
+)
+
+type dumpFileWriter struct {
+	file *osutils.RotatingFile
+}
+
+// NewDumpFileWriter returns a DumpWriter appending NDJSON records to a file,
+// the file is rotated when it exceeds maxSize bytes and at most maxBackups rotated files are kept
+func NewDumpFileWriter(path string, maxSize int64, maxBackups int) (DumpWriter, error) {
+	file, err := osutils.NewRotatingFile(path, maxSize, maxBackups)
+	if err != nil {
+		return nil, err
+	}
+	return &dumpFileWriter{file: file}, nil
+}
+
+func (w *dumpFileWriter) Write(record DumpRecord) error {
+	line, err := marshalDumpRecord(record)
+	if err != nil {
+		return err
+	}
+	_, err = w.file.Write(line)
+	return err
+}
+
+type dumpDirWriter struct {
+	dir string
+}
+
+// NewDumpDirWriter returns a DumpWriter writing every record to its own file in a directory
+func NewDumpDirWriter(dir string) (DumpWriter, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &dumpDirWriter{dir: dir}, nil
+}
+
+func (w *dumpDirWriter) Write(record DumpRecord) error {
+	line, err := marshalDumpRecord(record)
+	if err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%d-%s.json", record.Time.UnixNano(), record.Request.UID)
+	return os.WriteFile(filepath.Join(w.dir, name), line, 0o600)
+}
+
+func marshalDumpRecord(record DumpRecord) ([]byte, error) {
+	bytes, err := json.Marshal(record)
+	if err != nil {
+		return nil, err
+	}
+	return append(bytes, '\n'), nil
+}
@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gotest.tools/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func newDumpRecord(t *testing.T, uid string) DumpRecord {
+	payload, err := newAdmissionRequestPayload(AdmissionRequest{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			UID:       types.UID(uid),
+			Kind:      metav1.GroupVersionKind{Version: "v1", Kind: "ConfigMap"},
+			Resource:  metav1.GroupVersionResource{Version: "v1", Resource: "configmaps"},
+			Name:      "cm",
+			Namespace: "default",
+			Operation: admissionv1.Create,
+			Object:    runtime.RawExtension{Raw: []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm","namespace":"default"},"data":{"key":"value"}}`)},
+		},
+	})
+	assert.NilError(t, err)
+	return DumpRecord{
+		Time:     metav1.Now(),
+		Request:  payload,
+		Response: AdmissionResponse{UID: types.UID(uid), Allowed: true},
+	}
+}
+
+func readDumpRecords(t *testing.T, path string) []DumpRecord {
+	file, err := os.Open(path)
+	assert.NilError(t, err)
+	defer file.Close()
+	var records []DumpRecord
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var record DumpRecord
+		assert.NilError(t, json.Unmarshal(scanner.Bytes(), &record))
+		records = append(records, record)
+	}
+	assert.NilError(t, scanner.Err())
+	return records
+}
+
+func Test_DumpFileWriter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dump", "payloads.ndjson")
+	line, err := marshalDumpRecord(newDumpRecord(t, "0"))
+	assert.NilError(t, err)
+	// two records fit in a file
+	writer, err := NewDumpFileWriter(path, int64(2*len(line)), 2)
+	assert.NilError(t, err)
+	for _, uid := range []string{"1", "2", "3", "4", "5", "6", "7"} {
+		assert.NilError(t, writer.Write(newDumpRecord(t, uid)))
+	}
+	current := readDumpRecords(t, path)
+	assert.Equal(t, len(current), 1)
+	assert.Equal(t, string(current[0].Request.UID), "7")
+	backup := readDumpRecords(t, path+".1")
+	assert.Equal(t, len(backup), 2)
+	assert.Equal(t, string(backup[0].Request.UID), "5")
+	assert.Equal(t, len(readDumpRecords(t, path+".2")), 2)
+	_, err = os.Stat(path + ".3")
+	assert.Assert(t, os.IsNotExist(err))
+
+	request, err := current[0].Request.AdmissionRequest()
+	assert.NilError(t, err)
+	assert.Equal(t, request.Operation, admissionv1.Create)
+	assert.Equal(t, request.GroupVersionKind.Kind, "ConfigMap")
+	assert.Assert(t, len(request.Object.Raw) != 0)
+	assert.Assert(t, len(request.OldObject.Raw) == 0)
+}
+
+func Test_DumpDirWriter(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "dump")
+	writer, err := NewDumpDirWriter(dir)
+	assert.NilError(t, err)
+	assert.NilError(t, writer.Write(newDumpRecord(t, "1")))
+	assert.NilError(t, writer.Write(newDumpRecord(t, "2")))
+	entries, err := os.ReadDir(dir)
+	assert.NilError(t, err)
+	assert.Equal(t, len(entries), 2)
+}
+
+func Test_RedactResponse(t *testing.T) {
+	patchType := admissionv1.PatchTypeJSONPatch
+	response := AdmissionResponse{Allowed: true, Patch: []byte(`[{"op":"add","path":"/data/password","value":"c2VjcmV0"}]`), PatchType: &patchType}
+	redacted := redactResponse(&AdmissionRequestPayload{Kind: metav1.GroupVersionKind{Version: "v1", Kind: "Secret"}}, response)
+	assert.Assert(t, redacted.Patch == nil)
+	assert.Assert(t, response.Patch != nil)
+	kept := redactResponse(&AdmissionRequestPayload{Kind: metav1.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}}, response)
+	assert.Assert(t, kept.Patch != nil)
+}
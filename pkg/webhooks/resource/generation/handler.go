@@ -20,9 +20,12 @@ import (
 	"github.com/kyverno/kyverno/pkg/event"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	utils "github.com/kyverno/kyverno/pkg/utils/engine"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
 	webhookgenerate "github.com/kyverno/kyverno/pkg/webhooks/updaterequest"
 	webhookutils "github.com/kyverno/kyverno/pkg/webhooks/utils"
 	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 )
 
@@ -323,7 +326,12 @@ func (h *generationHandler) processRequest(ctx context.Context, policyContext *e
 		}
 
 		for _, rule := range policy.GetSpec().Rules {
-			if rule.Name == pRuleName && rule.Generation.Synchronize {
+			if rule.Name != pRuleName {
+				continue
+			}
+			// with drift detection, changes and deletions are re-evaluated to report the drift but are not remediated,
+			// the downstream resources are left untouched when the clone source is deleted
+			if rule.Generation.Synchronize || (rule.Generation.DriftDetection && !deleteDownstream) {
 				gvk, subresource := policyContext.ResourceKind()
 				if err := engineutils.MatchesResourceDescription(
 					old,
@@ -340,6 +348,11 @@ func (h *generationHandler) processRequest(ctx context.Context, policyContext *e
 				}
 
 				ruleCtx := buildRuleContext(rule, generateutils.TriggerFromLabels(labels), deleteDownstream)
+				ruleCtx.DriftCheck = rule.Generation.DriftDetection && !deleteDownstream
+				if !rule.Generation.Synchronize && !h.driftUpdateRequired(pKey, ruleCtx, old, new) {
+					h.log.V(4).Info("skip creating UR as the drift is already pending or the resource content did not change")
+					continue
+				}
 				urSpec.RuleContext = append(urSpec.RuleContext, ruleCtx)
 			}
 		}
@@ -352,3 +365,27 @@ func (h *generationHandler) processRequest(ctx context.Context, policyContext *e
 	}
 	return nil
 }
+
+// driftUpdateRequired returns true when a change to a downstream resource must be re-evaluated by a drift detection rule,
+// updates that leave the content untouched are ignored and a single pending request is kept per rule and trigger
+func (h *generationHandler) driftUpdateRequired(policyKey string, ruleCtx kyvernov2.RuleContext, old, new unstructured.Unstructured) bool {
+	if new.Object != nil && reportutils.CalculateResourceHash(old) == reportutils.CalculateResourceHash(new) {
+		return false
+	}
+	urs, err := h.urLister.List(labels.SelectorFromSet(common.GenerateLabelsSet(policyKey)))
+	if err != nil {
+		h.log.Error(err, "failed to list update requests", "policy", policyKey)
+		return true
+	}
+	for _, ur := range urs {
+		if ur.Spec.Policy != policyKey || (ur.Status.State != "" && ur.Status.State != kyvernov2.Pending) {
+			continue
+		}
+		for _, pending := range ur.Spec.RuleContext {
+			if pending.Rule == ruleCtx.Rule && pending.Trigger == ruleCtx.Trigger && !pending.DeleteDownstream {
+				return false
+			}
+		}
+	}
+	return true
+}
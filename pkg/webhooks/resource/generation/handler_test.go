@@ -0,0 +1,126 @@
+package generation
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	"github.com/kyverno/kyverno/pkg/background/common"
+	kyvernov2listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/cache"
+)
+
+func newConfigMap(data string, status string) unstructured.Unstructured {
+	return unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]any{
+			"name":      "downstream",
+			"namespace": "tenant",
+		},
+		"data":   map[string]any{"key": data},
+		"status": map[string]any{"phase": status},
+	}}
+}
+
+func Test_generationHandler_driftUpdateRequired(t *testing.T) {
+	trigger := kyvernov1.ResourceSpec{APIVersion: "v1", Kind: "Namespace", Name: "tenant"}
+	ruleCtx := kyvernov2.RuleContext{Rule: "generate", Trigger: trigger}
+	newUR := func(name string, state kyvernov2.UpdateRequestState, ruleCtx kyvernov2.RuleContext) *kyvernov2.UpdateRequest {
+		return &kyvernov2.UpdateRequest{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: config.KyvernoNamespace(),
+				Labels:    common.GenerateLabelsSet("policy"),
+			},
+			Spec: kyvernov2.UpdateRequestSpec{
+				Type:        kyvernov2.Generate,
+				Policy:      "policy",
+				RuleContext: []kyvernov2.RuleContext{ruleCtx},
+			},
+			Status: kyvernov2.UpdateRequestStatus{State: state},
+		}
+	}
+	tests := []struct {
+		name string
+		urs  []*kyvernov2.UpdateRequest
+		old  unstructured.Unstructured
+		new  unstructured.Unstructured
+		want bool
+	}{{
+		name: "delete",
+		old:  newConfigMap("foo", "ready"),
+		want: true,
+	}, {
+		name: "delete with a pending request",
+		urs:  []*kyvernov2.UpdateRequest{newUR("pending", kyvernov2.Pending, ruleCtx)},
+		old:  newConfigMap("foo", "ready"),
+		want: false,
+	}, {
+		name: "status update is ignored",
+		old:  newConfigMap("foo", "ready"),
+		new:  newConfigMap("foo", "failed"),
+		want: false,
+	}, {
+		name: "content update",
+		old:  newConfigMap("foo", "ready"),
+		new:  newConfigMap("bar", "ready"),
+		want: true,
+	}, {
+		name: "content update with a pending request",
+		urs:  []*kyvernov2.UpdateRequest{newUR("pending", kyvernov2.Pending, ruleCtx)},
+		old:  newConfigMap("foo", "ready"),
+		new:  newConfigMap("bar", "ready"),
+		want: false,
+	}, {
+		name: "content update with a request being created",
+		urs:  []*kyvernov2.UpdateRequest{newUR("created", "", ruleCtx)},
+		old:  newConfigMap("foo", "ready"),
+		new:  newConfigMap("bar", "ready"),
+		want: false,
+	}, {
+		name: "content update with a completed request",
+		urs:  []*kyvernov2.UpdateRequest{newUR("completed", kyvernov2.Completed, ruleCtx)},
+		old:  newConfigMap("foo", "ready"),
+		new:  newConfigMap("bar", "ready"),
+		want: true,
+	}, {
+		name: "content update with a pending request for another trigger",
+		urs: []*kyvernov2.UpdateRequest{newUR("other", kyvernov2.Pending, kyvernov2.RuleContext{
+			Rule:    "generate",
+			Trigger: kyvernov1.ResourceSpec{APIVersion: "v1", Kind: "Namespace", Name: "other"},
+		})},
+		old:  newConfigMap("foo", "ready"),
+		new:  newConfigMap("bar", "ready"),
+		want: true,
+	}, {
+		name: "content update with a pending downstream deletion",
+		urs: []*kyvernov2.UpdateRequest{newUR("delete", kyvernov2.Pending, kyvernov2.RuleContext{
+			Rule:             "generate",
+			Trigger:          trigger,
+			DeleteDownstream: true,
+		})},
+		old:  newConfigMap("foo", "ready"),
+		new:  newConfigMap("bar", "ready"),
+		want: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			for _, ur := range tt.urs {
+				require.NoError(t, indexer.Add(ur))
+			}
+			h := &generationHandler{
+				log:      logr.Discard(),
+				urLister: kyvernov2listers.NewUpdateRequestLister(indexer).UpdateRequests(config.KyvernoNamespace()),
+			}
+			assert.Equal(t, tt.want, h.driftUpdateRequired("policy", ruleCtx, tt.old, tt.new))
+		})
+	}
+}
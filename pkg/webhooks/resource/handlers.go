@@ -23,6 +23,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/event"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	"github.com/kyverno/kyverno/pkg/policycache"
+	"github.com/kyverno/kyverno/pkg/resultsink"
 	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
 	engineutils "github.com/kyverno/kyverno/pkg/utils/engine"
 	jsonutils "github.com/kyverno/kyverno/pkg/utils/json"
@@ -66,6 +67,7 @@ type resourceHandlers struct {
 	reportsServiceAccountName    string
 	auditPool                    *pond.WorkerPool
 	reportingConfig              reportutils.ReportingConfiguration
+	resultSink                   resultsink.Sink
 	breaker.Breaker
 }
 
@@ -89,6 +91,7 @@ func NewHandlers(
 	maxAuditWorkers int,
 	maxAuditCapacity int,
 	reportingConfig reportutils.ReportingConfiguration,
+	resultSink resultsink.Sink,
 ) *resourceHandlers {
 	return &resourceHandlers{
 		engine:                       engine,
@@ -109,6 +112,7 @@ func NewHandlers(
 		reportsServiceAccountName:    reportsServiceAccountName,
 		auditPool:                    pond.New(maxAuditWorkers, maxAuditCapacity, pond.Strategy(pond.Lazy())),
 		reportingConfig:              reportingConfig,
+		resultSink:                   resultSink,
 	}
 }
 
@@ -158,21 +162,23 @@ func (h *resourceHandlers) Validate(ctx context.Context, logger logr.Logger, req
 		logger.V(4).Info("admission request denied")
 		events := webhookutils.GenerateEvents(enforceResponses, true, h.configuration)
 		h.eventGen.Add(events...)
+		webhookutils.SendResults(ctx, logger, h.resultSink, request.AdmissionRequest, enforceResponses...)
 		return admissionutils.Response(request.UID, errors.New(msg), warnings...)
 	}
 	go h.auditPool.Submit(func() {
 		auditResponses := vh.HandleValidationAudit(ctx, request)
-		var events []event.Info
+		var responses []engineapi.EngineResponse
 
 		switch {
 		case len(auditResponses) == 0:
-			events = webhookutils.GenerateEvents(enforceResponses, false, h.configuration)
+			responses = enforceResponses
 		case len(enforceResponses) == 0:
-			events = webhookutils.GenerateEvents(auditResponses, false, h.configuration)
+			responses = auditResponses
 		default:
-			responses := mergeEngineResponses(auditResponses, enforceResponses)
-			events = webhookutils.GenerateEvents(responses, false, h.configuration)
+			responses = mergeEngineResponses(auditResponses, enforceResponses)
 		}
+		events := webhookutils.GenerateEvents(responses, false, h.configuration)
+		webhookutils.SendResults(ctx, logger, h.resultSink, request.AdmissionRequest, responses...)
 
 		h.eventGen.Add(events...)
 	})
@@ -221,6 +227,7 @@ func (h *resourceHandlers) Mutate(ctx context.Context, logger logr.Logger, reque
 			h.configuration,
 			h.nsLister,
 			h.reportingConfig,
+			h.resultSink,
 		)
 		imagePatches, imageVerifyWarnings, err := ivh.Handle(ctx, newRequest, verifyImagesPolicies, policyContext)
 		if err != nil {
@@ -14,6 +14,7 @@ import (
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/engine/mutate/patch"
 	"github.com/kyverno/kyverno/pkg/event"
+	"github.com/kyverno/kyverno/pkg/resultsink"
 	"github.com/kyverno/kyverno/pkg/tracing"
 	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
 	engineutils "github.com/kyverno/kyverno/pkg/utils/engine"
@@ -41,6 +42,7 @@ type imageVerificationHandler struct {
 	cfg              config.Configuration
 	nsLister         corev1listers.NamespaceLister
 	reportConfig     reportutils.ReportingConfiguration
+	resultSink       resultsink.Sink
 	breaker.Breaker
 }
 
@@ -53,6 +55,7 @@ func NewImageVerificationHandler(
 	cfg config.Configuration,
 	nsLister corev1listers.NamespaceLister,
 	reportConfig reportutils.ReportingConfiguration,
+	resultSink resultsink.Sink,
 ) ImageVerificationHandler {
 	return &imageVerificationHandler{
 		kyvernoClient:    kyvernoClient,
@@ -63,6 +66,7 @@ func NewImageVerificationHandler(
 		cfg:              cfg,
 		nsLister:         nsLister,
 		reportConfig:     reportConfig,
+		resultSink:       resultSink,
 	}
 }
 
@@ -130,6 +134,7 @@ func (h *imageVerificationHandler) handleVerifyImages(
 	blocked := webhookutils.BlockRequest(engineResponses, failurePolicy, logger)
 	events := webhookutils.GenerateEvents(engineResponses, blocked, cfg)
 	h.eventGen.Add(events...)
+	webhookutils.SendResults(ctx, logger, h.resultSink, request, engineResponses...)
 
 	if blocked {
 		logger.V(4).Info("admission request blocked")
@@ -14,25 +14,30 @@ import (
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/engine/mutate/patch"
 	eval "github.com/kyverno/kyverno/pkg/imageverification/evaluator"
+	"github.com/kyverno/kyverno/pkg/resultsink"
 	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
 	jsonutils "github.com/kyverno/kyverno/pkg/utils/json"
 	"github.com/kyverno/kyverno/pkg/webhooks/handlers"
+	webhookutils "github.com/kyverno/kyverno/pkg/webhooks/utils"
 	"go.uber.org/multierr"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 )
 
 type handler struct {
-	context libs.Context
-	engine  ivpolengine.Engine
+	context    libs.Context
+	engine     ivpolengine.Engine
+	resultSink resultsink.Sink
 }
 
 func New(
 	engine ivpolengine.Engine,
 	context libs.Context,
+	resultSink resultsink.Sink,
 ) *handler {
 	return &handler{
-		context: context,
-		engine:  engine,
+		context:    context,
+		engine:     engine,
+		resultSink: resultSink,
 	}
 }
 
@@ -64,9 +69,27 @@ func (h *handler) Validate(ctx context.Context, logger logr.Logger, admissionReq
 	if err != nil {
 		return admissionutils.Response(admissionRequest.UID, err)
 	}
+	// the results are sent once, when the verification outcome is validated
+	webhookutils.SendResults(ctx, logger, h.resultSink, admissionRequest.AdmissionRequest, engineResponses(response)...)
 	return h.validationResponse(request, response)
 }
 
+func engineResponses(response eval.ImageVerifyEngineResponse) []engineapi.EngineResponse {
+	if response.Resource == nil {
+		return nil
+	}
+	responses := make([]engineapi.EngineResponse, 0, len(response.Policies))
+	for _, policy := range response.Policies {
+		responses = append(responses, engineapi.EngineResponse{
+			Resource: *response.Resource,
+			PolicyResponse: engineapi.PolicyResponse{
+				Rules: []engineapi.RuleResponse{policy.Result},
+			},
+		}.WithPolicy(engineapi.NewImageValidatingPolicy(policy.Policy)))
+	}
+	return responses
+}
+
 func (h *handler) mutationResponse(request celengine.EngineRequest, response eval.ImageVerifyEngineResponse, rawPatches []byte) handlers.AdmissionResponse {
 	var warnings []string
 	for _, policy := range response.Policies {
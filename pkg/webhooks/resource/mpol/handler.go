@@ -16,11 +16,13 @@ import (
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/engine/mutate/patch"
+	"github.com/kyverno/kyverno/pkg/resultsink"
 	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
 	jsonutils "github.com/kyverno/kyverno/pkg/utils/json"
 	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
 	"github.com/kyverno/kyverno/pkg/webhooks/handlers"
 	webhookgenerate "github.com/kyverno/kyverno/pkg/webhooks/updaterequest"
+	webhookutils "github.com/kyverno/kyverno/pkg/webhooks/utils"
 	admissionv1 "k8s.io/api/admission/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
@@ -32,6 +34,7 @@ type handler struct {
 	reportsConfig                reportutils.ReportingConfiguration
 	urGenerator                  webhookgenerate.Generator
 	backgroundServiceAccountName string
+	resultSink                   resultsink.Sink
 }
 
 func New(
@@ -41,6 +44,7 @@ func New(
 	reportsConfig reportutils.ReportingConfiguration,
 	urGenerator webhookgenerate.Generator,
 	backgroundServiceAccountName string,
+	resultSink resultsink.Sink,
 ) *handler {
 	return &handler{
 		context:                      context,
@@ -49,6 +53,7 @@ func New(
 		reportsConfig:                reportsConfig,
 		urGenerator:                  urGenerator,
 		backgroundServiceAccountName: backgroundServiceAccountName,
+		resultSink:                   resultSink,
 	}
 }
 
@@ -77,7 +82,9 @@ func (h *handler) Mutate(ctx context.Context, logger logr.Logger, admissionReque
 	}
 
 	go func() {
-		if err := h.createReports(context.TODO(), response, request); err != nil {
+		engineResponses := h.engineResponses(response)
+		webhookutils.SendResults(context.TODO(), logger, h.resultSink, admissionRequest.AdmissionRequest, engineResponses...)
+		if err := h.createReports(context.TODO(), response, request, engineResponses); err != nil {
 			logger.Error(err, "failed to create reports")
 		}
 	}()
@@ -114,11 +121,10 @@ func (h *handler) Mutate(ctx context.Context, logger logr.Logger, admissionReque
 	return resp
 }
 
-func (h *handler) createReports(ctx context.Context, response mpolengine.EngineResponse, request celengine.EngineRequest) error {
-	if !h.needsReports(request) {
+func (h *handler) engineResponses(response mpolengine.EngineResponse) []engineapi.EngineResponse {
+	if response.Resource == nil {
 		return nil
 	}
-
 	engineResponses := make([]engineapi.EngineResponse, 0, len(response.Policies))
 	for _, res := range response.Policies {
 		engineResponses = append(engineResponses, engineapi.EngineResponse{
@@ -128,6 +134,13 @@ func (h *handler) createReports(ctx context.Context, response mpolengine.EngineR
 			},
 		}.WithPolicy(engineapi.NewMutatingPolicy(res.Policy)))
 	}
+	return engineResponses
+}
+
+func (h *handler) createReports(ctx context.Context, response mpolengine.EngineResponse, request celengine.EngineRequest, engineResponses []engineapi.EngineResponse) error {
+	if !h.needsReports(request) {
+		return nil
+	}
 
 	report := reportutils.BuildMutationReport(*response.Resource, request.Request, engineResponses...)
 	if len(report.GetResults()) > 0 {
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/alitto/pond"
 	"github.com/go-logr/logr"
 	"github.com/julienschmidt/httprouter"
 	"github.com/kyverno/kyverno/pkg/breaker"
@@ -14,12 +15,15 @@ import (
 	vpolengine "github.com/kyverno/kyverno/pkg/cel/policies/vpol/engine"
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/resultsink"
 	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
 	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
 	"github.com/kyverno/kyverno/pkg/webhooks/handlers"
 	"github.com/kyverno/kyverno/pkg/webhooks/resource/validation"
+	webhookutils "github.com/kyverno/kyverno/pkg/webhooks/utils"
 	"go.uber.org/multierr"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/util/wait"
 )
 
@@ -29,6 +33,9 @@ type handler struct {
 	kyvernoClient    versioned.Interface
 	admissionReports bool
 	reportConfig     reportutils.ReportingConfiguration
+	resultSink       resultsink.Sink
+	shadowMetrics    shadowMetrics
+	shadowPool       *pond.WorkerPool
 }
 
 func New(
@@ -37,6 +44,9 @@ func New(
 	kyvernoClient versioned.Interface,
 	admissionReports bool,
 	reportConfig reportutils.ReportingConfiguration,
+	resultSink resultsink.Sink,
+	maxShadowWorkers int,
+	maxShadowCapacity int,
 ) *handler {
 	return &handler{
 		context:          context,
@@ -44,6 +54,9 @@ func New(
 		kyvernoClient:    kyvernoClient,
 		admissionReports: admissionReports,
 		reportConfig:     reportConfig,
+		resultSink:       resultSink,
+		shadowMetrics:    newShadowMetrics(),
+		shadowPool:       pond.New(maxShadowWorkers, maxShadowCapacity, pond.Strategy(pond.Lazy())),
 	}
 }
 
@@ -59,23 +72,91 @@ func (h *handler) Validate(ctx context.Context, logger logr.Logger, admissionReq
 	if err != nil {
 		return admissionutils.Response(admissionRequest.UID, err)
 	}
+	admissionResponse := h.admissionResponse(request, response)
+	// shadow policies never affect the admission response, they are compared with the enforced policies
+	// and reported once the response is returned
+	if len(shadowEvaluations(response)) != 0 {
+		ctx := context.WithoutCancel(ctx)
+		go h.shadowPool.Submit(func() {
+			divergences := h.shadowEvaluation(ctx, logger, request, response)
+			h.report(ctx, logger, admissionRequest, request, response, divergences)
+		})
+		return admissionResponse
+	}
 	var group wait.Group
 	defer group.Wait()
 	group.Start(func() {
-		if validation.NeedsReports(admissionRequest, *response.Resource, h.admissionReports, h.reportConfig) {
-			err := h.admissionReport(ctx, request, response)
-			if err != nil {
-				logger.Error(err, "failed to create report")
+		h.report(ctx, logger, admissionRequest, request, response, nil)
+	})
+	return admissionResponse
+}
+
+// report sends the results of the evaluated policies to the result sink and creates the admission report
+func (h *handler) report(ctx context.Context, logger logr.Logger, admissionRequest handlers.AdmissionRequest, request vpolengine.EngineRequest, response vpolengine.EngineResponse, divergences map[string]string) {
+	needsReport := validation.NeedsReports(admissionRequest, *response.Resource, h.admissionReports, h.reportConfig)
+	if !needsReport && h.resultSink == nil {
+		return
+	}
+	object, responses, err := h.engineResponses(request, response, divergences)
+	if err != nil {
+		logger.Error(err, "failed to build engine responses")
+		return
+	}
+	webhookutils.SendResults(ctx, logger, h.resultSink, admissionRequest.AdmissionRequest, responses...)
+	if needsReport {
+		if err := h.admissionReport(ctx, request, object, responses); err != nil {
+			logger.Error(err, "failed to create report")
+		}
+	}
+}
+
+// shadowEvaluation compares the decisions of the shadow policies with the decisions of the enforced policies
+// they are a revision of, it returns the divergence of every evaluated shadow policy indexed by policy name
+func (h *handler) shadowEvaluation(ctx context.Context, logger logr.Logger, request vpolengine.EngineRequest, response vpolengine.EngineResponse) map[string]string {
+	shadows := shadowEvaluations(response)
+	if len(shadows) == 0 {
+		return nil
+	}
+	decisions := map[string]bool{}
+	enforcedDecisions(decisions, response)
+	var missing []string
+	for _, policy := range shadows {
+		if name := policy.Policy.Spec.ShadowPolicyName(); name != "" {
+			if _, ok := decisions[name]; !ok {
+				missing = append(missing, name)
 			}
 		}
-	})
-	return h.admissionResponse(request, response)
+	}
+	// shadow policies are served by a different webhook than the policies they are compared with
+	if len(missing) != 0 {
+		response, err := h.engine.Handle(ctx, request, vpolengine.MatchNames(missing...))
+		if err != nil {
+			logger.Error(err, "failed to evaluate the enforced policies of shadow policies")
+			return nil
+		}
+		enforcedDecisions(decisions, response)
+	}
+	divergences := map[string]string{}
+	for _, policy := range shadows {
+		// a policy that doesn't exist or doesn't match the request allows it
+		divergence := shadowDivergence(policy, decisions[policy.Policy.Spec.ShadowPolicyName()])
+		divergences[policy.Policy.GetName()] = divergence
+		h.shadowMetrics.recordEvaluation(ctx, policy.Policy.GetName(), divergence)
+		if divergence != shadowDivergenceNone {
+			logger.V(2).Info("shadow policy diverged from enforced policy", "policy", policy.Policy.GetName(), "enforced", policy.Policy.Spec.ShadowPolicyName(), "divergence", divergence)
+		}
+	}
+	return divergences
 }
 
 func (h *handler) admissionResponse(request vpolengine.EngineRequest, response vpolengine.EngineResponse) handlers.AdmissionResponse {
 	var errs []error
 	var warnings []string
 	for _, policy := range response.Policies {
+		// shadow policies never affect the admission response
+		if isShadow(policy) {
+			continue
+		}
 		if policy.Actions.Has(admissionregistrationv1.Deny) {
 			for _, rule := range policy.Rules {
 				switch rule.Status() {
@@ -100,27 +181,36 @@ func (h *handler) admissionResponse(request vpolengine.EngineRequest, response v
 	return admissionutils.Response(request.AdmissionRequest().UID, multierr.Combine(errs...), warnings...)
 }
 
-func (h *handler) admissionReport(ctx context.Context, request vpolengine.EngineRequest, response vpolengine.EngineResponse) error {
-	admissionRequest := request.AdmissionRequest()
-	object, oldObject, err := admissionutils.ExtractResources(nil, admissionRequest)
+// engineResponses converts the engine response to one engine response per policy, object is the admitted resource
+// or the deleted one for deletions
+func (h *handler) engineResponses(request vpolengine.EngineRequest, response vpolengine.EngineResponse, divergences map[string]string) (unstructured.Unstructured, []engineapi.EngineResponse, error) {
+	object, oldObject, err := admissionutils.ExtractResources(nil, request.AdmissionRequest())
 	if err != nil {
-		return err
+		return object, nil, err
 	}
 	if object.Object == nil {
 		object = oldObject
 	}
 	responses := make([]engineapi.EngineResponse, 0, len(response.Policies))
 	for _, r := range response.Policies {
+		rules := r.Rules
+		if isShadow(r) {
+			rules = shadowRules(rules, divergences[r.Policy.GetName()])
+		}
 		engineResponse := engineapi.EngineResponse{
 			Resource: object,
 			PolicyResponse: engineapi.PolicyResponse{
-				Rules: r.Rules,
+				Rules: rules,
 			},
 		}
 		engineResponse = engineResponse.WithPolicy(engineapi.NewValidatingPolicy(&r.Policy))
 		responses = append(responses, engineResponse)
 	}
-	report := reportutils.BuildAdmissionReport(object, admissionRequest, responses...)
+	return object, responses, nil
+}
+
+func (h *handler) admissionReport(ctx context.Context, request vpolengine.EngineRequest, object unstructured.Unstructured, responses []engineapi.EngineResponse) error {
+	report := reportutils.BuildAdmissionReport(object, request.AdmissionRequest(), responses...)
 	if len(report.GetResults()) > 0 {
 		err := breaker.GetReportsBreaker().Do(ctx, func(ctx context.Context) error {
 			_, err := reportutils.CreateEphemeralReport(ctx, report, h.kyvernoClient)
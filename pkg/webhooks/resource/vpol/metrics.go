@@ -0,0 +1,42 @@
+package vpol
+
+import (
+	"context"
+
+	"github.com/kyverno/kyverno/pkg/logging"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+type shadowMetrics struct {
+	evaluations metric.Int64Counter
+}
+
+func newShadowMetrics() shadowMetrics {
+	logger := logging.WithName("vpol-shadow")
+	meter := otel.GetMeterProvider().Meter(metrics.MeterName)
+	evaluations, err := meter.Int64Counter(
+		"kyverno_shadow_policy_evaluations",
+		metric.WithDescription("can be used to track the rate at which shadow policies diverge from admission decisions"),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_shadow_policy_evaluations")
+	}
+	return shadowMetrics{
+		evaluations: evaluations,
+	}
+}
+
+func (m shadowMetrics) recordEvaluation(ctx context.Context, policyName string, divergence string) {
+	if m.evaluations == nil {
+		return
+	}
+	// the request context may be expired, metrics must still be recorded
+	m.evaluations.Add(context.WithoutCancel(ctx), 1, metric.WithAttributes(
+		attribute.String("policy_name", policyName),
+		attribute.String("divergence", divergence),
+		attribute.Bool("divergent", divergence != shadowDivergenceNone),
+	))
+}
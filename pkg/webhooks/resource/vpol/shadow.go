@@ -0,0 +1,103 @@
+package vpol
+
+import (
+	"maps"
+
+	celengine "github.com/kyverno/kyverno/pkg/cel/engine"
+	vpolengine "github.com/kyverno/kyverno/pkg/cel/policies/vpol/engine"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+)
+
+const (
+	// shadowDivergenceNone means the shadow policy agrees with the enforced policy
+	shadowDivergenceNone = "none"
+	// shadowDivergenceDeny means the shadow policy would have denied a request the enforced policy allows
+	shadowDivergenceDeny = "deny"
+	// shadowDivergenceAllow means the shadow policy would have allowed a request the enforced policy denies
+	shadowDivergenceAllow = "allow"
+)
+
+const (
+	// propertyShadow marks report results produced by a shadow policy
+	propertyShadow = "shadow"
+	// propertyShadowDivergence records how the shadow policy diverged from the enforced policy
+	propertyShadowDivergence = "shadowDivergence"
+)
+
+func isShadow(policy celengine.ValidatingPolicyResponse) bool {
+	return policy.Policy.Spec.ShadowEnabled()
+}
+
+// evaluated returns true if the policy was applied to the request (not skipped nor unmatched)
+func evaluated(policy celengine.ValidatingPolicyResponse) bool {
+	for _, rule := range policy.Rules {
+		switch rule.Status() {
+		case engineapi.RuleStatusPass, engineapi.RuleStatusFail, engineapi.RuleStatusError:
+			return true
+		}
+	}
+	return false
+}
+
+// shadowEvaluations returns the shadow policies of the response that were applied to the request
+func shadowEvaluations(response vpolengine.EngineResponse) []celengine.ValidatingPolicyResponse {
+	var shadows []celengine.ValidatingPolicyResponse
+	for _, policy := range response.Policies {
+		if isShadow(policy) && evaluated(policy) {
+			shadows = append(shadows, policy)
+		}
+	}
+	return shadows
+}
+
+// denies returns true if the policy would deny the request
+func denies(policy celengine.ValidatingPolicyResponse) bool {
+	if !policy.Actions.Has(admissionregistrationv1.Deny) {
+		return false
+	}
+	for _, rule := range policy.Rules {
+		switch rule.Status() {
+		case engineapi.RuleStatusFail, engineapi.RuleStatusError:
+			return true
+		}
+	}
+	return false
+}
+
+// shadowDivergence compares the decision of a shadow policy with the decision of the enforced policy
+func shadowDivergence(policy celengine.ValidatingPolicyResponse, enforcedDenies bool) string {
+	if denies(policy) {
+		if !enforcedDenies {
+			return shadowDivergenceDeny
+		}
+	} else if enforcedDenies {
+		return shadowDivergenceAllow
+	}
+	return shadowDivergenceNone
+}
+
+// enforcedDecisions records if the enforced (not shadow) policies of the response deny the request, indexed by policy name
+func enforcedDecisions(decisions map[string]bool, response vpolengine.EngineResponse) {
+	for _, policy := range response.Policies {
+		if !isShadow(policy) {
+			decisions[policy.Policy.GetName()] = denies(policy)
+		}
+	}
+}
+
+// shadowRules returns the rule responses of a shadow policy tagged with the shadow report properties
+func shadowRules(rules []engineapi.RuleResponse, divergence string) []engineapi.RuleResponse {
+	out := make([]engineapi.RuleResponse, 0, len(rules))
+	for _, rule := range rules {
+		properties := map[string]string{
+			propertyShadow: "true",
+		}
+		maps.Copy(properties, rule.Properties())
+		if divergence != "" && divergence != shadowDivergenceNone {
+			properties[propertyShadowDivergence] = divergence
+		}
+		out = append(out, *rule.WithProperties(properties))
+	}
+	return out
+}
@@ -0,0 +1,167 @@
+package vpol
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	policiesv1alpha1 "github.com/kyverno/kyverno/api/policies.kyverno.io/v1alpha1"
+	celengine "github.com/kyverno/kyverno/pkg/cel/engine"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
+)
+
+type fakeEngine struct {
+	policies []celengine.ValidatingPolicyResponse
+}
+
+func (e *fakeEngine) Handle(_ context.Context, _ celengine.EngineRequest, predicate func(policiesv1alpha1.ValidatingPolicy) bool) (celengine.EngineResponse, error) {
+	var response celengine.EngineResponse
+	for _, policy := range e.policies {
+		if predicate(policy.Policy) {
+			response.Policies = append(response.Policies, policy)
+		}
+	}
+	return response, nil
+}
+
+func policyResponse(name string, shadow bool, status engineapi.RuleStatus) celengine.ValidatingPolicyResponse {
+	return shadowResponse(name, shadow, "", status)
+}
+
+func shadowResponse(name string, shadow bool, enforced string, status engineapi.RuleStatus) celengine.ValidatingPolicyResponse {
+	policy := policiesv1alpha1.ValidatingPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+	}
+	if shadow {
+		policy.Spec.EvaluationConfiguration = &policiesv1alpha1.EvaluationConfiguration{
+			Shadow: &policiesv1alpha1.ShadowConfiguration{Enabled: ptr.To(true), PolicyName: enforced},
+		}
+	}
+	return celengine.ValidatingPolicyResponse{
+		Actions: sets.New(admissionregistrationv1.Deny),
+		Policy:  policy,
+		Rules:   []engineapi.RuleResponse{*engineapi.NewRuleResponse("", engineapi.Validation, "message", status, nil)},
+	}
+}
+
+func TestShadowDivergence(t *testing.T) {
+	tests := []struct {
+		name           string
+		status         engineapi.RuleStatus
+		enforcedDenies bool
+		want           string
+	}{{
+		name:           "both allow",
+		status:         engineapi.RuleStatusPass,
+		enforcedDenies: false,
+		want:           shadowDivergenceNone,
+	}, {
+		name:           "both deny",
+		status:         engineapi.RuleStatusFail,
+		enforcedDenies: true,
+		want:           shadowDivergenceNone,
+	}, {
+		name:           "shadow would deny",
+		status:         engineapi.RuleStatusFail,
+		enforcedDenies: false,
+		want:           shadowDivergenceDeny,
+	}, {
+		name:           "shadow error would deny",
+		status:         engineapi.RuleStatusError,
+		enforcedDenies: false,
+		want:           shadowDivergenceDeny,
+	}, {
+		name:           "shadow would allow",
+		status:         engineapi.RuleStatusPass,
+		enforcedDenies: true,
+		want:           shadowDivergenceAllow,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := shadowDivergence(policyResponse("shadow", true, tt.status), tt.enforcedDenies)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestShadowDivergenceAuditOnly(t *testing.T) {
+	policy := policyResponse("shadow", true, engineapi.RuleStatusFail)
+	policy.Actions = sets.New(admissionregistrationv1.Audit)
+	assert.Equal(t, shadowDivergenceNone, shadowDivergence(policy, false))
+}
+
+func TestShadowEvaluation(t *testing.T) {
+	request := celengine.RequestFromAdmission(nil, admissionv1.AdmissionRequest{UID: "uid"})
+	h := &handler{
+		engine: &fakeEngine{
+			policies: []celengine.ValidatingPolicyResponse{
+				policyResponse("other", false, engineapi.RuleStatusPass),
+				policyResponse("remote", false, engineapi.RuleStatusFail),
+			},
+		},
+		shadowMetrics: newShadowMetrics(),
+	}
+	response := celengine.EngineResponse{
+		Policies: []celengine.ValidatingPolicyResponse{
+			// an unrelated policy denies the request, shadow policies are not compared with it
+			policyResponse("unrelated", false, engineapi.RuleStatusFail),
+			policyResponse("live", false, engineapi.RuleStatusPass),
+			shadowResponse("live-candidate", true, "live", engineapi.RuleStatusFail),
+			// the enforced policy is served by another webhook
+			shadowResponse("remote-candidate", true, "remote", engineapi.RuleStatusPass),
+			// a new policy is compared with admitting the request
+			shadowResponse("new", true, "", engineapi.RuleStatusPass),
+			shadowResponse("skipped", true, "live", engineapi.RuleStatusSkip),
+		},
+	}
+	got := h.shadowEvaluation(context.TODO(), logr.Discard(), request, response)
+	assert.Equal(t, map[string]string{
+		"live-candidate":   shadowDivergenceDeny,
+		"remote-candidate": shadowDivergenceAllow,
+		"new":              shadowDivergenceNone,
+	}, got)
+}
+
+func TestAdmissionResponseIgnoresShadowPolicies(t *testing.T) {
+	request := celengine.RequestFromAdmission(nil, admissionv1.AdmissionRequest{UID: "uid"})
+	h := &handler{}
+	response := h.admissionResponse(request, celengine.EngineResponse{
+		Policies: []celengine.ValidatingPolicyResponse{
+			policyResponse("live", false, engineapi.RuleStatusPass),
+			policyResponse("shadow", true, engineapi.RuleStatusFail),
+		},
+	})
+	assert.True(t, response.Allowed)
+	response = h.admissionResponse(request, celengine.EngineResponse{
+		Policies: []celengine.ValidatingPolicyResponse{
+			policyResponse("live", false, engineapi.RuleStatusFail),
+			policyResponse("shadow", true, engineapi.RuleStatusPass),
+		},
+	})
+	assert.False(t, response.Allowed)
+}
+
+func TestShadowRules(t *testing.T) {
+	rules := []engineapi.RuleResponse{
+		*engineapi.NewRuleResponse("", engineapi.Validation, "message", engineapi.RuleStatusFail, map[string]string{"foo": "bar"}),
+	}
+	got := shadowRules(rules, shadowDivergenceDeny)
+	assert.Equal(t, map[string]string{
+		"foo":                    "bar",
+		propertyShadow:           "true",
+		propertyShadowDivergence: shadowDivergenceDeny,
+	}, got[0].Properties())
+	got = shadowRules(rules, shadowDivergenceNone)
+	assert.Equal(t, map[string]string{
+		"foo":          "bar",
+		propertyShadow: "true",
+	}, got[0].Properties())
+	// the original rules must not be modified
+	assert.Equal(t, map[string]string{"foo": "bar"}, rules[0].Properties())
+}
@@ -78,7 +78,7 @@ func NewServer(
 		handlerFunc("MUTATE", resourceHandlers.MutatingPolicies, "").
 			WithFilter(configuration).
 			WithProtection(toggle.FromContext(ctx).ProtectManagedResources()).
-			WithDump(debugModeOpts.DumpPayload).
+			WithDump(debugModeOpts.DumpPayload, debugModeOpts.DumpWriter).
 			WithTopLevelGVK(discovery).
 			WithRoles(rbLister, crbLister).
 			WithMetrics(resourceLogger, metricsConfig.Config(), metrics.WebhookValidating).
@@ -92,7 +92,7 @@ func NewServer(
 		handlerFunc("VALIDATE", resourceHandlers.ValidatingPolicies, "").
 			WithFilter(configuration).
 			WithProtection(toggle.FromContext(ctx).ProtectManagedResources()).
-			WithDump(debugModeOpts.DumpPayload).
+			WithDump(debugModeOpts.DumpPayload, debugModeOpts.DumpWriter).
 			WithTopLevelGVK(discovery).
 			WithRoles(rbLister, crbLister).
 			WithMetrics(resourceLogger, metricsConfig.Config(), metrics.WebhookValidating).
@@ -105,7 +105,7 @@ func NewServer(
 		handlerFunc("IVPOL-VALIDATE", resourceHandlers.ImageVerificationPolicies, "").
 			WithFilter(configuration).
 			WithProtection(toggle.FromContext(ctx).ProtectManagedResources()).
-			WithDump(debugModeOpts.DumpPayload).
+			WithDump(debugModeOpts.DumpPayload, debugModeOpts.DumpWriter).
 			WithTopLevelGVK(discovery).
 			WithRoles(rbLister, crbLister).
 			WithMetrics(resourceLogger, metricsConfig.Config(), metrics.WebhookValidating).
@@ -118,7 +118,7 @@ func NewServer(
 		handlerFunc("IVPOL-MUTATE", resourceHandlers.ImageVerificationPoliciesMutation, "").
 			WithFilter(configuration).
 			WithProtection(toggle.FromContext(ctx).ProtectManagedResources()).
-			WithDump(debugModeOpts.DumpPayload).
+			WithDump(debugModeOpts.DumpPayload, debugModeOpts.DumpWriter).
 			WithTopLevelGVK(discovery).
 			WithRoles(rbLister, crbLister).
 			WithOperationFilter(admissionv1.Create, admissionv1.Update, admissionv1.Connect).
@@ -132,7 +132,7 @@ func NewServer(
 		handlerFunc("GENERATE", resourceHandlers.GeneratingPolicies, "").
 			WithFilter(configuration).
 			WithProtection(toggle.FromContext(ctx).ProtectManagedResources()).
-			WithDump(debugModeOpts.DumpPayload).
+			WithDump(debugModeOpts.DumpPayload, debugModeOpts.DumpWriter).
 			WithTopLevelGVK(discovery).
 			WithRoles(rbLister, crbLister).
 			WithMetrics(resourceLogger, metricsConfig.Config(), metrics.WebhookValidating).
@@ -148,7 +148,7 @@ func NewServer(
 			return handler.
 				WithFilter(configuration).
 				WithProtection(toggle.FromContext(ctx).ProtectManagedResources()).
-				WithDump(debugModeOpts.DumpPayload).
+				WithDump(debugModeOpts.DumpPayload, debugModeOpts.DumpWriter).
 				WithTopLevelGVK(discovery).
 				WithRoles(rbLister, crbLister).
 				WithOperationFilter(admissionv1.Create, admissionv1.Update, admissionv1.Connect).
@@ -165,7 +165,7 @@ func NewServer(
 			return handler.
 				WithFilter(configuration).
 				WithProtection(toggle.FromContext(ctx).ProtectManagedResources()).
-				WithDump(debugModeOpts.DumpPayload).
+				WithDump(debugModeOpts.DumpPayload, debugModeOpts.DumpWriter).
 				WithTopLevelGVK(discovery).
 				WithRoles(rbLister, crbLister).
 				WithMetrics(resourceLogger, metricsConfig.Config(), metrics.WebhookValidating).
@@ -176,7 +176,7 @@ func NewServer(
 		"POST",
 		config.PolicyMutatingWebhookServicePath,
 		handlerFunc("MUTATE", policyHandlers.Mutation, "").
-			WithDump(debugModeOpts.DumpPayload).
+			WithDump(debugModeOpts.DumpPayload, debugModeOpts.DumpWriter).
 			WithMetrics(policyLogger, metricsConfig.Config(), metrics.WebhookMutating).
 			WithAdmission(policyLogger.WithName("mutate")).
 			ToHandlerFunc("MUTATE"),
@@ -185,7 +185,7 @@ func NewServer(
 		"POST",
 		config.PolicyValidatingWebhookServicePath,
 		handlerFunc("VALIDATE", policyHandlers.Validation, "").
-			WithDump(debugModeOpts.DumpPayload).
+			WithDump(debugModeOpts.DumpPayload, debugModeOpts.DumpWriter).
 			WithSubResourceFilter().
 			WithMetrics(policyLogger, metricsConfig.Config(), metrics.WebhookValidating).
 			WithAdmission(policyLogger.WithName("validate")).
@@ -195,7 +195,7 @@ func NewServer(
 		"POST",
 		config.ExceptionValidatingWebhookServicePath,
 		handlerFunc("VALIDATE", exceptionHandlers.Validation, "").
-			WithDump(debugModeOpts.DumpPayload).
+			WithDump(debugModeOpts.DumpPayload, debugModeOpts.DumpWriter).
 			WithSubResourceFilter().
 			WithMetrics(exceptionLogger, metricsConfig.Config(), metrics.WebhookValidating).
 			WithAdmission(exceptionLogger.WithName("validate")).
@@ -205,7 +205,7 @@ func NewServer(
 		"POST",
 		config.CELExceptionValidatingWebhookServicePath,
 		handlerFunc("VALIDATE", celExceptionHandlers.Validation, "").
-			WithDump(debugModeOpts.DumpPayload).
+			WithDump(debugModeOpts.DumpPayload, debugModeOpts.DumpWriter).
 			WithSubResourceFilter().
 			WithMetrics(celExceptionLogger, metricsConfig.Config(), metrics.WebhookValidating).
 			WithAdmission(celExceptionLogger.WithName("validate")).
@@ -215,7 +215,7 @@ func NewServer(
 		"POST",
 		config.GlobalContextValidatingWebhookServicePath,
 		handlerFunc("VALIDATE", globalContextHandlers.Validation, "").
-			WithDump(debugModeOpts.DumpPayload).
+			WithDump(debugModeOpts.DumpPayload, debugModeOpts.DumpWriter).
 			WithSubResourceFilter().
 			WithMetrics(globalContextLogger, metricsConfig.Config(), metrics.WebhookValidating).
 			WithAdmission(globalContextLogger.WithName("validate")).
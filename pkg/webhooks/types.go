@@ -13,6 +13,8 @@ import (
 type DebugModeOptions struct {
 	// DumpPayload is used to activate/deactivate debug mode.
 	DumpPayload bool
+	// DumpWriter is used to record the admission payloads, recording is disabled when nil.
+	DumpWriter handlers.DumpWriter
 }
 
 type Handler interface {
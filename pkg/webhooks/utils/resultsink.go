@@ -0,0 +1,26 @@
+package utils
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/resultsink"
+	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
+	admissionv1 "k8s.io/api/admission/v1"
+)
+
+// SendResults streams the results of the engine responses to the result sink,
+// nothing is sent for dry run requests as they are never persisted
+func SendResults(ctx context.Context, logger logr.Logger, sink resultsink.Sink, request admissionv1.AdmissionRequest, engineResponses ...engineapi.EngineResponse) {
+	if sink == nil || admissionutils.IsDryRun(request) {
+		return
+	}
+	records := resultsink.FromEngineResponses(resultsink.SourceAdmission, string(request.Operation), engineResponses...)
+	if len(records) == 0 {
+		return
+	}
+	if err := sink.Send(ctx, records...); err != nil {
+		logger.Error(err, "failed to send results to the result sink")
+	}
+}